@@ -27,4 +27,36 @@ type Attendance struct {
 	// The user or service that created the attendance record.
 	// E.g. "auto-syncer", "user-id-123"
 	CreatedBy string `json:"created_by"`
+	// How the user attended the session. E.g. "on_time"
+	Status Status `json:"status"`
+	// Why the admin set the status. Empty if it's decided by the time when the user joined. E.g. "교통 체증"
+	StatusReason string `json:"status_reason"`
+	// The admin who set the status. Empty if it's decided by the time when the user joined. E.g. "user-id-123"
+	StatusSetBy string `json:"status_set_by"`
+}
+
+type Status string
+
+const (
+	// The user joined by the time when the session started.
+	StatusOnTime Status = "on_time"
+	// The user joined after the session had started.
+	StatusLate Status = "late"
+	// The user couldn't attend with an excuse that the admin accepted as the attendance.
+	StatusExcused Status = "excused"
+	// The admin applied it after the session had been closed.
+	StatusForceApplied Status = "force_applied"
+)
+
+// Returns true if it's one of the known statuses.
+func (s Status) IsValid() bool {
+	return s == StatusOnTime || s == StatusLate || s == StatusExcused || s == StatusForceApplied
+}
+
+// Returns the status by the time when the user joined. The user who joined at the start time is on time.
+func StatusByJoinedAt(sessionStartedAt time.Time, userJoinedAt time.Time) Status {
+	if userJoinedAt.After(sessionStartedAt) {
+		return StatusLate
+	}
+	return StatusOnTime
 }
@@ -0,0 +1,29 @@
+package attendance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusByJoinedAt(t *testing.T) {
+	startedAt := time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC)
+
+	t.Run("Is on time when the user joined before the session started", func(t *testing.T) {
+		assert.Equal(t, StatusOnTime, StatusByJoinedAt(startedAt, startedAt.Add(-5*time.Minute)))
+		assert.Equal(t, StatusOnTime, StatusByJoinedAt(startedAt, startedAt))
+	})
+
+	t.Run("Is late when the user joined after the session started", func(t *testing.T) {
+		assert.Equal(t, StatusLate, StatusByJoinedAt(startedAt, startedAt.Add(time.Minute)))
+	})
+}
+
+func TestStatusIsValid(t *testing.T) {
+	for _, status := range []Status{StatusOnTime, StatusLate, StatusExcused, StatusForceApplied} {
+		assert.True(t, status.IsValid(), status)
+	}
+	assert.False(t, Status("").IsValid())
+	assert.False(t, Status("absent").IsValid())
+}
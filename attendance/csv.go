@@ -0,0 +1,81 @@
+package attendance
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// The columns that the attendance CSV file should have in its header. The other columns are ignored.
+const (
+	csvColumnExternalName = "external_name"
+	csvColumnJoinedAt     = "joined_at"
+)
+
+// Parses the attendance CSV file as the submissions. E.g., the export of another attendance tool.
+// The first row is the header that has `external_name` and `joined_at` columns. `joined_at` is in RFC3339.
+// If a user appears multiple times, only the earliest row is kept as the form submissions.
+func ParseCsvSubmissions(reader io.Reader) ([]FormSubmission, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the CSV file is empty")
+		}
+		return nil, fmt.Errorf("failed to read the header: %w", err)
+	}
+
+	externalNameIndex, joinedAtIndex := -1, -1
+	for index, column := range header {
+		// Spreadsheet apps may add the byte order mark at the beginning of the file.
+		switch strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")) {
+		case csvColumnExternalName:
+			externalNameIndex = index
+		case csvColumnJoinedAt:
+			joinedAtIndex = index
+		}
+	}
+	if externalNameIndex < 0 || joinedAtIndex < 0 {
+		return nil, fmt.Errorf("the header should have %s and %s columns", csvColumnExternalName, csvColumnJoinedAt)
+	}
+
+	externalNames := []string{}
+	externalNameJoinedAtMap := map[string]time.Time{}
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read line %d: %w", line, err)
+		}
+
+		externalName := strings.TrimSpace(record[externalNameIndex])
+		if externalName == "" {
+			return nil, fmt.Errorf("%s is empty at line %d", csvColumnExternalName, line)
+		}
+		joinedAt, err := time.Parse(time.RFC3339, strings.TrimSpace(record[joinedAtIndex]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s at line %d: %w", csvColumnJoinedAt, line, err)
+		}
+
+		joinedAtFoundBefore, ok := externalNameJoinedAtMap[externalName]
+		if !ok {
+			externalNames = append(externalNames, externalName)
+		}
+		if ok && !joinedAt.Before(joinedAtFoundBefore) {
+			continue
+		}
+		externalNameJoinedAtMap[externalName] = joinedAt
+	}
+
+	submissions := []FormSubmission{}
+	for _, externalName := range externalNames {
+		submissions = append(submissions, FormSubmission{UserExternalName: externalName, SubmissionTime: externalNameJoinedAtMap[externalName]})
+	}
+	return submissions, nil
+}
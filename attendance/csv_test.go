@@ -0,0 +1,43 @@
+package attendance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCsvSubmissions(t *testing.T) {
+	t.Run("Parses the rows in order and keeps the earliest row of each user", func(t *testing.T) {
+		submissions, err := ParseCsvSubmissions(strings.NewReader(`note,external_name,joined_at
+first,김건,2025-07-01T20:05:00+09:00
+,양현우,2025-07-01T20:01:00+09:00
+again,김건,2025-07-01T20:00:00+09:00
+`))
+
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 2)
+		assert.Equal(t, "김건", submissions[0].UserExternalName)
+		assert.True(t, time.Date(2025, 7, 1, 11, 0, 0, 0, time.UTC).Equal(submissions[0].SubmissionTime))
+		assert.Equal(t, "양현우", submissions[1].UserExternalName)
+	})
+
+	t.Run("Fails without the required columns", func(t *testing.T) {
+		_, err := ParseCsvSubmissions(strings.NewReader("name,joined_at\n김건,2025-07-01T20:00:00+09:00\n"))
+
+		assert.EqualError(t, err, "the header should have external_name and joined_at columns")
+	})
+
+	t.Run("Fails with the invalid time", func(t *testing.T) {
+		_, err := ParseCsvSubmissions(strings.NewReader("external_name,joined_at\n김건,2025-07-01 20:00\n"))
+
+		assert.ErrorContains(t, err, "invalid joined_at at line 2")
+	})
+
+	t.Run("Fails with the empty file", func(t *testing.T) {
+		_, err := ParseCsvSubmissions(strings.NewReader(""))
+
+		assert.EqualError(t, err, "the CSV file is empty")
+	})
+}
@@ -1,6 +1,7 @@
 package attendance
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"rush/golang/array"
@@ -133,33 +134,78 @@ func (f *formHandler) GenerateForm(title string, description string, userOptions
 	return Form{Id: form.FormId, Uri: form.ResponderUri}, nil
 }
 
-// Fetches all the submissions of the form.
-func (f *formHandler) GetSubmissions(formId string) ([]FormSubmission, error) {
-	responses, err := f.googleFormService.Forms.Responses.List(formId).Do()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch form responses: %w", err)
+// Updates the title and the description of the form. Typically used when the session of the form is updated.
+func (f *formHandler) UpdateFormInfo(formId string, title string, description string) error {
+	updateRequest := &forms.BatchUpdateFormRequest{
+		Requests: []*forms.Request{
+			{
+				UpdateFormInfo: &forms.UpdateFormInfoRequest{
+					Info: &forms.Info{
+						Title:       title,
+						Description: description,
+					},
+					UpdateMask: "title,description",
+				},
+			},
+		},
 	}
 
-	var userExternalNameSubmissionTimeMap = make(map[string]time.Time)
-	for _, response := range responses.Responses {
-		submissionTime, err := time.Parse(time.RFC3339, response.LastSubmittedTime)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse submission time: %w", err)
-		}
+	if _, err := f.googleFormService.Forms.BatchUpdate(formId, updateRequest).Do(); err != nil {
+		return fmt.Errorf("failed to update the form info: %w", err)
+	}
+	return nil
+}
 
-		externalName, err := f.getUserExternalName(response)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user external name from response: %w", err)
-		}
+// The response of the form that couldn't be read as a submission. E.g., the option was edited by hand.
+// It's reported instead of failing all the other submissions.
+type InvalidSubmission struct {
+	// The answer as it was submitted. It's empty if there is no answer. E.g., "9:김건"
+	Answer string
+	// Why it couldn't be read. E.g., "invalid option format: 9:김건"
+	Reason string
+	// The time when the form was submitted. It's zero if it couldn't be read.
+	SubmissionTime time.Time
+}
 
-		timeFoundBefore, ok := userExternalNameSubmissionTimeMap[externalName]
-		if ok && submissionTime.After(timeFoundBefore) {
-			// The user might have submitted the form multiple times.
-			// Only keep the first submission.
-			continue
+// Fetches all the submissions of the form following all the pages of the responses.
+// The responses that can't be read are returned as the invalid submissions.
+func (f *formHandler) GetSubmissions(formId string) ([]FormSubmission, []InvalidSubmission, error) {
+	var userExternalNameSubmissionTimeMap = make(map[string]time.Time)
+	invalidSubmissions := []InvalidSubmission{}
+	if err := f.googleFormService.Forms.Responses.List(formId).Pages(context.Background(), func(responses *forms.ListFormResponsesResponse) error {
+		for _, response := range responses.Responses {
+			answer := getAnswer(response)
+			submissionTime, err := time.Parse(time.RFC3339, response.LastSubmittedTime)
+			if err != nil {
+				invalidSubmissions = append(invalidSubmissions, InvalidSubmission{
+					Answer: answer,
+					Reason: fmt.Sprintf("failed to parse submission time: %v", err),
+				})
+				continue
+			}
+
+			externalName, err := f.getUserExternalName(response)
+			if err != nil {
+				invalidSubmissions = append(invalidSubmissions, InvalidSubmission{
+					Answer:         answer,
+					Reason:         fmt.Sprintf("failed to get user external name from response: %v", err),
+					SubmissionTime: submissionTime,
+				})
+				continue
+			}
+
+			timeFoundBefore, ok := userExternalNameSubmissionTimeMap[externalName]
+			if ok && submissionTime.After(timeFoundBefore) {
+				// The user might have submitted the form multiple times.
+				// Only keep the first submission.
+				continue
+			}
+
+			userExternalNameSubmissionTimeMap[externalName] = submissionTime
 		}
-
-		userExternalNameSubmissionTimeMap[externalName] = submissionTime
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch form responses: %w", err)
 	}
 
 	var submissions []FormSubmission
@@ -167,7 +213,7 @@ func (f *formHandler) GetSubmissions(formId string) ([]FormSubmission, error) {
 		submissions = append(submissions, FormSubmission{UserExternalName: externalName, SubmissionTime: submissionTime})
 	}
 
-	return submissions, nil
+	return submissions, invalidSubmissions, nil
 }
 
 func (f *formHandler) getUserExternalName(response *forms.FormResponse) (string, error) {
@@ -188,6 +234,16 @@ func (f *formHandler) getUserExternalName(response *forms.FormResponse) (string,
 	return "", fmt.Errorf("no answer was found in the response")
 }
 
+// Returns the first answer of the response as it is so that it can be reported. It's empty if there is no answer.
+func getAnswer(response *forms.FormResponse) string {
+	for _, answer := range response.Answers {
+		if answer.TextAnswers != nil && len(answer.TextAnswers.Answers) > 0 {
+			return answer.TextAnswers.Answers[0].Value
+		}
+	}
+	return ""
+}
+
 type formOption struct {
 	Generation   float64
 	ExternalName string
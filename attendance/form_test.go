@@ -0,0 +1,180 @@
+package attendance
+
+import (
+	"rush/golang/googletest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/forms/v1"
+)
+
+func newTestFormHandler(t *testing.T) (*formHandler, *googletest.FakeServer) {
+	server := googletest.NewFakeServer(t)
+	return NewFormHandler(server.FormsService(t), server.DriveService(t)), server
+}
+
+func TestFormHandlerGenerateForm(t *testing.T) {
+	t.Run("Generates the form with the options of the users and shares it with the admins", func(t *testing.T) {
+		handler, server := newTestFormHandler(t)
+
+		form, err := handler.GenerateForm("Title", "Description", []UserOption{
+			{Generation: 9, ExternalName: "김건"},
+			{Generation: 9.5, ExternalName: "양현우"},
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, form.Uri)
+		generatedForm, ok := server.GetForm(form.Id)
+		assert.True(t, ok)
+		assert.Equal(t, "Title", generatedForm.Info.Title)
+		assert.Equal(t, "Description", generatedForm.Info.Description)
+		assert.Len(t, generatedForm.Items, 1)
+		options := generatedForm.Items[0].QuestionItem.Question.ChoiceQuestion.Options
+		assert.Len(t, options, 2)
+		assert.Equal(t, "9 - 김건", options[0].Value)
+		assert.Equal(t, "9.5 - 양현우", options[1].Value)
+		permissions := server.GetPermissions(form.Id)
+		assert.Len(t, permissions, len(handler.adminEmails))
+		for i, permission := range permissions {
+			assert.Equal(t, handler.adminEmails[i], permission.EmailAddress)
+			assert.Equal(t, "writer", permission.Role)
+		}
+	})
+}
+
+func TestFormHandlerUpdateFormInfo(t *testing.T) {
+	t.Run("Updates the title and the description", func(t *testing.T) {
+		handler, server := newTestFormHandler(t)
+		form, err := handler.GenerateForm("Title", "Description", []UserOption{{Generation: 9, ExternalName: "김건"}})
+		assert.NoError(t, err)
+
+		err = handler.UpdateFormInfo(form.Id, "New title", "New description")
+
+		assert.NoError(t, err)
+		updatedForm, _ := server.GetForm(form.Id)
+		assert.Equal(t, "New title", updatedForm.Info.Title)
+		assert.Equal(t, "New description", updatedForm.Info.Description)
+		assert.Len(t, updatedForm.Items, 1)
+	})
+
+	t.Run("Fails if the form doesn't exist", func(t *testing.T) {
+		handler, _ := newTestFormHandler(t)
+
+		err := handler.UpdateFormInfo("unknown", "New title", "New description")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFormHandlerGetSubmissions(t *testing.T) {
+	t.Run("Keeps the earliest submission of each user", func(t *testing.T) {
+		handler, server := newTestFormHandler(t)
+		form, err := handler.GenerateForm("Title", "Description", []UserOption{
+			{Generation: 9, ExternalName: "김건"},
+			{Generation: 9.5, ExternalName: "양현우"},
+		})
+		assert.NoError(t, err)
+		submittedAt := time.Date(2025, 7, 1, 11, 0, 0, 0, time.UTC)
+		server.AddResponse(form.Id, "9 - 김건", submittedAt.Add(time.Minute))
+		server.AddResponse(form.Id, "9 - 김건", submittedAt)
+		server.AddResponse(form.Id, "9.5 - 양현우", submittedAt.Add(2*time.Minute))
+		server.AddResponse(form.Id, "9 - 김건", submittedAt.Add(3*time.Minute))
+
+		submissions, invalidSubmissions, err := handler.GetSubmissions(form.Id)
+
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 2)
+		submissionTimes := map[string]time.Time{}
+		for _, submission := range submissions {
+			submissionTimes[submission.UserExternalName] = submission.SubmissionTime
+		}
+		assert.True(t, submittedAt.Equal(submissionTimes["김건"]))
+		assert.True(t, submittedAt.Add(2*time.Minute).Equal(submissionTimes["양현우"]))
+		assert.Empty(t, invalidSubmissions)
+	})
+
+	t.Run("Returns no submissions if the form has no responses", func(t *testing.T) {
+		handler, _ := newTestFormHandler(t)
+		form, err := handler.GenerateForm("Title", "Description", []UserOption{{Generation: 9, ExternalName: "김건"}})
+		assert.NoError(t, err)
+
+		submissions, invalidSubmissions, err := handler.GetSubmissions(form.Id)
+
+		assert.NoError(t, err)
+		assert.Empty(t, submissions)
+		assert.Empty(t, invalidSubmissions)
+	})
+
+	t.Run("Reads the responses of all the pages", func(t *testing.T) {
+		handler, server := newTestFormHandler(t)
+		form, err := handler.GenerateForm("Title", "Description", []UserOption{
+			{Generation: 9, ExternalName: "김건"},
+			{Generation: 9.5, ExternalName: "양현우"},
+			{Generation: 10, ExternalName: "김민경"},
+		})
+		assert.NoError(t, err)
+		server.SetResponsePageSize(2)
+		submittedAt := time.Date(2025, 7, 1, 11, 0, 0, 0, time.UTC)
+		server.AddResponse(form.Id, "9 - 김건", submittedAt.Add(time.Minute))
+		server.AddResponse(form.Id, "9.5 - 양현우", submittedAt)
+		server.AddResponse(form.Id, "10 - 김민경", submittedAt)
+		server.AddResponse(form.Id, "9 - 김건", submittedAt)
+		server.AddResponse(form.Id, "9:김건", submittedAt)
+
+		submissions, invalidSubmissions, err := handler.GetSubmissions(form.Id)
+
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 3)
+		for _, submission := range submissions {
+			assert.True(t, submittedAt.Equal(submission.SubmissionTime), submission.UserExternalName)
+		}
+		assert.Len(t, invalidSubmissions, 1)
+		assert.Equal(t, "9:김건", invalidSubmissions[0].Answer)
+	})
+
+	t.Run("Reports the malformed options and keeps the other submissions", func(t *testing.T) {
+		handler, server := newTestFormHandler(t)
+		form, err := handler.GenerateForm("Title", "Description", []UserOption{{Generation: 9, ExternalName: "김건"}})
+		assert.NoError(t, err)
+		submittedAt := time.Date(2025, 7, 1, 11, 0, 0, 0, time.UTC)
+		server.AddResponse(form.Id, "9:김건", submittedAt)
+		server.AddResponse(form.Id, "nine - 김건", submittedAt)
+		server.AddResponse(form.Id, "9 - 김건", submittedAt)
+
+		submissions, invalidSubmissions, err := handler.GetSubmissions(form.Id)
+
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 1)
+		assert.Equal(t, "김건", submissions[0].UserExternalName)
+		assert.Len(t, invalidSubmissions, 2)
+		assert.Equal(t, "9:김건", invalidSubmissions[0].Answer)
+		assert.Contains(t, invalidSubmissions[0].Reason, "invalid option format: 9:김건")
+		assert.True(t, submittedAt.Equal(invalidSubmissions[0].SubmissionTime))
+		assert.Equal(t, "nine - 김건", invalidSubmissions[1].Answer)
+		assert.Contains(t, invalidSubmissions[1].Reason, "failed to parse generation")
+	})
+
+	t.Run("Reports the responses without answers", func(t *testing.T) {
+		handler, server := newTestFormHandler(t)
+		form, err := handler.GenerateForm("Title", "Description", []UserOption{{Generation: 9, ExternalName: "김건"}})
+		assert.NoError(t, err)
+		server.AddRawResponse(form.Id, &forms.FormResponse{LastSubmittedTime: time.Now().UTC().Format(time.RFC3339)})
+
+		submissions, invalidSubmissions, err := handler.GetSubmissions(form.Id)
+
+		assert.NoError(t, err)
+		assert.Empty(t, submissions)
+		assert.Len(t, invalidSubmissions, 1)
+		assert.Empty(t, invalidSubmissions[0].Answer)
+		assert.Contains(t, invalidSubmissions[0].Reason, "no answer was found in the response")
+	})
+
+	t.Run("Fails if the form doesn't exist", func(t *testing.T) {
+		handler, _ := newTestFormHandler(t)
+
+		_, _, err := handler.GetSubmissions("unknown")
+
+		assert.ErrorContains(t, err, "failed to fetch form responses")
+	})
+}
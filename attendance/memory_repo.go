@@ -0,0 +1,194 @@
+package attendance
+
+import (
+	"fmt"
+	"rush/golang/array"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the attendances in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The attendances in the order of insertion.
+	attendances []Attendance
+	// The clock to get the current time. It's used to mock the time in tests.
+	clock clock.Clock
+}
+
+func NewMemoryRepo(clock clock.Clock) *memoryRepo {
+	return &memoryRepo{
+		attendances: []Attendance{},
+		clock:       clock,
+	}
+}
+
+func (r *memoryRepo) GetAll() ([]Attendance, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return array.Filter(r.attendances, func(Attendance) bool { return true }), nil
+}
+
+// Returns the attendance by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *memoryRepo) Get(id string) (Attendance, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, attendance := range r.attendances {
+		if attendance.Id == id {
+			return attendance, nil
+		}
+	}
+	return Attendance{}, ErrNotFound
+}
+
+func (r *memoryRepo) FindBySessionId(sessionId string) ([]Attendance, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attendances := array.Filter(r.attendances, func(attendance Attendance) bool { return attendance.SessionId == sessionId })
+	sort.SliceStable(attendances, func(i, j int) bool { return attendances[i].UserJoinedAt.Before(attendances[j].UserJoinedAt) })
+	return attendances, nil
+}
+
+func (r *memoryRepo) FindByUserId(userId string) ([]Attendance, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attendances := array.Filter(r.attendances, func(attendance Attendance) bool { return attendance.UserId == userId })
+	sort.SliceStable(attendances, func(i, j int) bool { return attendances[i].SessionStartedAt.After(attendances[j].SessionStartedAt) })
+	return attendances, nil
+}
+
+// Returns the attendances of the sessions that started within [from, to).
+func (r *memoryRepo) FindBySessionStartedAtBetween(from time.Time, to time.Time) ([]Attendance, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attendances := array.Filter(r.attendances, func(attendance Attendance) bool {
+		return !attendance.SessionStartedAt.Before(from) && attendance.SessionStartedAt.Before(to)
+	})
+	sort.SliceStable(attendances, func(i, j int) bool { return attendances[i].SessionStartedAt.Before(attendances[j].SessionStartedAt) })
+	return attendances, nil
+}
+
+// Inserts the attendances and returns their IDs in the same order.
+// It inserts all or nothing. If any user already has an attendance for the session, it returns ErrDuplicate.
+func (r *memoryRepo) BulkInsert(requests []AddAttendanceReq) ([]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	type key struct{ sessionId, userId string }
+	existing := map[key]bool{}
+	for _, attendance := range r.attendances {
+		existing[key{attendance.SessionId, attendance.UserId}] = true
+	}
+	for _, request := range requests {
+		if existing[key{request.SessionId, request.UserId}] {
+			return nil, fmt.Errorf("%w: user %s in session %s", ErrDuplicate, request.UserId, request.SessionId)
+		}
+		existing[key{request.SessionId, request.UserId}] = true
+	}
+
+	ids := make([]string, 0, len(requests))
+	now := r.clock.Now()
+	for _, request := range requests {
+		id := primitive.NewObjectID().Hex()
+		ids = append(ids, id)
+		r.attendances = append(r.attendances, Attendance{
+			Id:               id,
+			SessionId:        request.SessionId,
+			SessionName:      request.SessionName,
+			SessionScore:     request.SessionScore,
+			SessionStartedAt: request.SessionStartedAt,
+			UserId:           request.UserId,
+			UserExternalName: request.UserExternalName,
+			UserGeneration:   request.UserGeneration,
+			UserJoinedAt:     request.UserJoinedAt,
+			CreatedAt:        now,
+			CreatedBy:        request.CreatedBy,
+			Status:           request.Status,
+			StatusReason:     request.StatusReason,
+			StatusSetBy:      request.StatusSetBy,
+		})
+	}
+	return ids, nil
+}
+
+// Inserts the deleted attendance back as it was including its ID and creation time.
+// Typically used to undo Delete when the following write fails. If the user already has an attendance for the session, it returns ErrDuplicate.
+func (r *memoryRepo) Restore(attendance Attendance) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.attendances {
+		if existing.Id == attendance.Id || (existing.SessionId == attendance.SessionId && existing.UserId == attendance.UserId) {
+			return fmt.Errorf("%w: user %s in session %s", ErrDuplicate, attendance.UserId, attendance.SessionId)
+		}
+	}
+	r.attendances = append(r.attendances, attendance)
+	return nil
+}
+
+// Deletes the attendances by the IDs. Typically used to undo BulkInsert when the following write fails or to remove a wrong attendance.
+func (r *memoryRepo) Delete(ids []string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.attendances = array.Filter(r.attendances, func(attendance Attendance) bool { return !array.Contains(ids, attendance.Id) })
+	return nil
+}
+
+// Corrects the attendance. If not found, it returns ErrNotFound.
+func (r *memoryRepo) Update(id string, updateForm UpdateAttendanceForm) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for index := range r.attendances {
+		attendance := &r.attendances[index]
+		if attendance.Id != id {
+			continue
+		}
+		if updateForm.UserJoinedAt != nil {
+			attendance.UserJoinedAt = *updateForm.UserJoinedAt
+		}
+		if updateForm.Status != nil {
+			attendance.Status = *updateForm.Status
+		}
+		if updateForm.StatusReason != nil {
+			attendance.StatusReason = *updateForm.StatusReason
+		}
+		if updateForm.StatusSetBy != nil {
+			attendance.StatusSetBy = *updateForm.StatusSetBy
+		}
+		return nil
+	}
+	return ErrNotFound
+}
+
+// Update the information about the user through all of the attendance records of the user.
+func (r *memoryRepo) UpdateUserAttendance(userId string, updateForm UpdateUserAttendanceForm) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for index := range r.attendances {
+		attendance := &r.attendances[index]
+		if attendance.UserId != userId {
+			continue
+		}
+		if updateForm.UserExternalName != nil {
+			attendance.UserExternalName = *updateForm.UserExternalName
+		}
+		if updateForm.UserGeneration != nil {
+			attendance.UserGeneration = *updateForm.UserGeneration
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package attendance
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"rush/golang/array"
 	"time"
@@ -40,8 +41,19 @@ type mongodbAttendance struct {
 	CreatedBy string `bson:"created_by"`
 	// Whether the attendance record was force applied.
 	ForceApply bool `bson:"force_apply"`
+	// How the user attended the session. E.g. "on_time"
+	Status Status `bson:"status"`
+	// Why the admin set the status. E.g. "교통 체증"
+	StatusReason string `bson:"status_reason"`
+	// The admin who set the status. E.g. "user-id-123"
+	StatusSetBy string `bson:"status_set_by"`
 }
 
+// Returned when a user already has an attendance for the session.
+var ErrDuplicate = errors.New("attendance already exists")
+
+var ErrNotFound = errors.New("attendance not found")
+
 type mongodbRepo struct {
 	// The actual client that executes the queries.
 	collection *mongo.Collection
@@ -56,6 +68,20 @@ func NewMongoDbRepo(collection *mongo.Collection, clock clock.Clock) *mongodbRep
 	}
 }
 
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	GetAll() ([]Attendance, error)
+	Get(id string) (Attendance, error)
+	FindBySessionId(sessionId string) ([]Attendance, error)
+	FindByUserId(userId string) ([]Attendance, error)
+	FindBySessionStartedAtBetween(from time.Time, to time.Time) ([]Attendance, error)
+	BulkInsert(requests []AddAttendanceReq) ([]string, error)
+	Restore(attendance Attendance) error
+	Delete(ids []string) error
+	Update(id string, updateForm UpdateAttendanceForm) error
+	UpdateUserAttendance(userId string, updateForm UpdateUserAttendanceForm) error
+}
+
 func (m *mongodbRepo) GetAll() ([]Attendance, error) {
 	ctx := context.Background()
 
@@ -72,6 +98,25 @@ func (m *mongodbRepo) GetAll() ([]Attendance, error) {
 	return array.Map(attendances, toAttendance), nil
 }
 
+// Returns the attendance by the given ID.
+// If not found, it returns ErrNotFound.
+func (m *mongodbRepo) Get(id string) (Attendance, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Attendance{}, fmt.Errorf("invalid id: %w", err)
+	}
+
+	attendance := mongodbAttendance{}
+	if err := m.collection.FindOne(context.Background(), bson.M{"_id": objectId}).Decode(&attendance); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Attendance{}, ErrNotFound
+		}
+		return Attendance{}, fmt.Errorf("failed to get attendance: %w", err)
+	}
+
+	return toAttendance(attendance), nil
+}
+
 func (m *mongodbRepo) FindBySessionId(sessionId string) ([]Attendance, error) {
 	ctx := context.Background()
 
@@ -112,6 +157,27 @@ func (m *mongodbRepo) FindByUserId(userId string) ([]Attendance, error) {
 	return array.Map(attendances, toAttendance), nil
 }
 
+// Returns the attendances of the sessions that started within [from, to).
+func (m *mongodbRepo) FindBySessionStartedAtBetween(from time.Time, to time.Time) ([]Attendance, error) {
+	ctx := context.Background()
+
+	cursor, err := m.collection.Find(
+		ctx, bson.M{"session_started_at": bson.M{"$gte": from, "$lt": to}},
+		options.Find().SetSort(bson.D{{Key: "session_started_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attendances: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var attendances []mongodbAttendance
+	if err = cursor.All(ctx, &attendances); err != nil {
+		return nil, fmt.Errorf("failed to decode attendances: %w", err)
+	}
+
+	return array.Map(attendances, toAttendance), nil
+}
+
 // The request to add an attendance record.
 type AddAttendanceReq struct {
 	SessionId        string
@@ -123,19 +189,30 @@ type AddAttendanceReq struct {
 	UserGeneration   float64
 	UserJoinedAt     time.Time
 	CreatedBy        string
-	ForceApply       bool
+	Status           Status
+	// Empty if the status is decided by the time when the user joined.
+	StatusReason string
+	StatusSetBy  string
 }
 
-func (m *mongodbRepo) BulkInsert(requests []AddAttendanceReq) error {
+// Inserts the attendances and returns their IDs in the same order.
+// It inserts all or nothing. If any user already has an attendance for the session, it returns ErrDuplicate.
+func (m *mongodbRepo) BulkInsert(requests []AddAttendanceReq) ([]string, error) {
 	if len(requests) == 0 {
-		return nil
+		return []string{}, nil
 	}
 
+	ctx := context.Background()
 	// interface type because InsertMany requires []interface{}.
 	attendances := make([]interface{}, 0, len(requests))
+	ids := make([]primitive.ObjectID, 0, len(requests))
 	now := m.clock.Now()
 	for _, request := range requests {
+		// Generate the IDs in advance to remove the inserted ones if it fails in the middle.
+		id := primitive.NewObjectID()
+		ids = append(ids, id)
 		attendances = append(attendances, &mongodbAttendance{
+			Id:               id,
 			SessionId:        request.SessionId,
 			SessionName:      request.SessionName,
 			SessionScore:     request.SessionScore,
@@ -146,12 +223,122 @@ func (m *mongodbRepo) BulkInsert(requests []AddAttendanceReq) error {
 			UserJoinedAt:     request.UserJoinedAt,
 			CreatedAt:        now,
 			CreatedBy:        request.CreatedBy,
-			ForceApply:       request.ForceApply,
+			ForceApply:       request.Status == StatusForceApplied,
+			Status:           request.Status,
+			StatusReason:     request.StatusReason,
+			StatusSetBy:      request.StatusSetBy,
 		})
 	}
 
-	_, err := m.collection.InsertMany(context.Background(), attendances)
-	return err
+	if _, err := m.collection.InsertMany(ctx, attendances); err != nil {
+		// Transactions require a replica set. Remove the inserted ones instead.
+		if _, deleteErr := m.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); deleteErr != nil {
+			return nil, fmt.Errorf("failed to insert attendances: %w and failed to remove the inserted ones: %v", err, deleteErr)
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicate, err)
+		}
+		return nil, fmt.Errorf("failed to insert attendances: %w", err)
+	}
+
+	return array.Map(ids, func(id primitive.ObjectID) string { return id.Hex() }), nil
+}
+
+// Inserts the deleted attendance back as it was including its ID and creation time.
+// Typically used to undo Delete when the following write fails. If the user already has an attendance for the session, it returns ErrDuplicate.
+func (m *mongodbRepo) Restore(attendance Attendance) error {
+	id, err := primitive.ObjectIDFromHex(attendance.Id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+	if _, err := m.collection.InsertOne(context.Background(), &mongodbAttendance{
+		Id:               id,
+		SessionId:        attendance.SessionId,
+		SessionName:      attendance.SessionName,
+		SessionScore:     attendance.SessionScore,
+		SessionStartedAt: attendance.SessionStartedAt,
+		UserId:           attendance.UserId,
+		UserExternalName: attendance.UserExternalName,
+		UserGeneration:   attendance.UserGeneration,
+		UserJoinedAt:     attendance.UserJoinedAt,
+		CreatedAt:        attendance.CreatedAt,
+		CreatedBy:        attendance.CreatedBy,
+		ForceApply:       attendance.Status == StatusForceApplied,
+		Status:           attendance.Status,
+		StatusReason:     attendance.StatusReason,
+		StatusSetBy:      attendance.StatusSetBy,
+	}); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %v", ErrDuplicate, err)
+		}
+		return fmt.Errorf("failed to restore attendance: %w", err)
+	}
+	return nil
+}
+
+// Deletes the attendances by the IDs. Typically used to undo BulkInsert when the following write fails or to remove a wrong attendance.
+func (m *mongodbRepo) Delete(ids []string) error {
+	objectIds := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectId, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return fmt.Errorf("invalid id: %w", err)
+		}
+		objectIds = append(objectIds, objectId)
+	}
+
+	if _, err := m.collection.DeleteMany(context.Background(), bson.M{"_id": bson.M{"$in": objectIds}}); err != nil {
+		return fmt.Errorf("failed to delete attendances: %w", err)
+	}
+	return nil
+}
+
+// The form to correct an attendance record.
+type UpdateAttendanceForm struct {
+	// New time when the user joined the session. Leave it nil to not update.
+	UserJoinedAt *time.Time
+	// New status of the attendance with the reason and the admin who set it. Leave it nil to not update.
+	Status       *Status
+	StatusReason *string
+	StatusSetBy  *string
+}
+
+// Corrects the attendance. If not found, it returns ErrNotFound.
+func (m *mongodbRepo) Update(id string, updateForm UpdateAttendanceForm) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	update := bson.M{}
+	if updateForm.UserJoinedAt != nil {
+		update["user_joined_at"] = *updateForm.UserJoinedAt
+	}
+	if updateForm.Status != nil {
+		update["status"] = *updateForm.Status
+		update["force_apply"] = *updateForm.Status == StatusForceApplied
+	}
+	if updateForm.StatusReason != nil {
+		update["status_reason"] = *updateForm.StatusReason
+	}
+	if updateForm.StatusSetBy != nil {
+		update["status_set_by"] = *updateForm.StatusSetBy
+	}
+
+	// $set requires at least one field.
+	if len(update) == 0 {
+		_, err := m.Get(id)
+		return err
+	}
+
+	result, err := m.collection.UpdateOne(context.Background(), bson.M{"_id": objectId}, bson.M{"$set": update})
+	if err != nil {
+		return fmt.Errorf("failed to update attendance: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 // The form to update the attendance record of a user.
@@ -193,5 +380,8 @@ func toAttendance(attendance mongodbAttendance) Attendance {
 		UserJoinedAt:     attendance.UserJoinedAt,
 		CreatedAt:        attendance.CreatedAt,
 		CreatedBy:        attendance.CreatedBy,
+		Status:           attendance.Status,
+		StatusReason:     attendance.StatusReason,
+		StatusSetBy:      attendance.StatusSetBy,
 	}
 }
@@ -0,0 +1,218 @@
+package attendance
+
+import (
+	"context"
+	"rush/golang/array"
+	"rush/golang/mongotest"
+	"rush/migrate"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T, clock clock.Clock) Repo {
+		db := mongotest.NewDatabase(t)
+		// The repo relies on the indexes created by the migrations.
+		must.OK1(migrate.Run(context.Background(), db, migrate.Collections{
+			Users: "users", Sessions: "sessions", Attendances: "attendances", Terms: "terms", Rsvps: "rsvps", AuthTokens: "auth_tokens",
+			CheckInRejections: "check_in_rejections", UnmatchedSubmissions: "unmatched_submissions", Excuses: "excuses", AuditEntries: "audit_entries", ReliabilityOutcomes: "reliability_outcomes",
+		}))
+		return NewMongoDbRepo(db.Collection("attendances"), clock)
+	})
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T, clock clock.Clock) Repo { return NewMemoryRepo(clock) })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T, clock clock.Clock) Repo {
+		return NewSqliteRepo(must.OK1(sqlite.Open(":memory:")), clock)
+	})
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T, clock clock.Clock) Repo) {
+	requests := []AddAttendanceReq{
+		{SessionId: "session-1", SessionName: "정규런", SessionScore: 2, SessionStartedAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			UserId: "user-1", UserExternalName: "김건", UserGeneration: 9, UserJoinedAt: time.Date(2025, 7, 1, 0, 10, 0, 0, time.UTC), CreatedBy: "admin",
+			Status: StatusLate, StatusReason: "교통 체증", StatusSetBy: "admin"},
+		{SessionId: "session-2", SessionName: "번개런", SessionScore: 1, SessionStartedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			UserId: "user-1", UserExternalName: "김건", UserGeneration: 9, UserJoinedAt: time.Date(2025, 6, 1, 0, 10, 0, 0, time.UTC), CreatedBy: "admin"},
+		{SessionId: "session-1", SessionName: "정규런", SessionScore: 2, SessionStartedAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			UserId: "user-2", UserExternalName: "양현우", UserGeneration: 8, UserJoinedAt: time.Date(2025, 7, 1, 0, 5, 0, 0, time.UTC), CreatedBy: "admin"},
+	}
+
+	t.Run("Inserts attendances with the creation time", func(t *testing.T) {
+		mockClock := clock.NewMock()
+		mockClock.Set(time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC))
+		repo := newRepo(t, mockClock)
+
+		ids, err := repo.BulkInsert(requests)
+		assert.NoError(t, err)
+		assert.Len(t, ids, 3)
+		empty, err := repo.BulkInsert([]AddAttendanceReq{})
+		assert.NoError(t, err)
+		assert.Empty(t, empty)
+
+		all, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, ids, array.Map(all, func(attendance Attendance) string { return attendance.Id }))
+		assert.Equal(t, Attendance{
+			Id:               all[0].Id,
+			SessionId:        "session-1",
+			SessionName:      "정규런",
+			SessionScore:     2,
+			SessionStartedAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			UserId:           "user-1",
+			UserExternalName: "김건",
+			UserGeneration:   9,
+			UserJoinedAt:     time.Date(2025, 7, 1, 0, 10, 0, 0, time.UTC),
+			CreatedAt:        time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC),
+			CreatedBy:        "admin",
+			Status:           StatusLate,
+			StatusReason:     "교통 체증",
+			StatusSetBy:      "admin",
+		}, all[0])
+	})
+
+	t.Run("Finds attendances by the session sorted by the joined time", func(t *testing.T) {
+		repo := newRepo(t, clock.NewMock())
+		_, err := repo.BulkInsert(requests)
+		assert.NoError(t, err)
+
+		attendances, err := repo.FindBySessionId("session-1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"user-2", "user-1"}, []string{attendances[0].UserId, attendances[1].UserId})
+	})
+
+	t.Run("Finds attendances by the user sorted by the session start time in descending order", func(t *testing.T) {
+		repo := newRepo(t, clock.NewMock())
+		_, err := repo.BulkInsert(requests)
+		assert.NoError(t, err)
+
+		attendances, err := repo.FindByUserId("user-1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"session-1", "session-2"}, []string{attendances[0].SessionId, attendances[1].SessionId})
+
+		none, err := repo.FindByUserId("unknown")
+		assert.NoError(t, err)
+		assert.Empty(t, none)
+	})
+
+	t.Run("Finds attendances of the sessions that started within the period", func(t *testing.T) {
+		repo := newRepo(t, clock.NewMock())
+		_, err := repo.BulkInsert(requests)
+		assert.NoError(t, err)
+
+		// The start is inclusive and the end is exclusive.
+		attendances, err := repo.FindBySessionStartedAtBetween(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, "session-2", attendances[0].SessionId)
+	})
+
+	t.Run("Updates the user data of every attendance of the user", func(t *testing.T) {
+		repo := newRepo(t, clock.NewMock())
+		_, err := repo.BulkInsert(requests)
+		assert.NoError(t, err)
+
+		externalName := "김건2"
+		assert.NoError(t, repo.UpdateUserAttendance("user-1", UpdateUserAttendanceForm{UserExternalName: &externalName}))
+
+		attendances, err := repo.FindByUserId("user-1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"김건2", "김건2"}, []string{attendances[0].UserExternalName, attendances[1].UserExternalName})
+		assert.Equal(t, 9.0, attendances[0].UserGeneration)
+		others, err := repo.FindByUserId("user-2")
+		assert.NoError(t, err)
+		assert.Equal(t, "양현우", others[0].UserExternalName)
+	})
+
+	t.Run("Gets and corrects an attendance by the ID", func(t *testing.T) {
+		repo := newRepo(t, clock.NewMock())
+		ids, err := repo.BulkInsert(requests)
+		assert.NoError(t, err)
+
+		joinedAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		status := StatusOnTime
+		reason := "체크인 오류"
+		setBy := "another-admin"
+		assert.NoError(t, repo.Update(ids[1], UpdateAttendanceForm{UserJoinedAt: &joinedAt, Status: &status, StatusReason: &reason, StatusSetBy: &setBy}))
+		assert.NoError(t, repo.Update(ids[1], UpdateAttendanceForm{}))
+
+		attendance, err := repo.Get(ids[1])
+		assert.NoError(t, err)
+		assert.Equal(t, ids[1], attendance.Id)
+		assert.Equal(t, joinedAt, attendance.UserJoinedAt)
+		assert.Equal(t, StatusOnTime, attendance.Status)
+		assert.Equal(t, "체크인 오류", attendance.StatusReason)
+		assert.Equal(t, "another-admin", attendance.StatusSetBy)
+		untouched, err := repo.Get(ids[0])
+		assert.NoError(t, err)
+		assert.Equal(t, StatusLate, untouched.Status)
+
+		missingId := primitive.NewObjectID().Hex()
+		_, err = repo.Get(missingId)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, repo.Update(missingId, UpdateAttendanceForm{Status: &status}), ErrNotFound)
+		assert.ErrorIs(t, repo.Update(missingId, UpdateAttendanceForm{}), ErrNotFound)
+	})
+
+	t.Run("Inserts nothing if any user already attended the session", func(t *testing.T) {
+		repo := newRepo(t, clock.NewMock())
+		_, err := repo.BulkInsert(requests[:1])
+		assert.NoError(t, err)
+
+		_, err = repo.BulkInsert(requests)
+		assert.ErrorIs(t, err, ErrDuplicate)
+		all, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Len(t, all, 1)
+
+		// Duplicates in the same request are rejected as well.
+		_, err = repo.BulkInsert([]AddAttendanceReq{requests[2], requests[2]})
+		assert.ErrorIs(t, err, ErrDuplicate)
+		all, err = repo.GetAll()
+		assert.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("Deletes the attendances by the IDs", func(t *testing.T) {
+		repo := newRepo(t, clock.NewMock())
+		ids, err := repo.BulkInsert(requests)
+		assert.NoError(t, err)
+
+		assert.NoError(t, repo.Delete(ids[:2]))
+
+		all, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Len(t, all, 1)
+		assert.Equal(t, ids[2], all[0].Id)
+	})
+
+	t.Run("Restores the deleted attendance with its ID and creation time", func(t *testing.T) {
+		mockClock := clock.NewMock()
+		mockClock.Set(time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC))
+		repo := newRepo(t, mockClock)
+		ids, err := repo.BulkInsert(requests)
+		assert.NoError(t, err)
+		deleted, err := repo.Get(ids[0])
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Delete(ids[:1]))
+		mockClock.Add(time.Hour)
+
+		assert.NoError(t, repo.Restore(deleted))
+
+		restored, err := repo.Get(ids[0])
+		assert.NoError(t, err)
+		assert.Equal(t, deleted, restored)
+		assert.ErrorIs(t, repo.Restore(deleted), ErrDuplicate)
+	})
+}
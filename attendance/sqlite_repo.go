@@ -0,0 +1,218 @@
+package attendance
+
+import (
+	"database/sql"
+	"fmt"
+	"rush/sqlite"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+	// The clock to get the current time. It's used to mock the time in tests.
+	clock clock.Clock
+}
+
+func NewSqliteRepo(db *sql.DB, clock clock.Clock) *sqliteRepo {
+	return &sqliteRepo{
+		db:    db,
+		clock: clock,
+	}
+}
+
+const sqliteAttendanceColumns = "id, session_id, session_name, session_score, session_started_at, user_id, user_external_name, user_generation, user_joined_at, created_at, created_by, " +
+	"status, status_reason, status_set_by"
+
+func (r *sqliteRepo) GetAll() ([]Attendance, error) {
+	return r.query("SELECT " + sqliteAttendanceColumns + " FROM attendances ORDER BY rowid")
+}
+
+// Returns the attendance by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *sqliteRepo) Get(id string) (Attendance, error) {
+	attendances, err := r.query("SELECT "+sqliteAttendanceColumns+" FROM attendances WHERE id = ?", id)
+	if err != nil {
+		return Attendance{}, err
+	}
+	if len(attendances) == 0 {
+		return Attendance{}, ErrNotFound
+	}
+	return attendances[0], nil
+}
+
+func (r *sqliteRepo) FindBySessionId(sessionId string) ([]Attendance, error) {
+	return r.query("SELECT "+sqliteAttendanceColumns+" FROM attendances WHERE session_id = ? ORDER BY user_joined_at, rowid", sessionId)
+}
+
+func (r *sqliteRepo) FindByUserId(userId string) ([]Attendance, error) {
+	return r.query("SELECT "+sqliteAttendanceColumns+" FROM attendances WHERE user_id = ? ORDER BY session_started_at DESC, rowid", userId)
+}
+
+// Returns the attendances of the sessions that started within [from, to).
+func (r *sqliteRepo) FindBySessionStartedAtBetween(from time.Time, to time.Time) ([]Attendance, error) {
+	return r.query("SELECT "+sqliteAttendanceColumns+" FROM attendances WHERE session_started_at >= ? AND session_started_at < ? ORDER BY session_started_at, rowid",
+		sqlite.FromTime(from), sqlite.FromTime(to))
+}
+
+// Inserts the attendances and returns their IDs in the same order.
+// It inserts all or nothing. If any user already has an attendance for the session, it returns ErrDuplicate.
+func (r *sqliteRepo) BulkInsert(requests []AddAttendanceReq) ([]string, error) {
+	if len(requests) == 0 {
+		return []string{}, nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, 0, len(requests))
+	now := sqlite.FromTime(r.clock.Now())
+	for _, request := range requests {
+		id := sqlite.NewId()
+		if _, err := tx.Exec("INSERT INTO attendances ("+sqliteAttendanceColumns+", force_apply) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			id, request.SessionId, request.SessionName, request.SessionScore, sqlite.FromTime(request.SessionStartedAt),
+			request.UserId, request.UserExternalName, request.UserGeneration, sqlite.FromTime(request.UserJoinedAt),
+			now, request.CreatedBy, request.Status, request.StatusReason, request.StatusSetBy,
+			request.Status == StatusForceApplied); err != nil {
+			if sqlite.IsUniqueConstraintError(err) {
+				return nil, fmt.Errorf("%w: %v", ErrDuplicate, err)
+			}
+			return nil, fmt.Errorf("failed to insert attendance: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to insert attendances: %w", err)
+	}
+	return ids, nil
+}
+
+// Inserts the deleted attendance back as it was including its ID and creation time.
+// Typically used to undo Delete when the following write fails. If the user already has an attendance for the session, it returns ErrDuplicate.
+func (r *sqliteRepo) Restore(attendance Attendance) error {
+	if _, err := r.db.Exec("INSERT INTO attendances ("+sqliteAttendanceColumns+", force_apply) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		attendance.Id, attendance.SessionId, attendance.SessionName, attendance.SessionScore, sqlite.FromTime(attendance.SessionStartedAt),
+		attendance.UserId, attendance.UserExternalName, attendance.UserGeneration, sqlite.FromTime(attendance.UserJoinedAt),
+		sqlite.FromTime(attendance.CreatedAt), attendance.CreatedBy, attendance.Status, attendance.StatusReason, attendance.StatusSetBy,
+		attendance.Status == StatusForceApplied); err != nil {
+		if sqlite.IsUniqueConstraintError(err) {
+			return fmt.Errorf("%w: %v", ErrDuplicate, err)
+		}
+		return fmt.Errorf("failed to restore attendance: %w", err)
+	}
+	return nil
+}
+
+// Deletes the attendances by the IDs. Typically used to undo BulkInsert when the following write fails or to remove a wrong attendance.
+func (r *sqliteRepo) Delete(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for index, id := range ids {
+		args[index] = id
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	if _, err := r.db.Exec("DELETE FROM attendances WHERE id IN ("+placeholders+")", args...); err != nil {
+		return fmt.Errorf("failed to delete attendances: %w", err)
+	}
+	return nil
+}
+
+// Corrects the attendance. If not found, it returns ErrNotFound.
+func (r *sqliteRepo) Update(id string, updateForm UpdateAttendanceForm) error {
+	sets := []string{}
+	args := []interface{}{}
+	if updateForm.UserJoinedAt != nil {
+		sets = append(sets, "user_joined_at = ?")
+		args = append(args, sqlite.FromTime(*updateForm.UserJoinedAt))
+	}
+	if updateForm.Status != nil {
+		sets = append(sets, "status = ?", "force_apply = ?")
+		args = append(args, *updateForm.Status, *updateForm.Status == StatusForceApplied)
+	}
+	if updateForm.StatusReason != nil {
+		sets = append(sets, "status_reason = ?")
+		args = append(args, *updateForm.StatusReason)
+	}
+	if updateForm.StatusSetBy != nil {
+		sets = append(sets, "status_set_by = ?")
+		args = append(args, *updateForm.StatusSetBy)
+	}
+	if len(sets) == 0 {
+		_, err := r.Get(id)
+		return err
+	}
+
+	args = append(args, id)
+	result, err := r.db.Exec("UPDATE attendances SET "+strings.Join(sets, ", ")+" WHERE id = ?", args...)
+	if err != nil {
+		return fmt.Errorf("failed to update attendance: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update attendance: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Update the information about the user through all of the attendance records of the user.
+func (r *sqliteRepo) UpdateUserAttendance(userId string, updateForm UpdateUserAttendanceForm) error {
+	sets := []string{}
+	args := []interface{}{}
+	if updateForm.UserExternalName != nil {
+		sets = append(sets, "user_external_name = ?")
+		args = append(args, *updateForm.UserExternalName)
+	}
+	if updateForm.UserGeneration != nil {
+		sets = append(sets, "user_generation = ?")
+		args = append(args, *updateForm.UserGeneration)
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	args = append(args, userId)
+	if _, err := r.db.Exec("UPDATE attendances SET "+strings.Join(sets, ", ")+" WHERE user_id = ?", args...); err != nil {
+		return fmt.Errorf("failed to update attendances: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteRepo) query(query string, args ...interface{}) ([]Attendance, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attendances: %w", err)
+	}
+	defer rows.Close()
+
+	attendances := []Attendance{}
+	for rows.Next() {
+		var attendance Attendance
+		var sessionStartedAt, userJoinedAt, createdAt int64
+		if err := rows.Scan(&attendance.Id, &attendance.SessionId, &attendance.SessionName, &attendance.SessionScore, &sessionStartedAt,
+			&attendance.UserId, &attendance.UserExternalName, &attendance.UserGeneration, &userJoinedAt, &createdAt, &attendance.CreatedBy,
+			&attendance.Status, &attendance.StatusReason, &attendance.StatusSetBy); err != nil {
+			return nil, fmt.Errorf("failed to decode attendances: %w", err)
+		}
+		attendance.SessionStartedAt = sqlite.ToTime(sessionStartedAt)
+		attendance.UserJoinedAt = sqlite.ToTime(userJoinedAt)
+		attendance.CreatedAt = sqlite.ToTime(createdAt)
+		attendances = append(attendances, attendance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode attendances: %w", err)
+	}
+	return attendances, nil
+}
@@ -0,0 +1,47 @@
+// It keeps the append-only log of the changes that the admins made to the records of the members.
+// The entries are never updated or deleted so that the members can see what happened to their records.
+package audit
+
+import "time"
+
+type Action string
+
+const (
+	// The admin removed a wrong attendance of an applied session.
+	ActionAttendanceRemoved Action = "attendance_removed"
+	// The admin corrected the joined time or the status of an attendance of an applied session.
+	ActionAttendanceCorrected Action = "attendance_corrected"
+	// The super admin promoted or demoted a user.
+	ActionRoleChanged Action = "role_changed"
+)
+
+// A change that an admin made to a record of a member.
+type Entry struct {
+	// The ID of the entry. E.g., "abc123"
+	Id     string `json:"id"`
+	Action Action `json:"action"`
+	// The ID of the member whose record was changed. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// The ID of the session that the record belongs to. It's empty if it's not about a session. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The ID of the changed record. E.g., the ID of the attendance or the user.
+	TargetId string `json:"target_id"`
+	// Why the admin made the change. It's required for every change. E.g., "다른 회원으로 잘못 체크인"
+	Reason string `json:"reason"`
+	// The fields that were changed. The removed record has its fields with the empty values after the change.
+	Changes []Change `json:"changes"`
+	// The ID of the admin who made the change. E.g., "abc123"
+	ActorId string `json:"actor_id"`
+	// The time in UTC when the change was made.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// The value of a field before and after the change. The times are in RFC 3339.
+type Change struct {
+	// E.g., "status"
+	Field string `json:"field"`
+	// E.g., "late"
+	Before string `json:"before"`
+	// E.g., "on_time"
+	After string `json:"after"`
+}
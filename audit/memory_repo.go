@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"slices"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the audit entries in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The entries in the order of insertion.
+	entries []Entry
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		entries: []Entry{},
+	}
+}
+
+// Appends the entry with its creation time.
+func (r *memoryRepo) Add(entry Entry) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry.Id = primitive.NewObjectID().Hex()
+	entry.Changes = slices.Clone(entry.Changes)
+	if entry.Changes == nil {
+		entry.Changes = []Change{}
+	}
+	r.entries = append(r.entries, entry)
+	return entry.Id, nil
+}
+
+// Returns the entries about the records of the member in the order of the changes.
+func (r *memoryRepo) FindByUserId(userId string) ([]Entry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(entry Entry) bool { return entry.UserId == userId }), nil
+}
+
+// Returns the entries about the records of the session in the order of the changes.
+func (r *memoryRepo) FindBySessionId(sessionId string) ([]Entry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(entry Entry) bool { return entry.SessionId == sessionId }), nil
+}
+
+// Returns the copies of the entries that match the predicate.
+func (r *memoryRepo) filter(predicate func(Entry) bool) []Entry {
+	entries := []Entry{}
+	for _, entry := range r.entries {
+		if predicate(entry) {
+			entry.Changes = slices.Clone(entry.Changes)
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The audit entry in MongoDB.
+type mongodbEntry struct {
+	// The unique identifier for the entry. E.g. "1"
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// What the admin did. E.g. "attendance_removed"
+	Action Action `bson:"action"`
+	// The unique identifier for the member whose record was changed. E.g. "1"
+	UserId string `bson:"user_id"`
+	// The unique identifier for the session of the record. E.g. "1"
+	SessionId string `bson:"session_id"`
+	// The unique identifier for the changed record. E.g. "1"
+	TargetId string `bson:"target_id"`
+	// Why the admin made the change. E.g. "다른 회원으로 잘못 체크인"
+	Reason string `bson:"reason"`
+	// The fields that were changed.
+	Changes []mongodbChange `bson:"changes"`
+	// The unique identifier for the admin. E.g. "1"
+	ActorId string `bson:"actor_id"`
+	// The time when the change was made. E.g. "2025-07-01T00:00:00Z"
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type mongodbChange struct {
+	// The name of the field. E.g. "status"
+	Field string `bson:"field"`
+	// The values before and after the change. E.g. "late"
+	Before string `bson:"before"`
+	After  string `bson:"after"`
+}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+// It only appends the entries. There is no way to update or delete them.
+type Repo interface {
+	Add(entry Entry) (string, error)
+	FindByUserId(userId string) ([]Entry, error)
+	FindBySessionId(sessionId string) ([]Entry, error)
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+// Appends the entry with its creation time.
+func (r *mongodbRepo) Add(entry Entry) (string, error) {
+	changes := []mongodbChange{}
+	for _, change := range entry.Changes {
+		changes = append(changes, mongodbChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	result, err := r.collection.InsertOne(context.Background(), mongodbEntry{
+		Action:    entry.Action,
+		UserId:    entry.UserId,
+		SessionId: entry.SessionId,
+		TargetId:  entry.TargetId,
+		Reason:    entry.Reason,
+		Changes:   changes,
+		ActorId:   entry.ActorId,
+		CreatedAt: entry.CreatedAt,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to insert audit entry: %w", err)
+	}
+
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", fmt.Errorf("failed to get inserted id")
+	}
+
+	return id.Hex(), nil
+}
+
+// Returns the entries about the records of the member in the order of the changes.
+func (r *mongodbRepo) FindByUserId(userId string) ([]Entry, error) {
+	return r.find(bson.M{"user_id": userId})
+}
+
+// Returns the entries about the records of the session in the order of the changes.
+func (r *mongodbRepo) FindBySessionId(sessionId string) ([]Entry, error) {
+	return r.find(bson.M{"session_id": sessionId})
+}
+
+func (r *mongodbRepo) find(filter bson.M) ([]Entry, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongodbEntries []mongodbEntry
+	if err = cursor.All(ctx, &mongodbEntries); err != nil {
+		return nil, fmt.Errorf("failed to decode audit entries: %w", err)
+	}
+
+	entries := []Entry{}
+	for _, mongodbEntry := range mongodbEntries {
+		entries = append(entries, fromMongodbEntry(mongodbEntry))
+	}
+	return entries, nil
+}
+
+func fromMongodbEntry(entry mongodbEntry) Entry {
+	changes := []Change{}
+	for _, change := range entry.Changes {
+		changes = append(changes, Change{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	return Entry{
+		Id:        entry.Id.Hex(),
+		Action:    entry.Action,
+		UserId:    entry.UserId,
+		SessionId: entry.SessionId,
+		TargetId:  entry.TargetId,
+		Reason:    entry.Reason,
+		Changes:   changes,
+		ActorId:   entry.ActorId,
+		CreatedAt: entry.CreatedAt,
+	}
+}
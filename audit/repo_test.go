@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"rush/golang/mongotest"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	t.Run("Adds and finds the entries by the user and the session in the order of the changes", func(t *testing.T) {
+		repo := newRepo(t)
+		createdAt := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+		first, err := repo.Add(Entry{
+			Action:    ActionAttendanceCorrected,
+			UserId:    "user-id",
+			SessionId: "session-id",
+			TargetId:  "attendance-id",
+			Reason:    "체크인 시간 오류",
+			Changes:   []Change{{Field: "status", Before: "late", After: "on_time"}},
+			ActorId:   "admin-id",
+			CreatedAt: createdAt,
+		})
+		assert.NoError(t, err)
+		_, err = repo.Add(Entry{Action: ActionAttendanceRemoved, UserId: "another-user-id", SessionId: "session-id", Reason: "중복", CreatedAt: createdAt})
+		assert.NoError(t, err)
+		second, err := repo.Add(Entry{Action: ActionAttendanceRemoved, UserId: "user-id", SessionId: "another-session-id", Reason: "잘못 체크인", CreatedAt: createdAt})
+		assert.NoError(t, err)
+
+		entries, err := repo.FindByUserId("user-id")
+		assert.NoError(t, err)
+		assert.Equal(t, []Entry{
+			{
+				Id:        first,
+				Action:    ActionAttendanceCorrected,
+				UserId:    "user-id",
+				SessionId: "session-id",
+				TargetId:  "attendance-id",
+				Reason:    "체크인 시간 오류",
+				Changes:   []Change{{Field: "status", Before: "late", After: "on_time"}},
+				ActorId:   "admin-id",
+				CreatedAt: createdAt,
+			},
+			{Id: second, Action: ActionAttendanceRemoved, UserId: "user-id", SessionId: "another-session-id", Reason: "잘못 체크인", Changes: []Change{}, CreatedAt: createdAt},
+		}, entries)
+
+		sessionEntries, err := repo.FindBySessionId("session-id")
+		assert.NoError(t, err)
+		assert.Len(t, sessionEntries, 2)
+		assert.Equal(t, first, sessionEntries[0].Id)
+		assert.Equal(t, "another-user-id", sessionEntries[1].UserId)
+
+		none, err := repo.FindByUserId("unknown-user-id")
+		assert.NoError(t, err)
+		assert.Empty(t, none)
+	})
+}
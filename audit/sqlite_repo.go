@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"rush/sqlite"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteEntryColumns = "id, action, user_id, session_id, target_id, reason, changes, actor_id, created_at"
+
+// Appends the entry with its creation time.
+func (r *sqliteRepo) Add(entry Entry) (string, error) {
+	changes := entry.Changes
+	if changes == nil {
+		changes = []Change{}
+	}
+	encodedChanges, err := json.Marshal(changes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode changes: %w", err)
+	}
+
+	id := sqlite.NewId()
+	if _, err := r.db.Exec("INSERT INTO audit_entries ("+sqliteEntryColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, entry.Action, entry.UserId, entry.SessionId, entry.TargetId, entry.Reason, string(encodedChanges), entry.ActorId,
+		sqlite.FromTime(entry.CreatedAt)); err != nil {
+		return "", fmt.Errorf("failed to insert audit entry: %w", err)
+	}
+
+	return id, nil
+}
+
+// Returns the entries about the records of the member in the order of the changes.
+func (r *sqliteRepo) FindByUserId(userId string) ([]Entry, error) {
+	return r.query("SELECT "+sqliteEntryColumns+" FROM audit_entries WHERE user_id = ? ORDER BY rowid", userId)
+}
+
+// Returns the entries about the records of the session in the order of the changes.
+func (r *sqliteRepo) FindBySessionId(sessionId string) ([]Entry, error) {
+	return r.query("SELECT "+sqliteEntryColumns+" FROM audit_entries WHERE session_id = ? ORDER BY rowid", sessionId)
+}
+
+func (r *sqliteRepo) query(query string, args ...interface{}) ([]Entry, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var entry Entry
+		var changes string
+		var createdAt int64
+		if err := rows.Scan(&entry.Id, &entry.Action, &entry.UserId, &entry.SessionId, &entry.TargetId, &entry.Reason, &changes,
+			&entry.ActorId, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to decode audit entries: %w", err)
+		}
+		if err := json.Unmarshal([]byte(changes), &entry.Changes); err != nil {
+			return nil, fmt.Errorf("failed to decode changes: %w", err)
+		}
+		entry.CreatedAt = sqlite.ToTime(createdAt)
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode audit entries: %w", err)
+	}
+	return entries, nil
+}
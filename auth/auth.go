@@ -12,6 +12,9 @@ import (
 type Session struct {
 	// The ID of the user. E.g., 1234567890
 	Id string
+	// The ID of the token that the session is from. It's the jti claim. It's empty for the admin token,
+	// which is not issued by signing in. E.g., "9b1deb4d3b7d4bad9bdd2b0d7b3dcb6d"
+	TokenId string
 	// The role of the user. It is used to determine the access level of the user.
 	// E.g., member, admin, etc.
 	Role permission.Role
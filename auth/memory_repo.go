@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The repo that keeps the tokens in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The tokens by their IDs.
+	tokens map[string]*Token
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		tokens: map[string]*Token{},
+	}
+}
+
+// Keeps the issued token.
+func (r *memoryRepo) Add(token Token) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.tokens[token.Id]; ok {
+		return fmt.Errorf("failed to insert token: duplicate id %s", token.Id)
+	}
+	r.tokens[token.Id] = &token
+	return nil
+}
+
+// Returns the token by its ID. If not found, it returns ErrNotFound.
+func (r *memoryRepo) Get(id string) (Token, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	token, ok := r.tokens[id]
+	if !ok {
+		return Token{}, ErrNotFound
+	}
+	return *token, nil
+}
+
+// Revokes the token. The time of the first revocation is kept. If not found, it returns ErrNotFound.
+func (r *memoryRepo) Revoke(id string, revokedAt time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	token, ok := r.tokens[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if token.RevokedAt == nil {
+		token.RevokedAt = &revokedAt
+	}
+	return nil
+}
+
+// Revokes all the tokens of the user that are not revoked yet. E.g., when the user signs out everywhere.
+func (r *memoryRepo) RevokeAllByUserId(userId string, revokedAt time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, token := range r.tokens {
+		if token.UserId == userId && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
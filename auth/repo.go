@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The token record in MongoDB.
+type mongodbToken struct {
+	// The jti claim of the token. E.g. "9b1deb4d3b7d4bad9bdd2b0d7b3dcb6d"
+	Id string `bson:"_id"`
+	// The unique identifier for the user. E.g. "1"
+	UserId string `bson:"user_id"`
+	// The time when it was issued. E.g. "2025-07-01T00:00:00Z"
+	IssuedAt time.Time `bson:"issued_at"`
+	// The time when it expires. E.g. "2025-07-08T00:00:00Z"
+	ExpiresAt time.Time `bson:"expires_at"`
+	// The time when it was revoked. E.g. "2025-07-02T00:00:00Z"
+	RevokedAt *time.Time `bson:"revoked_at"`
+}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+var ErrNotFound = errors.New("token not found")
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	Add(token Token) error
+	Get(id string) (Token, error)
+	Revoke(id string, revokedAt time.Time) error
+	RevokeAllByUserId(userId string, revokedAt time.Time) error
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+// Keeps the issued token.
+func (r *mongodbRepo) Add(token Token) error {
+	if _, err := r.collection.InsertOne(context.Background(), mongodbToken{
+		Id:        token.Id,
+		UserId:    token.UserId,
+		IssuedAt:  token.IssuedAt,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to insert token: %w", err)
+	}
+	return nil
+}
+
+// Returns the token by its ID. If not found, it returns ErrNotFound.
+func (r *mongodbRepo) Get(id string) (Token, error) {
+	var token mongodbToken
+	if err := r.collection.FindOne(context.Background(), bson.M{"_id": id}).Decode(&token); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return Token{}, ErrNotFound
+		}
+		return Token{}, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	return Token{
+		Id:        token.Id,
+		UserId:    token.UserId,
+		IssuedAt:  token.IssuedAt,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+	}, nil
+}
+
+// Revokes the token. The time of the first revocation is kept. If not found, it returns ErrNotFound.
+func (r *mongodbRepo) Revoke(id string, revokedAt time.Time) error {
+	result, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": id},
+		bson.A{bson.M{"$set": bson.M{"revoked_at": bson.M{"$ifNull": bson.A{"$revoked_at", revokedAt}}}}})
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Revokes all the tokens of the user that are not revoked yet. E.g., when the user signs out everywhere.
+func (r *mongodbRepo) RevokeAllByUserId(userId string, revokedAt time.Time) error {
+	if _, err := r.collection.UpdateMany(context.Background(), bson.M{"user_id": userId, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": revokedAt}}); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	return nil
+}
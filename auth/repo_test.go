@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"rush/golang/mongotest"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	issuedAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := issuedAt.Add(7 * 24 * time.Hour)
+
+	t.Run("Adds and gets the token by the ID", func(t *testing.T) {
+		repo := newRepo(t)
+		token := Token{Id: "token-id", UserId: "user-id", IssuedAt: issuedAt, ExpiresAt: expiresAt}
+		assert.NoError(t, repo.Add(token))
+
+		got, err := repo.Get("token-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, token, got)
+		_, err = repo.Get("unknown-id")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Revokes the token only once", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Add(Token{Id: "token-id", UserId: "user-id", IssuedAt: issuedAt, ExpiresAt: expiresAt}))
+		revokedAt := issuedAt.Add(time.Hour)
+
+		assert.NoError(t, repo.Revoke("token-id", revokedAt))
+		assert.NoError(t, repo.Revoke("token-id", revokedAt.Add(time.Hour)))
+
+		token, err := repo.Get("token-id")
+		assert.NoError(t, err)
+		assert.Equal(t, &revokedAt, token.RevokedAt)
+		assert.ErrorIs(t, repo.Revoke("unknown-id", revokedAt), ErrNotFound)
+	})
+
+	t.Run("Revokes all the tokens of the user", func(t *testing.T) {
+		repo := newRepo(t)
+		revokedAt := issuedAt.Add(time.Hour)
+		assert.NoError(t, repo.Add(Token{Id: "revoked-token-id", UserId: "user-id", IssuedAt: issuedAt, ExpiresAt: expiresAt, RevokedAt: &revokedAt}))
+		assert.NoError(t, repo.Add(Token{Id: "token-id", UserId: "user-id", IssuedAt: issuedAt, ExpiresAt: expiresAt}))
+		assert.NoError(t, repo.Add(Token{Id: "another-token-id", UserId: "another-user-id", IssuedAt: issuedAt, ExpiresAt: expiresAt}))
+
+		assert.NoError(t, repo.RevokeAllByUserId("user-id", revokedAt.Add(time.Hour)))
+
+		revoked, err := repo.Get("revoked-token-id")
+		assert.NoError(t, err)
+		assert.Equal(t, &revokedAt, revoked.RevokedAt)
+		token, err := repo.Get("token-id")
+		assert.NoError(t, err)
+		assert.Equal(t, revokedAt.Add(time.Hour), *token.RevokedAt)
+		another, err := repo.Get("another-token-id")
+		assert.NoError(t, err)
+		assert.Nil(t, another.RevokedAt)
+	})
+}
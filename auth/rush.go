@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"rush/permission"
@@ -29,16 +31,24 @@ func NewRushAuth(adminToken string, secretKey string, clock clock.Clock) *rushAu
 	return &rushAuth{adminToken: adminToken, secretKey: []byte(secretKey), clock: clock}
 }
 
-func (r *rushAuth) SignIn(userId string, role permission.Role) (string, error) {
+// Returns the signed token and its session. The session has the ID of the token so that it can be revoked.
+func (r *rushAuth) SignIn(userId string, role permission.Role) (string, Session, error) {
 	if userId == "" {
-		return "", errors.New("user ID is empty")
+		return "", Session{}, errors.New("user ID is empty")
 	}
 
+	tokenId, err := newTokenId()
+	if err != nil {
+		return "", Session{}, fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	// JWT keeps the times in seconds.
+	expiresAt := r.clock.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
 	tokenSpec := jwt.NewWithClaims(jwt.SigningMethodHS256, rushClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenId,
 			Subject:   userId,
 			IssuedAt:  jwt.NewNumericDate(r.clock.Now()),
-			ExpiresAt: jwt.NewNumericDate(r.clock.Now().Add(7 * 24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 		Role: role,
 	})
@@ -46,7 +56,7 @@ func (r *rushAuth) SignIn(userId string, role permission.Role) (string, error) {
 	// Can not return an error because the secret key is byte slice and SHA256 is a basic golang hash function.
 	// https://github.com/golang-jwt/jwt/blob/v5.2.1/token.go#L63. https://github.com/golang-jwt/jwt/blob/v5.2.1/hmac.go#L83.
 	signedToken, _ := tokenSpec.SignedString(r.secretKey)
-	return signedToken, nil
+	return signedToken, Session{Id: userId, TokenId: tokenId, Role: role, ExpiresAt: expiresAt}, nil
 }
 
 // Get the session information from the token.
@@ -86,14 +96,28 @@ func (r *rushAuth) GetSession(token string) (Session, error) {
 	if subject == "" {
 		return Session{}, errors.New("the token does not have a subject")
 	}
+	// The tokens without the ID can't be revoked. They were issued before the tokens were kept on the server.
+	if rushClaims.ID == "" {
+		return Session{}, &InvalidTokenError{Err: errors.New("the token does not have an ID")}
+	}
 
 	return Session{
 		Id:        subject,
+		TokenId:   rushClaims.ID,
 		Role:      rushClaims.GetRole(),
 		ExpiresAt: rushClaims.ExpiresAt.Time,
 	}, nil
 }
 
+// Returns a random ID of the token. It's used as the jti claim.
+func newTokenId() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
 // TODO(#223): Check if jwt package requires it.
 func (r *rushClaims) GetRole() permission.Role {
 	return r.Role
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,10 +22,11 @@ func TestNewRushAuth(t *testing.T) {
 func TestSignInAndVerifyIdentifier(t *testing.T) {
 	t.Run("Fails if user ID is empty", func(t *testing.T) {
 		rushAuth := NewRushAuth("admin-token", "secret", clock.NewMock())
-		token, err := rushAuth.SignIn("", permission.RoleAdmin)
+		token, session, err := rushAuth.SignIn("", permission.RoleAdmin)
 
 		assert.EqualError(t, err, "user ID is empty")
 		assert.Empty(t, token)
+		assert.Equal(t, Session{}, session)
 	})
 
 	t.Run("Generates a token that has the correct claim and parse it successfully", func(t *testing.T) {
@@ -32,14 +34,21 @@ func TestSignInAndVerifyIdentifier(t *testing.T) {
 		mockClock.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
 
 		rushAuth := NewRushAuth("admin-token", "secret", mockClock)
-		token, err := rushAuth.SignIn("John Doe", permission.RoleAdmin)
+		token, issuedSession, err := rushAuth.SignIn("John Doe", permission.RoleAdmin)
 
 		assert.Nil(t, err)
+		assert.Len(t, issuedSession.TokenId, 32)
 
 		session, err := rushAuth.GetSession(token)
 		assert.Nil(t, err)
+		assert.Equal(t, issuedSession.TokenId, session.TokenId)
+		assert.True(t, issuedSession.ExpiresAt.Equal(session.ExpiresAt))
 		assert.Equal(t, "John Doe", session.Id)
 		assert.Equal(t, permission.RoleAdmin, session.Role)
+
+		_, anotherSession, err := rushAuth.SignIn("John Doe", permission.RoleAdmin)
+		assert.Nil(t, err)
+		assert.NotEqual(t, issuedSession.TokenId, anotherSession.TokenId)
 	})
 }
 
@@ -49,7 +58,7 @@ func TestGetSession(t *testing.T) {
 		mockClock.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
 
 		rushAuth := NewRushAuth("admin-token", "secret", mockClock)
-		token, err := rushAuth.SignIn("John Doe", permission.RoleAdmin)
+		token, _, err := rushAuth.SignIn("John Doe", permission.RoleAdmin)
 		assert.Nil(t, err)
 
 		mockClock.Add(14 * 24 * time.Hour)
@@ -79,7 +88,7 @@ func TestGetSession(t *testing.T) {
 		mockClock.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
 
 		rushAuth := NewRushAuth("admin-token", "secret", mockClock)
-		token, err := rushAuth.SignIn("John Doe", permission.RoleAdmin)
+		token, _, err := rushAuth.SignIn("John Doe", permission.RoleAdmin)
 		assert.Nil(t, err)
 
 		session, err := rushAuth.GetSession(token)
@@ -97,6 +106,26 @@ func TestGetSession(t *testing.T) {
 		session, err := rushAuth.GetSession("admin-token")
 		assert.Nil(t, err)
 		assert.Equal(t, "admin-token", session.Id)
+		assert.Empty(t, session.TokenId)
 		assert.Equal(t, permission.RoleSuperAdmin, session.Role)
 	})
+
+	t.Run("Returns InvalidTokenError if the token doesn't have the ID", func(t *testing.T) {
+		mockClock := clock.NewMock()
+		mockClock.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+		rushAuth := NewRushAuth("admin-token", "secret", mockClock)
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, rushClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "John Doe",
+				ExpiresAt: jwt.NewNumericDate(mockClock.Now().Add(time.Hour)),
+			},
+			Role: permission.RoleAdmin,
+		}).SignedString([]byte("secret"))
+
+		session, err := rushAuth.GetSession(token)
+
+		var invalidTokenErr *InvalidTokenError
+		assert.ErrorAs(t, err, &invalidTokenErr)
+		assert.Equal(t, Session{}, session)
+	})
 }
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"rush/sqlite"
+	"time"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+// Keeps the issued token.
+func (r *sqliteRepo) Add(token Token) error {
+	var revokedAt sql.NullInt64
+	if token.RevokedAt != nil {
+		revokedAt = sql.NullInt64{Int64: sqlite.FromTime(*token.RevokedAt), Valid: true}
+	}
+	if _, err := r.db.Exec("INSERT INTO auth_tokens (id, user_id, issued_at, expires_at, revoked_at) VALUES (?, ?, ?, ?, ?)",
+		token.Id, token.UserId, sqlite.FromTime(token.IssuedAt), sqlite.FromTime(token.ExpiresAt), revokedAt); err != nil {
+		return fmt.Errorf("failed to insert token: %w", err)
+	}
+	return nil
+}
+
+// Returns the token by its ID. If not found, it returns ErrNotFound.
+func (r *sqliteRepo) Get(id string) (Token, error) {
+	var token Token
+	var issuedAt, expiresAt int64
+	var revokedAt sql.NullInt64
+	if err := r.db.QueryRow("SELECT id, user_id, issued_at, expires_at, revoked_at FROM auth_tokens WHERE id = ?", id).
+		Scan(&token.Id, &token.UserId, &issuedAt, &expiresAt, &revokedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Token{}, ErrNotFound
+		}
+		return Token{}, fmt.Errorf("failed to get token: %w", err)
+	}
+	token.IssuedAt = sqlite.ToTime(issuedAt)
+	token.ExpiresAt = sqlite.ToTime(expiresAt)
+	if revokedAt.Valid {
+		revoked := sqlite.ToTime(revokedAt.Int64)
+		token.RevokedAt = &revoked
+	}
+	return token, nil
+}
+
+// Revokes the token. The time of the first revocation is kept. If not found, it returns ErrNotFound.
+func (r *sqliteRepo) Revoke(id string, revokedAt time.Time) error {
+	result, err := r.db.Exec("UPDATE auth_tokens SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?", sqlite.FromTime(revokedAt), id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Revokes all the tokens of the user that are not revoked yet. E.g., when the user signs out everywhere.
+func (r *sqliteRepo) RevokeAllByUserId(userId string, revokedAt time.Time) error {
+	if _, err := r.db.Exec("UPDATE auth_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL", sqlite.FromTime(revokedAt), userId); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package auth
+
+import "time"
+
+// The token issued by signing in. It's kept on the server so that it can be revoked before it expires.
+type Token struct {
+	// The ID of the token. It's the jti claim. E.g., "9b1deb4d3b7d4bad9bdd2b0d7b3dcb6d"
+	Id string
+	// The ID of the user who signed in. E.g., "abc123"
+	UserId string
+	// The time in UTC when it was issued.
+	IssuedAt time.Time
+	// The time in UTC when it expires.
+	ExpiresAt time.Time
+	// The time in UTC when it was revoked. It's nil if it's not revoked.
+	RevokedAt *time.Time
+}
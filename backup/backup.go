@@ -0,0 +1,375 @@
+// It reads the backup files exported before rolling over to a new term.
+// The file is `rush_backup_YYYYMMDD_HHMMSS.json` that has the raw documents of each collection.
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The collections in the backup file. They are the keys of the JSON object.
+const (
+	CollectionUsers       = "users"
+	CollectionSessions    = "sessions"
+	CollectionAttendances = "attendances"
+	CollectionTerms       = "terms"
+)
+
+// The type of the field that JSON can not tell.
+// E.g., ObjectID and time are exported as strings, and 9.0 is exported as 9.
+type fieldType string
+
+const (
+	fieldTypeObjectId fieldType = "object_id"
+	fieldTypeDateTime fieldType = "date_time"
+	fieldTypeString   fieldType = "string"
+	fieldTypeInt      fieldType = "int"
+	fieldTypeFloat    fieldType = "float"
+	fieldTypeBool     fieldType = "bool"
+	// The array of floats. E.g., the generations of a term where 9.0 is exported as 9.
+	fieldTypeFloats fieldType = "floats"
+)
+
+// The field types of each collection. It should be in sync with the MongoDB records of each repo.
+// The fields of the nested documents are listed by their paths, e.g., "meeting_point.latitude".
+// Fields that are not listed here are restored as they are read from JSON.
+var schemas = map[string]map[string]fieldType{
+	CollectionUsers: {
+		"_id":           fieldTypeObjectId,
+		"name":          fieldTypeString,
+		"role":          fieldTypeString,
+		"generation":    fieldTypeFloat,
+		"is_active":     fieldTypeBool,
+		"email":         fieldTypeString,
+		"external_name": fieldTypeString,
+	},
+	CollectionSessions: {
+		"_id":                           fieldTypeObjectId,
+		"name":                          fieldTypeString,
+		"description":                   fieldTypeString,
+		"created_by":                    fieldTypeString,
+		"created_at":                    fieldTypeDateTime,
+		"starts_at":                     fieldTypeDateTime,
+		"score":                         fieldTypeInt,
+		"attendance_status":             fieldTypeString,
+		"attendance_ignored_reason":     fieldTypeString,
+		"is_deleted":                    fieldTypeBool,
+		"series_id":                     fieldTypeString,
+		"capacity":                      fieldTypeInt,
+		"attendance_source.provider":    fieldTypeString,
+		"attendance_source.external_id": fieldTypeString,
+		"attendance_source.uri":         fieldTypeString,
+		"meeting_point.latitude":        fieldTypeFloat,
+		"meeting_point.longitude":       fieldTypeFloat,
+		"meeting_point.radius_meters":   fieldTypeFloat,
+	},
+	CollectionAttendances: {
+		"_id":                fieldTypeObjectId,
+		"session_id":         fieldTypeString,
+		"session_name":       fieldTypeString,
+		"session_score":      fieldTypeInt,
+		"session_started_at": fieldTypeDateTime,
+		"user_id":            fieldTypeString,
+		"user_external_name": fieldTypeString,
+		"user_generation":    fieldTypeFloat,
+		"user_joined_at":     fieldTypeDateTime,
+		"created_at":         fieldTypeDateTime,
+		"created_by":         fieldTypeString,
+		"force_apply":        fieldTypeBool,
+		"status":             fieldTypeString,
+		"status_reason":      fieldTypeString,
+		"status_set_by":      fieldTypeString,
+	},
+	CollectionTerms: {
+		"_id":                                 fieldTypeObjectId,
+		"name":                                fieldTypeString,
+		"starts_at":                           fieldTypeDateTime,
+		"ends_at":                             fieldTypeDateTime,
+		"generations":                         fieldTypeFloats,
+		"is_archived":                         fieldTypeBool,
+		"scoring_rules.minimum_score":         fieldTypeInt,
+		"scoring_rules.late_grace_minutes":    fieldTypeInt,
+		"scoring_rules.late_penalty":          fieldTypeInt,
+		"scoring_rules.weekly_cap":            fieldTypeInt,
+		"scoring_rules.exclude_force_applied": fieldTypeBool,
+		"created_at":                          fieldTypeDateTime,
+		"is_deleted":                          fieldTypeBool,
+	},
+}
+
+// The collections in the order of restoring.
+var Collections = []string{CollectionUsers, CollectionSessions, CollectionAttendances, CollectionTerms}
+
+// The collections that the backups exported before they were added don't have.
+// They are left as they are when restoring such a backup.
+var optionalCollections = map[string]bool{CollectionTerms: true}
+
+// The backup that is decoded to the BSON documents with their original types.
+type Backup struct {
+	// The time when the backup was exported.
+	ExportedAt time.Time
+	// The documents of each collection. The key is the collection name such as `CollectionUsers`.
+	Documents map[string][]bson.D
+	// The problems that don't block restoring but should be checked. E.g., an attendance of an unknown user.
+	Warnings []string
+}
+
+// Reads and validates the backup file.
+func Read(path string) (*Backup, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return Decode(data)
+}
+
+// Decodes and validates the backup JSON.
+// It fails if any document can not be restored with its original types.
+func Decode(data []byte) (*Backup, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep the numbers as they are to decide int or float by the schema.
+	decoder.UseNumber()
+
+	var raw map[string]json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode backup: %w", err)
+	}
+
+	backup := &Backup{Documents: map[string][]bson.D{}, Warnings: []string{}}
+	exportedAt, ok := raw["exported_at"]
+	if !ok {
+		return nil, fmt.Errorf("exported_at is missing")
+	}
+	var exportedAtString string
+	if err := json.Unmarshal(exportedAt, &exportedAtString); err != nil {
+		return nil, fmt.Errorf("invalid exported_at: %w", err)
+	}
+	parsedExportedAt, err := time.Parse(time.RFC3339, exportedAtString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exported_at: %w", err)
+	}
+	backup.ExportedAt = parsedExportedAt
+
+	for _, collection := range Collections {
+		rawDocs, ok := raw[collection]
+		if !ok {
+			if optionalCollections[collection] {
+				backup.Warnings = append(backup.Warnings, fmt.Sprintf("collection %s is missing and will not be restored", collection))
+				continue
+			}
+			return nil, fmt.Errorf("collection %s is missing", collection)
+		}
+
+		docsDecoder := json.NewDecoder(bytes.NewReader(rawDocs))
+		docsDecoder.UseNumber()
+		var docs []map[string]interface{}
+		if err := docsDecoder.Decode(&docs); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", collection, err)
+		}
+
+		ids := map[primitive.ObjectID]bool{}
+		converted := make([]bson.D, 0, len(docs))
+		for index, doc := range docs {
+			convertedDoc, err := convertDocument(schemas[collection], doc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid document in %s at %d: %w", collection, index, err)
+			}
+			id := convertedDoc.Map()["_id"].(primitive.ObjectID)
+			if ids[id] {
+				return nil, fmt.Errorf("duplicate _id in %s: %s", collection, id.Hex())
+			}
+			ids[id] = true
+			converted = append(converted, convertedDoc)
+		}
+		backup.Documents[collection] = converted
+	}
+
+	backup.Warnings = append(backup.Warnings, findDanglingReferences(backup.Documents)...)
+	return backup, nil
+}
+
+// Returns the warnings for the attendances that refer to the users or the sessions not in the backup.
+func findDanglingReferences(documents map[string][]bson.D) []string {
+	ids := func(collection string) map[string]bool {
+		result := map[string]bool{}
+		for _, doc := range documents[collection] {
+			result[doc.Map()["_id"].(primitive.ObjectID).Hex()] = true
+		}
+		return result
+	}
+	userIds := ids(CollectionUsers)
+	sessionIds := ids(CollectionSessions)
+
+	warnings := []string{}
+	for _, doc := range documents[CollectionAttendances] {
+		fields := doc.Map()
+		id := fields["_id"].(primitive.ObjectID).Hex()
+		if userId, _ := fields["user_id"].(string); !userIds[userId] {
+			warnings = append(warnings, fmt.Sprintf("attendance %s refers to the unknown user %s", id, userId))
+		}
+		if sessionId, _ := fields["session_id"].(string); !sessionIds[sessionId] {
+			warnings = append(warnings, fmt.Sprintf("attendance %s refers to the unknown session %s", id, sessionId))
+		}
+	}
+	return warnings
+}
+
+func convertDocument(schema map[string]fieldType, doc map[string]interface{}) (bson.D, error) {
+	if doc["_id"] == nil {
+		return nil, fmt.Errorf("_id is missing")
+	}
+
+	return convertFields(schema, "", doc)
+}
+
+// Converts the fields of the document or the nested document at the path.
+func convertFields(schema map[string]fieldType, path string, doc map[string]interface{}) (bson.D, error) {
+	// Sort the keys to keep the result deterministic.
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	converted := bson.D{}
+	for _, key := range keys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		value, err := convertField(schema, fieldPath, doc[key])
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %s: %w", fieldPath, err)
+		}
+		converted = append(converted, bson.E{Key: key, Value: value})
+	}
+	return converted, nil
+}
+
+// Converts the value by the type of its path in the schema.
+// The nested documents are converted by the paths of their fields so that they keep their types as well.
+func convertField(schema map[string]fieldType, path string, value interface{}) (interface{}, error) {
+	if fieldType, ok := schema[path]; ok {
+		return convertValue(fieldType, value)
+	}
+	if nested, ok := value.(map[string]interface{}); ok && hasNestedSchema(schema, path) {
+		return convertFields(schema, path, nested)
+	}
+	return convertUnknownValue(value), nil
+}
+
+// Returns true if any field under the path is in the schema.
+func hasNestedSchema(schema map[string]fieldType, path string) bool {
+	for fieldPath := range schema {
+		if strings.HasPrefix(fieldPath, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func convertValue(fieldType fieldType, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch fieldType {
+	case fieldTypeObjectId:
+		hex, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an object ID but got %v", value)
+		}
+		return primitive.ObjectIDFromHex(hex)
+	case fieldTypeDateTime:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a time but got %v", value)
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, text)
+		if err != nil {
+			return nil, err
+		}
+		return primitive.NewDateTimeFromTime(parsed), nil
+	case fieldTypeString:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string but got %v", value)
+		}
+		return text, nil
+	case fieldTypeInt:
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("expected an integer but got %v", value)
+		}
+		parsed, err := number.Int64()
+		if err != nil {
+			return nil, err
+		}
+		// The driver stores Go int as int32 if it fits.
+		if parsed >= -1<<31 && parsed < 1<<31 {
+			return int32(parsed), nil
+		}
+		return parsed, nil
+	case fieldTypeFloat:
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("expected a number but got %v", value)
+		}
+		return number.Float64()
+	case fieldTypeBool:
+		boolean, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a boolean but got %v", value)
+		}
+		return boolean, nil
+	case fieldTypeFloats:
+		elems, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an array but got %v", value)
+		}
+		converted := bson.A{}
+		for _, elem := range elems {
+			number, err := convertValue(fieldTypeFloat, elem)
+			if err != nil {
+				return nil, err
+			}
+			converted = append(converted, number)
+		}
+		return converted, nil
+	}
+
+	return convertUnknownValue(value), nil
+}
+
+// Converts the value whose type is not in the schema as close as possible to what it was.
+func convertUnknownValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case json.Number:
+		if parsed, err := typed.Int64(); err == nil {
+			return parsed
+		}
+		parsed, _ := typed.Float64()
+		return parsed
+	case []interface{}:
+		converted := bson.A{}
+		for _, elem := range typed {
+			converted = append(converted, convertUnknownValue(elem))
+		}
+		return converted
+	case map[string]interface{}:
+		converted := bson.M{}
+		for key, elem := range typed {
+			converted[key] = convertUnknownValue(elem)
+		}
+		return converted
+	}
+	return value
+}
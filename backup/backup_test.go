@@ -0,0 +1,199 @@
+package backup
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDecode(t *testing.T) {
+	t.Run("Fails if a collection is missing", func(t *testing.T) {
+		_, err := Decode([]byte(`{"exported_at": "2025-06-30T12:00:00+09:00", "users": [], "sessions": []}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Fails if _id is not an object ID", func(t *testing.T) {
+		_, err := Decode([]byte(`{
+			"exported_at": "2025-06-30T12:00:00+09:00",
+			"users": [{"_id": "not-an-object-id", "name": "김건"}],
+			"sessions": [],
+			"attendances": []
+		}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Fails if _id is duplicated", func(t *testing.T) {
+		_, err := Decode([]byte(`{
+			"exported_at": "2025-06-30T12:00:00+09:00",
+			"users": [{"_id": "6680d1f0a1b2c3d4e5f60718"}, {"_id": "6680d1f0a1b2c3d4e5f60718"}],
+			"sessions": [],
+			"attendances": []
+		}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Fails if the type is not expected", func(t *testing.T) {
+		_, err := Decode([]byte(`{
+			"exported_at": "2025-06-30T12:00:00+09:00",
+			"users": [{"_id": "6680d1f0a1b2c3d4e5f60718", "is_active": "true"}],
+			"sessions": [],
+			"attendances": []
+		}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Restores the original types", func(t *testing.T) {
+		backup, err := Decode([]byte(`{
+			"exported_at": "2025-06-30T12:00:00+09:00",
+			"users": [{"_id": "6680d1f0a1b2c3d4e5f60718", "name": "김건", "generation": 9, "is_active": true}],
+			"sessions": [{"_id": "6680d1f0a1b2c3d4e5f60719", "starts_at": "2025-06-01T11:00:00Z", "score": 2, "is_deleted": false}],
+			"attendances": [{
+				"_id": "6680d1f0a1b2c3d4e5f6071a",
+				"session_id": "6680d1f0a1b2c3d4e5f60719",
+				"user_id": "6680d1f0a1b2c3d4e5f60799",
+				"user_generation": 9.5,
+				"session_score": 2,
+				"unknown_field": 3
+			}],
+			"terms": []
+		}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 6, 30, 3, 0, 0, 0, time.UTC), backup.ExportedAt.UTC())
+		assert.Equal(t, []bson.D{{
+			{Key: "_id", Value: must.OK1(primitive.ObjectIDFromHex("6680d1f0a1b2c3d4e5f60718"))},
+			// 9 is exported as an integer but it's restored as a float.
+			{Key: "generation", Value: float64(9)},
+			{Key: "is_active", Value: true},
+			{Key: "name", Value: "김건"},
+		}}, backup.Documents[CollectionUsers])
+		assert.Equal(t, []bson.D{{
+			{Key: "_id", Value: must.OK1(primitive.ObjectIDFromHex("6680d1f0a1b2c3d4e5f60719"))},
+			{Key: "is_deleted", Value: false},
+			{Key: "score", Value: int32(2)},
+			{Key: "starts_at", Value: primitive.NewDateTimeFromTime(time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC))},
+		}}, backup.Documents[CollectionSessions])
+		assert.Equal(t, []bson.D{{
+			{Key: "_id", Value: must.OK1(primitive.ObjectIDFromHex("6680d1f0a1b2c3d4e5f6071a"))},
+			{Key: "session_id", Value: "6680d1f0a1b2c3d4e5f60719"},
+			{Key: "session_score", Value: int32(2)},
+			{Key: "unknown_field", Value: int64(3)},
+			{Key: "user_generation", Value: 9.5},
+			{Key: "user_id", Value: "6680d1f0a1b2c3d4e5f60799"},
+		}}, backup.Documents[CollectionAttendances])
+		assert.Equal(t, []string{"attendance 6680d1f0a1b2c3d4e5f6071a refers to the unknown user 6680d1f0a1b2c3d4e5f60799"}, backup.Warnings)
+	})
+
+	t.Run("Restores the session with the meeting point as it was exported", func(t *testing.T) {
+		// The fields of the session record in MongoDB that are nested.
+		type meetingPoint struct {
+			Latitude     float64 `bson:"latitude"`
+			Longitude    float64 `bson:"longitude"`
+			RadiusMeters float64 `bson:"radius_meters"`
+		}
+		type attendanceSource struct {
+			Provider   string `bson:"provider"`
+			ExternalId string `bson:"external_id"`
+		}
+		type session struct {
+			Id               primitive.ObjectID `bson:"_id"`
+			StartsAt         time.Time          `bson:"starts_at"`
+			Capacity         int                `bson:"capacity"`
+			AttendanceSource attendanceSource   `bson:"attendance_source"`
+			MeetingPoint     meetingPoint       `bson:"meeting_point"`
+		}
+		original := session{
+			Id:               must.OK1(primitive.ObjectIDFromHex("6680d1f0a1b2c3d4e5f60719")),
+			StartsAt:         time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC),
+			Capacity:         20,
+			AttendanceSource: attendanceSource{Provider: "check_in", ExternalId: "6680d1f0a1b2c3d4e5f60719"},
+			// The whole numbers are exported as integers.
+			MeetingPoint: meetingPoint{Latitude: 37, Longitude: 126.9326, RadiusMeters: 100},
+		}
+		// Exported the same way as the rollover command does.
+		var exported bson.M
+		assert.NoError(t, bson.Unmarshal(must.OK1(bson.Marshal(original)), &exported))
+		data := must.OK1(json.Marshal(map[string]interface{}{
+			"exported_at": "2025-06-30T12:00:00+09:00",
+			"users":       []bson.M{},
+			"sessions":    []bson.M{exported},
+			"attendances": []bson.M{},
+			"terms":       []bson.M{},
+		}))
+
+		backup, err := Decode(data)
+
+		assert.NoError(t, err)
+		restoredDoc := backup.Documents[CollectionSessions][0]
+		meetingPointDoc := restoredDoc.Map()["meeting_point"].(bson.D).Map()
+		assert.Equal(t, float64(100), meetingPointDoc["radius_meters"])
+		assert.Equal(t, float64(37), meetingPointDoc["latitude"])
+		var restored session
+		assert.NoError(t, bson.Unmarshal(must.OK1(bson.Marshal(restoredDoc)), &restored))
+		assert.Equal(t, original, restored)
+	})
+	t.Run("Restores the term with its generations and scoring rules as it was exported", func(t *testing.T) {
+		// The fields of the term record in MongoDB.
+		type scoringRules struct {
+			MinimumScore        int  `bson:"minimum_score"`
+			LateGraceMinutes    int  `bson:"late_grace_minutes"`
+			LatePenalty         int  `bson:"late_penalty"`
+			WeeklyCap           int  `bson:"weekly_cap"`
+			ExcludeForceApplied bool `bson:"exclude_force_applied"`
+		}
+		type term struct {
+			Id           primitive.ObjectID `bson:"_id"`
+			Name         string             `bson:"name"`
+			StartsAt     time.Time          `bson:"starts_at"`
+			EndsAt       time.Time          `bson:"ends_at"`
+			Generations  []float64          `bson:"generations"`
+			IsArchived   bool               `bson:"is_archived"`
+			ScoringRules scoringRules       `bson:"scoring_rules"`
+			IsDeleted    bool               `bson:"is_deleted"`
+		}
+		original := term{
+			Id:       must.OK1(primitive.ObjectIDFromHex("6680d1f0a1b2c3d4e5f6071b")),
+			Name:     "2025-1",
+			StartsAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			// The whole numbers are exported as integers.
+			Generations:  []float64{9, 9.5, 10},
+			IsArchived:   true,
+			ScoringRules: scoringRules{MinimumScore: 20, LateGraceMinutes: 10, LatePenalty: 1, WeeklyCap: 6, ExcludeForceApplied: true},
+		}
+		// Exported the same way as the rollover command does.
+		var exported bson.M
+		assert.NoError(t, bson.Unmarshal(must.OK1(bson.Marshal(original)), &exported))
+		data := must.OK1(json.Marshal(map[string]interface{}{
+			"exported_at": "2025-06-30T12:00:00+09:00",
+			"users":       []bson.M{},
+			"sessions":    []bson.M{},
+			"attendances": []bson.M{},
+			"terms":       []bson.M{exported},
+		}))
+
+		backup, err := Decode(data)
+
+		assert.NoError(t, err)
+		restoredDoc := backup.Documents[CollectionTerms][0]
+		assert.Equal(t, bson.A{float64(9), 9.5, float64(10)}, restoredDoc.Map()["generations"])
+		assert.Equal(t, int32(20), restoredDoc.Map()["scoring_rules"].(bson.D).Map()["minimum_score"])
+		var restored term
+		assert.NoError(t, bson.Unmarshal(must.OK1(bson.Marshal(restoredDoc)), &restored))
+		assert.Equal(t, original, restored)
+	})
+
+	t.Run("Leaves the terms untouched if the backup was exported before they were backed up", func(t *testing.T) {
+		backup, err := Decode([]byte(`{"exported_at": "2025-06-30T12:00:00+09:00", "users": [], "sessions": [], "attendances": []}`))
+
+		assert.NoError(t, err)
+		_, ok := backup.Documents[CollectionTerms]
+		assert.False(t, ok)
+		assert.Equal(t, []string{"collection terms is missing and will not be restored"}, backup.Warnings)
+	})
+}
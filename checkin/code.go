@@ -0,0 +1,71 @@
+// It issues and verifies the codes that the members submit to check in to a session at the meeting point.
+package checkin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// The code that is valid until `ExpiresAt`. The admin shows it as a QR code or as it is.
+type Code struct {
+	// The 6-digit code. E.g., "012345"
+	Value string
+	// The time when the next code is issued.
+	ExpiresAt time.Time
+}
+
+type codeIssuer struct {
+	secretKey []byte
+	// How long each code is shown. E.g., 30 seconds
+	ttl   time.Duration
+	clock clock.Clock
+}
+
+func NewCodeIssuer(secretKey string, ttl time.Duration, clock clock.Clock) *codeIssuer {
+	return &codeIssuer{
+		secretKey: []byte(secretKey),
+		ttl:       ttl,
+		clock:     clock,
+	}
+}
+
+// Returns the code of the session for the current period.
+// The code rotates every `ttl` and is signed per session so that it can't be reused for other sessions.
+func (c *codeIssuer) Issue(sessionId string) Code {
+	period := c.period(c.clock.Now())
+	return Code{
+		Value:     c.sign(sessionId, period),
+		ExpiresAt: time.Unix(0, (period+1)*int64(c.ttl)).UTC(),
+	}
+}
+
+// Checks if the code is the one of the session for the current or the previous period.
+// The previous one is accepted as well since the code may rotate while the member is typing it.
+func (c *codeIssuer) Verify(sessionId string, code string) bool {
+	period := c.period(c.clock.Now())
+	for _, candidate := range []int64{period, period - 1} {
+		if hmac.Equal([]byte(c.sign(sessionId, candidate)), []byte(code)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *codeIssuer) period(now time.Time) int64 {
+	return now.UnixNano() / int64(c.ttl)
+}
+
+// Truncates the HMAC of the session and the period to 6 digits in the same way as HOTP (RFC 4226).
+func (c *codeIssuer) sign(sessionId string, period int64) string {
+	mac := hmac.New(sha256.New, c.secretKey)
+	mac.Write([]byte(fmt.Sprintf("%s:%d", sessionId, period)))
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	truncated := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", truncated%1000000)
+}
@@ -0,0 +1,44 @@
+package checkin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeIssuer(t *testing.T) {
+	t.Run("Issues a 6-digit code that expires at the end of the period", func(t *testing.T) {
+		mockClock := clock.NewMock()
+		mockClock.Set(time.Date(2025, 7, 1, 20, 0, 10, 0, time.UTC))
+		issuer := NewCodeIssuer("secret", 30*time.Second, mockClock)
+
+		code := issuer.Issue("session-id")
+
+		assert.Len(t, code.Value, 6)
+		assert.Equal(t, time.Date(2025, 7, 1, 20, 0, 30, 0, time.UTC), code.ExpiresAt)
+		assert.True(t, issuer.Verify("session-id", code.Value))
+	})
+
+	t.Run("Accepts the code of the previous period only", func(t *testing.T) {
+		mockClock := clock.NewMock()
+		mockClock.Set(time.Date(2025, 7, 1, 20, 0, 10, 0, time.UTC))
+		issuer := NewCodeIssuer("secret", 30*time.Second, mockClock)
+		code := issuer.Issue("session-id")
+
+		mockClock.Add(30 * time.Second)
+		assert.True(t, issuer.Verify("session-id", code.Value))
+		mockClock.Add(30 * time.Second)
+		assert.False(t, issuer.Verify("session-id", code.Value))
+	})
+
+	t.Run("Rejects the code of another session or another key", func(t *testing.T) {
+		mockClock := clock.NewMock()
+		issuer := NewCodeIssuer("secret", 30*time.Second, mockClock)
+		code := issuer.Issue("session-id")
+
+		assert.False(t, issuer.Verify("other-session-id", code.Value))
+		assert.False(t, NewCodeIssuer("other-secret", 30*time.Second, mockClock).Verify("session-id", code.Value))
+	})
+}
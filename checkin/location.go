@@ -0,0 +1,17 @@
+package checkin
+
+import "math"
+
+// The mean radius of the Earth in meters.
+const earthRadiusMeters = 6371000
+
+// Returns the great-circle distance in meters between the two coordinates by the haversine formula.
+// It's accurate enough for a geofence of a few hundred meters.
+func Distance(latitude1 float64, longitude1 float64, latitude2 float64, longitude2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	deltaLatitude := toRadians(latitude2 - latitude1)
+	deltaLongitude := toRadians(longitude2 - longitude1)
+	a := math.Pow(math.Sin(deltaLatitude/2), 2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Pow(math.Sin(deltaLongitude/2), 2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
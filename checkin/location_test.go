@@ -0,0 +1,20 @@
+package checkin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	t.Run("Returns zero for the same coordinates", func(t *testing.T) {
+		assert.Equal(t, 0.0, Distance(37.5283, 126.9326, 37.5283, 126.9326))
+	})
+
+	t.Run("Returns the distance in meters", func(t *testing.T) {
+		// 0.001 degree of latitude is about 111 meters anywhere.
+		assert.InDelta(t, 111, Distance(37.5283, 126.9326, 37.5293, 126.9326), 1)
+		// A degree of longitude gets shorter towards the poles. It's about 88 km at Seoul.
+		assert.InDelta(t, 88, Distance(37.5283, 126.9326, 37.5283, 126.9336), 1)
+	})
+}
@@ -0,0 +1,46 @@
+package checkin
+
+import (
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the rejections in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The rejections in the order of insertion.
+	rejections []Rejection
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		rejections: []Rejection{},
+	}
+}
+
+func (r *memoryRepo) AddRejection(rejection Rejection) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rejection.Id = primitive.NewObjectID().Hex()
+	r.rejections = append(r.rejections, rejection)
+	return rejection.Id, nil
+}
+
+// Returns the rejections of the session in the order of the attempts.
+func (r *memoryRepo) FindRejectionsBySessionId(sessionId string) ([]Rejection, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rejections := []Rejection{}
+	for _, rejection := range r.rejections {
+		if rejection.SessionId == sessionId {
+			rejections = append(rejections, rejection)
+		}
+	}
+	sort.SliceStable(rejections, func(i, j int) bool { return rejections[i].AttemptedAt.Before(rejections[j].AttemptedAt) })
+	return rejections, nil
+}
@@ -0,0 +1,39 @@
+package checkin
+
+import "time"
+
+// The check-in that is rejected. It's kept so that the admins can review whether the member was really there,
+// e.g., when the GPS of the phone is not accurate. The invalid codes are kept to lock out guessing them as well.
+type Rejection struct {
+	// The ID of the rejection. E.g., "abc123"
+	Id string `json:"id"`
+	// The ID of the session that the member tried to check in to. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The ID of the member who tried to check in. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// The coordinates that the member submitted. E.g., 37.5283, 126.9326
+	// They are zero for the check-ins with the code.
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// The accuracy of the coordinates in meters that the device reported. E.g., 15
+	AccuracyMeters float64 `json:"accuracy_meters"`
+	// The distance in meters from the meeting point. E.g., 320.5
+	DistanceMeters float64 `json:"distance_meters"`
+	// Why it's rejected.
+	Reason RejectionReason `json:"reason"`
+	// The time in UTC when the member tried to check in.
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+type RejectionReason string
+
+const (
+	// The member tried to check in too early or too late.
+	RejectionReasonOutsideTimeWindow RejectionReason = "outside_time_window"
+	// The member is farther from the meeting point than the radius.
+	RejectionReasonOutsideGeofence RejectionReason = "outside_geofence"
+	// The accuracy of the location is worse than the radius so it can't tell if the member is inside.
+	RejectionReasonInaccurateLocation RejectionReason = "inaccurate_location"
+	// The member submitted a code that is not the current one of the session.
+	RejectionReasonInvalidCode RejectionReason = "invalid_code"
+)
@@ -0,0 +1,110 @@
+package checkin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The rejected check-in record in MongoDB.
+type mongodbRejection struct {
+	// The unique identifier for the rejection. E.g. "1"
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// The unique identifier for the session. E.g. "1"
+	SessionId string `bson:"session_id"`
+	// The unique identifier for the user who tried to check in. E.g. "1"
+	UserId string `bson:"user_id"`
+	// The latitude that the user submitted. E.g. 37.5283
+	Latitude float64 `bson:"latitude"`
+	// The longitude that the user submitted. E.g. 126.9326
+	Longitude float64 `bson:"longitude"`
+	// The accuracy of the location in meters. E.g. 15
+	AccuracyMeters float64 `bson:"accuracy_meters"`
+	// The distance in meters from the meeting point. E.g. 320.5
+	DistanceMeters float64 `bson:"distance_meters"`
+	// Why it's rejected. E.g. "outside_geofence"
+	Reason RejectionReason `bson:"reason"`
+	// The time when the user tried to check in. E.g. "2025-07-01T00:00:00Z"
+	AttemptedAt time.Time `bson:"attempted_at"`
+}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	AddRejection(rejection Rejection) (string, error)
+	FindRejectionsBySessionId(sessionId string) ([]Rejection, error)
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+func (r *mongodbRepo) AddRejection(rejection Rejection) (string, error) {
+	result, err := r.collection.InsertOne(context.Background(), mongodbRejection{
+		SessionId:      rejection.SessionId,
+		UserId:         rejection.UserId,
+		Latitude:       rejection.Latitude,
+		Longitude:      rejection.Longitude,
+		AccuracyMeters: rejection.AccuracyMeters,
+		DistanceMeters: rejection.DistanceMeters,
+		Reason:         rejection.Reason,
+		AttemptedAt:    rejection.AttemptedAt,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to insert rejection: %w", err)
+	}
+
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", fmt.Errorf("failed to get inserted id")
+	}
+
+	return id.Hex(), nil
+}
+
+// Returns the rejections of the session in the order of the attempts.
+func (r *mongodbRepo) FindRejectionsBySessionId(sessionId string) ([]Rejection, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"session_id": sessionId},
+		options.Find().SetSort(bson.D{{Key: "attempted_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rejections: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongodbRejections []mongodbRejection
+	if err := cursor.All(ctx, &mongodbRejections); err != nil {
+		return nil, fmt.Errorf("failed to decode rejections: %w", err)
+	}
+
+	rejections := []Rejection{}
+	for _, mongodbRejection := range mongodbRejections {
+		rejections = append(rejections, *fromMongodbRejection(&mongodbRejection))
+	}
+	return rejections, nil
+}
+
+func fromMongodbRejection(rejection *mongodbRejection) *Rejection {
+	return &Rejection{
+		Id:             rejection.Id.Hex(),
+		SessionId:      rejection.SessionId,
+		UserId:         rejection.UserId,
+		Latitude:       rejection.Latitude,
+		Longitude:      rejection.Longitude,
+		AccuracyMeters: rejection.AccuracyMeters,
+		DistanceMeters: rejection.DistanceMeters,
+		Reason:         rejection.Reason,
+		AttemptedAt:    rejection.AttemptedAt,
+	}
+}
@@ -0,0 +1,62 @@
+package checkin
+
+import (
+	"rush/golang/mongotest"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	t.Run("Adds and finds the rejections of the session in the order of the attempts", func(t *testing.T) {
+		repo := newRepo(t)
+		later := Rejection{
+			SessionId:      "session-id",
+			UserId:         "user-id",
+			Latitude:       37.5283,
+			Longitude:      126.9326,
+			AccuracyMeters: 15,
+			DistanceMeters: 320.5,
+			Reason:         RejectionReasonOutsideGeofence,
+			AttemptedAt:    time.Date(2025, 7, 1, 20, 5, 0, 0, time.UTC),
+		}
+		earlier := later
+		earlier.Reason = RejectionReasonOutsideTimeWindow
+		earlier.AttemptedAt = time.Date(2025, 7, 1, 19, 0, 0, 0, time.UTC)
+		other := later
+		other.SessionId = "other-session-id"
+
+		laterId, err := repo.AddRejection(later)
+		assert.NoError(t, err)
+		earlierId, err := repo.AddRejection(earlier)
+		assert.NoError(t, err)
+		_, err = repo.AddRejection(other)
+		assert.NoError(t, err)
+
+		rejections, err := repo.FindRejectionsBySessionId("session-id")
+		assert.NoError(t, err)
+		earlier.Id = earlierId
+		later.Id = laterId
+		assert.Equal(t, []Rejection{earlier, later}, rejections)
+
+		rejections, err = repo.FindRejectionsBySessionId("unknown-session-id")
+		assert.NoError(t, err)
+		assert.Empty(t, rejections)
+	})
+}
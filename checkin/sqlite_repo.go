@@ -0,0 +1,55 @@
+package checkin
+
+import (
+	"database/sql"
+	"fmt"
+	"rush/sqlite"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteRejectionColumns = "id, session_id, user_id, latitude, longitude, accuracy_meters, distance_meters, reason, attempted_at"
+
+func (r *sqliteRepo) AddRejection(rejection Rejection) (string, error) {
+	id := sqlite.NewId()
+	if _, err := r.db.Exec("INSERT INTO check_in_rejections ("+sqliteRejectionColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, rejection.SessionId, rejection.UserId, rejection.Latitude, rejection.Longitude, rejection.AccuracyMeters, rejection.DistanceMeters,
+		rejection.Reason, sqlite.FromTime(rejection.AttemptedAt)); err != nil {
+		return "", fmt.Errorf("failed to insert rejection: %w", err)
+	}
+
+	return id, nil
+}
+
+// Returns the rejections of the session in the order of the attempts.
+func (r *sqliteRepo) FindRejectionsBySessionId(sessionId string) ([]Rejection, error) {
+	rows, err := r.db.Query("SELECT "+sqliteRejectionColumns+" FROM check_in_rejections WHERE session_id = ? ORDER BY attempted_at, rowid", sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rejections: %w", err)
+	}
+	defer rows.Close()
+
+	rejections := []Rejection{}
+	for rows.Next() {
+		var rejection Rejection
+		var attemptedAt int64
+		if err := rows.Scan(&rejection.Id, &rejection.SessionId, &rejection.UserId, &rejection.Latitude, &rejection.Longitude,
+			&rejection.AccuracyMeters, &rejection.DistanceMeters, &rejection.Reason, &attemptedAt); err != nil {
+			return nil, fmt.Errorf("failed to decode rejections: %w", err)
+		}
+		rejection.AttemptedAt = sqlite.ToTime(attemptedAt)
+		rejections = append(rejections, rejection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode rejections: %w", err)
+	}
+	return rejections, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"rush/migrate"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Applies the pending MongoDB migrations. The server also applies them at startup
+// unless `MONGODB_MIGRATE_ON_STARTUP` is false.
+func main() {
+	mongoURI := flag.String("mongo-uri", "", "MongoDB URI (required)")
+	dbName := flag.String("db", "rush", "database name")
+	usersCol := flag.String("users-col", "users", "users collection name")
+	sessionsCol := flag.String("sessions-col", "sessions", "sessions collection name")
+	attendancesCol := flag.String("attendances-col", "attendances", "attendances collection name")
+	termsCol := flag.String("terms-col", "terms", "terms collection name")
+	rsvpsCol := flag.String("rsvps-col", "rsvps", "RSVPs collection name")
+	authTokensCol := flag.String("auth-tokens-col", "auth_tokens", "auth tokens collection name")
+	checkInRejectionsCol := flag.String("check-in-rejections-col", "check_in_rejections", "check-in rejections collection name")
+	unmatchedSubmissionsCol := flag.String("unmatched-submissions-col", "unmatched_submissions", "unmatched submissions collection name")
+	excusesCol := flag.String("excuses-col", "excuses", "excuses collection name")
+	auditEntriesCol := flag.String("audit-entries-col", "audit_entries", "audit entries collection name")
+	reliabilityOutcomesCol := flag.String("reliability-outcomes-col", "reliability_outcomes", "reliability outcomes collection name")
+	dryRun := flag.Bool("dry-run", false, "only print the pending migrations")
+	flag.Parse()
+
+	if *mongoURI == "" {
+		log.Fatal("-mongo-uri is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(*mongoURI))
+	if err != nil {
+		log.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("failed to ping MongoDB: %v", err)
+	}
+	log.Println("Connected to MongoDB")
+
+	db := client.Database(*dbName)
+	pending, err := migrate.Pending(ctx, db)
+	if err != nil {
+		log.Fatalf("failed to get pending migrations: %v", err)
+	}
+	fmt.Printf("Pending migrations (%d)\n", len(pending))
+	for _, migration := range pending {
+		fmt.Printf("  %d: %s\n", migration.Version, migration.Description)
+	}
+	if *dryRun || len(pending) == 0 {
+		return
+	}
+
+	results, err := migrate.Run(ctx, db, migrate.Collections{
+		Users:                *usersCol,
+		Sessions:             *sessionsCol,
+		Attendances:          *attendancesCol,
+		Terms:                *termsCol,
+		Rsvps:                *rsvpsCol,
+		AuthTokens:           *authTokensCol,
+		CheckInRejections:    *checkInRejectionsCol,
+		UnmatchedSubmissions: *unmatchedSubmissionsCol,
+		Excuses:              *excusesCol,
+		AuditEntries:         *auditEntriesCol,
+		ReliabilityOutcomes:  *reliabilityOutcomesCol,
+	})
+	for _, result := range results {
+		log.Printf("Applied migration %d: %s", result.Version, result.Description)
+	}
+	if err != nil {
+		log.Fatalf("failed to migrate: %v", err)
+	}
+}
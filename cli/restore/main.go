@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"rush/backup"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// How the backup is restored.
+const (
+	// Inserts the documents of the backup and overwrites the ones with the same ID.
+	// Documents that are not in the backup are kept.
+	modeMerge = "merge"
+	// Deletes every document of the collections and then inserts the documents of the backup.
+	modeReplace = "replace"
+)
+
+// Restores the users, sessions, attendances and terms from `rush_backup_YYYYMMDD_HHMMSS.json`
+// while keeping their original ObjectIDs and types.
+func main() {
+	filePath := flag.String("file", "", "path to the backup JSON (required)")
+	mongoURI := flag.String("mongo-uri", "", "MongoDB URI (required)")
+	dbName := flag.String("db", "rush", "database name")
+	usersCol := flag.String("users-col", "users", "users collection name")
+	sessionsCol := flag.String("sessions-col", "sessions", "sessions collection name")
+	attendancesCol := flag.String("attendances-col", "attendances", "attendances collection name")
+	termsCol := flag.String("terms-col", "terms", "terms collection name")
+	mode := flag.String("mode", modeMerge, "merge: upsert the documents by _id, replace: delete every document first")
+	dryRun := flag.Bool("dry-run", false, "only validate the backup and print what would be changed")
+	flag.Parse()
+
+	if *filePath == "" || *mongoURI == "" {
+		log.Fatal("-file and -mongo-uri are required")
+	}
+	if *mode != modeMerge && *mode != modeReplace {
+		log.Fatalf("-mode should be %s or %s", modeMerge, modeReplace)
+	}
+
+	restored, err := backup.Read(*filePath)
+	if err != nil {
+		log.Fatalf("invalid backup: %v", err)
+	}
+	log.Printf("Backup exported at %s is valid", restored.ExportedAt.Format(time.RFC3339))
+	for _, warning := range restored.Warnings {
+		log.Printf("WARNING: %s", warning)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(*mongoURI))
+	if err != nil {
+		log.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("failed to ping MongoDB: %v", err)
+	}
+	log.Println("Connected to MongoDB")
+
+	db := client.Database(*dbName)
+	collections := map[string]*mongo.Collection{
+		backup.CollectionUsers:       db.Collection(*usersCol),
+		backup.CollectionSessions:    db.Collection(*sessionsCol),
+		backup.CollectionAttendances: db.Collection(*attendancesCol),
+		backup.CollectionTerms:       db.Collection(*termsCol),
+	}
+
+	// --- Diff ---
+	for _, name := range backup.Collections {
+		docs, ok := restored.Documents[name]
+		if !ok {
+			fmt.Printf("%s: not in the backup, untouched\n", name)
+			continue
+		}
+		ids := make([]interface{}, 0, len(docs))
+		for _, doc := range docs {
+			ids = append(ids, doc.Map()["_id"])
+		}
+		existing, err := collections[name].CountDocuments(ctx, bson.M{"_id": bson.M{"$in": ids}})
+		if err != nil {
+			log.Fatalf("failed to count %s: %v", name, err)
+		}
+		total, err := collections[name].CountDocuments(ctx, bson.M{})
+		if err != nil {
+			log.Fatalf("failed to count %s: %v", name, err)
+		}
+
+		if *mode == modeMerge {
+			fmt.Printf("%s: %d to insert, %d to overwrite, %d untouched\n",
+				name, int64(len(docs))-existing, existing, total-existing)
+			continue
+		}
+		fmt.Printf("%s: %d to delete, %d to insert\n", name, total, len(docs))
+	}
+	if *dryRun {
+		return
+	}
+
+	// --- Confirmation ---
+	fmt.Printf("This will restore the backup with the %s mode. Type 'yes' to continue: ", *mode)
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	if strings.TrimSpace(scanner.Text()) != "yes" {
+		log.Fatal("Aborted")
+	}
+
+	// --- Restore ---
+	for _, name := range backup.Collections {
+		if _, ok := restored.Documents[name]; !ok {
+			continue
+		}
+		if err := restore(ctx, collections[name], restored.Documents[name], *mode); err != nil {
+			log.Fatalf("failed to restore %s: %v", name, err)
+		}
+		log.Printf("Restored %d %s", len(restored.Documents[name]), name)
+	}
+}
+
+func restore(ctx context.Context, collection *mongo.Collection, docs []bson.D, mode string) error {
+	if mode == modeReplace {
+		if _, err := collection.DeleteMany(ctx, bson.M{}); err != nil {
+			return fmt.Errorf("failed to delete documents: %w", err)
+		}
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(docs))
+	for _, doc := range docs {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": doc.Map()["_id"]}).
+			SetReplacement(doc).
+			SetUpsert(true))
+	}
+	if _, err := collection.BulkWrite(ctx, models); err != nil {
+		return fmt.Errorf("failed to write documents: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"rush/golang/array"
+	"rush/server"
+	"rush/term"
+	"rush/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Rolls the club over to a new term without deleting anything.
+// It backs up every collection, shows the diff and applies it after the confirmation.
+func main() {
+	csvPath := flag.String("csv", "./new_members.csv", "path to the roster CSV of the new term")
+	outDir := flag.String("out", ".", "output directory for backup JSON")
+	mongoURI := flag.String("mongo-uri", "", "MongoDB URI (required)")
+	dbName := flag.String("db", "rush", "database name")
+	usersCol := flag.String("users-col", "users", "users collection name")
+	sessionsCol := flag.String("sessions-col", "sessions", "sessions collection name")
+	attendancesCol := flag.String("attendances-col", "attendances", "attendances collection name")
+	termsCol := flag.String("terms-col", "terms", "terms collection name")
+	termName := flag.String("term-name", "", "name of the new term, e.g. 2025-2 (required)")
+	startsAt := flag.String("starts-at", "", "start date of the new term in YYYY-MM-DD, inclusive (required)")
+	endsAt := flag.String("ends-at", "", "end date of the new term in YYYY-MM-DD, exclusive (required)")
+	generations := flag.String("generations", "", "comma separated generations of the new term, e.g. 9,9.5,10")
+	dryRun := flag.Bool("dry-run", false, "only print the diff without writing anything")
+	flag.Parse()
+
+	if *mongoURI == "" {
+		log.Fatal("-mongo-uri is required")
+	}
+	if *termName == "" || *startsAt == "" || *endsAt == "" {
+		log.Fatal("-term-name, -starts-at and -ends-at are required")
+	}
+
+	location, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		log.Fatalf("failed to load location: %v", err)
+	}
+	termStartsAt, err := time.ParseInLocation("2006-01-02", *startsAt, location)
+	if err != nil {
+		log.Fatalf("failed to parse -starts-at: %v", err)
+	}
+	termEndsAt, err := time.ParseInLocation("2006-01-02", *endsAt, location)
+	if err != nil {
+		log.Fatalf("failed to parse -ends-at: %v", err)
+	}
+	termGenerations, err := parseGenerations(*generations)
+	if err != nil {
+		log.Fatalf("failed to parse -generations: %v", err)
+	}
+
+	roster, err := user.ParseCSV(*csvPath)
+	if err != nil {
+		log.Fatalf("failed to parse CSV: %v", err)
+	}
+	rosterMembers := array.Map(roster, func(rosterUser user.User) server.RosterMember {
+		return server.RosterMember{
+			Name:         rosterUser.Name,
+			ExternalName: rosterUser.ExternalName,
+			Generation:   rosterUser.Generation,
+			Email:        rosterUser.Email,
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(*mongoURI))
+	if err != nil {
+		log.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("failed to ping MongoDB: %v", err)
+	}
+	log.Println("Connected to MongoDB")
+
+	db := client.Database(*dbName)
+	usersCollection := db.Collection(*usersCol)
+	userRepo := user.NewMongoDbRepo(usersCollection)
+	rushServer := server.New(server.Deps{
+		UserRepo:         userRepo,
+		TermRepo:         term.NewMongoDbRepo(db.Collection(*termsCol)),
+		UserRoller:       user.NewRoller(userRepo),
+		FormTimeLocation: location,
+		Clock:            clock.New(),
+	})
+
+	report, err := rushServer.RolloverTerm(*termName, termStartsAt, termEndsAt, termGenerations, rosterMembers, true /* =dryRun */)
+	if err != nil {
+		log.Fatalf("failed to plan the rollover: %v", err)
+	}
+	printReport(report)
+	if *dryRun {
+		return
+	}
+
+	// --- Export ---
+	// Nothing is deleted by the rollover, but keep the backup in case it has to be undone.
+	if err := export(ctx, *outDir, map[string]*mongo.Collection{
+		"users":       usersCollection,
+		"sessions":    db.Collection(*sessionsCol),
+		"attendances": db.Collection(*attendancesCol),
+		// The rollover cuts and archives the current term and adds the new one.
+		"terms": db.Collection(*termsCol),
+	}); err != nil {
+		log.Fatalf("failed to export backup: %v", err)
+	}
+
+	// --- Confirmation ---
+	fmt.Print("This will apply the diff above. Type 'yes' to continue: ")
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	if strings.TrimSpace(scanner.Text()) != "yes" {
+		log.Fatal("Aborted")
+	}
+
+	// --- Apply ---
+	report, err = rushServer.RolloverTerm(*termName, termStartsAt, termEndsAt, termGenerations, rosterMembers, false /* =dryRun */)
+	if err != nil {
+		log.Fatalf("failed to roll over: %v", err)
+	}
+	log.Printf("Rolled over to %s (%s): %d deactivated, %d returning, %d added",
+		report.NewTerm.Name, report.NewTerm.Id, len(report.Deactivated), len(report.Returning), len(report.Added))
+}
+
+func parseGenerations(value string) ([]float64, error) {
+	generations := []float64{}
+	if strings.TrimSpace(value) == "" {
+		return generations, nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		generation, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid generation %q: %w", part, err)
+		}
+		generations = append(generations, generation)
+	}
+	return generations, nil
+}
+
+func printReport(report server.TermRolloverReport) {
+	if report.ArchivedTerm != nil {
+		fmt.Printf("Archive term %s (%s ~ %s)\n", report.ArchivedTerm.Name,
+			report.ArchivedTerm.StartsAt.Format(time.RFC3339), report.ArchivedTerm.EndsAt.Format(time.RFC3339))
+	}
+	fmt.Printf("New term %s (%s ~ %s)\n", report.NewTerm.Name,
+		report.NewTerm.StartsAt.Format(time.RFC3339), report.NewTerm.EndsAt.Format(time.RFC3339))
+
+	fmt.Printf("\nDeactivated (%d)\n", len(report.Deactivated))
+	for _, deactivated := range report.Deactivated {
+		fmt.Printf("  - %s (%v, %s)\n", deactivated.Name, deactivated.Generation, deactivated.Email)
+	}
+	fmt.Printf("\nKept active as admins (%d)\n", len(report.Kept))
+	for _, kept := range report.Kept {
+		fmt.Printf("  = %s (%v, %s)\n", kept.Name, kept.Generation, kept.Email)
+	}
+	fmt.Printf("\nReturning (%d)\n", len(report.Returning))
+	for _, returning := range report.Returning {
+		fmt.Printf("  ~ %s (%v, %s) -> %s (%v, %s, active: %t)\n",
+			returning.Before.ExternalName, returning.Before.Generation, returning.Before.Email,
+			returning.After.ExternalName, returning.After.Generation, returning.After.Email, returning.After.IsActive)
+	}
+	fmt.Printf("\nAdded (%d)\n", len(report.Added))
+	for _, added := range report.Added {
+		fmt.Printf("  + %s (%v, %s)\n", added.ExternalName, added.Generation, added.Email)
+	}
+	fmt.Println()
+}
+
+// Writes `rush_backup_YYYYMMDD_HHMMSS.json` that has every document of the collections.
+func export(ctx context.Context, outDir string, collections map[string]*mongo.Collection) error {
+	backup := map[string]interface{}{
+		"exported_at": time.Now().Format(time.RFC3339),
+	}
+	counts := []string{}
+	for name, collection := range collections {
+		// Use raw bson.M to preserve all fields exactly as stored (including is_deleted, force_apply, etc.)
+		docs, err := fetchAll(ctx, collection)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", name, err)
+		}
+		backup[name] = docs
+		counts = append(counts, fmt.Sprintf("%d %s", len(docs), name))
+	}
+
+	filename := fmt.Sprintf("rush_backup_%s.json", time.Now().Format("20060102_150405"))
+	outPath := filepath.Join(outDir, filename)
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup: %w", err)
+	}
+	if err := os.WriteFile(outPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	log.Printf("Exported %s to %s", strings.Join(counts, ", "), outPath)
+	return nil
+}
+
+func fetchAll(ctx context.Context, col *mongo.Collection) ([]bson.M, error) {
+	cursor, err := col.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	if docs == nil {
+		docs = []bson.M{}
+	}
+	return docs, nil
+}
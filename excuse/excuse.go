@@ -0,0 +1,54 @@
+// It handles the excuses of the members who will miss the sessions. E.g., injuries or travels.
+package excuse
+
+import "time"
+
+// The excuse that a member submits in advance for the sessions that they will miss.
+// The admins approve or reject it. The approved one is shown apart from the absence.
+type Excuse struct {
+	// The ID of the excuse. E.g., "abc123"
+	Id string `json:"id"`
+	// The ID of the member who will miss the sessions. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// The ID of the session that the member will miss. It's empty if the excuse is for a date range. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The time range [StartsAt, EndsAt) in UTC that the member will miss.
+	// Both are the start time of the session if the excuse is for a session.
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// Why the member will miss the sessions. E.g., "발목 부상"
+	Reason string `json:"reason"`
+	Status Status `json:"status"`
+	// Whether the excused sessions count toward the score of the member. The admin decides it on approval.
+	CountsTowardScore bool `json:"counts_toward_score"`
+	// The ID of the admin who reviewed it. It's empty while it's pending. E.g., "abc123"
+	ReviewedBy string `json:"reviewed_by"`
+	// The time in UTC when it was reviewed. It's zero while it's pending.
+	ReviewedAt time.Time `json:"reviewed_at"`
+	// The time in UTC when it was submitted.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Returns true if the excuse is for the session. The range excuse covers the sessions starting in the range.
+func (e *Excuse) Covers(sessionId string, sessionStartsAt time.Time) bool {
+	if e.SessionId != "" {
+		return e.SessionId == sessionId
+	}
+	return !sessionStartsAt.Before(e.StartsAt) && sessionStartsAt.Before(e.EndsAt)
+}
+
+type Status string
+
+const (
+	// The excuse is waiting for the review of the admins.
+	StatusPending Status = "pending"
+	// The admins accepted the excuse.
+	StatusApproved Status = "approved"
+	// The admins didn't accept the excuse. The sessions are regarded as absent.
+	StatusRejected Status = "rejected"
+)
+
+// Returns true if it's one of the known statuses.
+func (s Status) IsValid() bool {
+	return s == StatusPending || s == StatusApproved || s == StatusRejected
+}
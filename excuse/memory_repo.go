@@ -0,0 +1,96 @@
+package excuse
+
+import (
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the excuses in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The excuses in the order of insertion.
+	excuses []*Excuse
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		excuses: []*Excuse{},
+	}
+}
+
+// Returns the excuse by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *memoryRepo) Get(id string) (Excuse, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, excuse := range r.excuses {
+		if excuse.Id == id {
+			return *excuse, nil
+		}
+	}
+	return Excuse{}, ErrNotFound
+}
+
+// Adds the excuse as pending.
+func (r *memoryRepo) Add(excuse Excuse) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	excuse.Id = primitive.NewObjectID().Hex()
+	excuse.Status = StatusPending
+	excuse.CountsTowardScore = false
+	excuse.ReviewedBy = ""
+	excuse.ReviewedAt = time.Time{}
+	excuse.CreatedAt = time.Now()
+	r.excuses = append(r.excuses, &excuse)
+	return excuse.Id, nil
+}
+
+// Returns the excuses of the member in the order of submission.
+func (r *memoryRepo) FindByUserId(userId string) ([]Excuse, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(excuse Excuse) bool { return excuse.UserId == userId }), nil
+}
+
+// Returns the excuses of the status in the order of submission.
+func (r *memoryRepo) FindByStatus(status Status) ([]Excuse, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(excuse Excuse) bool { return excuse.Status == status }), nil
+}
+
+// Reviews the pending excuse. If it's not found or already reviewed, it returns ErrNotFound.
+func (r *memoryRepo) Review(id string, reviewForm ReviewForm) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, excuse := range r.excuses {
+		if excuse.Id != id || excuse.Status != StatusPending {
+			continue
+		}
+		excuse.Status = reviewForm.Status
+		excuse.CountsTowardScore = reviewForm.CountsTowardScore
+		excuse.ReviewedBy = reviewForm.ReviewedBy
+		excuse.ReviewedAt = reviewForm.ReviewedAt
+		return nil
+	}
+	return ErrNotFound
+}
+
+// Returns the copies of the excuses that match the predicate.
+func (r *memoryRepo) filter(predicate func(Excuse) bool) []Excuse {
+	excuses := []Excuse{}
+	for _, excuse := range r.excuses {
+		if predicate(*excuse) {
+			excuses = append(excuses, *excuse)
+		}
+	}
+	return excuses
+}
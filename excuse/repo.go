@@ -0,0 +1,179 @@
+package excuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The excuse record in MongoDB.
+type mongodbExcuse struct {
+	// The unique identifier for the excuse. E.g. "1"
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// The unique identifier for the member. E.g. "1"
+	UserId string `bson:"user_id"`
+	// The unique identifier for the session. It's empty for a date range. E.g. "1"
+	SessionId string `bson:"session_id"`
+	// The time range that the member will miss. E.g. "2025-07-01T00:00:00Z"
+	StartsAt time.Time `bson:"starts_at"`
+	EndsAt   time.Time `bson:"ends_at"`
+	// Why the member will miss the sessions. E.g. "발목 부상"
+	Reason string `bson:"reason"`
+	// The status of the review. E.g. "pending"
+	Status Status `bson:"status"`
+	// Whether the excused sessions count toward the score. E.g. false
+	CountsTowardScore bool `bson:"counts_toward_score"`
+	// The unique identifier for the admin who reviewed it. E.g. "1"
+	ReviewedBy string `bson:"reviewed_by"`
+	// The time when it was reviewed. E.g. "2025-07-01T00:00:00Z"
+	ReviewedAt time.Time `bson:"reviewed_at"`
+	// The time when it was submitted. E.g. "2025-07-01T00:00:00Z"
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+var ErrNotFound = errors.New("excuse not found")
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	Get(id string) (Excuse, error)
+	Add(excuse Excuse) (string, error)
+	FindByUserId(userId string) ([]Excuse, error)
+	FindByStatus(status Status) ([]Excuse, error)
+	Review(id string, reviewForm ReviewForm) error
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+// Returns the excuse by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *mongodbRepo) Get(id string) (Excuse, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Excuse{}, fmt.Errorf("invalid id: %w", err)
+	}
+
+	excuse := &mongodbExcuse{}
+	if err := r.collection.FindOne(context.Background(), bson.M{"_id": objectID}).Decode(excuse); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Excuse{}, ErrNotFound
+		}
+		return Excuse{}, fmt.Errorf("failed to get excuse: %w", err)
+	}
+
+	return *fromMongodbExcuse(excuse), nil
+}
+
+// Adds the excuse as pending.
+func (r *mongodbRepo) Add(excuse Excuse) (string, error) {
+	result, err := r.collection.InsertOne(context.Background(), mongodbExcuse{
+		UserId:    excuse.UserId,
+		SessionId: excuse.SessionId,
+		StartsAt:  excuse.StartsAt,
+		EndsAt:    excuse.EndsAt,
+		Reason:    excuse.Reason,
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to insert excuse: %w", err)
+	}
+
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", fmt.Errorf("failed to get inserted id")
+	}
+
+	return id.Hex(), nil
+}
+
+// Returns the excuses of the member in the order of submission.
+func (r *mongodbRepo) FindByUserId(userId string) ([]Excuse, error) {
+	return r.find(bson.M{"user_id": userId})
+}
+
+// Returns the excuses of the status in the order of submission.
+func (r *mongodbRepo) FindByStatus(status Status) ([]Excuse, error) {
+	return r.find(bson.M{"status": status})
+}
+
+// The form to review the excuse.
+type ReviewForm struct {
+	// Either StatusApproved or StatusRejected.
+	Status            Status
+	CountsTowardScore bool
+	ReviewedBy        string
+	ReviewedAt        time.Time
+}
+
+// Reviews the pending excuse. If it's not found or already reviewed, it returns ErrNotFound.
+func (r *mongodbRepo) Review(id string, reviewForm ReviewForm) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	result, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": objectID, "status": StatusPending}, bson.M{"$set": bson.M{
+		"status":              reviewForm.Status,
+		"counts_toward_score": reviewForm.CountsTowardScore,
+		"reviewed_by":         reviewForm.ReviewedBy,
+		"reviewed_at":         reviewForm.ReviewedAt,
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to review excuse: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *mongodbRepo) find(filter bson.M) ([]Excuse, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excuses: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongodbExcuses []mongodbExcuse
+	if err = cursor.All(ctx, &mongodbExcuses); err != nil {
+		return nil, fmt.Errorf("failed to decode excuses: %w", err)
+	}
+
+	excuses := []Excuse{}
+	for _, mongodbExcuse := range mongodbExcuses {
+		excuses = append(excuses, *fromMongodbExcuse(&mongodbExcuse))
+	}
+	return excuses, nil
+}
+
+func fromMongodbExcuse(excuse *mongodbExcuse) *Excuse {
+	return &Excuse{
+		Id:                excuse.Id.Hex(),
+		UserId:            excuse.UserId,
+		SessionId:         excuse.SessionId,
+		StartsAt:          excuse.StartsAt,
+		EndsAt:            excuse.EndsAt,
+		Reason:            excuse.Reason,
+		Status:            excuse.Status,
+		CountsTowardScore: excuse.CountsTowardScore,
+		ReviewedBy:        excuse.ReviewedBy,
+		ReviewedAt:        excuse.ReviewedAt,
+		CreatedAt:         excuse.CreatedAt,
+	}
+}
@@ -0,0 +1,100 @@
+package excuse
+
+import (
+	"rush/golang/mongotest"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	newExcuse := func(userId string) Excuse {
+		return Excuse{
+			UserId:   userId,
+			StartsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC),
+			Reason:   "해외 출장",
+		}
+	}
+
+	t.Run("Adds and gets an excuse as pending", func(t *testing.T) {
+		repo := newRepo(t)
+
+		id, err := repo.Add(newExcuse("user-id"))
+		assert.NoError(t, err)
+
+		excuse, err := repo.Get(id)
+		assert.NoError(t, err)
+		assert.Equal(t, Excuse{
+			Id:        id,
+			UserId:    "user-id",
+			StartsAt:  time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:    time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC),
+			Reason:    "해외 출장",
+			Status:    StatusPending,
+			CreatedAt: excuse.CreatedAt,
+		}, excuse)
+
+		_, err = repo.Get(primitive.NewObjectID().Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Finds the excuses by the user and the status in the order of submission", func(t *testing.T) {
+		repo := newRepo(t)
+		first, err := repo.Add(newExcuse("user-id"))
+		assert.NoError(t, err)
+		_, err = repo.Add(newExcuse("another-user-id"))
+		assert.NoError(t, err)
+		second, err := repo.Add(newExcuse("user-id"))
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Review(first, ReviewForm{Status: StatusApproved, ReviewedBy: "admin-id", ReviewedAt: time.Now()}))
+
+		excuses, err := repo.FindByUserId("user-id")
+		assert.NoError(t, err)
+		assert.Len(t, excuses, 2)
+		assert.Equal(t, first, excuses[0].Id)
+		assert.Equal(t, second, excuses[1].Id)
+
+		pending, err := repo.FindByStatus(StatusPending)
+		assert.NoError(t, err)
+		assert.Len(t, pending, 2)
+		assert.Equal(t, "another-user-id", pending[0].UserId)
+		assert.Equal(t, second, pending[1].Id)
+	})
+
+	t.Run("Reviews only the pending excuse", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add(newExcuse("user-id"))
+		assert.NoError(t, err)
+		reviewedAt := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+		assert.NoError(t, repo.Review(id, ReviewForm{Status: StatusApproved, CountsTowardScore: true, ReviewedBy: "admin-id", ReviewedAt: reviewedAt}))
+		err = repo.Review(id, ReviewForm{Status: StatusRejected, ReviewedBy: "admin-id", ReviewedAt: reviewedAt})
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		excuse, err := repo.Get(id)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusApproved, excuse.Status)
+		assert.True(t, excuse.CountsTowardScore)
+		assert.Equal(t, "admin-id", excuse.ReviewedBy)
+		assert.Equal(t, reviewedAt, excuse.ReviewedAt)
+		assert.ErrorIs(t, repo.Review(primitive.NewObjectID().Hex(), ReviewForm{Status: StatusRejected}), ErrNotFound)
+	})
+}
@@ -0,0 +1,115 @@
+package excuse
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"rush/sqlite"
+	"time"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteExcuseColumns = "id, user_id, session_id, starts_at, ends_at, reason, status, counts_toward_score, reviewed_by, reviewed_at, created_at"
+
+// Returns the excuse by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *sqliteRepo) Get(id string) (Excuse, error) {
+	excuse, err := scanSqliteExcuse(r.db.QueryRow("SELECT "+sqliteExcuseColumns+" FROM excuses WHERE id = ?", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Excuse{}, ErrNotFound
+		}
+		return Excuse{}, fmt.Errorf("failed to get excuse: %w", err)
+	}
+	return excuse, nil
+}
+
+// Adds the excuse as pending.
+func (r *sqliteRepo) Add(excuse Excuse) (string, error) {
+	id := sqlite.NewId()
+	if _, err := r.db.Exec("INSERT INTO excuses ("+sqliteExcuseColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, 0, '', 0, ?)",
+		id, excuse.UserId, excuse.SessionId, sqlite.FromTime(excuse.StartsAt), sqlite.FromTime(excuse.EndsAt), excuse.Reason, StatusPending,
+		sqlite.FromTime(time.Now())); err != nil {
+		return "", fmt.Errorf("failed to insert excuse: %w", err)
+	}
+
+	return id, nil
+}
+
+// Returns the excuses of the member in the order of submission.
+func (r *sqliteRepo) FindByUserId(userId string) ([]Excuse, error) {
+	return r.query("SELECT "+sqliteExcuseColumns+" FROM excuses WHERE user_id = ? ORDER BY rowid", userId)
+}
+
+// Returns the excuses of the status in the order of submission.
+func (r *sqliteRepo) FindByStatus(status Status) ([]Excuse, error) {
+	return r.query("SELECT "+sqliteExcuseColumns+" FROM excuses WHERE status = ? ORDER BY rowid", status)
+}
+
+// Reviews the pending excuse. If it's not found or already reviewed, it returns ErrNotFound.
+func (r *sqliteRepo) Review(id string, reviewForm ReviewForm) error {
+	result, err := r.db.Exec("UPDATE excuses SET status = ?, counts_toward_score = ?, reviewed_by = ?, reviewed_at = ? WHERE id = ? AND status = ?",
+		reviewForm.Status, reviewForm.CountsTowardScore, reviewForm.ReviewedBy, sqlite.FromTime(reviewForm.ReviewedAt), id, StatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to review excuse: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to review excuse: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *sqliteRepo) query(query string, args ...interface{}) ([]Excuse, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excuses: %w", err)
+	}
+	defer rows.Close()
+
+	excuses := []Excuse{}
+	for rows.Next() {
+		excuse, err := scanSqliteExcuse(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode excuses: %w", err)
+		}
+		excuses = append(excuses, excuse)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode excuses: %w", err)
+	}
+	return excuses, nil
+}
+
+// Either *sql.Row or *sql.Rows.
+type sqliteScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSqliteExcuse(scanner sqliteScanner) (Excuse, error) {
+	var excuse Excuse
+	var startsAt, endsAt, reviewedAt, createdAt int64
+	if err := scanner.Scan(&excuse.Id, &excuse.UserId, &excuse.SessionId, &startsAt, &endsAt, &excuse.Reason, &excuse.Status,
+		&excuse.CountsTowardScore, &excuse.ReviewedBy, &reviewedAt, &createdAt); err != nil {
+		return Excuse{}, err
+	}
+	excuse.StartsAt = sqlite.ToTime(startsAt)
+	excuse.EndsAt = sqlite.ToTime(endsAt)
+	if excuse.Status != StatusPending {
+		excuse.ReviewedAt = sqlite.ToTime(reviewedAt)
+	}
+	excuse.CreatedAt = sqlite.ToTime(createdAt)
+	return excuse, nil
+}
@@ -0,0 +1,328 @@
+// Helper package to run tests against a fake Google Forms and Drive server.
+// It serves the endpoints that RUSH uses in the process so that tests can run without network access.
+package googletest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/forms/v1"
+	"google.golang.org/api/option"
+)
+
+// The fake server of the Forms v1 and the Drive v3 APIs. It keeps the forms, their responses and
+// the permissions in the memory.
+// It serves forms.create, forms.batchUpdate, forms.responses.list and drive.permissions.create.
+type FakeServer struct {
+	httpServer *httptest.Server
+
+	mutex sync.Mutex
+	// The forms by their IDs.
+	forms map[string]*forms.Form
+	// The responses of each form in the order of submission.
+	responses map[string][]*forms.FormResponse
+	// The permissions of each file. A form is a file in Drive.
+	permissions map[string][]*drive.Permission
+	// The number of responses in a page when the request doesn't specify it. 0 means all responses in a page.
+	responsePageSize int
+}
+
+// Returns a new fake server that is closed after the test.
+func NewFakeServer(t *testing.T) *FakeServer {
+	t.Helper()
+
+	server := &FakeServer{
+		forms:       map[string]*forms.Form{},
+		responses:   map[string][]*forms.FormResponse{},
+		permissions: map[string][]*drive.Permission{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/forms", server.handleCreateForm)
+	// The form ID and the method are in the same segment, e.g. "/v1/forms/abc:batchUpdate", so that they are parsed manually.
+	mux.HandleFunc("/v1/forms/", server.handleForm)
+	mux.HandleFunc("POST /drive/v3/files/{fileId}/permissions", server.handleCreatePermission)
+	server.httpServer = httptest.NewServer(mux)
+	t.Cleanup(server.httpServer.Close)
+	return server
+}
+
+// Returns the Forms service that sends the requests to the fake server.
+func (s *FakeServer) FormsService(t *testing.T) *forms.Service {
+	t.Helper()
+
+	service, err := forms.NewService(context.Background(), s.clientOptions("/")...)
+	if err != nil {
+		t.Fatalf("failed to create the forms service: %v", err)
+	}
+	return service
+}
+
+// Returns the Drive service that sends the requests to the fake server.
+func (s *FakeServer) DriveService(t *testing.T) *drive.Service {
+	t.Helper()
+
+	service, err := drive.NewService(context.Background(), s.clientOptions("/drive/v3/")...)
+	if err != nil {
+		t.Fatalf("failed to create the drive service: %v", err)
+	}
+	return service
+}
+
+func (s *FakeServer) clientOptions(basePath string) []option.ClientOption {
+	return []option.ClientOption{
+		option.WithEndpoint(s.httpServer.URL + basePath),
+		option.WithHTTPClient(s.httpServer.Client()),
+		option.WithoutAuthentication(),
+	}
+}
+
+// Sets the number of responses in a page when the request doesn't specify it. E.g., 2
+func (s *FakeServer) SetResponsePageSize(pageSize int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.responsePageSize = pageSize
+}
+
+// Adds the response that selects the option of the first question of the form. E.g., "9 - 김건"
+func (s *FakeServer) AddResponse(formId string, selectedOption string, submittedAt time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	questionId := "question"
+	if form, ok := s.forms[formId]; ok && len(form.Items) > 0 && form.Items[0].QuestionItem != nil {
+		questionId = form.Items[0].QuestionItem.Question.QuestionId
+	}
+	s.addResponse(formId, &forms.FormResponse{
+		Answers: map[string]forms.Answer{
+			questionId: {
+				QuestionId:  questionId,
+				TextAnswers: &forms.TextAnswers{Answers: []*forms.TextAnswer{{Value: selectedOption}}},
+			},
+		},
+		LastSubmittedTime: submittedAt.UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// Adds the response as it is. It's used to add the malformed responses.
+func (s *FakeServer) AddRawResponse(formId string, response *forms.FormResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.addResponse(formId, response)
+}
+
+func (s *FakeServer) addResponse(formId string, response *forms.FormResponse) {
+	if response.ResponseId == "" {
+		response.ResponseId = primitive.NewObjectID().Hex()
+	}
+	s.responses[formId] = append(s.responses[formId], response)
+}
+
+// Returns the form of the ID. It returns false if the form doesn't exist.
+func (s *FakeServer) GetForm(formId string) (*forms.Form, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	form, ok := s.forms[formId]
+	return form, ok
+}
+
+// Returns the permissions of the file in the order of creation.
+func (s *FakeServer) GetPermissions(fileId string) []*drive.Permission {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*drive.Permission{}, s.permissions[fileId]...)
+}
+
+func (s *FakeServer) handleCreateForm(w http.ResponseWriter, r *http.Request) {
+	var form forms.Form
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid form: %v", err))
+		return
+	}
+	if form.Info == nil || form.Info.Title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	form.FormId = primitive.NewObjectID().Hex()
+	form.ResponderUri = fmt.Sprintf("%s/forms/d/e/%s/viewform", s.httpServer.URL, form.FormId)
+	// Only the title can be set when it's created.
+	form.Info = &forms.Info{Title: form.Info.Title, DocumentTitle: form.Info.DocumentTitle}
+	form.Items = nil
+	s.forms[form.FormId] = &form
+	writeJson(w, &form)
+}
+
+func (s *FakeServer) handleForm(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/forms/")
+	switch {
+	case r.Method == http.MethodPost && strings.HasSuffix(path, ":batchUpdate"):
+		s.handleBatchUpdate(w, r, strings.TrimSuffix(path, ":batchUpdate"))
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/responses"):
+		s.handleListResponses(w, r, strings.TrimSuffix(path, "/responses"))
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not supported by the fake server", r.Method, r.URL.Path))
+	}
+}
+
+func (s *FakeServer) handleBatchUpdate(w http.ResponseWriter, r *http.Request, formId string) {
+	var request forms.BatchUpdateFormRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	form, ok := s.forms[formId]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("form %s is not found", formId))
+		return
+	}
+	// Updates the copy so that the form is not changed if any of the requests is invalid.
+	updated := *form
+	info := *form.Info
+	updated.Info = &info
+	updated.Items = append([]*forms.Item{}, form.Items...)
+	for _, request := range request.Requests {
+		switch {
+		case request.UpdateFormInfo != nil:
+			for _, field := range strings.Split(request.UpdateFormInfo.UpdateMask, ",") {
+				switch strings.TrimSpace(field) {
+				case "title":
+					updated.Info.Title = request.UpdateFormInfo.Info.Title
+				case "description":
+					updated.Info.Description = request.UpdateFormInfo.Info.Description
+				default:
+					writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid update mask: %s", request.UpdateFormInfo.UpdateMask))
+					return
+				}
+			}
+		case request.CreateItem != nil:
+			index := 0
+			if request.CreateItem.Location != nil {
+				index = int(request.CreateItem.Location.Index)
+			}
+			if index < 0 || index > len(updated.Items) {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid location index: %d", index))
+				return
+			}
+			item := *request.CreateItem.Item
+			item.ItemId = primitive.NewObjectID().Hex()
+			if item.QuestionItem != nil && item.QuestionItem.Question != nil {
+				question := *item.QuestionItem.Question
+				question.QuestionId = primitive.NewObjectID().Hex()
+				item.QuestionItem = &forms.QuestionItem{Question: &question}
+			}
+			updated.Items = append(updated.Items[:index], append([]*forms.Item{&item}, updated.Items[index:]...)...)
+		default:
+			writeError(w, http.StatusBadRequest, "the request is not supported by the fake server")
+			return
+		}
+	}
+
+	s.forms[formId] = &updated
+	writeJson(w, &forms.BatchUpdateFormResponse{Form: &updated})
+}
+
+func (s *FakeServer) handleListResponses(w http.ResponseWriter, r *http.Request, formId string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.forms[formId]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("form %s is not found", formId))
+		return
+	}
+
+	pageSize := s.responsePageSize
+	if value := r.URL.Query().Get("pageSize"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid page size: %s", value))
+			return
+		}
+		pageSize = parsed
+	}
+	// The token is the offset of the page. The real server's token is opaque.
+	offset := 0
+	if value := r.URL.Query().Get("pageToken"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid page token: %s", value))
+			return
+		}
+		offset = parsed
+	}
+
+	responses := s.responses[formId]
+	if offset > len(responses) {
+		offset = len(responses)
+	}
+	end := len(responses)
+	if pageSize > 0 && offset+pageSize < end {
+		end = offset + pageSize
+	}
+	result := &forms.ListFormResponsesResponse{Responses: responses[offset:end]}
+	if end < len(responses) {
+		result.NextPageToken = strconv.Itoa(end)
+	}
+	writeJson(w, result)
+}
+
+func (s *FakeServer) handleCreatePermission(w http.ResponseWriter, r *http.Request) {
+	fileId := r.PathValue("fileId")
+	var permission drive.Permission
+	if err := json.NewDecoder(r.Body).Decode(&permission); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid permission: %v", err))
+		return
+	}
+	if permission.Type == "" || permission.Role == "" {
+		writeError(w, http.StatusBadRequest, "type and role are required")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.forms[fileId]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("file %s is not found", fileId))
+		return
+	}
+	permission.Id = primitive.NewObjectID().Hex()
+	s.permissions[fileId] = append(s.permissions[fileId], &permission)
+	writeJson(w, &permission)
+}
+
+func writeJson(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+// Writes the error in the format of the Google APIs so that the client returns it as *googleapi.Error.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,55 @@
+// Helper package to run tests against a real MongoDB.
+package mongotest
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Returns a new empty collection that is dropped after the test.
+// It skips the test if `MONGODB_TEST_URI` is not set so that tests can run without MongoDB.
+func NewCollection(t *testing.T) *mongo.Collection {
+	t.Helper()
+
+	// A unique name so that the tests don't affect each other.
+	return NewDatabase(t).Collection(primitive.NewObjectID().Hex())
+}
+
+// Returns a new empty database that is dropped after the test.
+// It skips the test if `MONGODB_TEST_URI` is not set so that tests can run without MongoDB.
+func NewDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Fatalf("failed to ping MongoDB: %v", err)
+	}
+
+	prefix := os.Getenv("MONGODB_TEST_DB_NAME")
+	if prefix == "" {
+		prefix = "rush_test"
+	}
+	// A unique name so that the tests don't affect each other.
+	db := client.Database(prefix + "_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	return db
+}
@@ -17,6 +17,12 @@ type badRequest interface {
 	BadRequest()
 }
 
+type conflict interface {
+	// This error is returned when the request conflicts with the current state. E.g., another admin has
+	// applied the same attendance at the same time.
+	Conflict()
+}
+
 type internalServer interface {
 	// This error is returned when the server fails to process the request.
 	// It's normally because of errors that are not supposed to happen.
@@ -33,6 +39,9 @@ func getHttpStatus(err error) int {
 	if isNotFound(err) {
 		return http.StatusNotFound
 	}
+	if isConflict(err) {
+		return http.StatusConflict
+	}
 	if isInternalServerProblem(err) {
 		return http.StatusInternalServerError
 	}
@@ -54,6 +63,11 @@ func isBadRequest(err error) bool {
 	return ok && badRequest != nil
 }
 
+func isConflict(err error) bool {
+	conflict, ok := err.(conflict)
+	return ok && conflict != nil
+}
+
 func isInternalServerProblem(err error) bool {
 	internalServer, ok := err.(internalServer)
 	return ok && internalServer != nil
@@ -8,8 +8,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"rush/attendance"
+	"rush/excuse"
 	"rush/golang/array"
 	"rush/permission"
+	"rush/scoring"
 	"rush/server"
 )
 
@@ -67,9 +70,28 @@ func handleAuth(server *server.Server) gin.HandlerFunc {
 	}
 }
 
-func handleListUsers(server *server.Server) gin.HandlerFunc {
+// Returns the actions that the user can perform so that the UI shows only what they can do.
+func handleListMyPermissions(server *server.Server) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		users, err := server.GetAllActiveUsers()
+		role, ok := c.Get(userRoleKey)
+		if !ok {
+			log.Printf("Error getting user role from context, it is supposed to be set by the middleware")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+		userRole, ok := role.(permission.Role)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user role"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"role": userRole, "actions": userRole.AllowedActions()})
+	}
+}
+
+func handleAdminListUsers(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		users, err := server.AdminListUsers()
 		if err != nil {
 			log.Printf("Error getting users: %+v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
@@ -80,6 +102,37 @@ func handleListUsers(server *server.Server) gin.HandlerFunc {
 	}
 }
 
+func handleAdminGetUser(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := server.AdminGetUser(c.Param("id"))
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+				return
+			}
+
+			log.Printf("Error getting user: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, user)
+	}
+}
+
+func handleAdminListUnreliableUsers(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		users, err := server.AdminListUnreliableUsers()
+		if err != nil {
+			log.Printf("Error getting unreliable users: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"users": users})
+	}
+}
+
 func handleGetUser(server *server.Server) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
@@ -95,7 +148,9 @@ func handleGetUser(server *server.Server) gin.HandlerFunc {
 			return
 		}
 
-		if role != permission.RoleAdmin && role != permission.RoleSuperAdmin {
+		// The users can always read themselves.
+		userRole, _ := role.(permission.Role)
+		if !userRole.Can(permission.ActionUserReadAny) {
 			callerId := c.GetString(userIdKey)
 			if callerId != id {
 				c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
@@ -315,6 +370,65 @@ func handleAddSession(server *server.Server) gin.HandlerFunc {
 	}
 }
 
+type updateSessionRequest struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	StartsAt    time.Time `json:"starts_at"`
+	Score       int       `json:"score"`
+
+	FieldMask []string `json:"field_mask"`
+}
+
+func handleUpdateSession(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req updateSessionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if len(req.FieldMask) <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Field mask is required"})
+			return
+		}
+
+		name := (*string)(nil)
+		if array.Contains(req.FieldMask, "name") {
+			name = &req.Name
+		}
+		description := (*string)(nil)
+		if array.Contains(req.FieldMask, "description") {
+			description = &req.Description
+		}
+		startsAt := (*time.Time)(nil)
+		if array.Contains(req.FieldMask, "starts_at") {
+			startsAt = &req.StartsAt
+		}
+		score := (*int)(nil)
+		if array.Contains(req.FieldMask, "score") {
+			score = &req.Score
+		}
+		session, err := server.UpdateSession(c.Param("id"), name, description, startsAt, score)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			log.Printf("Error updating session: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, session)
+	}
+}
+
 func handleDeleteSession(server *server.Server) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
@@ -324,6 +438,11 @@ func handleDeleteSession(server *server.Server) gin.HandlerFunc {
 				return
 			}
 
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
 			log.Printf("Error deleting session: %+v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 			return
@@ -373,6 +492,11 @@ func handleApplyAttendanceByFormSubmissions(server *server.Server) gin.HandlerFu
 				return
 			}
 
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
 			log.Printf("Error closing session: %+v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 			return
@@ -417,14 +541,19 @@ func handleGetAttendanceForSession(server *server.Server) gin.HandlerFunc {
 
 func handleHalfYearAttendance(server *server.Server) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		result, err := server.GetHalfYearAttendance()
+		result, err := server.GetHalfYearAttendance(c.Query("term_id"))
 		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+				return
+			}
+
 			log.Printf("Error applying half year attendance: %+v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"sessions": result.Sessions, "users": result.Users, "attendances": result.Attendances})
+		c.JSON(http.StatusOK, gin.H{"term": result.Term, "sessions": result.Sessions, "users": result.Users, "attendances": result.Attendances})
 	}
 }
 
@@ -453,6 +582,11 @@ func handleMarkUsersAsPresent(server *server.Server) gin.HandlerFunc {
 				return
 			}
 
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
 			log.Printf("Error marking users as present: %+v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 			return
@@ -462,8 +596,52 @@ func handleMarkUsersAsPresent(server *server.Server) gin.HandlerFunc {
 	}
 }
 
+func handleImportAttendanceCsv(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			log.Printf("Error opening the attendance CSV file: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+		defer file.Close()
+
+		callerId := c.GetString(userIdKey)
+		if err := server.ImportAttendanceCsv(sessionId, file, fileHeader.Filename, callerId); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error importing the attendance CSV file: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Attendance imported successfully"})
+	}
+}
+
 type lateApplyAttendanceRequest struct {
-	UserIds []string `json:"user_ids"`
+	UserIds []string          `json:"user_ids"`
+	Status  attendance.Status `json:"status"`
+	Reason  string            `json:"reason"`
 }
 
 func handleLateApplyAttendance(server *server.Server) gin.HandlerFunc {
@@ -476,7 +654,22 @@ func handleLateApplyAttendance(server *server.Server) gin.HandlerFunc {
 		}
 
 		callerId := c.GetString(userIdKey)
-		if err := server.MarkUsersAsPresent(sessionId, req.UserIds, true /* =forceApply */, callerId); err != nil {
+		if err := server.LateApplyAttendance(sessionId, req.UserIds, req.Status, req.Reason, callerId); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
 			log.Printf("Error late applying attendance: %+v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 			return
@@ -485,3 +678,1093 @@ func handleLateApplyAttendance(server *server.Server) gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{"message": "Users marked as present successfully"})
 	}
 }
+
+func handleGetCheckInCode(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		code, err := server.GetCheckInCode(sessionId)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			log.Printf("Error getting check-in code: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, code)
+	}
+}
+
+type checkInRequest struct {
+	Code string `json:"code"`
+}
+
+func handleCheckIn(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		var req checkInRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		callerId := c.GetString(userIdKey)
+		if err := server.CheckIn(sessionId, callerId, req.Code); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": "Already checked in"})
+				return
+			}
+
+			log.Printf("Error checking in: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Checked in successfully"})
+	}
+}
+
+type meetingPointRequest struct {
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	RadiusMeters float64 `json:"radius_meters"`
+}
+
+func handleSetSessionMeetingPoint(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		var req meetingPointRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		session, err := server.SetSessionMeetingPoint(sessionId, req.Latitude, req.Longitude, req.RadiusMeters)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			log.Printf("Error setting session meeting point: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, session)
+	}
+}
+
+func handleDeleteSessionMeetingPoint(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		session, err := server.UnsetSessionMeetingPoint(sessionId)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			log.Printf("Error deleting session meeting point: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, session)
+	}
+}
+
+type locationCheckInRequest struct {
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	AccuracyMeters float64 `json:"accuracy_meters"`
+}
+
+func handleCheckInAtMeetingPoint(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		var req locationCheckInRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		callerId := c.GetString(userIdKey)
+		if err := server.CheckInAtMeetingPoint(sessionId, callerId, req.Latitude, req.Longitude, req.AccuracyMeters); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": "Already checked in"})
+				return
+			}
+
+			log.Printf("Error checking in at meeting point: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Checked in successfully"})
+	}
+}
+
+func handleAdminListCheckInRejections(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		rejections, err := server.AdminListCheckInRejections(sessionId)
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			log.Printf("Error listing check-in rejections: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rejections": rejections})
+	}
+}
+
+func handleAdminListUnmatchedSubmissions(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		submissions, err := server.AdminListUnmatchedSubmissions(sessionId)
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			log.Printf("Error listing unmatched submissions: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"submissions": submissions})
+	}
+}
+
+type resolveUnmatchedSubmissionsRequest struct {
+	Resolutions []server.ResolveUnmatchedSubmissionReq `json:"resolutions"`
+}
+
+func handleResolveUnmatchedSubmissions(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("id")
+		var req resolveUnmatchedSubmissionsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		callerId := c.GetString(userIdKey)
+		if err := server.ResolveUnmatchedSubmissions(sessionId, req.Resolutions, callerId); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error resolving unmatched submissions: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Unmatched submissions resolved successfully"})
+	}
+}
+
+func handleAdminListTerms(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		terms, err := server.AdminListTerms()
+		if err != nil {
+			log.Printf("Error getting terms: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"terms": terms})
+	}
+}
+
+func handleAdminGetTerm(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		term, err := server.AdminGetTerm(id)
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+				return
+			}
+
+			log.Printf("Error getting term: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+		c.JSON(http.StatusOK, term)
+	}
+}
+
+type addTermRequest struct {
+	Name        string    `json:"name"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Generations []float64 `json:"generations"`
+}
+
+func handleAddTerm(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req addTermRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		id, err := server.AddTerm(req.Name, req.StartsAt, req.EndsAt, req.Generations)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error adding term: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id})
+	}
+}
+
+type updateTermRequest struct {
+	Name        string    `json:"name"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Generations []float64 `json:"generations"`
+
+	FieldMask []string `json:"field_mask"`
+}
+
+func handleUpdateTerm(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req updateTermRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if len(req.FieldMask) <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Field mask is required"})
+			return
+		}
+
+		name := (*string)(nil)
+		if array.Contains(req.FieldMask, "name") {
+			name = &req.Name
+		}
+		startsAt := (*time.Time)(nil)
+		if array.Contains(req.FieldMask, "starts_at") {
+			startsAt = &req.StartsAt
+		}
+		endsAt := (*time.Time)(nil)
+		if array.Contains(req.FieldMask, "ends_at") {
+			endsAt = &req.EndsAt
+		}
+		generations := (*[]float64)(nil)
+		if array.Contains(req.FieldMask, "generations") {
+			generations = &req.Generations
+		}
+		if err := server.UpdateTerm(c.Param("id"), name, startsAt, endsAt, generations); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error updating term: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Term updated successfully"})
+	}
+}
+
+func handleSetTermScoringRules(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req scoring.Rules
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		term, err := server.SetTermScoringRules(c.Param("id"), req)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+				return
+			}
+
+			log.Printf("Error setting term scoring rules: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, term)
+	}
+}
+
+func handleAdminGetTermStandings(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		standings, err := server.GetTermStandings(c.Param("id"))
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+				return
+			}
+
+			log.Printf("Error getting term standings: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, standings)
+	}
+}
+
+func handleDeleteTerm(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := server.DeleteTerm(c.Param("id")); err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+				return
+			}
+
+			log.Printf("Error deleting term: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Term deleted successfully"})
+	}
+}
+
+func handleAdminGetTermSessions(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessions, err := server.AdminGetTermSessions(c.Param("id"))
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+				return
+			}
+
+			log.Printf("Error getting sessions of term: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+	}
+}
+
+type rolloverTermRequest struct {
+	Name        string                `json:"name"`
+	StartsAt    time.Time             `json:"starts_at"`
+	EndsAt      time.Time             `json:"ends_at"`
+	Generations []float64             `json:"generations"`
+	Members     []server.RosterMember `json:"members"`
+	DryRun      bool                  `json:"dry_run"`
+}
+
+func handleRolloverTerm(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req rolloverTermRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		report, err := server.RolloverTerm(req.Name, req.StartsAt, req.EndsAt, req.Generations, req.Members, req.DryRun)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error rolling over term: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+func handleAdminListSessionSeries(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		seriesList, err := server.AdminListSessionSeries()
+		if err != nil {
+			log.Printf("Error getting session series: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"series": seriesList})
+	}
+}
+
+func handleAdminGetSessionSeries(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		series, err := server.AdminGetSessionSeries(c.Param("id"))
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+				return
+			}
+
+			log.Printf("Error getting session series: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, series)
+	}
+}
+
+type addSessionSeriesRequest struct {
+	// The template of the session names. E.g., "{{.Date}} 여의도 정규런"
+	Name string `json:"name"`
+	// The template of the session descriptions.
+	Description string `json:"description"`
+	// 0 is Sunday.
+	Weekday time.Weekday `json:"weekday"`
+	Hour    int          `json:"hour"`
+	Minute  int          `json:"minute"`
+	Score   int          `json:"score"`
+}
+
+func handleAddSessionSeries(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req addSessionSeriesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		id, err := server.AddSessionSeries(req.Name, req.Description, c.GetString(userIdKey), req.Weekday, req.Hour, req.Minute, req.Score)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error adding session series: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id})
+	}
+}
+
+type updateSessionSeriesRequest struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Weekday     time.Weekday `json:"weekday"`
+	Hour        int          `json:"hour"`
+	Minute      int          `json:"minute"`
+	Score       int          `json:"score"`
+
+	FieldMask []string `json:"field_mask"`
+}
+
+func handleUpdateSessionSeries(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req updateSessionSeriesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if len(req.FieldMask) <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Field mask is required"})
+			return
+		}
+
+		name := (*string)(nil)
+		if array.Contains(req.FieldMask, "name") {
+			name = &req.Name
+		}
+		description := (*string)(nil)
+		if array.Contains(req.FieldMask, "description") {
+			description = &req.Description
+		}
+		weekday := (*time.Weekday)(nil)
+		if array.Contains(req.FieldMask, "weekday") {
+			weekday = &req.Weekday
+		}
+		hour := (*int)(nil)
+		if array.Contains(req.FieldMask, "hour") {
+			hour = &req.Hour
+		}
+		minute := (*int)(nil)
+		if array.Contains(req.FieldMask, "minute") {
+			minute = &req.Minute
+		}
+		score := (*int)(nil)
+		if array.Contains(req.FieldMask, "score") {
+			score = &req.Score
+		}
+		if err := server.UpdateSessionSeries(c.Param("id"), name, description, weekday, hour, minute, score); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+				return
+			}
+
+			log.Printf("Error updating session series: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Series updated successfully"})
+	}
+}
+
+func handleCancelSessionSeries(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := server.CancelSessionSeries(c.Param("id")); err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+				return
+			}
+
+			log.Printf("Error cancelling session series: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Series cancelled successfully"})
+	}
+}
+
+type submitExcuseRequest struct {
+	// Either the session or the date range is given.
+	SessionId string     `json:"session_id"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	Reason    string     `json:"reason"`
+}
+
+func handleSubmitExcuse(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req submitExcuseRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		callerId := c.GetString(userIdKey)
+		submitted, err := server.SubmitExcuse(callerId, req.SessionId, req.StartsAt, req.EndsAt, req.Reason)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error submitting excuse: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, submitted)
+	}
+}
+
+func handleListMyExcuses(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		excuses, err := server.ListMyExcuses(c.GetString(userIdKey))
+		if err != nil {
+			log.Printf("Error listing excuses: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"excuses": excuses})
+	}
+}
+
+func handleAdminListExcuses(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		excuses, err := server.AdminListExcuses(excuse.Status(c.Query("status")))
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error listing excuses: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"excuses": excuses})
+	}
+}
+
+type reviewExcuseRequest struct {
+	Approve           bool `json:"approve"`
+	CountsTowardScore bool `json:"counts_toward_score"`
+}
+
+func handleReviewExcuse(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req reviewExcuseRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		callerId := c.GetString(userIdKey)
+		reviewed, err := server.ReviewExcuse(c.Param("id"), req.Approve, req.CountsTowardScore, callerId)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Excuse not found"})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error reviewing excuse: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, reviewed)
+	}
+}
+
+type removeAttendanceRequest struct {
+	Reason string `json:"reason"`
+}
+
+func handleRemoveAttendance(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req removeAttendanceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		callerId := c.GetString(userIdKey)
+		if err := server.RemoveAttendance(c.Param("id"), req.Reason, callerId); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Attendance not found"})
+				return
+			}
+
+			log.Printf("Error removing attendance: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Attendance removed successfully"})
+	}
+}
+
+type correctAttendanceRequest = server.CorrectAttendanceReq
+
+func handleCorrectAttendance(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req correctAttendanceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		callerId := c.GetString(userIdKey)
+		corrected, err := server.CorrectAttendance(c.Param("id"), req, callerId)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Attendance not found"})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error correcting attendance: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, corrected)
+	}
+}
+
+func handleListMyAuditEntries(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entries, err := server.ListMyAuditEntries(c.GetString(userIdKey))
+		if err != nil {
+			log.Printf("Error listing audit entries: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"entries": entries})
+	}
+}
+
+func handleAdminListSessionAuditEntries(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entries, err := server.AdminListSessionAuditEntries(c.Param("id"))
+		if err != nil {
+			log.Printf("Error listing audit entries of session: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"entries": entries})
+	}
+}
+
+type sessionCapacityRequest struct {
+	Capacity int `json:"capacity"`
+}
+
+func handleSetSessionCapacity(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req sessionCapacityRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		session, err := server.SetSessionCapacity(c.Param("id"), req.Capacity)
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			log.Printf("Error setting session capacity: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, session)
+	}
+}
+
+func handleRsvpToSession(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rsvp, err := server.RsvpToSession(c.Param("id"), c.GetString(userIdKey))
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error RSVPing to session: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, rsvp)
+	}
+}
+
+func handleCancelRsvp(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := server.CancelRsvp(c.Param("id"), c.GetString(userIdKey)); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error cancelling RSVP: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "RSVP cancelled successfully"})
+	}
+}
+
+func handleListMyRsvps(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rsvps, err := server.ListMyRsvps(c.GetString(userIdKey))
+		if err != nil {
+			log.Printf("Error listing RSVPs: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rsvps": rsvps})
+	}
+}
+
+func handleAdminListSessionRsvps(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rsvps, err := server.AdminListSessionRsvps(c.Param("id"))
+		if err != nil {
+			log.Printf("Error listing RSVPs of session: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rsvps": rsvps})
+	}
+}
+
+func handleAdminGetRsvpReport(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := server.AdminGetRsvpReport(c.Param("id"))
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+
+			log.Printf("Error getting RSVP report: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+func handleAdminListUserAuditEntries(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entries, err := server.AdminListUserAuditEntries(c.Param("id"))
+		if err != nil {
+			log.Printf("Error listing audit entries of user: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"entries": entries})
+	}
+}
+
+type changeUserRoleRequest struct {
+	Role   permission.Role `json:"role"`
+	Reason string          `json:"reason"`
+}
+
+func handleChangeUserRole(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req changeUserRoleRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, err := server.ChangeUserRole(c.Param("id"), req.Role, req.Reason, c.GetString(userIdKey))
+		if err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+				return
+			}
+
+			if isConflict(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+
+			log.Printf("Error changing user role: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, user)
+	}
+}
+
+func handleSignOut(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := server.SignOut(c.GetString(tokenIdKey)); err != nil {
+			if isBadRequest(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Token not found"})
+				return
+			}
+
+			log.Printf("Error signing out: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		clearAuthCookie(c)
+		c.JSON(http.StatusOK, gin.H{"message": "Signed out successfully"})
+	}
+}
+
+func handleSignOutEverywhere(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := server.SignOutEverywhere(c.GetString(userIdKey)); err != nil {
+			log.Printf("Error signing out everywhere: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		clearAuthCookie(c)
+		c.JSON(http.StatusOK, gin.H{"message": "Signed out everywhere successfully"})
+	}
+}
+
+func handleAdminSignOutUser(server *server.Server) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := server.AdminSignOutUser(c.Param("id")); err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+				return
+			}
+
+			log.Printf("Error signing out user: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "User signed out successfully"})
+	}
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"rush/attendance"
+	"rush/auth"
+	"rush/server"
+	"rush/session"
+	"rush/unmatched"
+	"rush/user"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fails to insert as if the same attendances have just been applied by another request.
+type racingAttendanceRepo struct {
+	attendance.Repo
+}
+
+func (r *racingAttendanceRepo) BulkInsert(requests []attendance.AddAttendanceReq) ([]string, error) {
+	return nil, fmt.Errorf("%w: applied by another request", attendance.ErrDuplicate)
+}
+
+// Returns the submissions of the given external names for any form.
+type fakeFormHandler struct {
+	externalNames []string
+	submittedAt   time.Time
+}
+
+func (f *fakeFormHandler) GenerateForm(title string, description string, userOptions []attendance.UserOption) (attendance.Form, error) {
+	return attendance.Form{Id: "form-id", Uri: "https://forms.example.com/form-id"}, nil
+}
+
+func (f *fakeFormHandler) UpdateFormInfo(formId string, title string, description string) error {
+	return nil
+}
+
+func (f *fakeFormHandler) GetSubmissions(formId string) ([]attendance.FormSubmission, []attendance.InvalidSubmission, error) {
+	submissions := []attendance.FormSubmission{}
+	for _, externalName := range f.externalNames {
+		submissions = append(submissions, attendance.FormSubmission{UserExternalName: externalName, SubmissionTime: f.submittedAt})
+	}
+	return submissions, []attendance.InvalidSubmission{}, nil
+}
+
+// Returns the server whose attendances are always applied by another request first, the ID of an open session
+// and the ID of an active user. The user submits the attendance form once it's created.
+func newRacingAttendanceServer(t *testing.T) (*server.Server, string, string) {
+	startsAt := time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC)
+	mockClock := clock.NewMock()
+	mockClock.Set(startsAt.Add(-time.Hour))
+	userRepo := user.NewMemoryRepo()
+	sessionRepo := session.NewMemoryRepo()
+	rushServer := server.New(server.Deps{
+		UserRepo:                userRepo,
+		SessionRepo:             sessionRepo,
+		OpenSessionRepo:         session.NewService(sessionRepo),
+		AttendanceFormHandler:   &fakeFormHandler{externalNames: []string{"김건"}, submittedAt: startsAt.Add(-time.Minute)},
+		AttendanceRepo:          &racingAttendanceRepo{Repo: attendance.NewMemoryRepo(mockClock)},
+		UnmatchedSubmissionRepo: unmatched.NewMemoryRepo(),
+		FormTimeLocation:        time.UTC,
+		Clock:                   mockClock,
+	})
+
+	assert.NoError(t, userRepo.Add(user.User{Name: "김건", Generation: 9, IsActive: true, ExternalName: "김건"}))
+	users, err := userRepo.GetAll()
+	assert.NoError(t, err)
+	sessionId, err := rushServer.AddSession("정규런", "", "admin-id", startsAt, 2)
+	assert.NoError(t, err)
+	return rushServer, sessionId, users[0].Id
+}
+
+// Serves the handler as the admin and returns the response.
+func serveAsAdmin(handler gin.HandlerFunc, path string, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/sessions/:id", func(c *gin.Context) { c.Set(userIdKey, "admin-id") }, handler)
+
+	resRecorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(resRecorder, req)
+	return resRecorder
+}
+
+func TestApplyAttendanceHandlers(t *testing.T) {
+	t.Run("Returns 409 when the form submissions have been applied by another request", func(t *testing.T) {
+		rushServer, sessionId, _ := newRacingAttendanceServer(t)
+		_, err := rushServer.CreateAttendanceForm(sessionId)
+		assert.NoError(t, err)
+
+		res := serveAsAdmin(handleApplyAttendanceByFormSubmissions(rushServer), "/sessions/"+sessionId, "")
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+
+	t.Run("Returns 409 when the users have been marked as present by another request", func(t *testing.T) {
+		rushServer, sessionId, userId := newRacingAttendanceServer(t)
+
+		res := serveAsAdmin(handleMarkUsersAsPresent(rushServer), "/sessions/"+sessionId, `{"user_ids": ["`+userId+`"]}`)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+
+	t.Run("Returns 409 when the attendances have been late applied by another request", func(t *testing.T) {
+		rushServer, sessionId, userId := newRacingAttendanceServer(t)
+
+		res := serveAsAdmin(handleLateApplyAttendance(rushServer), "/sessions/"+sessionId,
+			`{"user_ids": ["`+userId+`"], "status": "late", "reason": "폼 마감 후 도착"}`)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+}
+
+func TestSignOutHandler(t *testing.T) {
+	t.Run("Removes the auth cookie and the refreshed token after signing out", func(t *testing.T) {
+		tokenRepo := auth.NewMemoryRepo()
+		assert.NoError(t, tokenRepo.Add(auth.Token{Id: "token_id", UserId: "user_id"}))
+		rushServer := server.New(server.Deps{TokenRepo: tokenRepo, Clock: clock.NewMock()})
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/sign-out", func(c *gin.Context) {
+			c.Header(replaceCookieHeader, "new_token")
+			c.Set(tokenIdKey, "token_id")
+		}, handleSignOut(rushServer))
+
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://rush.example.com:8080/sign-out", nil)
+		router.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Empty(t, res.Header().Get(replaceCookieHeader))
+		cookies := res.Result().Cookies()
+		assert.Len(t, cookies, 1)
+		assert.Equal(t, authCookieName, cookies[0].Name)
+		assert.Equal(t, "", cookies[0].Value)
+		assert.Equal(t, "rush.example.com", cookies[0].Domain)
+		assert.True(t, cookies[0].MaxAge < 0)
+	})
+}
@@ -2,7 +2,7 @@ package http
 
 import (
 	"net/http"
-	"rush/golang/array"
+	"net/url"
 	"rush/permission"
 	"rush/server"
 
@@ -17,6 +17,7 @@ type userSessionFetcher interface {
 const authCookieName = "rush-auth"
 const userIdKey = "userId"
 const userRoleKey = "userRole"
+const tokenIdKey = "tokenId"
 const replaceCookieHeader = "X-Replace-Cookie"
 
 func UseAuthMiddleware(userSessionFetcher userSessionFetcher) gin.HandlerFunc {
@@ -52,11 +53,20 @@ func UseAuthMiddleware(userSessionFetcher userSessionFetcher) gin.HandlerFunc {
 
 		c.Set(userIdKey, userSession.UserId)
 		c.Set(userRoleKey, userSession.Role)
+		c.Set(tokenIdKey, userSession.TokenId)
 		c.Next()
 	}
 }
 
-func RequireRole(requiredRoles ...permission.Role) gin.HandlerFunc {
+// Removes the auth cookie and drops the token that the middleware may have refreshed for the request.
+// The UI sets the cookie for the host name of the server, so it's removed for the same domain.
+func clearAuthCookie(c *gin.Context) {
+	c.Writer.Header().Del(replaceCookieHeader)
+	c.SetCookie(authCookieName, "", -1, "/", (&url.URL{Host: c.Request.Host}).Hostname(), false, false)
+}
+
+// Lets the request through only if the role of the user is granted the action by the permission policy.
+func RequireAction(action permission.Action) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get(userRoleKey)
 		if !exists {
@@ -72,7 +82,7 @@ func RequireRole(requiredRoles ...permission.Role) gin.HandlerFunc {
 			return
 		}
 
-		if array.Contains(requiredRoles, role) {
+		if role.Can(action) {
 			c.Next()
 			return
 		}
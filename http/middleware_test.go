@@ -148,9 +148,9 @@ func TestUseAuthMiddleware(t *testing.T) {
 	})
 }
 
-func TestRequireRole(t *testing.T) {
+func TestRequireAction(t *testing.T) {
 	t.Run("Should return a gin.HandlerFunc", func(t *testing.T) {
-		middleware := RequireRole(permission.RoleAdmin)
+		middleware := RequireAction(permission.ActionSessionCreate)
 
 		assert.NotNil(t, middleware)
 		assert.IsType(t, gin.HandlerFunc(nil), middleware)
@@ -161,7 +161,7 @@ func TestRequireRole(t *testing.T) {
 		ctx, _ := gin.CreateTestContext(resRecorder)
 		ctx.Request, _ = http.NewRequest("GET", "/", nil)
 
-		middleware := RequireRole(permission.RoleAdmin)
+		middleware := RequireAction(permission.ActionSessionCreate)
 		middleware(ctx)
 
 		assert.Equal(t, http.StatusInternalServerError, ctx.Writer.Status())
@@ -173,7 +173,7 @@ func TestRequireRole(t *testing.T) {
 		ctx.Request, _ = http.NewRequest("GET", "/", nil)
 		ctx.Set(userRoleKey, "invalid")
 
-		middleware := RequireRole(permission.RoleAdmin)
+		middleware := RequireAction(permission.ActionSessionCreate)
 		middleware(ctx)
 
 		assert.Equal(t, http.StatusInternalServerError, ctx.Writer.Status())
@@ -185,7 +185,7 @@ func TestRequireRole(t *testing.T) {
 		ctx.Request, _ = http.NewRequest("GET", "/", nil)
 		ctx.Set(userRoleKey, permission.RoleMember)
 
-		middleware := RequireRole(permission.RoleAdmin, permission.RoleSuperAdmin)
+		middleware := RequireAction(permission.ActionSessionCreate)
 		middleware(ctx)
 
 		assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
@@ -197,9 +197,21 @@ func TestRequireRole(t *testing.T) {
 		ctx.Request, _ = http.NewRequest("GET", "/", nil)
 		ctx.Set(userRoleKey, permission.RoleAdmin)
 
-		middleware := RequireRole(permission.RoleAdmin, permission.RoleSuperAdmin)
+		middleware := RequireAction(permission.ActionSessionCreate)
 		middleware(ctx)
 
 		assert.Equal(t, http.StatusOK, ctx.Writer.Status())
 	})
+
+	t.Run("Should return 403 when the action is only for the super admins", func(t *testing.T) {
+		resRecorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(resRecorder)
+		ctx.Request, _ = http.NewRequest("GET", "/", nil)
+		ctx.Set(userRoleKey, permission.RoleAdmin)
+
+		middleware := RequireAction(permission.ActionUserChangeRole)
+		middleware(ctx)
+
+		assert.Equal(t, http.StatusForbidden, ctx.Writer.Status())
+	})
 }
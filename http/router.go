@@ -22,31 +22,83 @@ func SetUpRouter(router *gin.Engine, server *server.Server) {
 		{
 			// handleAuth doesn't immplement anything. It relies on the middleware to check the token.
 			protected.GET("/auth", handleAuth(server))
+			protected.GET("/permissions", handleListMyPermissions(server))
+			protected.POST("/sign-out", handleSignOut(server))
+			protected.POST("/sign-out/everywhere", handleSignOutEverywhere(server))
 
 			protected.GET("/users/:id/attendances", handleGetAttendanceForUser(server))
 			protected.GET("/users/:id", handleGetUser(server))
 
 			protected.GET("/sessions/:id/attendances", handleGetAttendanceForSession(server))
+			protected.POST("/sessions/:id/check-in", handleCheckIn(server))
+			protected.POST("/sessions/:id/check-in/location", handleCheckInAtMeetingPoint(server))
+			protected.POST("/sessions/:id/rsvp", handleRsvpToSession(server))
+			protected.DELETE("/sessions/:id/rsvp", handleCancelRsvp(server))
+			protected.GET("/rsvps", handleListMyRsvps(server))
+
+			protected.POST("/excuses", handleSubmitExcuse(server))
+			protected.GET("/excuses", handleListMyExcuses(server))
+
+			protected.GET("/audit-entries", handleListMyAuditEntries(server))
 
 			// TODO(#138): Move it to the admin group after fixing the UI to handle permission denied error on it more properly.
 			protected.GET("attendances/half-year", handleHalfYearAttendance(server))
 
-			adminProtected := protected.Group("/admin")
-			adminProtected.Use(RequireRole(permission.RoleAdmin, permission.RoleSuperAdmin))
+			// Each API requires the action that the permission policy grants to the roles.
+			admin := protected.Group("/admin")
 			{
-				adminProtected.POST("/users", handleAddUser(server))
-				adminProtected.GET("/users", handleListUsers(server))
-				adminProtected.PATCH("/users/:id", handleUpdateUser(server))
-
-				adminProtected.POST("/sessions", handleAddSession(server))
-				adminProtected.GET("/sessions", handleAdminListSessions(server))
-				adminProtected.GET("/sessions/:id", handleAdminGetSession(server))
-				adminProtected.DELETE("/sessions/:id", handleDeleteSession(server))
-
-				adminProtected.POST("/sessions/:id/attendance-form", handleCreateAttendanceForm(server))
-				adminProtected.POST("/sessions/:id/attendance/form", handleApplyAttendanceByFormSubmissions(server))
-				adminProtected.POST("/sessions/:id/attendance/manual", handleMarkUsersAsPresent(server))
-				adminProtected.POST("/sessions/:id/attendance/late", handleLateApplyAttendance(server))
+				admin.POST("/users", RequireAction(permission.ActionUserCreate), handleAddUser(server))
+				admin.GET("/users", RequireAction(permission.ActionUserReadAny), handleAdminListUsers(server))
+				admin.GET("/users/unreliable", RequireAction(permission.ActionUserReadAny), handleAdminListUnreliableUsers(server))
+				admin.GET("/users/:id", RequireAction(permission.ActionUserReadAny), handleAdminGetUser(server))
+				admin.PATCH("/users/:id", RequireAction(permission.ActionUserUpdate), handleUpdateUser(server))
+				admin.GET("/users/:id/audit-entries", RequireAction(permission.ActionUserReadAny), handleAdminListUserAuditEntries(server))
+				admin.PUT("/users/:id/role", RequireAction(permission.ActionUserChangeRole), handleChangeUserRole(server))
+				admin.POST("/users/:id/sign-out", RequireAction(permission.ActionUserSignOutAny), handleAdminSignOutUser(server))
+
+				admin.POST("/sessions", RequireAction(permission.ActionSessionCreate), handleAddSession(server))
+				admin.GET("/sessions", RequireAction(permission.ActionSessionReadAny), handleAdminListSessions(server))
+				admin.GET("/sessions/:id", RequireAction(permission.ActionSessionReadAny), handleAdminGetSession(server))
+				admin.PATCH("/sessions/:id", RequireAction(permission.ActionSessionUpdate), handleUpdateSession(server))
+				admin.DELETE("/sessions/:id", RequireAction(permission.ActionSessionDelete), handleDeleteSession(server))
+
+				admin.POST("/sessions/:id/attendance-form", RequireAction(permission.ActionSessionUpdate), handleCreateAttendanceForm(server))
+				admin.POST("/sessions/:id/attendance/form", RequireAction(permission.ActionAttendanceApply), handleApplyAttendanceByFormSubmissions(server))
+				admin.POST("/sessions/:id/attendance/manual", RequireAction(permission.ActionAttendanceApply), handleMarkUsersAsPresent(server))
+				admin.POST("/sessions/:id/attendance/late", RequireAction(permission.ActionAttendanceForceApply), handleLateApplyAttendance(server))
+				admin.POST("/sessions/:id/attendance/csv", RequireAction(permission.ActionAttendanceApply), handleImportAttendanceCsv(server))
+				admin.GET("/sessions/:id/attendance/unmatched", RequireAction(permission.ActionAttendanceApply), handleAdminListUnmatchedSubmissions(server))
+				admin.POST("/sessions/:id/attendance/unmatched/resolve", RequireAction(permission.ActionAttendanceApply), handleResolveUnmatchedSubmissions(server))
+				admin.GET("/sessions/:id/check-in-code", RequireAction(permission.ActionSessionIssueCheckInCode), handleGetCheckInCode(server))
+				admin.PUT("/sessions/:id/meeting-point", RequireAction(permission.ActionSessionUpdate), handleSetSessionMeetingPoint(server))
+				admin.DELETE("/sessions/:id/meeting-point", RequireAction(permission.ActionSessionUpdate), handleDeleteSessionMeetingPoint(server))
+				admin.GET("/sessions/:id/check-in-rejections", RequireAction(permission.ActionSessionReadAny), handleAdminListCheckInRejections(server))
+				admin.GET("/sessions/:id/audit-entries", RequireAction(permission.ActionSessionReadAny), handleAdminListSessionAuditEntries(server))
+				admin.PUT("/sessions/:id/capacity", RequireAction(permission.ActionSessionUpdate), handleSetSessionCapacity(server))
+				admin.GET("/sessions/:id/rsvps", RequireAction(permission.ActionSessionReadAny), handleAdminListSessionRsvps(server))
+				admin.GET("/sessions/:id/rsvps/report", RequireAction(permission.ActionSessionReadAny), handleAdminGetRsvpReport(server))
+
+				admin.PATCH("/attendances/:id", RequireAction(permission.ActionAttendanceCorrect), handleCorrectAttendance(server))
+				admin.DELETE("/attendances/:id", RequireAction(permission.ActionAttendanceCorrect), handleRemoveAttendance(server))
+
+				admin.POST("/session-series", RequireAction(permission.ActionSeriesManage), handleAddSessionSeries(server))
+				admin.GET("/session-series", RequireAction(permission.ActionSeriesManage), handleAdminListSessionSeries(server))
+				admin.GET("/session-series/:id", RequireAction(permission.ActionSeriesManage), handleAdminGetSessionSeries(server))
+				admin.PATCH("/session-series/:id", RequireAction(permission.ActionSeriesManage), handleUpdateSessionSeries(server))
+				admin.DELETE("/session-series/:id", RequireAction(permission.ActionSeriesManage), handleCancelSessionSeries(server))
+
+				admin.GET("/excuses", RequireAction(permission.ActionExcuseReview), handleAdminListExcuses(server))
+				admin.POST("/excuses/:id/review", RequireAction(permission.ActionExcuseReview), handleReviewExcuse(server))
+
+				admin.POST("/terms", RequireAction(permission.ActionTermManage), handleAddTerm(server))
+				admin.POST("/terms/rollover", RequireAction(permission.ActionTermRollover), handleRolloverTerm(server))
+				admin.GET("/terms", RequireAction(permission.ActionTermRead), handleAdminListTerms(server))
+				admin.GET("/terms/:id", RequireAction(permission.ActionTermRead), handleAdminGetTerm(server))
+				admin.PATCH("/terms/:id", RequireAction(permission.ActionTermManage), handleUpdateTerm(server))
+				admin.DELETE("/terms/:id", RequireAction(permission.ActionTermManage), handleDeleteTerm(server))
+				admin.GET("/terms/:id/sessions", RequireAction(permission.ActionTermRead), handleAdminGetTermSessions(server))
+				admin.PUT("/terms/:id/scoring-rules", RequireAction(permission.ActionTermManage), handleSetTermScoringRules(server))
+				admin.GET("/terms/:id/standings", RequireAction(permission.ActionTermRead), handleAdminGetTermStandings(server))
 			}
 		}
 	}
@@ -0,0 +1,75 @@
+package job
+
+import (
+	"rush/golang/array"
+	"rush/session"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+//go:generate mockgen -source=reliability.go -destination=reliability_mock.go -package=job
+
+type recentSessionGetter interface {
+	// Returns the sessions that start within [from, to).
+	GetAllStartingBetween(from time.Time, to time.Time) ([]session.Session, error)
+}
+
+type sessionReliabilityEvaluator interface {
+	// Compares the confirmed RSVPs of the applied session with its attendances, and keeps whether each member showed up.
+	EvaluateSessionReliability(sessionId string) error
+}
+
+type reliabilityExecutor struct {
+	sessionGetter               recentSessionGetter
+	sessionReliabilityEvaluator sessionReliabilityEvaluator
+	logger                      logger
+	clock                       clock.Clock
+	// How far back the applied sessions are evaluated again. The corrections of their attendances are reflected
+	// within it. E.g., 7 days
+	lookback time.Duration
+}
+
+func NewReliabilityExecutor(sessionGetter recentSessionGetter, sessionReliabilityEvaluator sessionReliabilityEvaluator,
+	logger logger, clock clock.Clock, lookback time.Duration) *reliabilityExecutor {
+	return &reliabilityExecutor{
+		sessionGetter:               sessionGetter,
+		sessionReliabilityEvaluator: sessionReliabilityEvaluator,
+		logger:                      logger,
+		clock:                       clock,
+		lookback:                    lookback,
+	}
+}
+
+// Evaluates the no-shows of the applied sessions that started within the lookback.
+// The sessions whose attendances are not applied yet are evaluated after they are applied.
+func (e *reliabilityExecutor) EvaluateNoShows() {
+	now := e.clock.Now()
+	recentSessions, err := e.sessionGetter.GetAllStartingBetween(now.Add(-e.lookback), now)
+	if err != nil {
+		e.logger.Errorw("Failed to get recent sessions", "error", err.Error())
+		return
+	}
+
+	failedSessionIds := []string{}
+	succeededSessionIds := []string{}
+	evaluateErr := []error{}
+	for _, recentSession := range recentSessions {
+		if recentSession.AttendanceStatus != session.AttendanceStatusApplied {
+			continue
+		}
+		if err := e.sessionReliabilityEvaluator.EvaluateSessionReliability(recentSession.Id); err != nil {
+			failedSessionIds = append(failedSessionIds, recentSession.Id)
+			evaluateErr = append(evaluateErr, err)
+			continue
+		}
+		succeededSessionIds = append(succeededSessionIds, recentSession.Id)
+	}
+
+	e.logger.Infow("Evaluated no-shows of sessions", "session_ids", strings.Join(succeededSessionIds, ", "))
+	if len(failedSessionIds) > 0 {
+		e.logger.Errorw("Failed to evaluate no-shows of sessions", "session_ids", strings.Join(failedSessionIds, ", "),
+			"errors", strings.Join(array.Map(evaluateErr, func(err error) string { return err.Error() }), ", "))
+	}
+}
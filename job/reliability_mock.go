@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reliability.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reliability.go -destination=reliability_mock.go -package=job
+//
+
+// Package job is a generated GoMock package.
+package job
+
+import (
+	reflect "reflect"
+	session "rush/session"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockrecentSessionGetter is a mock of recentSessionGetter interface.
+type MockrecentSessionGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockrecentSessionGetterMockRecorder
+}
+
+// MockrecentSessionGetterMockRecorder is the mock recorder for MockrecentSessionGetter.
+type MockrecentSessionGetterMockRecorder struct {
+	mock *MockrecentSessionGetter
+}
+
+// NewMockrecentSessionGetter creates a new mock instance.
+func NewMockrecentSessionGetter(ctrl *gomock.Controller) *MockrecentSessionGetter {
+	mock := &MockrecentSessionGetter{ctrl: ctrl}
+	mock.recorder = &MockrecentSessionGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrecentSessionGetter) EXPECT() *MockrecentSessionGetterMockRecorder {
+	return m.recorder
+}
+
+// GetAllStartingBetween mocks base method.
+func (m *MockrecentSessionGetter) GetAllStartingBetween(from, to time.Time) ([]session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllStartingBetween", from, to)
+	ret0, _ := ret[0].([]session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllStartingBetween indicates an expected call of GetAllStartingBetween.
+func (mr *MockrecentSessionGetterMockRecorder) GetAllStartingBetween(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStartingBetween", reflect.TypeOf((*MockrecentSessionGetter)(nil).GetAllStartingBetween), from, to)
+}
+
+// MocksessionReliabilityEvaluator is a mock of sessionReliabilityEvaluator interface.
+type MocksessionReliabilityEvaluator struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionReliabilityEvaluatorMockRecorder
+}
+
+// MocksessionReliabilityEvaluatorMockRecorder is the mock recorder for MocksessionReliabilityEvaluator.
+type MocksessionReliabilityEvaluatorMockRecorder struct {
+	mock *MocksessionReliabilityEvaluator
+}
+
+// NewMocksessionReliabilityEvaluator creates a new mock instance.
+func NewMocksessionReliabilityEvaluator(ctrl *gomock.Controller) *MocksessionReliabilityEvaluator {
+	mock := &MocksessionReliabilityEvaluator{ctrl: ctrl}
+	mock.recorder = &MocksessionReliabilityEvaluatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionReliabilityEvaluator) EXPECT() *MocksessionReliabilityEvaluatorMockRecorder {
+	return m.recorder
+}
+
+// EvaluateSessionReliability mocks base method.
+func (m *MocksessionReliabilityEvaluator) EvaluateSessionReliability(sessionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateSessionReliability", sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EvaluateSessionReliability indicates an expected call of EvaluateSessionReliability.
+func (mr *MocksessionReliabilityEvaluatorMockRecorder) EvaluateSessionReliability(sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateSessionReliability", reflect.TypeOf((*MocksessionReliabilityEvaluator)(nil).EvaluateSessionReliability), sessionId)
+}
@@ -0,0 +1,48 @@
+package job
+
+import (
+	"errors"
+	"rush/session"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestEvaluateNoShows(t *testing.T) {
+	t.Run("Fails if it fails to get recent sessions", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		sessionGetter := NewMockrecentSessionGetter(controller)
+		evaluator := NewMocksessionReliabilityEvaluator(controller)
+		mockLogger := NewMocklogger(controller)
+		executor := NewReliabilityExecutor(sessionGetter, evaluator, mockLogger, clock.NewMock(), 7*24*time.Hour)
+
+		sessionGetter.EXPECT().GetAllStartingBetween(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+		mockLogger.EXPECT().Errorw("Failed to get recent sessions", "error", assert.AnError.Error())
+		executor.EvaluateNoShows()
+	})
+
+	t.Run("Evaluates the applied sessions within the lookback and logs the failed ones", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		sessionGetter := NewMockrecentSessionGetter(controller)
+		evaluator := NewMocksessionReliabilityEvaluator(controller)
+		mockLogger := NewMocklogger(controller)
+		clock := clock.NewMock()
+		executor := NewReliabilityExecutor(sessionGetter, evaluator, mockLogger, clock, 7*24*time.Hour)
+
+		clock.Set(time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC))
+		sessionGetter.EXPECT().GetAllStartingBetween(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC)).Return([]session.Session{
+			{Id: "sessionId1", AttendanceStatus: session.AttendanceStatusApplied},
+			{Id: "sessionId2", AttendanceStatus: session.AttendanceStatusNotAppliedYet},
+			{Id: "sessionId3", AttendanceStatus: session.AttendanceStatusApplied},
+			{Id: "sessionId4", AttendanceStatus: session.AttendanceStatusIgnored},
+		}, nil)
+		evaluator.EXPECT().EvaluateSessionReliability("sessionId1").Return(nil)
+		evaluator.EXPECT().EvaluateSessionReliability("sessionId3").Return(errors.New("error3"))
+		mockLogger.EXPECT().Infow("Evaluated no-shows of sessions", "session_ids", "sessionId1")
+		mockLogger.EXPECT().Errorw("Failed to evaluate no-shows of sessions", "session_ids", "sessionId3", "errors", "error3")
+		executor.EvaluateNoShows()
+	})
+}
@@ -0,0 +1,70 @@
+package job
+
+import (
+	"rush/golang/array"
+	"rush/series"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+//go:generate mockgen -source=series.go -destination=series_mock.go -package=job
+
+type seriesGetter interface {
+	// Returns the series that are not cancelled.
+	GetAllActive() ([]series.Series, error)
+}
+
+type seriesMaterializer interface {
+	// Creates the sessions of the series that start before `until` and returns their IDs.
+	MaterializeSessionSeries(seriesId string, until time.Time) ([]string, error)
+}
+
+type seriesExecutor struct {
+	seriesGetter       seriesGetter
+	seriesMaterializer seriesMaterializer
+	logger             logger
+	clock              clock.Clock
+	// How many weeks ahead the sessions are created. E.g., 4
+	weeksAhead int
+}
+
+func NewSeriesExecutor(seriesGetter seriesGetter, seriesMaterializer seriesMaterializer, logger logger, clock clock.Clock, weeksAhead int) *seriesExecutor {
+	return &seriesExecutor{
+		seriesGetter:       seriesGetter,
+		seriesMaterializer: seriesMaterializer,
+		logger:             logger,
+		clock:              clock,
+		weeksAhead:         weeksAhead,
+	}
+}
+
+// Creates the sessions of the active series that start within the next `weeksAhead` weeks.
+func (e *seriesExecutor) MaterializeSessionSeries() {
+	activeSeries, err := e.seriesGetter.GetAllActive()
+	if err != nil {
+		e.logger.Errorw("Failed to get active series", "error", err.Error())
+		return
+	}
+
+	until := e.clock.Now().AddDate(0, 0, 7*e.weeksAhead)
+	failedSeriesIds := []string{}
+	createdSessionIds := []string{}
+	materializeErr := []error{}
+	for _, series := range activeSeries {
+		// The sessions created before the failure are still created.
+		sessionIds, err := e.seriesMaterializer.MaterializeSessionSeries(series.Id, until)
+		createdSessionIds = append(createdSessionIds, sessionIds...)
+		if err != nil {
+			failedSeriesIds = append(failedSeriesIds, series.Id)
+			materializeErr = append(materializeErr, err)
+		}
+	}
+
+	e.logger.Infow("Created sessions of series", "session_ids", strings.Join(createdSessionIds, ", "))
+	if len(failedSeriesIds) > 0 {
+		e.logger.Errorw("Failed to create sessions of series", "series_ids", strings.Join(failedSeriesIds, ", "),
+			"errors", strings.Join(array.Map(materializeErr, func(err error) string { return err.Error() }), ", "))
+	}
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: series.go
+//
+// Generated by this command:
+//
+//	mockgen -source=series.go -destination=series_mock.go -package=job
+//
+
+// Package job is a generated GoMock package.
+package job
+
+import (
+	reflect "reflect"
+	series "rush/series"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockseriesGetter is a mock of seriesGetter interface.
+type MockseriesGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockseriesGetterMockRecorder
+}
+
+// MockseriesGetterMockRecorder is the mock recorder for MockseriesGetter.
+type MockseriesGetterMockRecorder struct {
+	mock *MockseriesGetter
+}
+
+// NewMockseriesGetter creates a new mock instance.
+func NewMockseriesGetter(ctrl *gomock.Controller) *MockseriesGetter {
+	mock := &MockseriesGetter{ctrl: ctrl}
+	mock.recorder = &MockseriesGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockseriesGetter) EXPECT() *MockseriesGetterMockRecorder {
+	return m.recorder
+}
+
+// GetAllActive mocks base method.
+func (m *MockseriesGetter) GetAllActive() ([]series.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllActive")
+	ret0, _ := ret[0].([]series.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllActive indicates an expected call of GetAllActive.
+func (mr *MockseriesGetterMockRecorder) GetAllActive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActive", reflect.TypeOf((*MockseriesGetter)(nil).GetAllActive))
+}
+
+// MockseriesMaterializer is a mock of seriesMaterializer interface.
+type MockseriesMaterializer struct {
+	ctrl     *gomock.Controller
+	recorder *MockseriesMaterializerMockRecorder
+}
+
+// MockseriesMaterializerMockRecorder is the mock recorder for MockseriesMaterializer.
+type MockseriesMaterializerMockRecorder struct {
+	mock *MockseriesMaterializer
+}
+
+// NewMockseriesMaterializer creates a new mock instance.
+func NewMockseriesMaterializer(ctrl *gomock.Controller) *MockseriesMaterializer {
+	mock := &MockseriesMaterializer{ctrl: ctrl}
+	mock.recorder = &MockseriesMaterializerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockseriesMaterializer) EXPECT() *MockseriesMaterializerMockRecorder {
+	return m.recorder
+}
+
+// MaterializeSessionSeries mocks base method.
+func (m *MockseriesMaterializer) MaterializeSessionSeries(seriesId string, until time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaterializeSessionSeries", seriesId, until)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaterializeSessionSeries indicates an expected call of MaterializeSessionSeries.
+func (mr *MockseriesMaterializerMockRecorder) MaterializeSessionSeries(seriesId, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeSessionSeries", reflect.TypeOf((*MockseriesMaterializer)(nil).MaterializeSessionSeries), seriesId, until)
+}
@@ -0,0 +1,45 @@
+package job
+
+import (
+	"errors"
+	"rush/series"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestMaterializeSessionSeries(t *testing.T) {
+	t.Run("Fails if it fails to get active series", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		seriesGetter := NewMockseriesGetter(controller)
+		seriesMaterializer := NewMockseriesMaterializer(controller)
+		mockLogger := NewMocklogger(controller)
+		executor := NewSeriesExecutor(seriesGetter, seriesMaterializer, mockLogger, clock.NewMock(), 4)
+
+		seriesGetter.EXPECT().GetAllActive().Return(nil, assert.AnError)
+		mockLogger.EXPECT().Errorw("Failed to get active series", "error", assert.AnError.Error())
+		executor.MaterializeSessionSeries()
+	})
+
+	t.Run("Creates the sessions of each series and logs the failed ones", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		seriesGetter := NewMockseriesGetter(controller)
+		seriesMaterializer := NewMockseriesMaterializer(controller)
+		mockLogger := NewMocklogger(controller)
+		clock := clock.NewMock()
+		executor := NewSeriesExecutor(seriesGetter, seriesMaterializer, mockLogger, clock, 4)
+
+		clock.Set(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+		until := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+		seriesGetter.EXPECT().GetAllActive().Return([]series.Series{{Id: "seriesId1"}, {Id: "seriesId2"}, {Id: "seriesId3"}}, nil)
+		seriesMaterializer.EXPECT().MaterializeSessionSeries("seriesId1", until).Return([]string{"sessionId1", "sessionId2"}, nil)
+		seriesMaterializer.EXPECT().MaterializeSessionSeries("seriesId2", until).Return([]string{"sessionId3"}, errors.New("error1"))
+		seriesMaterializer.EXPECT().MaterializeSessionSeries("seriesId3", until).Return([]string{}, nil)
+		mockLogger.EXPECT().Infow("Created sessions of series", "session_ids", "sessionId1, sessionId2, sessionId3")
+		mockLogger.EXPECT().Errorw("Failed to create sessions of series", "series_ids", "seriesId2", "errors", "error1")
+		executor.MaterializeSessionSeries()
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"rush/golang/array"
 	"rush/session"
 	"strings"
+	"time"
 
 	"github.com/benbjohnson/clock"
 )
@@ -12,16 +13,23 @@ import (
 //go:generate mockgen -source=session.go -destination=session_mock.go -package=job
 
 type sessionGetter interface {
-	// Get open sessions with the form. Open means the session has not closed, as in the attendance
+	// Get open sessions with the attendance source. Open means the session has not closed, as in the attendance
 	// is not applied yet.
-	GetOpenSessionsWithForm() ([]session.Session, error)
+	GetOpenSessionsWithSource() ([]session.Session, error)
+	// Returns the sessions that start within [from, to).
+	GetAllStartingBetween(from time.Time, to time.Time) ([]session.Session, error)
 }
 
 type sessionAttendanceApplier interface {
-	// Apply the attendances of the users who submitted the form.
+	// Apply the attendances of the users who submitted to the attendance source of the session.
 	ApplyAttendanceByFormSubmissions(sessionId string, callerId string) error
 }
 
+type attendanceFormCreator interface {
+	// Creates the attendance form of the open session and returns its URI.
+	CreateAttendanceForm(sessionId string) (string, error)
+}
+
 type logger interface {
 	// Logs the given info with the info level.
 	// Info level indicates any information that should be logged.
@@ -34,34 +42,45 @@ type logger interface {
 type executor struct {
 	sessionGetter            sessionGetter
 	sessionAttendanceApplier sessionAttendanceApplier
+	attendanceFormCreator    attendanceFormCreator
 	logger                   logger
 	clock                    clock.Clock
+	// How long before the session starts the attendance form is created. E.g., 24 hours
+	formLeadTime time.Duration
 }
 
 // The job ID of the session attendance syncer.
 // It is used to identify the attendances applied by the syncer.
 var jobId = "session-attendance-syncer"
 
-func NewExecutor(sessionGetter sessionGetter, sessionAttendanceApplier sessionAttendanceApplier, logger logger, clock clock.Clock) *executor {
+func NewExecutor(sessionGetter sessionGetter, sessionAttendanceApplier sessionAttendanceApplier, attendanceFormCreator attendanceFormCreator,
+	logger logger, clock clock.Clock, formLeadTime time.Duration) *executor {
 	return &executor{
 		sessionGetter:            sessionGetter,
 		sessionAttendanceApplier: sessionAttendanceApplier,
+		attendanceFormCreator:    attendanceFormCreator,
 		logger:                   logger,
 		clock:                    clock,
+		formLeadTime:             formLeadTime,
 	}
 }
 
-// Closes the open sessions that are past the start time.
+// Closes the open sessions whose attendance sources are closed. E.g., the form is closed when the session starts.
 func (e *executor) CloseExpiredSessions() {
-	openSessions, err := e.sessionGetter.GetOpenSessionsWithForm()
+	openSessions, err := e.sessionGetter.GetOpenSessionsWithSource()
 	if err != nil {
-		e.logger.Errorw("Failed to get open sessions with form", "error", err.Error())
+		e.logger.Errorw("Failed to get open sessions with attendance source", "error", err.Error())
 		return
 	}
 
 	now := e.clock.Now()
-	sessionsToClose := array.Filter(openSessions, func(session session.Session) bool {
-		return now.After(session.StartsAt) || now.Equal(session.StartsAt)
+	sessionsToClose := array.Filter(openSessions, func(openSession session.Session) bool {
+		// The CSV file is applied when it's imported and can't be fetched again.
+		if openSession.AttendanceSource.Provider == session.AttendanceSourceProviderCsv {
+			return false
+		}
+		closesAt := openSession.AttendanceClosesAt()
+		return now.After(closesAt) || now.Equal(closesAt)
 	})
 
 	failedSessionIds := []string{}
@@ -83,3 +102,38 @@ func (e *executor) CloseExpiredSessions() {
 		return
 	}
 }
+
+// Creates the attendance forms of the open sessions that start within the form lead time.
+// The sessions that already have an attendance source or are closed are skipped.
+func (e *executor) CreateAttendanceForms() {
+	now := e.clock.Now()
+	upcomingSessions, err := e.sessionGetter.GetAllStartingBetween(now, now.Add(e.formLeadTime))
+	if err != nil {
+		e.logger.Errorw("Failed to get upcoming sessions", "error", err.Error())
+		return
+	}
+
+	failedSessionIds := []string{}
+	succeededSessionIds := []string{}
+	skippedSessionIds := []string{}
+	createErr := []error{}
+	for _, upcomingSession := range upcomingSessions {
+		if upcomingSession.AttendanceSource.IsSet() || upcomingSession.AttendanceStatus != session.AttendanceStatusNotAppliedYet {
+			skippedSessionIds = append(skippedSessionIds, upcomingSession.Id)
+			continue
+		}
+		if _, err := e.attendanceFormCreator.CreateAttendanceForm(upcomingSession.Id); err != nil {
+			failedSessionIds = append(failedSessionIds, upcomingSession.Id)
+			createErr = append(createErr, err)
+			continue
+		}
+		succeededSessionIds = append(succeededSessionIds, upcomingSession.Id)
+	}
+
+	e.logger.Infow("Created attendance forms", "session_ids", strings.Join(succeededSessionIds, ", "))
+	e.logger.Infow("Skipped creating attendance forms", "session_ids", strings.Join(skippedSessionIds, ", "))
+	if len(failedSessionIds) > 0 {
+		e.logger.Errorw("Failed to create attendance forms", "session_ids", strings.Join(failedSessionIds, ", "),
+			"errors", strings.Join(array.Map(createErr, func(err error) string { return err.Error() }), ", "))
+	}
+}
@@ -12,6 +12,7 @@ package job
 import (
 	reflect "reflect"
 	session "rush/session"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -39,19 +40,34 @@ func (m *MocksessionGetter) EXPECT() *MocksessionGetterMockRecorder {
 	return m.recorder
 }
 
-// GetOpenSessionsWithForm mocks base method.
-func (m *MocksessionGetter) GetOpenSessionsWithForm() ([]session.Session, error) {
+// GetAllStartingBetween mocks base method.
+func (m *MocksessionGetter) GetAllStartingBetween(from, to time.Time) ([]session.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOpenSessionsWithForm")
+	ret := m.ctrl.Call(m, "GetAllStartingBetween", from, to)
 	ret0, _ := ret[0].([]session.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOpenSessionsWithForm indicates an expected call of GetOpenSessionsWithForm.
-func (mr *MocksessionGetterMockRecorder) GetOpenSessionsWithForm() *gomock.Call {
+// GetAllStartingBetween indicates an expected call of GetAllStartingBetween.
+func (mr *MocksessionGetterMockRecorder) GetAllStartingBetween(from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenSessionsWithForm", reflect.TypeOf((*MocksessionGetter)(nil).GetOpenSessionsWithForm))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStartingBetween", reflect.TypeOf((*MocksessionGetter)(nil).GetAllStartingBetween), from, to)
+}
+
+// GetOpenSessionsWithSource mocks base method.
+func (m *MocksessionGetter) GetOpenSessionsWithSource() ([]session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenSessionsWithSource")
+	ret0, _ := ret[0].([]session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenSessionsWithSource indicates an expected call of GetOpenSessionsWithSource.
+func (mr *MocksessionGetterMockRecorder) GetOpenSessionsWithSource() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenSessionsWithSource", reflect.TypeOf((*MocksessionGetter)(nil).GetOpenSessionsWithSource))
 }
 
 // MocksessionAttendanceApplier is a mock of sessionAttendanceApplier interface.
@@ -91,6 +107,44 @@ func (mr *MocksessionAttendanceApplierMockRecorder) ApplyAttendanceByFormSubmiss
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyAttendanceByFormSubmissions", reflect.TypeOf((*MocksessionAttendanceApplier)(nil).ApplyAttendanceByFormSubmissions), sessionId, callerId)
 }
 
+// MockattendanceFormCreator is a mock of attendanceFormCreator interface.
+type MockattendanceFormCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockattendanceFormCreatorMockRecorder
+}
+
+// MockattendanceFormCreatorMockRecorder is the mock recorder for MockattendanceFormCreator.
+type MockattendanceFormCreatorMockRecorder struct {
+	mock *MockattendanceFormCreator
+}
+
+// NewMockattendanceFormCreator creates a new mock instance.
+func NewMockattendanceFormCreator(ctrl *gomock.Controller) *MockattendanceFormCreator {
+	mock := &MockattendanceFormCreator{ctrl: ctrl}
+	mock.recorder = &MockattendanceFormCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockattendanceFormCreator) EXPECT() *MockattendanceFormCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateAttendanceForm mocks base method.
+func (m *MockattendanceFormCreator) CreateAttendanceForm(sessionId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttendanceForm", sessionId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttendanceForm indicates an expected call of CreateAttendanceForm.
+func (mr *MockattendanceFormCreatorMockRecorder) CreateAttendanceForm(sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttendanceForm", reflect.TypeOf((*MockattendanceFormCreator)(nil).CreateAttendanceForm), sessionId)
+}
+
 // Mocklogger is a mock of logger interface.
 type Mocklogger struct {
 	ctrl     *gomock.Controller
@@ -17,10 +17,10 @@ func TestCloseExpiredSessions(t *testing.T) {
 		sessionGetter := NewMocksessionGetter(controller)
 		sessionAttendanceApplier := NewMocksessionAttendanceApplier(controller)
 		mockLogger := NewMocklogger(controller)
-		executor := NewExecutor(sessionGetter, sessionAttendanceApplier, mockLogger, clock.NewMock())
+		executor := NewExecutor(sessionGetter, sessionAttendanceApplier, nil, mockLogger, clock.NewMock(), time.Hour)
 
-		sessionGetter.EXPECT().GetOpenSessionsWithForm().Return([]session.Session{}, assert.AnError)
-		mockLogger.EXPECT().Errorw("Failed to get open sessions with form", "error", assert.AnError.Error())
+		sessionGetter.EXPECT().GetOpenSessionsWithSource().Return([]session.Session{}, assert.AnError)
+		mockLogger.EXPECT().Errorw("Failed to get open sessions with attendance source", "error", assert.AnError.Error())
 		executor.CloseExpiredSessions()
 	})
 
@@ -30,10 +30,10 @@ func TestCloseExpiredSessions(t *testing.T) {
 		sessionAttendanceApplier := NewMocksessionAttendanceApplier(controller)
 		mockLogger := NewMocklogger(controller)
 		clock := clock.NewMock()
-		executor := NewExecutor(sessionGetter, sessionAttendanceApplier, mockLogger, clock)
+		executor := NewExecutor(sessionGetter, sessionAttendanceApplier, nil, mockLogger, clock, time.Hour)
 
 		clock.Set(time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC))
-		sessionGetter.EXPECT().GetOpenSessionsWithForm().Return([]session.Session{
+		sessionGetter.EXPECT().GetOpenSessionsWithSource().Return([]session.Session{
 			{Id: "sessionId1", StartsAt: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)},
 			{Id: "sessionId2", StartsAt: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)},
 			{Id: "sessionId3", StartsAt: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)},
@@ -52,10 +52,10 @@ func TestCloseExpiredSessions(t *testing.T) {
 		sessionAttendanceApplier := NewMocksessionAttendanceApplier(controller)
 		mockLogger := NewMocklogger(controller)
 		clock := clock.NewMock()
-		executor := NewExecutor(sessionGetter, sessionAttendanceApplier, mockLogger, clock)
+		executor := NewExecutor(sessionGetter, sessionAttendanceApplier, nil, mockLogger, clock, time.Hour)
 
 		clock.Set(time.Date(2024, 1, 2, 12, 30, 0, 0, time.UTC))
-		sessionGetter.EXPECT().GetOpenSessionsWithForm().Return([]session.Session{
+		sessionGetter.EXPECT().GetOpenSessionsWithSource().Return([]session.Session{
 			{Id: "sessionId1", StartsAt: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)},
 			{Id: "sessionId2", StartsAt: time.Date(2024, 1, 2, 12, 30, 0, 0, time.UTC)},
 			{Id: "sessionId3", StartsAt: time.Date(2024, 1, 3, 12, 30, 0, 0, time.UTC)},
@@ -66,4 +66,89 @@ func TestCloseExpiredSessions(t *testing.T) {
 		mockLogger.EXPECT().Infow("Closed sessions", "session_ids", "sessionId1, sessionId2")
 		executor.CloseExpiredSessions()
 	})
+	t.Run("Closes the check-in sessions after the check-in window", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		sessionGetter := NewMocksessionGetter(controller)
+		sessionAttendanceApplier := NewMocksessionAttendanceApplier(controller)
+		mockLogger := NewMocklogger(controller)
+		clock := clock.NewMock()
+		executor := NewExecutor(sessionGetter, sessionAttendanceApplier, nil, mockLogger, clock, time.Hour)
+
+		clock.Set(time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC))
+		checkIn := session.AttendanceSource{Provider: session.AttendanceSourceProviderCheckIn}
+		sessionGetter.EXPECT().GetOpenSessionsWithSource().Return([]session.Session{
+			{Id: "sessionId1", StartsAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), AttendanceSource: checkIn},
+			{Id: "sessionId2", StartsAt: time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC), AttendanceSource: checkIn},
+		}, nil)
+		sessionAttendanceApplier.EXPECT().ApplyAttendanceByFormSubmissions("sessionId1", "session-attendance-syncer").Return(nil)
+		// The members can still check in to sessionId2.
+		mockLogger.EXPECT().Infow("Closed sessions", "session_ids", "sessionId1")
+		executor.CloseExpiredSessions()
+	})
+
+	t.Run("Skips the sessions whose CSV file is not applied", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		sessionGetter := NewMocksessionGetter(controller)
+		sessionAttendanceApplier := NewMocksessionAttendanceApplier(controller)
+		mockLogger := NewMocklogger(controller)
+		clock := clock.NewMock()
+		executor := NewExecutor(sessionGetter, sessionAttendanceApplier, nil, mockLogger, clock, time.Hour)
+
+		clock.Set(time.Date(2024, 1, 2, 12, 30, 0, 0, time.UTC))
+		sessionGetter.EXPECT().GetOpenSessionsWithSource().Return([]session.Session{
+			{Id: "sessionId1", StartsAt: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)},
+			{
+				Id:               "sessionId2",
+				StartsAt:         time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderCsv, ExternalId: "attendance.csv"},
+			},
+		}, nil)
+		sessionAttendanceApplier.EXPECT().ApplyAttendanceByFormSubmissions("sessionId1", "session-attendance-syncer").Return(nil)
+		mockLogger.EXPECT().Infow("Closed sessions", "session_ids", "sessionId1")
+		executor.CloseExpiredSessions()
+	})
+}
+
+func TestCreateAttendanceForms(t *testing.T) {
+	t.Run("Fails if it fails to get upcoming sessions", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		sessionGetter := NewMocksessionGetter(controller)
+		attendanceFormCreator := NewMockattendanceFormCreator(controller)
+		mockLogger := NewMocklogger(controller)
+		clock := clock.NewMock()
+		executor := NewExecutor(sessionGetter, nil, attendanceFormCreator, mockLogger, clock, 24*time.Hour)
+
+		clock.Set(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+		sessionGetter.EXPECT().GetAllStartingBetween(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)).
+			Return(nil, assert.AnError)
+		mockLogger.EXPECT().Errorw("Failed to get upcoming sessions", "error", assert.AnError.Error())
+		executor.CreateAttendanceForms()
+	})
+
+	t.Run("Creates the forms of the open sessions without the form", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		sessionGetter := NewMocksessionGetter(controller)
+		attendanceFormCreator := NewMockattendanceFormCreator(controller)
+		mockLogger := NewMocklogger(controller)
+		clock := clock.NewMock()
+		executor := NewExecutor(sessionGetter, nil, attendanceFormCreator, mockLogger, clock, 24*time.Hour)
+
+		clock.Set(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+		sessionGetter.EXPECT().GetAllStartingBetween(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)).
+			Return([]session.Session{
+				{Id: "sessionId1", AttendanceStatus: session.AttendanceStatusNotAppliedYet},
+				{Id: "sessionId2", AttendanceStatus: session.AttendanceStatusNotAppliedYet, AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "formId"}},
+				{Id: "sessionId3", AttendanceStatus: session.AttendanceStatusIgnored},
+				{Id: "sessionId4", AttendanceStatus: session.AttendanceStatusNotAppliedYet},
+				{Id: "sessionId5", AttendanceStatus: session.AttendanceStatusNotAppliedYet},
+				{Id: "sessionId6", AttendanceStatus: session.AttendanceStatusNotAppliedYet, AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderCheckIn, ExternalId: "sessionId6"}},
+			}, nil)
+		attendanceFormCreator.EXPECT().CreateAttendanceForm("sessionId1").Return("formUri1", nil)
+		attendanceFormCreator.EXPECT().CreateAttendanceForm("sessionId4").Return("", errors.New("error1"))
+		attendanceFormCreator.EXPECT().CreateAttendanceForm("sessionId5").Return("formUri5", nil)
+		mockLogger.EXPECT().Infow("Created attendance forms", "session_ids", "sessionId1, sessionId5")
+		mockLogger.EXPECT().Infow("Skipped creating attendance forms", "session_ids", "sessionId2, sessionId3, sessionId6")
+		mockLogger.EXPECT().Errorw("Failed to create attendance forms", "session_ids", "sessionId4", "errors", "error1")
+		executor.CreateAttendanceForms()
+	})
 }
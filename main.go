@@ -7,13 +7,13 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	firebase "firebase.google.com/go"
 	"github.com/benbjohnson/clock"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/ridge/must/v2"
 	"github.com/robfig/cron/v3"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -25,13 +25,23 @@ import (
 	"google.golang.org/api/option"
 
 	"rush/attendance"
+	"rush/audit"
 	"rush/auth"
+	"rush/checkin"
+	"rush/excuse"
 	"rush/golang/env"
 	rushHttp "rush/http"
 	"rush/job"
+	"rush/migrate"
 	"rush/oauth"
+	"rush/reliability"
+	"rush/rsvp"
+	"rush/series"
 	"rush/server"
 	"rush/session"
+	"rush/sqlite"
+	"rush/term"
+	"rush/unmatched"
 	rushUser "rush/user"
 )
 
@@ -42,19 +52,98 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	mongoDbEndpoint := env.GetRequiredStringVariable("MONGODB_URI")
-	clientOptions := options.Client().ApplyURI(mongoDbEndpoint)
-	log.Println("Connecting to MongoDB")
-	mongodbClient := must.OK1(mongo.Connect(ctx, clientOptions))
-	must.OK(mongodbClient.Ping(ctx, nil))
-
-	mongodbDatabaseName := env.GetRequiredStringVariable("MONGODB_DB_NAME")
-	mongodbSessionColName := env.GetRequiredStringVariable("MONGODB_SESSION_COLLECTION_NAME")
-	mongodbUserColName := env.GetRequiredStringVariable("MONGODB_USER_COLLECTION_NAME")
-	mongodbAttendanceColName := env.GetRequiredStringVariable("MONGODB_ATTENDANCE_COLLECTION_NAME")
-	sessionCollection := mongodbClient.Database(mongodbDatabaseName).Collection(mongodbSessionColName)
-	userCollection := mongodbClient.Database(mongodbDatabaseName).Collection(mongodbUserColName)
-	attendanceCollection := mongodbClient.Database(mongodbDatabaseName).Collection(mongodbAttendanceColName)
+	clock := clock.New()
+	var userRepo rushUser.Repo
+	var sessionRepo session.Repo
+	var attendanceRepo attendance.Repo
+	var termRepo term.Repo
+	var seriesRepo series.Repo
+	var checkInRepo checkin.Repo
+	var unmatchedRepo unmatched.Repo
+	var excuseRepo excuse.Repo
+	var auditRepo audit.Repo
+	var rsvpRepo rsvp.Repo
+	var reliabilityRepo reliability.Repo
+	var tokenRepo auth.Repo
+	switch storageBackend := env.GetOptionalStringVariable("STORAGE_BACKEND", "mongodb"); storageBackend {
+	case "mongodb":
+		mongoDbEndpoint := env.GetRequiredStringVariable("MONGODB_URI")
+		clientOptions := options.Client().ApplyURI(mongoDbEndpoint)
+		log.Println("Connecting to MongoDB")
+		mongodbClient := must.OK1(mongo.Connect(ctx, clientOptions))
+		must.OK(mongodbClient.Ping(ctx, nil))
+
+		mongodbDatabase := mongodbClient.Database(env.GetRequiredStringVariable("MONGODB_DB_NAME"))
+		collections := migrate.Collections{
+			Users:                env.GetRequiredStringVariable("MONGODB_USER_COLLECTION_NAME"),
+			Sessions:             env.GetRequiredStringVariable("MONGODB_SESSION_COLLECTION_NAME"),
+			Attendances:          env.GetRequiredStringVariable("MONGODB_ATTENDANCE_COLLECTION_NAME"),
+			Terms:                env.GetOptionalStringVariable("MONGODB_TERM_COLLECTION_NAME", "terms"),
+			Rsvps:                env.GetOptionalStringVariable("MONGODB_RSVP_COLLECTION_NAME", "rsvps"),
+			AuthTokens:           env.GetOptionalStringVariable("MONGODB_AUTH_TOKEN_COLLECTION_NAME", "auth_tokens"),
+			CheckInRejections:    env.GetOptionalStringVariable("MONGODB_CHECK_IN_REJECTION_COLLECTION_NAME", "check_in_rejections"),
+			UnmatchedSubmissions: env.GetOptionalStringVariable("MONGODB_UNMATCHED_SUBMISSION_COLLECTION_NAME", "unmatched_submissions"),
+			Excuses:              env.GetOptionalStringVariable("MONGODB_EXCUSE_COLLECTION_NAME", "excuses"),
+			AuditEntries:         env.GetOptionalStringVariable("MONGODB_AUDIT_COLLECTION_NAME", "audit_entries"),
+			ReliabilityOutcomes:  env.GetOptionalStringVariable("MONGODB_RELIABILITY_OUTCOME_COLLECTION_NAME", "reliability_outcomes"),
+		}
+		if env.GetOptionalStringVariable("MONGODB_MIGRATE_ON_STARTUP", "true") == "true" {
+			// Building indexes may take longer than the initialization timeout.
+			migrateCtx, migrateCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			results := must.OK1(migrate.Run(migrateCtx, mongodbDatabase, collections))
+			migrateCancel()
+			for _, result := range results {
+				log.Printf("Applied migration %d: %s", result.Version, result.Description)
+			}
+		}
+
+		userRepo = rushUser.NewMongoDbRepo(mongodbDatabase.Collection(collections.Users))
+		sessionRepo = session.NewMongoDbRepo(mongodbDatabase.Collection(collections.Sessions))
+		attendanceRepo = attendance.NewMongoDbRepo(mongodbDatabase.Collection(collections.Attendances), clock)
+		termRepo = term.NewMongoDbRepo(mongodbDatabase.Collection(collections.Terms))
+		seriesRepo = series.NewMongoDbRepo(mongodbDatabase.Collection(env.GetOptionalStringVariable("MONGODB_SESSION_SERIES_COLLECTION_NAME", "session_series")))
+		checkInRepo = checkin.NewMongoDbRepo(mongodbDatabase.Collection(collections.CheckInRejections))
+		unmatchedRepo = unmatched.NewMongoDbRepo(mongodbDatabase.Collection(collections.UnmatchedSubmissions))
+		excuseRepo = excuse.NewMongoDbRepo(mongodbDatabase.Collection(collections.Excuses))
+		auditRepo = audit.NewMongoDbRepo(mongodbDatabase.Collection(collections.AuditEntries))
+		rsvpRepo = rsvp.NewMongoDbRepo(mongodbDatabase.Collection(collections.Rsvps))
+		reliabilityRepo = reliability.NewMongoDbRepo(mongodbDatabase.Collection(collections.ReliabilityOutcomes))
+		tokenRepo = auth.NewMongoDbRepo(mongodbDatabase.Collection(collections.AuthTokens))
+	case "sqlite":
+		// A single file database. It's for small clubs and local development without a MongoDB cluster.
+		log.Println("Opening SQLite")
+		db := must.OK1(sqlite.Open(env.GetRequiredStringVariable("SQLITE_PATH")))
+
+		userRepo = rushUser.NewSqliteRepo(db)
+		sessionRepo = session.NewSqliteRepo(db)
+		attendanceRepo = attendance.NewSqliteRepo(db, clock)
+		termRepo = term.NewSqliteRepo(db)
+		seriesRepo = series.NewSqliteRepo(db)
+		checkInRepo = checkin.NewSqliteRepo(db)
+		unmatchedRepo = unmatched.NewSqliteRepo(db)
+		excuseRepo = excuse.NewSqliteRepo(db)
+		auditRepo = audit.NewSqliteRepo(db)
+		rsvpRepo = rsvp.NewSqliteRepo(db)
+		reliabilityRepo = reliability.NewSqliteRepo(db)
+		tokenRepo = auth.NewSqliteRepo(db)
+	case "memory":
+		// Nothing is persisted. It's for trying RUSH out locally.
+		log.Println("Using the memory storage")
+		userRepo = rushUser.NewMemoryRepo()
+		sessionRepo = session.NewMemoryRepo()
+		attendanceRepo = attendance.NewMemoryRepo(clock)
+		termRepo = term.NewMemoryRepo()
+		seriesRepo = series.NewMemoryRepo()
+		checkInRepo = checkin.NewMemoryRepo()
+		unmatchedRepo = unmatched.NewMemoryRepo()
+		excuseRepo = excuse.NewMemoryRepo()
+		auditRepo = audit.NewMemoryRepo()
+		rsvpRepo = rsvp.NewMemoryRepo()
+		reliabilityRepo = reliability.NewMemoryRepo()
+		tokenRepo = auth.NewMemoryRepo()
+	default:
+		log.Fatalf("Unknown STORAGE_BACKEND: %s", storageBackend)
+	}
 
 	googleCreds := getGoogleCredentials(ctx, env.GetRequiredStringVariable("ENVIRONMENT"))
 	log.Printf("project id: %s", googleCreds.ProjectID)
@@ -63,38 +152,62 @@ func main() {
 	driveService := must.OK1(drive.NewService(ctx, googleOption))
 	firebaseAuthClient := must.OK1(must.OK1(firebase.NewApp(ctx, nil, googleOption)).Auth(ctx))
 
-	clock := clock.New()
-	userRepo := rushUser.NewMongoDbRepo(userCollection)
-	sessionRepo := session.NewMongoDbRepo(sessionCollection)
-	attendanceRepo := attendance.NewMongoDbRepo(attendanceCollection, clock)
-	server := server.New(
-		oauth.NewFbClient(firebaseAuthClient),
+	jwtSecretKey := env.GetRequiredStringVariable("JWT_SECRET_KEY")
+	checkInCodeTtlSeconds := must.OK1(strconv.Atoi(env.GetOptionalStringVariable("CHECK_IN_CODE_TTL_SECONDS", "30")))
+	server := server.New(server.Deps{
+		OauthClient: oauth.NewFbClient(firebaseAuthClient),
 		// https://learn.microsoft.com/en-us/dotnet/api/system.security.cryptography.hmacsha256.-ctor?view=net-8.0
 		// The secret key is recommended to be 64 bytes long for HMACSHA256. RushAuth uses HMACSHA256 to sign the token.
-		auth.NewRushAuth(env.GetRequiredStringVariable("DEV_AUTH_TOKEN"), env.GetRequiredStringVariable("JWT_SECRET_KEY"), clock),
-		userRepo, rushUser.NewAdder(userRepo), rushUser.NewUpdater(userRepo, attendanceRepo),
-		sessionRepo,
-		session.NewService(sessionRepo),
-		attendance.NewFormHandler(formsService, driveService),
-		attendance.NewMongoDbRepo(attendanceCollection, clock),
-		must.OK1(time.LoadLocation("Asia/Seoul")),
-		clock,
-	)
+		AuthHandler:             auth.NewRushAuth(env.GetRequiredStringVariable("DEV_AUTH_TOKEN"), jwtSecretKey, clock),
+		UserRepo:                userRepo,
+		UserAdder:               rushUser.NewAdder(userRepo),
+		UserUpdater:             rushUser.NewUpdater(userRepo, attendanceRepo),
+		SessionRepo:             sessionRepo,
+		OpenSessionRepo:         session.NewService(sessionRepo),
+		AttendanceFormHandler:   attendance.NewFormHandler(formsService, driveService),
+		AttendanceRepo:          attendanceRepo,
+		TermRepo:                termRepo,
+		UserRoller:              rushUser.NewRoller(userRepo),
+		SessionSeriesRepo:       seriesRepo,
+		CheckInCodeHandler:      checkin.NewCodeIssuer(env.GetOptionalStringVariable("CHECK_IN_SECRET_KEY", jwtSecretKey), time.Duration(checkInCodeTtlSeconds)*time.Second, clock),
+		CheckInRejectionRepo:    checkInRepo,
+		UnmatchedSubmissionRepo: unmatchedRepo,
+		ExcuseRepo:              excuseRepo,
+		AuditRepo:               auditRepo,
+		RsvpRepo:                rsvpRepo,
+		ReliabilityRepo:         reliabilityRepo,
+		ReliabilityThresholds: reliability.Thresholds{
+			MinSignUps: must.OK1(strconv.Atoi(env.GetOptionalStringVariable("RELIABILITY_MIN_SIGN_UPS", "3"))),
+			MinScore:   must.OK1(strconv.Atoi(env.GetOptionalStringVariable("RELIABILITY_MIN_SCORE", "70"))),
+		},
+		TokenRepo:        tokenRepo,
+		FormTimeLocation: must.OK1(time.LoadLocation("Asia/Seoul")),
+		Clock:            clock,
+	})
 
 	router := gin.Default()
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowOrigins = []string{env.GetRequiredStringVariable("CORS_ORIGIN")}
 	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
 	corsConfig.AllowCredentials = true
 	router.Use(cors.New(corsConfig))
 
 	rushHttp.SetUpRouter(router, server)
 
-	jobExecutor := job.NewExecutor(sessionRepo, server, must.OK1(zap.NewProduction()).Sugar(), clock)
+	logger := must.OK1(zap.NewProduction()).Sugar()
+	formLeadHours := must.OK1(strconv.Atoi(env.GetOptionalStringVariable("ATTENDANCE_FORM_LEAD_HOURS", "24")))
+	jobExecutor := job.NewExecutor(sessionRepo, server, server, logger, clock, time.Duration(formLeadHours)*time.Hour)
+	seriesWeeksAhead := must.OK1(strconv.Atoi(env.GetOptionalStringVariable("SESSION_SERIES_WEEKS_AHEAD", "4")))
+	seriesJobExecutor := job.NewSeriesExecutor(seriesRepo, server, logger, clock, seriesWeeksAhead)
+	noShowLookbackDays := must.OK1(strconv.Atoi(env.GetOptionalStringVariable("NO_SHOW_LOOKBACK_DAYS", "7")))
+	reliabilityJobExecutor := job.NewReliabilityExecutor(sessionRepo, server, logger, clock, time.Duration(noShowLookbackDays)*24*time.Hour)
 	if env.GetRequiredStringVariable("ENVIRONMENT") != "local" {
 		scheduler := cron.New()
 		scheduler.AddFunc("30 * * * *", func() { jobExecutor.CloseExpiredSessions() })
+		scheduler.AddFunc("15 * * * *", func() { jobExecutor.CreateAttendanceForms() })
+		scheduler.AddFunc("0 * * * *", func() { seriesJobExecutor.MaterializeSessionSeries() })
+		scheduler.AddFunc("45 * * * *", func() { reliabilityJobExecutor.EvaluateNoShows() })
 		scheduler.Start()
 	}
 
@@ -0,0 +1,124 @@
+// It migrates the MongoDB collections to the schema that the repos expect.
+// Each migration is numbered and applied only once. The applied ones are recorded in `schema_migrations`.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The name of the collection that records the applied migrations.
+const migrationsCollectionName = "schema_migrations"
+
+// The names of the collections to migrate. They are configurable by the environment variables.
+type Collections struct {
+	Users                string
+	Sessions             string
+	Attendances          string
+	Terms                string
+	Rsvps                string
+	AuthTokens           string
+	CheckInRejections    string
+	UnmatchedSubmissions string
+	Excuses              string
+	AuditEntries         string
+	ReliabilityOutcomes  string
+}
+
+type migration struct {
+	// The version of the migration. It starts from 1 and increases by 1.
+	version int
+	// What the migration does. E.g. "Create indexes"
+	description string
+	// Applies the migration. It should be idempotent so that it's safe to run again
+	// when it fails in the middle or another instance runs it at the same time.
+	up func(ctx context.Context, db *mongo.Database, collections Collections) error
+}
+
+// The record of an applied migration in `schema_migrations`.
+type mongodbMigration struct {
+	// The version of the migration. E.g. 1
+	Version int `bson:"_id"`
+	// What the migration does. E.g. "Create indexes"
+	Description string `bson:"description"`
+	// The time when the migration was applied. E.g. "2025-07-01T00:00:00Z"
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// The result of a migration.
+type Result struct {
+	// The version of the migration. E.g. 1
+	Version int
+	// What the migration does. E.g. "Create indexes"
+	Description string
+}
+
+// Returns the migrations that have not been applied yet.
+func Pending(ctx context.Context, db *mongo.Database) ([]Result, error) {
+	applied, err := getAppliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []Result{}
+	for _, migration := range migrations {
+		if !applied[migration.version] {
+			pending = append(pending, Result{Version: migration.version, Description: migration.description})
+		}
+	}
+	return pending, nil
+}
+
+// Applies the migrations that have not been applied yet in order and returns them.
+func Run(ctx context.Context, db *mongo.Database, collections Collections) ([]Result, error) {
+	applied, err := getAppliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	for _, migration := range migrations {
+		if applied[migration.version] {
+			continue
+		}
+
+		if err := migration.up(ctx, db, collections); err != nil {
+			return results, fmt.Errorf("failed to apply migration %d (%s): %w", migration.version, migration.description, err)
+		}
+		_, err := db.Collection(migrationsCollectionName).InsertOne(ctx, mongodbMigration{
+			Version:     migration.version,
+			Description: migration.description,
+			AppliedAt:   time.Now(),
+		})
+		// Another instance may have applied it at the same time. It's fine because migrations are idempotent.
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return results, fmt.Errorf("failed to record migration %d: %w", migration.version, err)
+		}
+		results = append(results, Result{Version: migration.version, Description: migration.description})
+	}
+	return results, nil
+}
+
+func getAppliedVersions(ctx context.Context, db *mongo.Database) (map[int]bool, error) {
+	cursor, err := db.Collection(migrationsCollectionName).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var records []mongodbMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode applied migrations: %w", err)
+	}
+
+	applied := map[int]bool{}
+	for _, record := range records {
+		applied[record.Version] = true
+	}
+	return applied, nil
+}
@@ -0,0 +1,53 @@
+package migrate
+
+import (
+	"context"
+	"rush/golang/mongotest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigrations(t *testing.T) {
+	t.Run("Versions start from 1 and increase by 1", func(t *testing.T) {
+		for index, migration := range migrations {
+			assert.Equal(t, index+1, migration.version)
+		}
+	})
+}
+
+func TestRun(t *testing.T) {
+	collections := Collections{
+		Users: "users", Sessions: "sessions", Attendances: "attendances", Terms: "terms", Rsvps: "rsvps", AuthTokens: "auth_tokens",
+		CheckInRejections: "check_in_rejections", UnmatchedSubmissions: "unmatched_submissions", Excuses: "excuses", AuditEntries: "audit_entries", ReliabilityOutcomes: "reliability_outcomes",
+	}
+
+	t.Run("Applies every migration only once", func(t *testing.T) {
+		ctx := context.Background()
+		db := mongotest.NewDatabase(t)
+		_, err := db.Collection("sessions").InsertOne(ctx, bson.M{"name": "old session"})
+		assert.NoError(t, err)
+		_, err = db.Collection("users").InsertOne(ctx, bson.M{"name": "old user"})
+		assert.NoError(t, err)
+
+		results, err := Run(ctx, db, collections)
+		assert.NoError(t, err)
+		assert.Len(t, results, len(migrations))
+
+		var session bson.M
+		assert.NoError(t, db.Collection("sessions").FindOne(ctx, bson.M{}).Decode(&session))
+		assert.Equal(t, false, session["is_deleted"])
+		assert.Equal(t, "", session["attendance_ignored_reason"])
+		var user bson.M
+		assert.NoError(t, db.Collection("users").FindOne(ctx, bson.M{}).Decode(&user))
+		assert.Equal(t, "member", user["role"])
+
+		results, err = Run(ctx, db, collections)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		pending, err := Pending(ctx, db)
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+}
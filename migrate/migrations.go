@@ -0,0 +1,247 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The migrations in order. Never modify or remove a migration that has been released. Append a new one instead.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "Backfill the fields that were added after the documents had been created",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			return backfill(ctx, db, []fieldDefault{
+				// Repos filter with `is_deleted: false` which doesn't match the documents without the field.
+				{collections.Sessions, "is_deleted", false},
+				{collections.Sessions, "attendance_ignored_reason", ""},
+				{collections.Attendances, "force_apply", false},
+				{collections.Terms, "is_deleted", false},
+				{collections.Terms, "is_archived", false},
+			})
+		},
+	},
+	{
+		version:     2,
+		description: "Set the role of the users without it to member",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			// The users added before the role was stored have no role and fail to be decoded.
+			_, err := db.Collection(collections.Users).UpdateMany(ctx,
+				bson.M{"$or": bson.A{bson.M{"role": bson.M{"$exists": false}}, bson.M{"role": ""}}},
+				bson.M{"$set": bson.M{"role": "member"}})
+			return err
+		},
+	},
+	{
+		version:     3,
+		description: "Create the indexes that the repos rely on",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			return createIndexes(ctx, db, map[string][]string{
+				collections.Users:       {"email", "external_name"},
+				collections.Sessions:    {"starts_at"},
+				collections.Attendances: {"session_id", "user_id", "session_started_at"},
+				collections.Terms:       {"starts_at"},
+			})
+		},
+	},
+	{
+		version:     4,
+		description: "Allow only one attendance of a user for each session",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			// It fails if there are duplicates already. They should be removed manually before migrating.
+			if _, err := db.Collection(collections.Attendances).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "session_id", Value: 1}, {Key: "user_id", Value: 1}},
+				Options: options.Index().SetName("session_id_user_id").SetUnique(true),
+			}); err != nil {
+				return fmt.Errorf("failed to create the unique index of %s. Remove the duplicate attendances first: %w", collections.Attendances, err)
+			}
+			return nil
+		},
+	},
+	{
+		version:     5,
+		description: "Link the sessions to the series that they are created for",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			if err := backfill(ctx, db, []fieldDefault{
+				{collection: collections.Sessions, field: "series_id", value: ""},
+			}); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, map[string][]string{
+				collections.Sessions: {"series_id"},
+			})
+		},
+	},
+	{
+		version:     6,
+		description: "Add whether the members check in with the code to sessions",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			return backfill(ctx, db, []fieldDefault{
+				{collection: collections.Sessions, field: "check_in_enabled", value: false},
+			})
+		},
+	},
+	{
+		version:     7,
+		description: "Add the meeting point to sessions",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			return backfill(ctx, db, []fieldDefault{
+				{collection: collections.Sessions, field: "meeting_point", value: bson.M{"latitude": 0.0, "longitude": 0.0, "radius_meters": 0.0}},
+			})
+		},
+	},
+	{
+		version:     8,
+		description: "Replace the Google form and the check-in fields of sessions with the attendance source",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			// The sessions with the form keep it. The check-in sessions use their own ID as the external ID.
+			source := bson.M{"$switch": bson.M{
+				"branches": bson.A{
+					bson.M{
+						"case": bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$google_form_id", ""}}, ""}},
+						"then": bson.M{"provider": "google_form", "external_id": "$google_form_id", "uri": bson.M{"$ifNull": bson.A{"$google_form_uri", ""}}},
+					},
+					bson.M{
+						"case": bson.M{"$eq": bson.A{"$check_in_enabled", true}},
+						"then": bson.M{"provider": "check_in", "external_id": bson.M{"$toString": "$_id"}, "uri": ""},
+					},
+				},
+				"default": bson.M{"provider": "", "external_id": "", "uri": ""},
+			}}
+			if _, err := db.Collection(collections.Sessions).UpdateMany(ctx,
+				bson.M{"attendance_source": bson.M{"$exists": false}},
+				mongo.Pipeline{
+					{{Key: "$set", Value: bson.M{"attendance_source": source}}},
+					{{Key: "$unset", Value: bson.A{"google_form_id", "google_form_uri", "check_in_enabled"}}},
+				}); err != nil {
+				return fmt.Errorf("failed to set the attendance source of %s: %w", collections.Sessions, err)
+			}
+			return nil
+		},
+	},
+	{
+		version:     9,
+		description: "Add how the users attended to attendances",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			// The force-applied ones are kept as they are and the others are regarded as on time.
+			if _, err := db.Collection(collections.Attendances).UpdateMany(ctx,
+				bson.M{"status": bson.M{"$exists": false}},
+				mongo.Pipeline{
+					{{Key: "$set", Value: bson.M{"status": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$force_apply", true}}, "force_applied", "on_time"}}}}},
+				}); err != nil {
+				return fmt.Errorf("failed to set the status of %s: %w", collections.Attendances, err)
+			}
+			return backfill(ctx, db, []fieldDefault{
+				{collection: collections.Attendances, field: "status_reason", value: ""},
+				{collection: collections.Attendances, field: "status_set_by", value: ""},
+			})
+		},
+	},
+	{
+		version:     10,
+		description: "Add the capacity to sessions",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			return backfill(ctx, db, []fieldDefault{
+				{collection: collections.Sessions, field: "capacity", value: 0},
+			})
+		},
+	},
+	{
+		version:     11,
+		description: "Allow only one active RSVP of a member for each session",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			if err := createIndexes(ctx, db, map[string][]string{
+				collections.Rsvps: {"session_id", "user_id"},
+			}); err != nil {
+				return err
+			}
+			// The cancelled ones are kept, so only the active ones are unique. The partial filter can't have $in or $or
+			// before MongoDB 6.0, so the active ones are marked with is_active instead of filtering by the status.
+			// $exists in the partial filter needs MongoDB 3.2 or later.
+			if _, err := db.Collection(collections.Rsvps).UpdateMany(ctx,
+				bson.M{"status": bson.M{"$in": bson.A{"confirmed", "waitlisted"}}},
+				bson.M{"$set": bson.M{"is_active": true}}); err != nil {
+				return fmt.Errorf("failed to mark the active RSVPs of %s: %w", collections.Rsvps, err)
+			}
+			if _, err := db.Collection(collections.Rsvps).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys: bson.D{{Key: "session_id", Value: 1}, {Key: "user_id", Value: 1}},
+				Options: options.Index().SetName("session_id_user_id_active").SetUnique(true).
+					SetPartialFilterExpression(bson.M{"is_active": bson.M{"$exists": true}}),
+			}); err != nil {
+				return fmt.Errorf("failed to create the unique index of %s. Cancel the duplicate RSVPs first: %w", collections.Rsvps, err)
+			}
+			return nil
+		},
+	},
+	{
+		version:     12,
+		description: "Remove the auth tokens once they expire",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			if err := createIndexes(ctx, db, map[string][]string{
+				collections.AuthTokens: {"user_id"},
+			}); err != nil {
+				return err
+			}
+			// The expired tokens are rejected anyway, so they are removed as soon as they expire.
+			if _, err := db.Collection(collections.AuthTokens).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetName("expires_at").SetExpireAfterSeconds(0),
+			}); err != nil {
+				return fmt.Errorf("failed to create the TTL index of %s: %w", collections.AuthTokens, err)
+			}
+			return nil
+		},
+	},
+	{
+		version:     13,
+		description: "Create the indexes that the repos added after the migrations rely on",
+		up: func(ctx context.Context, db *mongo.Database, collections Collections) error {
+			return createIndexes(ctx, db, map[string][]string{
+				collections.CheckInRejections:    {"session_id"},
+				collections.UnmatchedSubmissions: {"session_id"},
+				collections.Excuses:              {"user_id", "status"},
+				collections.AuditEntries:         {"user_id", "session_id"},
+				collections.ReliabilityOutcomes:  {"session_id", "user_id"},
+			})
+		},
+	},
+}
+
+// The default value of the field for the documents that don't have it.
+type fieldDefault struct {
+	collection string
+	field      string
+	value      interface{}
+}
+
+func backfill(ctx context.Context, db *mongo.Database, defaults []fieldDefault) error {
+	for _, fieldDefault := range defaults {
+		if _, err := db.Collection(fieldDefault.collection).UpdateMany(ctx,
+			bson.M{fieldDefault.field: bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{fieldDefault.field: fieldDefault.value}}); err != nil {
+			return fmt.Errorf("failed to backfill %s.%s: %w", fieldDefault.collection, fieldDefault.field, err)
+		}
+	}
+	return nil
+}
+
+// Creates an ascending index for each field. Creating an index that already exists does nothing.
+func createIndexes(ctx context.Context, db *mongo.Database, fields map[string][]string) error {
+	for collection, collectionFields := range fields {
+		models := []mongo.IndexModel{}
+		for _, field := range collectionFields {
+			models = append(models, mongo.IndexModel{
+				Keys:    bson.D{{Key: field, Value: 1}},
+				Options: options.Index().SetName(field),
+			})
+		}
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("failed to create indexes of %s: %w", collection, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package permission
+
+import "sort"
+
+// What a user does through the APIs. The roles are granted the actions in `policy` instead of being checked by each API.
+type Action string
+
+const (
+	// Reads any user including their reliability and audit log. The users can always read themselves.
+	ActionUserReadAny Action = "user.read_any"
+	ActionUserCreate  Action = "user.create"
+	// Updates the external name or the generation of any user.
+	ActionUserUpdate Action = "user.update"
+	// Promotes or demotes any user except themselves.
+	ActionUserChangeRole Action = "user.change_role"
+	// Signs any user out on every device. E.g., when the device of the user is lost.
+	ActionUserSignOutAny Action = "user.sign_out_any"
+
+	// Reads the sessions with the details for the admins. E.g., the RSVPs and the rejected check-ins.
+	ActionSessionReadAny Action = "session.read_any"
+	ActionSessionCreate  Action = "session.create"
+	// Updates the open session. E.g., its meeting point, its capacity or its attendance form.
+	ActionSessionUpdate Action = "session.update"
+	ActionSessionDelete Action = "session.delete"
+	// Shows the check-in code of the session to the members.
+	ActionSessionIssueCheckInCode Action = "session.issue_check_in_code"
+
+	// Applies the attendance of the session by its source, the CSV or the manual selection.
+	ActionAttendanceApply Action = "attendance.apply"
+	// Applies the attendance after the session has been closed.
+	ActionAttendanceForceApply Action = "attendance.force_apply"
+	// Corrects or removes the attendance of the applied session.
+	ActionAttendanceCorrect Action = "attendance.correct"
+
+	// Creates, reads, updates and cancels the session series.
+	ActionSeriesManage Action = "series.manage"
+	ActionExcuseReview Action = "excuse.review"
+	// Reads the terms with their sessions and standings.
+	ActionTermRead Action = "term.read"
+	// Creates, updates and deletes the terms and their scoring rules.
+	ActionTermManage Action = "term.manage"
+	// Rolls the users over to a new term.
+	ActionTermRollover Action = "term.rollover"
+)
+
+// The roles that can perform each action. Nobody can perform the action that is not in it.
+var policy = map[Action][]Role{
+	ActionUserReadAny:    {RoleSuperAdmin, RoleAdmin},
+	ActionUserCreate:     {RoleSuperAdmin, RoleAdmin},
+	ActionUserUpdate:     {RoleSuperAdmin, RoleAdmin},
+	ActionUserChangeRole: {RoleSuperAdmin},
+	ActionUserSignOutAny: {RoleSuperAdmin, RoleAdmin},
+
+	ActionSessionReadAny:          {RoleSuperAdmin, RoleAdmin},
+	ActionSessionCreate:           {RoleSuperAdmin, RoleAdmin},
+	ActionSessionUpdate:           {RoleSuperAdmin, RoleAdmin},
+	ActionSessionDelete:           {RoleSuperAdmin, RoleAdmin},
+	ActionSessionIssueCheckInCode: {RoleSuperAdmin, RoleAdmin},
+
+	ActionAttendanceApply:      {RoleSuperAdmin, RoleAdmin},
+	ActionAttendanceForceApply: {RoleSuperAdmin, RoleAdmin},
+	ActionAttendanceCorrect:    {RoleSuperAdmin, RoleAdmin},
+
+	ActionSeriesManage: {RoleSuperAdmin, RoleAdmin},
+	ActionExcuseReview: {RoleSuperAdmin, RoleAdmin},
+	ActionTermRead:     {RoleSuperAdmin, RoleAdmin},
+	ActionTermManage:   {RoleSuperAdmin, RoleAdmin},
+	ActionTermRollover: {RoleSuperAdmin, RoleAdmin},
+}
+
+// Returns true if the role is granted the action.
+func (r Role) Can(action Action) bool {
+	for _, role := range policy[action] {
+		if role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the actions that the role is granted in the alphabetical order. E.g., for the UI to show only what the user can do.
+func (r Role) AllowedActions() []Action {
+	actions := []Action{}
+	for action := range policy {
+		if r.Can(action) {
+			actions = append(actions, action)
+		}
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	return actions
+}
@@ -0,0 +1,33 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCan(t *testing.T) {
+	t.Run("Grants the actions only to the roles in the policy", func(t *testing.T) {
+		assert.True(t, RoleSuperAdmin.Can(ActionUserChangeRole))
+		assert.False(t, RoleAdmin.Can(ActionUserChangeRole))
+		assert.True(t, RoleAdmin.Can(ActionAttendanceForceApply))
+		assert.False(t, RoleMember.Can(ActionSessionCreate))
+		assert.False(t, RoleUnknown.Can(ActionUserReadAny))
+		assert.False(t, RoleSuperAdmin.Can(Action("unknown.action")))
+	})
+
+	t.Run("Grants every action to the super admin", func(t *testing.T) {
+		for action := range policy {
+			assert.True(t, RoleSuperAdmin.Can(action), action)
+		}
+	})
+}
+
+func TestAllowedActions(t *testing.T) {
+	assert.Empty(t, RoleMember.AllowedActions())
+	assert.Len(t, RoleSuperAdmin.AllowedActions(), len(policy))
+	adminActions := RoleAdmin.AllowedActions()
+	assert.Len(t, adminActions, len(policy)-1)
+	assert.NotContains(t, adminActions, ActionUserChangeRole)
+	assert.IsIncreasing(t, adminActions)
+}
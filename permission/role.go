@@ -17,3 +17,8 @@ const (
 	// or access their own data.
 	RoleMember Role = "member"
 )
+
+// Returns true if the role can be given to a user.
+func (r Role) IsValid() bool {
+	return r == RoleSuperAdmin || r == RoleAdmin || r == RoleMember
+}
@@ -0,0 +1,73 @@
+package reliability
+
+import (
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the outcomes in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The outcomes in the order of insertion.
+	outcomes []Outcome
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		outcomes: []Outcome{},
+	}
+}
+
+// Replaces the outcomes of the session with the given ones. The session can be evaluated again this way,
+// e.g., after the admins correct its attendance.
+func (r *memoryRepo) ReplaceBySessionId(sessionId string, outcomes []Outcome) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	kept := []Outcome{}
+	for _, outcome := range r.outcomes {
+		if outcome.SessionId != sessionId {
+			kept = append(kept, outcome)
+		}
+	}
+	for _, outcome := range outcomes {
+		outcome.Id = primitive.NewObjectID().Hex()
+		outcome.SessionId = sessionId
+		kept = append(kept, outcome)
+	}
+	r.outcomes = kept
+	return nil
+}
+
+// Returns the outcomes of the member in the order of the start time of the sessions.
+func (r *memoryRepo) FindByUserId(userId string) ([]Outcome, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(outcome Outcome) bool { return outcome.UserId == userId }), nil
+}
+
+// Returns all the outcomes in the order of the start time of the sessions.
+func (r *memoryRepo) GetAll() ([]Outcome, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(Outcome) bool { return true }), nil
+}
+
+// Returns the copies of the outcomes that match the predicate in the order of the start time of the sessions.
+func (r *memoryRepo) filter(predicate func(Outcome) bool) []Outcome {
+	outcomes := []Outcome{}
+	for _, outcome := range r.outcomes {
+		if predicate(outcome) {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	sort.SliceStable(outcomes, func(i, j int) bool {
+		return outcomes[i].SessionStartsAt.Before(outcomes[j].SessionStartsAt)
+	})
+	return outcomes
+}
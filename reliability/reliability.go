@@ -0,0 +1,60 @@
+// It tracks whether the members attend the sessions that they had a spot for, and scores how reliable they are.
+package reliability
+
+import "time"
+
+// Whether the member attended the session that they had a confirmed RSVP to. It's evaluated after the attendance
+// of the session is applied.
+type Outcome struct {
+	// The ID of the outcome. E.g., "abc123"
+	Id string `json:"id"`
+	// The ID of the session. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The ID of the member. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// False if the member didn't show up.
+	Attended bool `json:"attended"`
+	// The time in UTC when the session started.
+	SessionStartsAt time.Time `json:"session_starts_at"`
+	// The time in UTC when it was evaluated last time.
+	EvaluatedAt time.Time `json:"evaluated_at"`
+}
+
+// When the members are regarded as unreliable.
+type Thresholds struct {
+	// How many sign-ups are needed before the member can be regarded as unreliable. E.g., 3
+	MinSignUps int
+	// The member is unreliable if the score is below it. E.g., 70
+	MinScore int
+}
+
+// How reliable the member is by their sign-ups.
+type Score struct {
+	// How many confirmed RSVPs of the applied sessions the member had. E.g., 5
+	SignUps int `json:"sign_ups"`
+	// How many of them the member attended. E.g., 4
+	Attended int `json:"attended"`
+	// How many of them the member didn't attend. E.g., 1
+	NoShows int `json:"no_shows"`
+	// The percentage of the sign-ups that the member attended. It's 100 without any sign-up. E.g., 80
+	Score int `json:"score"`
+	// True if the member has enough sign-ups and the score is below the threshold.
+	IsUnreliable bool `json:"is_unreliable"`
+}
+
+// Scores the member by their outcomes.
+func Calculate(outcomes []Outcome, thresholds Thresholds) Score {
+	score := Score{SignUps: len(outcomes), Score: 100}
+	for _, outcome := range outcomes {
+		if outcome.Attended {
+			score.Attended++
+		} else {
+			score.NoShows++
+		}
+	}
+	if score.SignUps > 0 {
+		score.Score = score.Attended * 100 / score.SignUps
+	}
+	score.IsUnreliable = score.SignUps >= thresholds.MinSignUps && score.Score < thresholds.MinScore
+	return score
+}
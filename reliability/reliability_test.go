@@ -0,0 +1,28 @@
+package reliability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculate(t *testing.T) {
+	thresholds := Thresholds{MinSignUps: 3, MinScore: 70}
+
+	t.Run("Scores 100 without any sign-up", func(t *testing.T) {
+		assert.Equal(t, Score{Score: 100}, Calculate([]Outcome{}, thresholds))
+	})
+
+	t.Run("Doesn't regard the member as unreliable before enough sign-ups", func(t *testing.T) {
+		score := Calculate([]Outcome{{Attended: false}, {Attended: false}}, thresholds)
+
+		assert.Equal(t, Score{SignUps: 2, NoShows: 2, Score: 0, IsUnreliable: false}, score)
+	})
+
+	t.Run("Regards the member as unreliable if the score is below the threshold", func(t *testing.T) {
+		outcomes := []Outcome{{Attended: true}, {Attended: true}, {Attended: false}}
+
+		assert.Equal(t, Score{SignUps: 3, Attended: 2, NoShows: 1, Score: 66, IsUnreliable: true}, Calculate(outcomes, thresholds))
+		assert.False(t, Calculate(append(outcomes, Outcome{Attended: true}), thresholds).IsUnreliable)
+	})
+}
@@ -0,0 +1,111 @@
+package reliability
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The outcome record in MongoDB.
+type mongodbOutcome struct {
+	// The unique identifier for the outcome. E.g. "1"
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// The unique identifier for the session. E.g. "1"
+	SessionId string `bson:"session_id"`
+	// The unique identifier for the member. E.g. "1"
+	UserId string `bson:"user_id"`
+	// Whether the member attended the session. E.g. true
+	Attended bool `bson:"attended"`
+	// The time when the session started. E.g. "2025-07-01T00:00:00Z"
+	SessionStartsAt time.Time `bson:"session_starts_at"`
+	// The time when it was evaluated last time. E.g. "2025-07-01T00:00:00Z"
+	EvaluatedAt time.Time `bson:"evaluated_at"`
+}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	ReplaceBySessionId(sessionId string, outcomes []Outcome) error
+	FindByUserId(userId string) ([]Outcome, error)
+	GetAll() ([]Outcome, error)
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+// Replaces the outcomes of the session with the given ones. The session can be evaluated again this way,
+// e.g., after the admins correct its attendance.
+func (r *mongodbRepo) ReplaceBySessionId(sessionId string, outcomes []Outcome) error {
+	ctx := context.Background()
+
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"session_id": sessionId}); err != nil {
+		return fmt.Errorf("failed to delete outcomes: %w", err)
+	}
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	documents := []interface{}{}
+	for _, outcome := range outcomes {
+		documents = append(documents, mongodbOutcome{
+			SessionId:       sessionId,
+			UserId:          outcome.UserId,
+			Attended:        outcome.Attended,
+			SessionStartsAt: outcome.SessionStartsAt,
+			EvaluatedAt:     outcome.EvaluatedAt,
+		})
+	}
+	if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to insert outcomes: %w", err)
+	}
+	return nil
+}
+
+// Returns the outcomes of the member in the order of the start time of the sessions.
+func (r *mongodbRepo) FindByUserId(userId string) ([]Outcome, error) {
+	return r.find(bson.M{"user_id": userId})
+}
+
+// Returns all the outcomes in the order of the start time of the sessions.
+func (r *mongodbRepo) GetAll() ([]Outcome, error) {
+	return r.find(bson.M{})
+}
+
+func (r *mongodbRepo) find(filter bson.M) ([]Outcome, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "session_starts_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outcomes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongodbOutcomes []mongodbOutcome
+	if err = cursor.All(ctx, &mongodbOutcomes); err != nil {
+		return nil, fmt.Errorf("failed to decode outcomes: %w", err)
+	}
+
+	outcomes := []Outcome{}
+	for _, mongodbOutcome := range mongodbOutcomes {
+		outcomes = append(outcomes, Outcome{
+			Id:              mongodbOutcome.Id.Hex(),
+			SessionId:       mongodbOutcome.SessionId,
+			UserId:          mongodbOutcome.UserId,
+			Attended:        mongodbOutcome.Attended,
+			SessionStartsAt: mongodbOutcome.SessionStartsAt,
+			EvaluatedAt:     mongodbOutcome.EvaluatedAt,
+		})
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,75 @@
+package reliability
+
+import (
+	"rush/golang/mongotest"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	startsAt := time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC)
+	evaluatedAt := startsAt.Add(time.Hour)
+
+	t.Run("Finds the outcomes in the order of the sessions", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.ReplaceBySessionId("later-session-id", []Outcome{
+			{UserId: "user-id", Attended: false, SessionStartsAt: startsAt.AddDate(0, 0, 7), EvaluatedAt: evaluatedAt},
+		}))
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", []Outcome{
+			{UserId: "user-id", Attended: true, SessionStartsAt: startsAt, EvaluatedAt: evaluatedAt},
+			{UserId: "another-user-id", Attended: false, SessionStartsAt: startsAt, EvaluatedAt: evaluatedAt},
+		}))
+
+		outcomes, err := repo.FindByUserId("user-id")
+
+		assert.NoError(t, err)
+		assert.Len(t, outcomes, 2)
+		assert.NotEmpty(t, outcomes[0].Id)
+		assert.Equal(t, Outcome{Id: outcomes[0].Id, SessionId: "session-id", UserId: "user-id", Attended: true, SessionStartsAt: startsAt, EvaluatedAt: evaluatedAt}, outcomes[0])
+		assert.Equal(t, "later-session-id", outcomes[1].SessionId)
+		assert.False(t, outcomes[1].Attended)
+		all, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Len(t, all, 3)
+		assert.Equal(t, "later-session-id", all[2].SessionId)
+	})
+
+	t.Run("Replaces the outcomes of the session only", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", []Outcome{
+			{UserId: "user-id", Attended: false, SessionStartsAt: startsAt, EvaluatedAt: evaluatedAt},
+		}))
+		assert.NoError(t, repo.ReplaceBySessionId("another-session-id", []Outcome{
+			{UserId: "user-id", Attended: true, SessionStartsAt: startsAt.AddDate(0, 0, 1), EvaluatedAt: evaluatedAt},
+		}))
+
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", []Outcome{
+			{UserId: "user-id", Attended: true, SessionStartsAt: startsAt, EvaluatedAt: evaluatedAt.Add(time.Hour)},
+		}))
+		assert.NoError(t, repo.ReplaceBySessionId("another-session-id", []Outcome{}))
+
+		outcomes, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Len(t, outcomes, 1)
+		assert.Equal(t, "session-id", outcomes[0].SessionId)
+		assert.True(t, outcomes[0].Attended)
+		assert.Equal(t, evaluatedAt.Add(time.Hour), outcomes[0].EvaluatedAt)
+	})
+}
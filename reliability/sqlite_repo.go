@@ -0,0 +1,78 @@
+package reliability
+
+import (
+	"database/sql"
+	"fmt"
+	"rush/sqlite"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteOutcomeColumns = "id, session_id, user_id, attended, session_starts_at, evaluated_at"
+
+// Replaces the outcomes of the session with the given ones. The session can be evaluated again this way,
+// e.g., after the admins correct its attendance.
+func (r *sqliteRepo) ReplaceBySessionId(sessionId string, outcomes []Outcome) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM reliability_outcomes WHERE session_id = ?", sessionId); err != nil {
+		return fmt.Errorf("failed to delete outcomes: %w", err)
+	}
+	for _, outcome := range outcomes {
+		if _, err := tx.Exec("INSERT INTO reliability_outcomes ("+sqliteOutcomeColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+			sqlite.NewId(), sessionId, outcome.UserId, outcome.Attended,
+			sqlite.FromTime(outcome.SessionStartsAt), sqlite.FromTime(outcome.EvaluatedAt)); err != nil {
+			return fmt.Errorf("failed to insert outcome: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit outcomes: %w", err)
+	}
+	return nil
+}
+
+// Returns the outcomes of the member in the order of the start time of the sessions.
+func (r *sqliteRepo) FindByUserId(userId string) ([]Outcome, error) {
+	return r.query("SELECT "+sqliteOutcomeColumns+" FROM reliability_outcomes WHERE user_id = ? ORDER BY session_starts_at, rowid", userId)
+}
+
+// Returns all the outcomes in the order of the start time of the sessions.
+func (r *sqliteRepo) GetAll() ([]Outcome, error) {
+	return r.query("SELECT " + sqliteOutcomeColumns + " FROM reliability_outcomes ORDER BY session_starts_at, rowid")
+}
+
+func (r *sqliteRepo) query(query string, args ...interface{}) ([]Outcome, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	outcomes := []Outcome{}
+	for rows.Next() {
+		var outcome Outcome
+		var sessionStartsAt, evaluatedAt int64
+		if err := rows.Scan(&outcome.Id, &outcome.SessionId, &outcome.UserId, &outcome.Attended, &sessionStartsAt, &evaluatedAt); err != nil {
+			return nil, fmt.Errorf("failed to decode outcomes: %w", err)
+		}
+		outcome.SessionStartsAt = sqlite.ToTime(sessionStartsAt)
+		outcome.EvaluatedAt = sqlite.ToTime(evaluatedAt)
+		outcomes = append(outcomes, outcome)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode outcomes: %w", err)
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,83 @@
+package rsvp
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the RSVPs in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The RSVPs in the order of insertion.
+	rsvps []*Rsvp
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		rsvps: []*Rsvp{},
+	}
+}
+
+// Adds the RSVP with the given status and times.
+// If the member already has an active RSVP for the session, it returns ErrDuplicate.
+func (r *memoryRepo) Add(rsvp Rsvp) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.rsvps {
+		if existing.SessionId == rsvp.SessionId && existing.UserId == rsvp.UserId && existing.Status.IsActive() && rsvp.Status.IsActive() {
+			return "", fmt.Errorf("%w: user %s in session %s", ErrDuplicate, rsvp.UserId, rsvp.SessionId)
+		}
+	}
+	rsvp.Id = primitive.NewObjectID().Hex()
+	r.rsvps = append(r.rsvps, &rsvp)
+	return rsvp.Id, nil
+}
+
+// Returns the RSVPs of the session in the order of the RSVPs. It's the order of the waitlist as well.
+func (r *memoryRepo) FindBySessionId(sessionId string) ([]Rsvp, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(rsvp Rsvp) bool { return rsvp.SessionId == sessionId }), nil
+}
+
+// Returns the RSVPs of the member in the order of the RSVPs.
+func (r *memoryRepo) FindByUserId(userId string) ([]Rsvp, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(rsvp Rsvp) bool { return rsvp.UserId == userId }), nil
+}
+
+// Changes the status of the RSVP only if it's still in the given status.
+// It returns ErrNotFound if it's not found or the status has been changed by someone else.
+func (r *memoryRepo) UpdateStatus(id string, from Status, to Status, updatedAt time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, rsvp := range r.rsvps {
+		if rsvp.Id != id || rsvp.Status != from {
+			continue
+		}
+		rsvp.Status = to
+		rsvp.UpdatedAt = updatedAt
+		return nil
+	}
+	return ErrNotFound
+}
+
+// Returns the copies of the RSVPs that match the predicate.
+func (r *memoryRepo) filter(predicate func(Rsvp) bool) []Rsvp {
+	rsvps := []Rsvp{}
+	for _, rsvp := range r.rsvps {
+		if predicate(*rsvp) {
+			rsvps = append(rsvps, *rsvp)
+		}
+	}
+	return rsvps
+}
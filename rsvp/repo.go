@@ -0,0 +1,145 @@
+package rsvp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The RSVP record in MongoDB.
+type mongodbRsvp struct {
+	// The unique identifier for the RSVP. E.g. "1"
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// The unique identifier for the session. E.g. "1"
+	SessionId string `bson:"session_id"`
+	// The unique identifier for the member. E.g. "1"
+	UserId string `bson:"user_id"`
+	// The status of the RSVP. E.g. "confirmed"
+	Status Status `bson:"status"`
+	// Set only while the RSVP is confirmed or waitlisted. The unique index covers the documents that have it.
+	// It's omitted rather than false because the index matches any value of it.
+	IsActive bool `bson:"is_active,omitempty"`
+	// The time when the member RSVPed. E.g. "2025-07-01T00:00:00Z"
+	CreatedAt time.Time `bson:"created_at"`
+	// The time when the status was changed last time. E.g. "2025-07-01T00:00:00Z"
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+var ErrNotFound = errors.New("rsvp not found")
+
+// The member already has an active RSVP for the session.
+var ErrDuplicate = errors.New("rsvp already exists")
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	Add(rsvp Rsvp) (string, error)
+	FindBySessionId(sessionId string) ([]Rsvp, error)
+	FindByUserId(userId string) ([]Rsvp, error)
+	UpdateStatus(id string, from Status, to Status, updatedAt time.Time) error
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+// Adds the RSVP with the given status and times.
+// If the member already has an active RSVP for the session, it returns ErrDuplicate.
+func (r *mongodbRepo) Add(rsvp Rsvp) (string, error) {
+	result, err := r.collection.InsertOne(context.Background(), mongodbRsvp{
+		SessionId: rsvp.SessionId,
+		UserId:    rsvp.UserId,
+		Status:    rsvp.Status,
+		IsActive:  rsvp.Status.IsActive(),
+		CreatedAt: rsvp.CreatedAt,
+		UpdatedAt: rsvp.UpdatedAt,
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", fmt.Errorf("%w: %v", ErrDuplicate, err)
+		}
+		return "", fmt.Errorf("failed to insert rsvp: %w", err)
+	}
+
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", fmt.Errorf("failed to get inserted id")
+	}
+
+	return id.Hex(), nil
+}
+
+// Returns the RSVPs of the session in the order of the RSVPs. It's the order of the waitlist as well.
+func (r *mongodbRepo) FindBySessionId(sessionId string) ([]Rsvp, error) {
+	return r.find(bson.M{"session_id": sessionId})
+}
+
+// Returns the RSVPs of the member in the order of the RSVPs.
+func (r *mongodbRepo) FindByUserId(userId string) ([]Rsvp, error) {
+	return r.find(bson.M{"user_id": userId})
+}
+
+// Changes the status of the RSVP only if it's still in the given status.
+// It returns ErrNotFound if it's not found or the status has been changed by someone else.
+func (r *mongodbRepo) UpdateStatus(id string, from Status, to Status, updatedAt time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{"status": to, "updated_at": updatedAt, "is_active": true}}
+	if !to.IsActive() {
+		update = bson.M{"$set": bson.M{"status": to, "updated_at": updatedAt}, "$unset": bson.M{"is_active": ""}}
+	}
+	result, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": objectID, "status": from}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update rsvp: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *mongodbRepo) find(filter bson.M) ([]Rsvp, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rsvps: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongodbRsvps []mongodbRsvp
+	if err = cursor.All(ctx, &mongodbRsvps); err != nil {
+		return nil, fmt.Errorf("failed to decode rsvps: %w", err)
+	}
+
+	rsvps := []Rsvp{}
+	for _, mongodbRsvp := range mongodbRsvps {
+		rsvps = append(rsvps, fromMongodbRsvp(mongodbRsvp))
+	}
+	return rsvps, nil
+}
+
+func fromMongodbRsvp(rsvp mongodbRsvp) Rsvp {
+	return Rsvp{
+		Id:        rsvp.Id.Hex(),
+		SessionId: rsvp.SessionId,
+		UserId:    rsvp.UserId,
+		Status:    rsvp.Status,
+		CreatedAt: rsvp.CreatedAt,
+		UpdatedAt: rsvp.UpdatedAt,
+	}
+}
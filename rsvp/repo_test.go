@@ -0,0 +1,92 @@
+package rsvp
+
+import (
+	"context"
+	"rush/golang/mongotest"
+	"rush/migrate"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo {
+		db := mongotest.NewDatabase(t)
+		// The repo relies on the indexes created by the migrations.
+		must.OK1(migrate.Run(context.Background(), db, migrate.Collections{
+			Users: "users", Sessions: "sessions", Attendances: "attendances", Terms: "terms", Rsvps: "rsvps", AuthTokens: "auth_tokens",
+			CheckInRejections: "check_in_rejections", UnmatchedSubmissions: "unmatched_submissions", Excuses: "excuses", AuditEntries: "audit_entries", ReliabilityOutcomes: "reliability_outcomes",
+		}))
+		return NewMongoDbRepo(db.Collection("rsvps"))
+	})
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	createdAt := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Adds and finds the RSVPs by the session and the user in the order of the RSVPs", func(t *testing.T) {
+		repo := newRepo(t)
+		first, err := repo.Add(Rsvp{SessionId: "session-id", UserId: "user-id", Status: StatusConfirmed, CreatedAt: createdAt, UpdatedAt: createdAt})
+		assert.NoError(t, err)
+		second, err := repo.Add(Rsvp{SessionId: "session-id", UserId: "another-user-id", Status: StatusWaitlisted, CreatedAt: createdAt, UpdatedAt: createdAt})
+		assert.NoError(t, err)
+		_, err = repo.Add(Rsvp{SessionId: "another-session-id", UserId: "user-id", Status: StatusConfirmed, CreatedAt: createdAt, UpdatedAt: createdAt})
+		assert.NoError(t, err)
+
+		rsvps, err := repo.FindBySessionId("session-id")
+		assert.NoError(t, err)
+		assert.Equal(t, []Rsvp{
+			{Id: first, SessionId: "session-id", UserId: "user-id", Status: StatusConfirmed, CreatedAt: createdAt, UpdatedAt: createdAt},
+			{Id: second, SessionId: "session-id", UserId: "another-user-id", Status: StatusWaitlisted, CreatedAt: createdAt, UpdatedAt: createdAt},
+		}, rsvps)
+
+		userRsvps, err := repo.FindByUserId("user-id")
+		assert.NoError(t, err)
+		assert.Len(t, userRsvps, 2)
+		assert.Equal(t, first, userRsvps[0].Id)
+		assert.Equal(t, "another-session-id", userRsvps[1].SessionId)
+	})
+
+	t.Run("Updates the status only if it's still in the given status", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add(Rsvp{SessionId: "session-id", UserId: "user-id", Status: StatusWaitlisted, CreatedAt: createdAt, UpdatedAt: createdAt})
+		assert.NoError(t, err)
+		updatedAt := createdAt.Add(time.Hour)
+
+		assert.NoError(t, repo.UpdateStatus(id, StatusWaitlisted, StatusConfirmed, updatedAt))
+		err = repo.UpdateStatus(id, StatusWaitlisted, StatusCancelled, updatedAt)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		rsvps, err := repo.FindBySessionId("session-id")
+		assert.NoError(t, err)
+		assert.Equal(t, StatusConfirmed, rsvps[0].Status)
+		assert.Equal(t, updatedAt, rsvps[0].UpdatedAt)
+		assert.ErrorIs(t, repo.UpdateStatus(primitive.NewObjectID().Hex(), StatusConfirmed, StatusCancelled, updatedAt), ErrNotFound)
+	})
+	t.Run("Fails to add another active RSVP of the member for the session", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add(Rsvp{SessionId: "session-id", UserId: "user-id", Status: StatusConfirmed, CreatedAt: createdAt, UpdatedAt: createdAt})
+		assert.NoError(t, err)
+
+		_, err = repo.Add(Rsvp{SessionId: "session-id", UserId: "user-id", Status: StatusWaitlisted, CreatedAt: createdAt, UpdatedAt: createdAt})
+		assert.ErrorIs(t, err, ErrDuplicate)
+
+		// The member can RSVP again after cancelling it.
+		assert.NoError(t, repo.UpdateStatus(id, StatusConfirmed, StatusCancelled, createdAt))
+		_, err = repo.Add(Rsvp{SessionId: "session-id", UserId: "user-id", Status: StatusConfirmed, CreatedAt: createdAt, UpdatedAt: createdAt})
+		assert.NoError(t, err)
+	})
+}
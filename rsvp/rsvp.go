@@ -0,0 +1,35 @@
+// It handles the RSVPs of the members to the upcoming sessions. The sessions with the limited spots have the waitlist.
+package rsvp
+
+import "time"
+
+type Status string
+
+const (
+	// The member has a spot in the session.
+	StatusConfirmed Status = "confirmed"
+	// The session is full. The member gets a spot when a confirmed member cancels.
+	StatusWaitlisted Status = "waitlisted"
+	// The member cancelled it. It's kept to compare the RSVPs with the attendance.
+	StatusCancelled Status = "cancelled"
+)
+
+// Returns true if the member still plans to join the session.
+func (s Status) IsActive() bool {
+	return s == StatusConfirmed || s == StatusWaitlisted
+}
+
+// The RSVP of a member to a session. The waitlisted members are promoted in the order of their RSVPs.
+type Rsvp struct {
+	// The ID of the RSVP. E.g., "abc123"
+	Id string `json:"id"`
+	// The ID of the session. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The ID of the member. E.g., "abc123"
+	UserId string `json:"user_id"`
+	Status Status `json:"status"`
+	// The time in UTC when the member RSVPed.
+	CreatedAt time.Time `json:"created_at"`
+	// The time in UTC when the status was changed last time. E.g., when it's promoted or cancelled.
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,86 @@
+package rsvp
+
+import (
+	"database/sql"
+	"fmt"
+	"rush/sqlite"
+	"time"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteRsvpColumns = "id, session_id, user_id, status, created_at, updated_at"
+
+// Adds the RSVP with the given status and times.
+// If the member already has an active RSVP for the session, it returns ErrDuplicate.
+func (r *sqliteRepo) Add(rsvp Rsvp) (string, error) {
+	id := sqlite.NewId()
+	if _, err := r.db.Exec("INSERT INTO rsvps ("+sqliteRsvpColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		id, rsvp.SessionId, rsvp.UserId, rsvp.Status, sqlite.FromTime(rsvp.CreatedAt), sqlite.FromTime(rsvp.UpdatedAt)); err != nil {
+		if sqlite.IsUniqueConstraintError(err) {
+			return "", fmt.Errorf("%w: %v", ErrDuplicate, err)
+		}
+		return "", fmt.Errorf("failed to insert rsvp: %w", err)
+	}
+
+	return id, nil
+}
+
+// Returns the RSVPs of the session in the order of the RSVPs. It's the order of the waitlist as well.
+func (r *sqliteRepo) FindBySessionId(sessionId string) ([]Rsvp, error) {
+	return r.query("SELECT "+sqliteRsvpColumns+" FROM rsvps WHERE session_id = ? ORDER BY rowid", sessionId)
+}
+
+// Returns the RSVPs of the member in the order of the RSVPs.
+func (r *sqliteRepo) FindByUserId(userId string) ([]Rsvp, error) {
+	return r.query("SELECT "+sqliteRsvpColumns+" FROM rsvps WHERE user_id = ? ORDER BY rowid", userId)
+}
+
+// Changes the status of the RSVP only if it's still in the given status.
+// It returns ErrNotFound if it's not found or the status has been changed by someone else.
+func (r *sqliteRepo) UpdateStatus(id string, from Status, to Status, updatedAt time.Time) error {
+	result, err := r.db.Exec("UPDATE rsvps SET status = ?, updated_at = ? WHERE id = ? AND status = ?", to, sqlite.FromTime(updatedAt), id, from)
+	if err != nil {
+		return fmt.Errorf("failed to update rsvp: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update rsvp: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *sqliteRepo) query(query string, args ...interface{}) ([]Rsvp, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rsvps: %w", err)
+	}
+	defer rows.Close()
+
+	rsvps := []Rsvp{}
+	for rows.Next() {
+		var rsvp Rsvp
+		var createdAt, updatedAt int64
+		if err := rows.Scan(&rsvp.Id, &rsvp.SessionId, &rsvp.UserId, &rsvp.Status, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to decode rsvps: %w", err)
+		}
+		rsvp.CreatedAt = sqlite.ToTime(createdAt)
+		rsvp.UpdatedAt = sqlite.ToTime(updatedAt)
+		rsvps = append(rsvps, rsvp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode rsvps: %w", err)
+	}
+	return rsvps, nil
+}
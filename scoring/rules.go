@@ -0,0 +1,52 @@
+// It computes the scores of the members from their attendances by the rules of the term.
+package scoring
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The rules that the admins set for a term. The zero value scores each session by its score and everyone passes.
+type Rules struct {
+	// The total score that a member should reach to pass the term. 0 means everyone passes. E.g., 20
+	MinimumScore int `json:"minimum_score"`
+	// How many minutes after the session starts a member can join without the late penalty. E.g., 10
+	LateGraceMinutes int `json:"late_grace_minutes"`
+	// The points deducted from the session score when a member joins late. The session doesn't go below 0. E.g., 1
+	LatePenalty int `json:"late_penalty"`
+	// The maximum points that a member can earn from the sessions in a week from Monday. 0 means no cap. E.g., 6
+	WeeklyCap int `json:"weekly_cap"`
+	// Whether the attendances that the admins applied after the session was closed earn no points. E.g., true
+	ExcludeForceApplied bool `json:"exclude_force_applied"`
+	// The sessions that give extra points to the members who attended them. E.g., a race.
+	// The extra points are not limited by the weekly cap.
+	BonusSessions []BonusSession `json:"bonus_sessions"`
+}
+
+type BonusSession struct {
+	// The ID of the session. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The extra points on top of the session score. E.g., 3
+	Points int `json:"points"`
+}
+
+// Checks if the rules can be evaluated.
+func (r *Rules) Validate() error {
+	if r.MinimumScore < 0 || r.LateGraceMinutes < 0 || r.LatePenalty < 0 || r.WeeklyCap < 0 {
+		return errors.New("minimum score, late grace minutes, late penalty and weekly cap should not be negative")
+	}
+	sessionIdSet := map[string]bool{}
+	for _, bonusSession := range r.BonusSessions {
+		if bonusSession.SessionId == "" {
+			return errors.New("session ID of the bonus session is required")
+		}
+		if bonusSession.Points <= 0 {
+			return fmt.Errorf("points of the bonus session (%s) should be positive", bonusSession.SessionId)
+		}
+		if sessionIdSet[bonusSession.SessionId] {
+			return fmt.Errorf("bonus session (%s) is duplicated", bonusSession.SessionId)
+		}
+		sessionIdSet[bonusSession.SessionId] = true
+	}
+	return nil
+}
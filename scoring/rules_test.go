@@ -0,0 +1,14 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&Rules{}).Validate())
+	assert.Error(t, (&Rules{LatePenalty: -1}).Validate())
+	assert.Error(t, (&Rules{BonusSessions: []BonusSession{{SessionId: "race", Points: 0}}}).Validate())
+	assert.Error(t, (&Rules{BonusSessions: []BonusSession{{SessionId: "race", Points: 1}, {SessionId: "race", Points: 2}}}).Validate())
+}
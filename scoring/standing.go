@@ -0,0 +1,199 @@
+package scoring
+
+import (
+	"fmt"
+	"rush/attendance"
+	"slices"
+	"time"
+)
+
+// A session that counts toward the score of a member. It's either an attendance or an excused absence.
+type Record struct {
+	UserId           string
+	SessionId        string
+	SessionScore     int
+	SessionStartedAt time.Time
+	// The time when the member joined. Zero if it's unknown such as when the admin marked them as present.
+	UserJoinedAt time.Time
+	// How the member attended the session. Empty for the absences and the attendances recorded before the status.
+	Status attendance.Status
+	// Whether it's an absence with the approved excuse that counts toward the score.
+	// It's never late and doesn't earn the bonus points. So is the attendance of the excused status.
+	IsExcused bool
+}
+
+// Whether the member may have joined late. The on-time, excused and force-applied attendances are never late.
+func (r *Record) mayBeLate() bool {
+	return r.Status == "" || r.Status == attendance.StatusLate
+}
+
+type Rule string
+
+const (
+	// The score of the attended session.
+	RuleSession Rule = "session"
+	// The score of the session that the member missed with the approved excuse.
+	RuleExcused     Rule = "excused"
+	RuleLatePenalty Rule = "late_penalty"
+	// The session score taken back from the force-applied attendance when the rules exclude them.
+	RuleForceApplied Rule = "force_applied"
+	RuleWeeklyCap    Rule = "weekly_cap"
+	RuleBonus        Rule = "bonus"
+	RuleMinimumScore Rule = "minimum_score"
+)
+
+// How a rule changed the total of a member.
+type Evaluation struct {
+	Rule Rule `json:"rule"`
+	// The session that the rule is applied to. Empty for the rules across the sessions. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The points added to the total. It's negative for the penalties and 0 for the minimum score. E.g., -1
+	Points int `json:"points"`
+	// The explanation for the members. E.g., "joined 15 minutes late"
+	Detail string `json:"detail"`
+}
+
+type Standing struct {
+	UserId string `json:"user_id"`
+	Total  int    `json:"total"`
+	// 1 is the highest. The members of the same total share the rank. E.g., 1, 2, 2, 4
+	Rank   int  `json:"rank"`
+	Passed bool `json:"passed"`
+	// The evaluations in the order of the sessions. The weekly caps follow the sessions of the week.
+	Evaluations []Evaluation `json:"evaluations"`
+}
+
+// Evaluates the records of the members by the rules and returns the standings in the order of the ranks.
+// The members of the same rank keep the given order. The weeks are split by the given location.
+func Evaluate(rules Rules, userIds []string, records []Record, location *time.Location) []Standing {
+	bonusPoints := map[string]int{}
+	for _, bonusSession := range rules.BonusSessions {
+		bonusPoints[bonusSession.SessionId] = bonusSession.Points
+	}
+	userRecords := map[string][]Record{}
+	for _, record := range records {
+		userRecords[record.UserId] = append(userRecords[record.UserId], record)
+	}
+
+	standings := []Standing{}
+	for _, userId := range userIds {
+		standing := evaluateUser(rules, bonusPoints, userRecords[userId], location)
+		standing.UserId = userId
+		standings = append(standings, standing)
+	}
+
+	slices.SortStableFunc(standings, func(standing1, standing2 Standing) int {
+		return standing2.Total - standing1.Total
+	})
+	for index := range standings {
+		if index > 0 && standings[index].Total == standings[index-1].Total {
+			standings[index].Rank = standings[index-1].Rank
+		} else {
+			standings[index].Rank = index + 1
+		}
+	}
+	return standings
+}
+
+func evaluateUser(rules Rules, bonusPoints map[string]int, records []Record, location *time.Location) Standing {
+	records = slices.Clone(records)
+	slices.SortStableFunc(records, func(record1, record2 Record) int {
+		return record1.SessionStartedAt.Compare(record2.SessionStartedAt)
+	})
+
+	standing := Standing{Evaluations: []Evaluation{}}
+	// The points of the sessions in the current week that the weekly cap limits.
+	weekStartsAt := time.Time{}
+	weekPoints := 0
+	closeWeek := func() {
+		if rules.WeeklyCap > 0 && weekPoints > rules.WeeklyCap {
+			standing.Evaluations = append(standing.Evaluations, Evaluation{
+				Rule:   RuleWeeklyCap,
+				Points: rules.WeeklyCap - weekPoints,
+				Detail: fmt.Sprintf("%d points in the week of %s are capped to %d", weekPoints, weekStartsAt.Format(time.DateOnly), rules.WeeklyCap),
+			})
+			standing.Total += rules.WeeklyCap - weekPoints
+		}
+		weekPoints = 0
+	}
+
+	for _, record := range records {
+		if recordWeekStartsAt := startOfWeek(record.SessionStartedAt, location); !recordWeekStartsAt.Equal(weekStartsAt) {
+			closeWeek()
+			weekStartsAt = recordWeekStartsAt
+		}
+
+		if record.IsExcused || record.Status == attendance.StatusExcused {
+			detail := "missed with the approved excuse"
+			if !record.IsExcused {
+				detail = "attended with the excuse"
+			}
+			standing.Evaluations = append(standing.Evaluations, Evaluation{
+				Rule:      RuleExcused,
+				SessionId: record.SessionId,
+				Points:    record.SessionScore,
+				Detail:    detail,
+			})
+			standing.Total += record.SessionScore
+			weekPoints += record.SessionScore
+			continue
+		}
+
+		standing.Evaluations = append(standing.Evaluations, Evaluation{
+			Rule:      RuleSession,
+			SessionId: record.SessionId,
+			Points:    record.SessionScore,
+			Detail:    "attended",
+		})
+		if rules.ExcludeForceApplied && record.Status == attendance.StatusForceApplied {
+			standing.Evaluations = append(standing.Evaluations, Evaluation{
+				Rule:      RuleForceApplied,
+				SessionId: record.SessionId,
+				Points:    -record.SessionScore,
+				Detail:    "applied by the admin after the session was closed",
+			})
+			continue
+		}
+		points := record.SessionScore
+		lateness := record.UserJoinedAt.Sub(record.SessionStartedAt)
+		if rules.LatePenalty > 0 && record.mayBeLate() && !record.UserJoinedAt.IsZero() && lateness > time.Duration(rules.LateGraceMinutes)*time.Minute {
+			penalty := min(rules.LatePenalty, points)
+			standing.Evaluations = append(standing.Evaluations, Evaluation{
+				Rule:      RuleLatePenalty,
+				SessionId: record.SessionId,
+				Points:    -penalty,
+				Detail:    fmt.Sprintf("joined %d minutes late", int(lateness.Minutes())),
+			})
+			points -= penalty
+		}
+		standing.Total += points
+		weekPoints += points
+
+		if bonus := bonusPoints[record.SessionId]; bonus > 0 {
+			standing.Evaluations = append(standing.Evaluations, Evaluation{
+				Rule:      RuleBonus,
+				SessionId: record.SessionId,
+				Points:    bonus,
+				Detail:    "attended the bonus session",
+			})
+			standing.Total += bonus
+		}
+	}
+	closeWeek()
+
+	standing.Passed = standing.Total >= rules.MinimumScore
+	if rules.MinimumScore > 0 {
+		standing.Evaluations = append(standing.Evaluations, Evaluation{
+			Rule:   RuleMinimumScore,
+			Detail: fmt.Sprintf("%d of %d points required", standing.Total, rules.MinimumScore),
+		})
+	}
+	return standing
+}
+
+// Returns the start of the Monday of the week in the location.
+func startOfWeek(at time.Time, location *time.Location) time.Time {
+	local := at.In(location)
+	daysSinceMonday := (int(local.Weekday()) + 6) % 7
+	return time.Date(local.Year(), local.Month(), local.Day()-daysSinceMonday, 0, 0, 0, 0, location)
+}
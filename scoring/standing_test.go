@@ -0,0 +1,101 @@
+package scoring
+
+import (
+	"rush/attendance"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	// Tuesday.
+	tuesday := time.Date(2025, 7, 1, 11, 0, 0, 0, time.UTC)
+	thursday := tuesday.Add(2 * 24 * time.Hour)
+	nextTuesday := tuesday.Add(7 * 24 * time.Hour)
+
+	t.Run("Sums the session scores and ranks the members of the same total together", func(t *testing.T) {
+		standings := Evaluate(Rules{}, []string{"1", "2", "3"}, []Record{
+			{UserId: "1", SessionId: "session1", SessionScore: 2, SessionStartedAt: tuesday},
+			{UserId: "2", SessionId: "session1", SessionScore: 2, SessionStartedAt: tuesday},
+			{UserId: "3", SessionId: "session1", SessionScore: 2, SessionStartedAt: tuesday},
+			{UserId: "3", SessionId: "session2", SessionScore: 2, SessionStartedAt: thursday},
+		}, time.UTC)
+
+		assert.Equal(t, []string{"3", "1", "2"}, []string{standings[0].UserId, standings[1].UserId, standings[2].UserId})
+		assert.Equal(t, []int{4, 2, 2}, []int{standings[0].Total, standings[1].Total, standings[2].Total})
+		assert.Equal(t, []int{1, 2, 2}, []int{standings[0].Rank, standings[1].Rank, standings[2].Rank})
+		assert.True(t, standings[2].Passed)
+	})
+
+	t.Run("Deducts the late penalty after the grace minutes but not below 0", func(t *testing.T) {
+		rules := Rules{LateGraceMinutes: 10, LatePenalty: 3}
+
+		standings := Evaluate(rules, []string{"1"}, []Record{
+			{UserId: "1", SessionId: "session1", SessionScore: 2, SessionStartedAt: tuesday, UserJoinedAt: tuesday.Add(10 * time.Minute)},
+			{UserId: "1", SessionId: "session2", SessionScore: 2, SessionStartedAt: thursday, UserJoinedAt: thursday.Add(15 * time.Minute)},
+			// The admin marked them as present without the time.
+			{UserId: "1", SessionId: "session3", SessionScore: 2, SessionStartedAt: nextTuesday},
+		}, time.UTC)
+
+		assert.Equal(t, 4, standings[0].Total)
+		assert.Contains(t, standings[0].Evaluations, Evaluation{Rule: RuleLatePenalty, SessionId: "session2", Points: -2, Detail: "joined 15 minutes late"})
+	})
+
+	t.Run("Treats the attendances by their statuses", func(t *testing.T) {
+		rules := Rules{LateGraceMinutes: 10, LatePenalty: 1, ExcludeForceApplied: true, BonusSessions: []BonusSession{{SessionId: "race", Points: 5}}}
+
+		standings := Evaluate(rules, []string{"1"}, []Record{
+			{UserId: "1", SessionId: "session1", SessionScore: 2, SessionStartedAt: tuesday, UserJoinedAt: tuesday.Add(15 * time.Minute), Status: attendance.StatusLate},
+			// The admin applied it after closing with the time when they applied it.
+			{UserId: "1", SessionId: "session2", SessionScore: 2, SessionStartedAt: thursday, UserJoinedAt: thursday.Add(time.Hour), Status: attendance.StatusForceApplied},
+			{UserId: "1", SessionId: "race", SessionScore: 2, SessionStartedAt: nextTuesday, UserJoinedAt: nextTuesday.Add(time.Hour), Status: attendance.StatusExcused},
+		}, time.UTC)
+
+		assert.Equal(t, 1+0+2, standings[0].Total)
+		assert.Equal(t, []Evaluation{
+			{Rule: RuleSession, SessionId: "session1", Points: 2, Detail: "attended"},
+			{Rule: RuleLatePenalty, SessionId: "session1", Points: -1, Detail: "joined 15 minutes late"},
+			{Rule: RuleSession, SessionId: "session2", Points: 2, Detail: "attended"},
+			{Rule: RuleForceApplied, SessionId: "session2", Points: -2, Detail: "applied by the admin after the session was closed"},
+			{Rule: RuleExcused, SessionId: "race", Points: 2, Detail: "attended with the excuse"},
+		}, standings[0].Evaluations)
+	})
+
+	t.Run("Caps the points of each week in the location and adds the bonus on top", func(t *testing.T) {
+		rules := Rules{WeeklyCap: 3, BonusSessions: []BonusSession{{SessionId: "race", Points: 5}}}
+		seoul, err := time.LoadLocation("Asia/Seoul")
+		assert.NoError(t, err)
+		// It's Monday in Seoul, but Sunday in UTC.
+		monday := time.Date(2025, 6, 29, 16, 0, 0, 0, time.UTC)
+
+		standings := Evaluate(rules, []string{"1"}, []Record{
+			{UserId: "1", SessionId: "session1", SessionScore: 2, SessionStartedAt: monday},
+			{UserId: "1", SessionId: "session2", SessionScore: 2, SessionStartedAt: tuesday},
+			{UserId: "1", SessionId: "race", SessionScore: 2, SessionStartedAt: thursday},
+			{UserId: "1", SessionId: "session3", SessionScore: 2, SessionStartedAt: nextTuesday, IsExcused: true},
+		}, seoul)
+
+		assert.Equal(t, 3+5+2, standings[0].Total)
+		assert.Equal(t, []Evaluation{
+			{Rule: RuleSession, SessionId: "session1", Points: 2, Detail: "attended"},
+			{Rule: RuleSession, SessionId: "session2", Points: 2, Detail: "attended"},
+			{Rule: RuleSession, SessionId: "race", Points: 2, Detail: "attended"},
+			{Rule: RuleBonus, SessionId: "race", Points: 5, Detail: "attended the bonus session"},
+			{Rule: RuleWeeklyCap, Points: -3, Detail: "6 points in the week of 2025-06-30 are capped to 3"},
+			{Rule: RuleExcused, SessionId: "session3", Points: 2, Detail: "missed with the approved excuse"},
+		}, standings[0].Evaluations)
+	})
+
+	t.Run("Fails the members below the minimum score", func(t *testing.T) {
+		standings := Evaluate(Rules{MinimumScore: 4}, []string{"1", "2"}, []Record{
+			{UserId: "1", SessionId: "session1", SessionScore: 2, SessionStartedAt: tuesday},
+		}, time.UTC)
+
+		assert.False(t, standings[0].Passed)
+		assert.Equal(t, Evaluation{Rule: RuleMinimumScore, Detail: "2 of 4 points required"}, standings[0].Evaluations[1])
+		assert.Equal(t, "2", standings[1].UserId)
+		assert.Equal(t, 0, standings[1].Total)
+		assert.False(t, standings[1].Passed)
+	})
+}
@@ -0,0 +1,110 @@
+package series
+
+import (
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the series in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The series in the order of insertion.
+	seriesList []*Series
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		seriesList: []*Series{},
+	}
+}
+
+// Returns the series by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *memoryRepo) Get(id string) (Series, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, series := range r.seriesList {
+		if series.Id == id {
+			return *series, nil
+		}
+	}
+	return Series{}, ErrNotFound
+}
+
+// Returns all the series including the cancelled ones in the order of creation.
+func (r *memoryRepo) GetAll() ([]Series, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(Series) bool { return true }), nil
+}
+
+// Returns the series that are not cancelled in the order of creation.
+func (r *memoryRepo) GetAllActive() ([]Series, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(series Series) bool { return !series.IsCancelled }), nil
+}
+
+func (r *memoryRepo) Add(series Series) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	series.Id = primitive.NewObjectID().Hex()
+	series.IsCancelled = false
+	series.CreatedAt = time.Now()
+	r.seriesList = append(r.seriesList, &series)
+	return series.Id, nil
+}
+
+func (r *memoryRepo) Update(id string, updateForm UpdateForm) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, series := range r.seriesList {
+		if series.Id != id {
+			continue
+		}
+		if updateForm.NameTemplate != nil {
+			series.NameTemplate = *updateForm.NameTemplate
+		}
+		if updateForm.DescriptionTemplate != nil {
+			series.DescriptionTemplate = *updateForm.DescriptionTemplate
+		}
+		if updateForm.Weekday != nil {
+			series.Weekday = *updateForm.Weekday
+		}
+		if updateForm.Hour != nil {
+			series.Hour = *updateForm.Hour
+		}
+		if updateForm.Minute != nil {
+			series.Minute = *updateForm.Minute
+		}
+		if updateForm.Score != nil {
+			series.Score = *updateForm.Score
+		}
+		if updateForm.MaterializedUntil != nil {
+			series.MaterializedUntil = *updateForm.MaterializedUntil
+		}
+		if updateForm.IsCancelled != nil {
+			series.IsCancelled = *updateForm.IsCancelled
+		}
+	}
+	return nil
+}
+
+// Returns the copies of the series that match the predicate.
+func (r *memoryRepo) filter(predicate func(Series) bool) []Series {
+	seriesList := []Series{}
+	for _, series := range r.seriesList {
+		if predicate(*series) {
+			seriesList = append(seriesList, *series)
+		}
+	}
+	return seriesList
+}
@@ -0,0 +1,205 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The series record in MongoDB.
+type mongodbSeries struct {
+	// The unique identifier for the series. E.g. "1"
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// The template of the session names. E.g. "{{.Date}} 여의도 정규런"
+	NameTemplate string `bson:"name_template"`
+	// The template of the session descriptions. E.g. "여의도 공원에서 정규런을 진행합니다."
+	DescriptionTemplate string `bson:"description_template"`
+	// The unique identifier for the user who created the series. E.g. "1"
+	CreatedBy string `bson:"created_by"`
+	// The weekday when the sessions start. 0 is Sunday. E.g. 2
+	Weekday int `bson:"weekday"`
+	// The hour when the sessions start in the form time location. E.g. 20
+	Hour int `bson:"hour"`
+	// The minute when the sessions start in the form time location. E.g. 30
+	Minute int `bson:"minute"`
+	// The score of the sessions. E.g. 2
+	Score int `bson:"score"`
+	// The time until when the sessions have been created. E.g. "2025-07-29T00:00:00Z"
+	MaterializedUntil time.Time `bson:"materialized_until"`
+	// Whether the series is cancelled. E.g. false
+	IsCancelled bool `bson:"is_cancelled"`
+	// The time when the series was created. E.g. "2025-07-01T00:00:00Z"
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+var ErrNotFound = errors.New("series not found")
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	Get(id string) (Series, error)
+	GetAll() ([]Series, error)
+	GetAllActive() ([]Series, error)
+	Add(series Series) (string, error)
+	Update(id string, updateForm UpdateForm) error
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+// Returns the series by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *mongodbRepo) Get(id string) (Series, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Series{}, fmt.Errorf("invalid id: %w", err)
+	}
+
+	series := &mongodbSeries{}
+	if err := r.collection.FindOne(context.Background(), bson.M{"_id": objectID}).Decode(series); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Series{}, ErrNotFound
+		}
+		return Series{}, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	return *fromMongodbSeries(series), nil
+}
+
+// Returns all the series including the cancelled ones in the order of creation.
+func (r *mongodbRepo) GetAll() ([]Series, error) {
+	return r.find(bson.M{})
+}
+
+// Returns the series that are not cancelled in the order of creation.
+func (r *mongodbRepo) GetAllActive() ([]Series, error) {
+	return r.find(bson.M{"is_cancelled": false})
+}
+
+func (r *mongodbRepo) Add(series Series) (string, error) {
+	result, err := r.collection.InsertOne(context.Background(), mongodbSeries{
+		NameTemplate:        series.NameTemplate,
+		DescriptionTemplate: series.DescriptionTemplate,
+		CreatedBy:           series.CreatedBy,
+		Weekday:             int(series.Weekday),
+		Hour:                series.Hour,
+		Minute:              series.Minute,
+		Score:               series.Score,
+		MaterializedUntil:   series.MaterializedUntil,
+		IsCancelled:         false,
+		CreatedAt:           time.Now(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to insert series: %w", err)
+	}
+
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", fmt.Errorf("failed to get inserted id")
+	}
+
+	return id.Hex(), nil
+}
+
+// The form to update the series. It only includes fields that can be updated.
+type UpdateForm struct {
+	NameTemplate        *string
+	DescriptionTemplate *string
+	Weekday             *time.Weekday
+	Hour                *int
+	Minute              *int
+	Score               *int
+	MaterializedUntil   *time.Time
+	IsCancelled         *bool
+}
+
+func (r *mongodbRepo) Update(id string, updateForm UpdateForm) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	update := bson.M{}
+	if updateForm.NameTemplate != nil {
+		update["name_template"] = *updateForm.NameTemplate
+	}
+	if updateForm.DescriptionTemplate != nil {
+		update["description_template"] = *updateForm.DescriptionTemplate
+	}
+	if updateForm.Weekday != nil {
+		update["weekday"] = int(*updateForm.Weekday)
+	}
+	if updateForm.Hour != nil {
+		update["hour"] = *updateForm.Hour
+	}
+	if updateForm.Minute != nil {
+		update["minute"] = *updateForm.Minute
+	}
+	if updateForm.Score != nil {
+		update["score"] = *updateForm.Score
+	}
+	if updateForm.MaterializedUntil != nil {
+		update["materialized_until"] = *updateForm.MaterializedUntil
+	}
+	if updateForm.IsCancelled != nil {
+		update["is_cancelled"] = *updateForm.IsCancelled
+	}
+	if len(update) == 0 {
+		return nil
+	}
+
+	if _, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": objectID}, bson.M{"$set": update}); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mongodbRepo) find(filter bson.M) ([]Series, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongodbSeriesList []mongodbSeries
+	if err = cursor.All(ctx, &mongodbSeriesList); err != nil {
+		return nil, fmt.Errorf("failed to decode series: %w", err)
+	}
+
+	seriesList := []Series{}
+	for _, mongodbSeries := range mongodbSeriesList {
+		seriesList = append(seriesList, *fromMongodbSeries(&mongodbSeries))
+	}
+	return seriesList, nil
+}
+
+func fromMongodbSeries(series *mongodbSeries) *Series {
+	return &Series{
+		Id:                  series.Id.Hex(),
+		NameTemplate:        series.NameTemplate,
+		DescriptionTemplate: series.DescriptionTemplate,
+		CreatedBy:           series.CreatedBy,
+		Weekday:             time.Weekday(series.Weekday),
+		Hour:                series.Hour,
+		Minute:              series.Minute,
+		Score:               series.Score,
+		MaterializedUntil:   series.MaterializedUntil,
+		IsCancelled:         series.IsCancelled,
+		CreatedAt:           series.CreatedAt,
+	}
+}
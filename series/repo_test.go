@@ -0,0 +1,102 @@
+package series
+
+import (
+	"rush/golang/mongotest"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	newSeries := func() Series {
+		return Series{
+			NameTemplate:        "{{.Date}} 정규런",
+			DescriptionTemplate: "여의도",
+			CreatedBy:           "user-id",
+			Weekday:             time.Tuesday,
+			Hour:                20,
+			Minute:              30,
+			Score:               2,
+			MaterializedUntil:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	t.Run("Adds and gets a series", func(t *testing.T) {
+		repo := newRepo(t)
+
+		id, err := repo.Add(newSeries())
+		assert.NoError(t, err)
+
+		series, err := repo.Get(id)
+		assert.NoError(t, err)
+		assert.Equal(t, Series{
+			Id:                  id,
+			NameTemplate:        "{{.Date}} 정규런",
+			DescriptionTemplate: "여의도",
+			CreatedBy:           "user-id",
+			Weekday:             time.Tuesday,
+			Hour:                20,
+			Minute:              30,
+			Score:               2,
+			MaterializedUntil:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			CreatedAt:           series.CreatedAt,
+		}, series)
+
+		_, err = repo.Get(primitive.NewObjectID().Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Updates only the given fields", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add(newSeries())
+		assert.NoError(t, err)
+
+		weekday := time.Thursday
+		materializedUntil := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+		assert.NoError(t, repo.Update(id, UpdateForm{Weekday: &weekday, MaterializedUntil: &materializedUntil}))
+
+		series, err := repo.Get(id)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Thursday, series.Weekday)
+		assert.Equal(t, time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), series.MaterializedUntil)
+		assert.Equal(t, 20, series.Hour)
+		assert.Equal(t, "{{.Date}} 정규런", series.NameTemplate)
+	})
+
+	t.Run("Returns only the active series", func(t *testing.T) {
+		repo := newRepo(t)
+		active, err := repo.Add(newSeries())
+		assert.NoError(t, err)
+		cancelled, err := repo.Add(newSeries())
+		assert.NoError(t, err)
+		isCancelled := true
+		assert.NoError(t, repo.Update(cancelled, UpdateForm{IsCancelled: &isCancelled}))
+
+		all, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{active, cancelled}, []string{all[0].Id, all[1].Id})
+		assert.True(t, all[1].IsCancelled)
+
+		activeSeries, err := repo.GetAllActive()
+		assert.NoError(t, err)
+		assert.Len(t, activeSeries, 1)
+		assert.Equal(t, active, activeSeries[0].Id)
+	})
+}
@@ -0,0 +1,120 @@
+// It handles the session series, the sessions that repeat every week such as the regular runs.
+package series
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Series represents the sessions that repeat every week at the same time.
+// The job creates the sessions of the series ahead of time so that they can be handled like the other sessions.
+type Series struct {
+	// The ID of the series. It's a unique identifier. E.g., "abc123"
+	Id string `json:"id"`
+	// The template of the session names. E.g., "{{.Date}} 여의도 정규런"
+	NameTemplate string `json:"name_template"`
+	// The template of the session descriptions. E.g., "{{.Date}} {{.Time}}에 여의도 공원에서 만나요."
+	DescriptionTemplate string `json:"description_template"`
+	// The ID of the user who created the series. The sessions of the series are created by the user.
+	CreatedBy string `json:"created_by"`
+	// The weekday when the sessions start.
+	Weekday time.Weekday `json:"weekday"`
+	// The hour when the sessions start in the form time location. E.g., 20
+	Hour int `json:"hour"`
+	// The minute when the sessions start in the form time location. E.g., 30
+	Minute int `json:"minute"`
+	// The attendance score of the sessions. E.g., 2
+	Score int `json:"score"`
+	// The sessions starting before it have been created. The next sessions are created from it.
+	// It's kept even if the sessions are deleted so that the deleted ones are not created again.
+	MaterializedUntil time.Time `json:"materialized_until"`
+	// Whether the series is cancelled. No more sessions are created for the cancelled series.
+	IsCancelled bool `json:"is_cancelled"`
+	// The time in UTC when the series was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// The values that the name and the description templates can use.
+type templateData struct {
+	// The date when the session starts. E.g., "2025-07-01"
+	Date string
+	// The time when the session starts. E.g., "20:00"
+	Time string
+}
+
+// Checks if the series can create sessions.
+func (s *Series) Validate() error {
+	if strings.TrimSpace(s.NameTemplate) == "" {
+		return errors.New("name is required")
+	}
+	if s.Weekday < time.Sunday || s.Weekday > time.Saturday {
+		return fmt.Errorf("invalid weekday: %d", s.Weekday)
+	}
+	if s.Hour < 0 || s.Hour > 23 || s.Minute < 0 || s.Minute > 59 {
+		return fmt.Errorf("invalid time: %02d:%02d", s.Hour, s.Minute)
+	}
+	if s.Score < 0 {
+		return errors.New("score should not be negative")
+	}
+	// Render once to find out the invalid fields used in the templates as well.
+	if _, _, err := s.Render(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.UTC); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Returns the start times in UTC of the sessions that start within [from, to).
+// The weekday and the time of the series are in the given location.
+func (s *Series) Occurrences(from time.Time, to time.Time, location *time.Location) []time.Time {
+	localFrom := from.In(location)
+	days := (int(s.Weekday) - int(localFrom.Weekday()) + 7) % 7
+	date := time.Date(localFrom.Year(), localFrom.Month(), localFrom.Day()+days, 0, 0, 0, 0, location)
+
+	occurrences := []time.Time{}
+	for {
+		// Build it from the date every time rather than adding a week so that it keeps the local time over DST changes.
+		startsAt := time.Date(date.Year(), date.Month(), date.Day(), s.Hour, s.Minute, 0, 0, location)
+		if !startsAt.Before(to) {
+			return occurrences
+		}
+		if !startsAt.Before(from) {
+			occurrences = append(occurrences, startsAt.UTC())
+		}
+		date = date.AddDate(0, 0, 7)
+	}
+}
+
+// Returns the name and the description of the session that starts at the given time.
+func (s *Series) Render(startsAt time.Time, location *time.Location) (string, string, error) {
+	localStartsAt := startsAt.In(location)
+	data := templateData{
+		Date: localStartsAt.Format("2006-01-02"),
+		Time: localStartsAt.Format("15:04"),
+	}
+
+	name, err := render(s.NameTemplate, data)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid name template: %w", err)
+	}
+	description, err := render(s.DescriptionTemplate, data)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid description template: %w", err)
+	}
+	return name, description, nil
+}
+
+func render(text string, data templateData) (string, error) {
+	parsed, err := template.New("").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	if err := parsed.Execute(&builder, data); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
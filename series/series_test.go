@@ -0,0 +1,64 @@
+package series
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeries_Occurrences(t *testing.T) {
+	seoul := must.OK1(time.LoadLocation("Asia/Seoul"))
+	series := Series{Weekday: time.Tuesday, Hour: 20, Minute: 30}
+
+	// 2025-07-01 is Tuesday.
+	occurrences := series.Occurrences(time.Date(2025, 7, 1, 11, 30, 0, 0, time.UTC), time.Date(2025, 7, 15, 11, 30, 0, 0, time.UTC), seoul)
+
+	// The start is inclusive and the end is exclusive.
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 7, 1, 11, 30, 0, 0, time.UTC),
+		time.Date(2025, 7, 8, 11, 30, 0, 0, time.UTC),
+	}, occurrences)
+
+	t.Run("Keeps the local time over DST changes", func(t *testing.T) {
+		newYork := must.OK1(time.LoadLocation("America/New_York"))
+		series := Series{Weekday: time.Sunday, Hour: 9}
+
+		// DST starts on 2025-03-09.
+		occurrences := series.Occurrences(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), newYork)
+
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 3, 2, 14, 0, 0, 0, time.UTC),
+			time.Date(2025, 3, 9, 13, 0, 0, 0, time.UTC),
+		}, occurrences)
+	})
+}
+
+func TestSeries_Render(t *testing.T) {
+	seoul := must.OK1(time.LoadLocation("Asia/Seoul"))
+	series := Series{NameTemplate: "{{.Date}} 정규런", DescriptionTemplate: "{{.Time}}에 여의도 공원"}
+
+	name, description, err := series.Render(time.Date(2025, 7, 1, 11, 30, 0, 0, time.UTC), seoul)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-07-01 정규런", name)
+	assert.Equal(t, "20:30에 여의도 공원", description)
+}
+
+func TestSeries_Validate(t *testing.T) {
+	valid := Series{NameTemplate: "정규런", Weekday: time.Tuesday, Hour: 20, Score: 2}
+	assert.NoError(t, valid.Validate())
+
+	invalidTime := valid
+	invalidTime.Hour = 24
+	assert.Error(t, invalidTime.Validate())
+
+	unknownField := valid
+	unknownField.DescriptionTemplate = "{{.Place}}"
+	assert.Error(t, unknownField.Validate())
+
+	emptyName := valid
+	emptyName.NameTemplate = " "
+	assert.Error(t, emptyName.Validate())
+}
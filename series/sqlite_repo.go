@@ -0,0 +1,143 @@
+package series
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"rush/sqlite"
+	"strings"
+	"time"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteSeriesColumns = "id, name_template, description_template, created_by, weekday, hour, minute, score, materialized_until, is_cancelled, created_at"
+
+// Returns the series by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *sqliteRepo) Get(id string) (Series, error) {
+	series, err := scanSqliteSeries(r.db.QueryRow("SELECT "+sqliteSeriesColumns+" FROM session_series WHERE id = ?", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Series{}, ErrNotFound
+		}
+		return Series{}, fmt.Errorf("failed to get series: %w", err)
+	}
+	return series, nil
+}
+
+// Returns all the series including the cancelled ones in the order of creation.
+func (r *sqliteRepo) GetAll() ([]Series, error) {
+	return r.query("SELECT " + sqliteSeriesColumns + " FROM session_series ORDER BY rowid")
+}
+
+// Returns the series that are not cancelled in the order of creation.
+func (r *sqliteRepo) GetAllActive() ([]Series, error) {
+	return r.query("SELECT " + sqliteSeriesColumns + " FROM session_series WHERE is_cancelled = 0 ORDER BY rowid")
+}
+
+func (r *sqliteRepo) Add(series Series) (string, error) {
+	id := sqlite.NewId()
+	if _, err := r.db.Exec("INSERT INTO session_series ("+sqliteSeriesColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?)",
+		id, series.NameTemplate, series.DescriptionTemplate, series.CreatedBy, int(series.Weekday), series.Hour, series.Minute, series.Score,
+		sqlite.FromTime(series.MaterializedUntil), sqlite.FromTime(time.Now())); err != nil {
+		return "", fmt.Errorf("failed to insert series: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *sqliteRepo) Update(id string, updateForm UpdateForm) error {
+	sets := []string{}
+	args := []interface{}{}
+	if updateForm.NameTemplate != nil {
+		sets = append(sets, "name_template = ?")
+		args = append(args, *updateForm.NameTemplate)
+	}
+	if updateForm.DescriptionTemplate != nil {
+		sets = append(sets, "description_template = ?")
+		args = append(args, *updateForm.DescriptionTemplate)
+	}
+	if updateForm.Weekday != nil {
+		sets = append(sets, "weekday = ?")
+		args = append(args, int(*updateForm.Weekday))
+	}
+	if updateForm.Hour != nil {
+		sets = append(sets, "hour = ?")
+		args = append(args, *updateForm.Hour)
+	}
+	if updateForm.Minute != nil {
+		sets = append(sets, "minute = ?")
+		args = append(args, *updateForm.Minute)
+	}
+	if updateForm.Score != nil {
+		sets = append(sets, "score = ?")
+		args = append(args, *updateForm.Score)
+	}
+	if updateForm.MaterializedUntil != nil {
+		sets = append(sets, "materialized_until = ?")
+		args = append(args, sqlite.FromTime(*updateForm.MaterializedUntil))
+	}
+	if updateForm.IsCancelled != nil {
+		sets = append(sets, "is_cancelled = ?")
+		args = append(args, *updateForm.IsCancelled)
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	args = append(args, id)
+	if _, err := r.db.Exec("UPDATE session_series SET "+strings.Join(sets, ", ")+" WHERE id = ?", args...); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteRepo) query(query string, args ...interface{}) ([]Series, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	defer rows.Close()
+
+	seriesList := []Series{}
+	for rows.Next() {
+		series, err := scanSqliteSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode series: %w", err)
+		}
+		seriesList = append(seriesList, series)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode series: %w", err)
+	}
+	return seriesList, nil
+}
+
+// Either *sql.Row or *sql.Rows.
+type sqliteScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSqliteSeries(scanner sqliteScanner) (Series, error) {
+	var series Series
+	var weekday int
+	var materializedUntil, createdAt int64
+	if err := scanner.Scan(&series.Id, &series.NameTemplate, &series.DescriptionTemplate, &series.CreatedBy, &weekday, &series.Hour, &series.Minute,
+		&series.Score, &materializedUntil, &series.IsCancelled, &createdAt); err != nil {
+		return Series{}, err
+	}
+	series.Weekday = time.Weekday(weekday)
+	series.MaterializedUntil = sqlite.ToTime(materializedUntil)
+	series.CreatedAt = sqlite.ToTime(createdAt)
+	return series, nil
+}
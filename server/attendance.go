@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"rush/attendance"
+	"rush/excuse"
 	"rush/golang/array"
 	"rush/session"
+	"rush/term"
 	"rush/user"
 	"slices"
 	"sort"
@@ -13,8 +15,8 @@ import (
 	"time"
 )
 
-// Creates attendance form for the given session.
-// Fails if the session is already closed or the form already exists.
+// Creates attendance form for the given session and sets it as the attendance source of the session.
+// Fails if the session is already closed or already has an attendance source.
 func (s *Server) CreateAttendanceForm(sessionId string) (string, error) {
 	dbSession, err := s.sessionRepo.Get(sessionId)
 	if err != nil {
@@ -23,6 +25,12 @@ func (s *Server) CreateAttendanceForm(sessionId string) (string, error) {
 	if !dbSession.CanUpdateMetadata() {
 		return "", newBadRequestError(errors.New("session is already closed"))
 	}
+	if dbSession.AttendanceSource.Provider == session.AttendanceSourceProviderGoogleForm {
+		return "", newBadRequestError(fmt.Errorf("form already exists: URI is %s", dbSession.AttendanceSource.Uri))
+	}
+	if dbSession.AttendanceSource.IsSet() {
+		return "", newBadRequestError(fmt.Errorf("session already has another attendance source: %s", dbSession.AttendanceSource.Provider))
+	}
 
 	activeUsers, err := s.userRepo.GetAllActive()
 	if err != nil {
@@ -36,16 +44,7 @@ func (s *Server) CreateAttendanceForm(sessionId string) (string, error) {
 		return activeUsers[i].Name < activeUsers[j].Name
 	})
 
-	if dbSession.GoogleFormUri != "" {
-		return "", newBadRequestError(fmt.Errorf("form already exists: URI is %s", dbSession.GoogleFormUri))
-	}
-
-	formTitle := fmt.Sprintf("[출석] %s", dbSession.Name)
-	startsAt := dbSession.StartsAt.In(s.formTimeLocation)
-	expiresAt := startsAt.Add(-time.Second)
-	formDescription := fmt.Sprintf(`%s을(를) 위한 출석용 구글폼입니다.
-폼 마감 시간은 %s입니다. %s 이후 요청은 무시됩니다.`, dbSession.Name, expiresAt.Format("2006-01-02 15:04:05"), startsAt.Format("2006-01-02 15:04:05"))
-
+	formTitle, formDescription := s.attendanceFormInfo(dbSession)
 	attendanceForm, err := s.attendanceFormHandler.GenerateForm(formTitle, formDescription,
 		array.Map(activeUsers, func(user user.User) attendance.UserOption {
 			return attendance.UserOption{
@@ -58,8 +57,11 @@ func (s *Server) CreateAttendanceForm(sessionId string) (string, error) {
 	}
 
 	_, err = s.openSessionRepo.UpdateOpenSession(sessionId, session.OpenSessionUpdateForm{
-		GoogleFormId:  &attendanceForm.Id,
-		GoogleFormUri: &attendanceForm.Uri,
+		AttendanceSource: &session.AttendanceSource{
+			Provider:   session.AttendanceSourceProviderGoogleForm,
+			ExternalId: attendanceForm.Id,
+			Uri:        attendanceForm.Uri,
+		},
 	})
 	if err != nil {
 		return "", newInternalServerError(fmt.Errorf("failed to update session: %w", err))
@@ -68,6 +70,17 @@ func (s *Server) CreateAttendanceForm(sessionId string) (string, error) {
 	return attendanceForm.Uri, nil
 }
 
+// Returns the title and the description of the attendance form for the session.
+// The description has the deadline so that it should be rebuilt when the session's start time is changed.
+func (s *Server) attendanceFormInfo(dbSession session.Session) (string, string) {
+	formTitle := fmt.Sprintf("[출석] %s", dbSession.Name)
+	startsAt := dbSession.StartsAt.In(s.formTimeLocation)
+	expiresAt := startsAt.Add(-time.Second)
+	formDescription := fmt.Sprintf(`%s을(를) 위한 출석용 구글폼입니다.
+폼 마감 시간은 %s입니다. %s 이후 요청은 무시됩니다.`, dbSession.Name, expiresAt.Format("2006-01-02 15:04:05"), startsAt.Format("2006-01-02 15:04:05"))
+	return formTitle, formDescription
+}
+
 // Returns the attendances of the given user.
 func (s *Server) GetAttendanceByUserId(userId string) ([]Attendance, error) {
 	attendances, err := s.attendanceRepo.FindByUserId(userId)
@@ -94,12 +107,25 @@ func (s *Server) GetAttendanceBySessionId(sessionId string) ([]Attendance, error
 }
 
 type HalfYearAttendace struct {
+	// The term of the half year. It's nil if there is no term for the half year.
+	Term *Term `json:"term"`
 	// All the sessions that are held in the half year so far.
 	Sessions []sessionForAttendance `json:"sessions"`
 	// All the users who joined the sessions in the half year so far.
 	Users []userForAttendance `json:"users"`
 	// All the attendances in the half year so far.
 	Attendances []Attendance `json:"attendances"`
+	// The sessions that the users missed with the approved excuses. They are shown apart from the absences.
+	Excuses []excusedAbsence `json:"excuses"`
+}
+
+type excusedAbsence struct {
+	UserId       string `json:"user_id"`
+	SessionId    string `json:"session_id"`
+	SessionScore int    `json:"session_score"`
+	ExcuseId     string `json:"excuse_id"`
+	// Whether the session counts toward the score of the user as if they attended.
+	CountsTowardScore bool `json:"counts_toward_score"`
 }
 
 type userForAttendance struct {
@@ -115,16 +141,147 @@ type sessionForAttendance struct {
 }
 
 // Returns the half year attendances. Half year is the amount of time that Rush handles the attendances for.
-// For example, 2024-1, 2024-2, etc.
-func (s *Server) GetHalfYearAttendance() (HalfYearAttendace, error) {
-	// TODO(#113): Save the data of each generation, startsAt, finishesAt, name, etc.
-	// And then replace it to a method to get attendance within certain period.
+// For example, 2024-1, 2024-2, etc. It's persisted as a term.
+// If the term ID is empty, the term of the current time is used.
+// If there is no term for the current time, all the active users and all the attendances are regarded as the half year.
+func (s *Server) GetHalfYearAttendance(termId string) (HalfYearAttendace, error) {
+	dbTerm, err := s.getTermOrCurrent(termId)
+	if err != nil {
+		return HalfYearAttendace{}, err
+	}
+	if dbTerm == nil {
+		return s.getAllAttendanceAsHalfYear()
+	}
+
+	users, err := s.userRepo.GetAll()
+	if err != nil {
+		return HalfYearAttendace{}, newInternalServerError(fmt.Errorf("failed to get users: %w", err))
+	}
+	attendances, err := s.attendanceRepo.FindBySessionStartedAtBetween(dbTerm.StartsAt, dbTerm.EndsAt)
+	if err != nil {
+		return HalfYearAttendace{}, newInternalServerError(fmt.Errorf("failed to get attendances of the term: %w", err))
+	}
+
+	attendedUserIdSet := map[string]bool{}
+	for _, attendance := range attendances {
+		attendedUserIdSet[attendance.UserId] = true
+	}
+	// Users who attended are included even if they are inactive now so that the past terms can be viewed.
+	termUsers := array.Filter(users, func(user user.User) bool {
+		return attendedUserIdSet[user.Id] || (user.IsActive && dbTerm.HasGeneration(user.Generation))
+	})
+
+	excuses, err := s.excuseRepo.FindByStatus(excuse.StatusApproved)
+	if err != nil {
+		return HalfYearAttendace{}, newInternalServerError(fmt.Errorf("failed to get the approved excuses: %w", err))
+	}
+
+	excusedSessions, err := s.getExcusedSessions(dbTerm, excuses)
+	if err != nil {
+		return HalfYearAttendace{}, err
+	}
+
+	converted := fromTerm(*dbTerm)
+	return toHalfYearAttendance(&converted, termUsers, attendances, excusedSessions, excuses), nil
+}
+
+// Returns the term of the given ID. If the ID is empty, it returns the term of the current time.
+// It returns nil without an error if there is no term for the current time.
+func (s *Server) getTermOrCurrent(termId string) (*term.Term, error) {
+	if termId != "" {
+		dbTerm, err := s.termRepo.Get(termId)
+		if err != nil {
+			if errors.Is(err, term.ErrNotFound) {
+				return nil, newNotFoundError(fmt.Errorf("failed to get term: %w", err))
+			}
+			return nil, newInternalServerError(fmt.Errorf("failed to get term: %w", err))
+		}
+		return &dbTerm, nil
+	}
+
+	dbTerm, err := s.termRepo.GetByTime(s.clock.Now())
+	if err != nil {
+		if errors.Is(err, term.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, newInternalServerError(fmt.Errorf("failed to get the current term: %w", err))
+	}
+	return &dbTerm, nil
+}
+
+// Regards all the active users and all the attendances as the half year.
+// It's used when there is no term to scope them.
+func (s *Server) getAllAttendanceAsHalfYear() (HalfYearAttendace, error) {
 	users, err := s.userRepo.GetAllActive()
 	if err != nil {
 		return HalfYearAttendace{}, newInternalServerError(fmt.Errorf("failed to get users: %w", err))
 	}
 	activeUsers := array.Filter(users, func(user user.User) bool { return user.IsActive })
-	slices.SortStableFunc(activeUsers, func(user1, user2 user.User) int {
+
+	attendances, err := s.attendanceRepo.GetAll()
+	if err != nil {
+		return HalfYearAttendace{}, newInternalServerError(fmt.Errorf("failed to get all attendances: %w", err))
+	}
+
+	excuses, err := s.excuseRepo.FindByStatus(excuse.StatusApproved)
+	if err != nil {
+		return HalfYearAttendace{}, newInternalServerError(fmt.Errorf("failed to get the approved excuses: %w", err))
+	}
+
+	excusedSessions, err := s.getExcusedSessions(nil, excuses)
+	if err != nil {
+		return HalfYearAttendace{}, err
+	}
+
+	return toHalfYearAttendance(nil, activeUsers, attendances, excusedSessions, excuses), nil
+}
+
+// Returns the sessions that the excuses cover. They are looked up by the excuses themselves
+// since nobody may have attended them. Only the applied sessions within the term are returned.
+// If the term is nil, the applied sessions of any time are returned.
+func (s *Server) getExcusedSessions(dbTerm *term.Term, excuses []excuse.Excuse) ([]session.Session, error) {
+	idSessionMap := map[string]session.Session{}
+	for _, approved := range excuses {
+		if approved.SessionId != "" {
+			dbSession, err := s.sessionRepo.Get(approved.SessionId)
+			if err != nil {
+				// The session may have been deleted after the excuse was approved.
+				if errors.Is(err, session.ErrNotFound) {
+					continue
+				}
+				return nil, newInternalServerError(fmt.Errorf("failed to get the session of excuse %s: %w", approved.Id, err))
+			}
+			idSessionMap[dbSession.Id] = dbSession
+			continue
+		}
+
+		sessions, err := s.sessionRepo.GetAllStartingBetween(approved.StartsAt, approved.EndsAt)
+		if err != nil {
+			return nil, newInternalServerError(fmt.Errorf("failed to get the sessions of excuse %s: %w", approved.Id, err))
+		}
+		for _, dbSession := range sessions {
+			idSessionMap[dbSession.Id] = dbSession
+		}
+	}
+
+	excusedSessions := []session.Session{}
+	for _, dbSession := range idSessionMap {
+		if dbSession.AttendanceStatus != session.AttendanceStatusApplied {
+			continue
+		}
+		if dbTerm != nil && (dbSession.StartsAt.Before(dbTerm.StartsAt) || !dbSession.StartsAt.Before(dbTerm.EndsAt)) {
+			continue
+		}
+		excusedSessions = append(excusedSessions, dbSession)
+	}
+	return excusedSessions, nil
+}
+
+// Sorts the users and the sessions of the attendances and the excuses and builds the half year attendance.
+// The excuses only mark the sessions of the users who didn't attend them.
+func toHalfYearAttendance(term *Term, users []user.User, attendances []attendance.Attendance,
+	excusedSessions []session.Session, excuses []excuse.Excuse) HalfYearAttendace {
+	slices.SortStableFunc(users, func(user1, user2 user.User) int {
 		if user1.Generation > user2.Generation {
 			return 1
 		}
@@ -141,10 +298,6 @@ func (s *Server) GetHalfYearAttendance() (HalfYearAttendace, error) {
 		return 0
 	})
 
-	attendances, err := s.attendanceRepo.GetAll()
-	if err != nil {
-		return HalfYearAttendace{}, newInternalServerError(fmt.Errorf("failed to get all attendances: %w", err))
-	}
 	convertedAttendances := []Attendance{}
 	for _, attendance := range attendances {
 		convertedAttendances = append(convertedAttendances, *fromAttendance(&attendance))
@@ -158,6 +311,13 @@ func (s *Server) GetHalfYearAttendance() (HalfYearAttendace, error) {
 			StartedAt: attendance.SessionStartedAt,
 		}
 	}
+	for _, excusedSession := range excusedSessions {
+		idSessionMap[excusedSession.Id] = sessionForAttendance{
+			Id:        excusedSession.Id,
+			Name:      excusedSession.Name,
+			StartedAt: excusedSession.StartsAt,
+		}
+	}
 	uniqueSessions := []sessionForAttendance{}
 	for id := range idSessionMap {
 		uniqueSessions = append(uniqueSessions, idSessionMap[id])
@@ -168,10 +328,44 @@ func (s *Server) GetHalfYearAttendance() (HalfYearAttendace, error) {
 		}
 		return -1
 	})
+	slices.SortStableFunc(excusedSessions, func(session1, session2 session.Session) int {
+		return session1.StartsAt.Compare(session2.StartsAt)
+	})
+
+	userIdSet := map[string]bool{}
+	for _, user := range users {
+		userIdSet[user.Id] = true
+	}
+	attendedSet := map[string]bool{}
+	for _, attendance := range convertedAttendances {
+		attendedSet[attendance.UserId+"/"+attendance.SessionId] = true
+	}
+	excusedAbsences := []excusedAbsence{}
+	for _, approved := range excuses {
+		if !userIdSet[approved.UserId] {
+			continue
+		}
+		for _, excusedSession := range excusedSessions {
+			key := approved.UserId + "/" + excusedSession.Id
+			if attendedSet[key] || !approved.Covers(excusedSession.Id, excusedSession.StartsAt) {
+				continue
+			}
+			// The session is excused once even if the excuses overlap.
+			attendedSet[key] = true
+			excusedAbsences = append(excusedAbsences, excusedAbsence{
+				UserId:            approved.UserId,
+				SessionId:         excusedSession.Id,
+				SessionScore:      excusedSession.Score,
+				ExcuseId:          approved.Id,
+				CountsTowardScore: approved.CountsTowardScore,
+			})
+		}
+	}
 
-	halfYearAttendace := HalfYearAttendace{
+	return HalfYearAttendace{
+		Term:     term,
 		Sessions: uniqueSessions,
-		Users: array.Map(activeUsers, func(user user.User) userForAttendance {
+		Users: array.Map(users, func(user user.User) userForAttendance {
 			return userForAttendance{
 				Id:         user.Id,
 				Name:       user.Name,
@@ -179,14 +373,38 @@ func (s *Server) GetHalfYearAttendance() (HalfYearAttendace, error) {
 			}
 		}),
 		Attendances: convertedAttendances,
+		Excuses:     excusedAbsences,
 	}
-	return halfYearAttendace, nil
 }
 
-// Marks the users as present for the given session.
+// Marks the users as present for the given session. The admin who called it is recorded as the one who set the status.
 // Fails if the session is already closed or the users are not active.
-// If forceApply is true, it will apply the attendance no matter what.
+// If forceApply is true, it will apply the attendance no matter what and the status is force_applied.
 func (s *Server) MarkUsersAsPresent(sessionId string, userIds []string, forceApply bool, calledBy string) error {
+	status := attendance.StatusOnTime
+	if forceApply {
+		status = attendance.StatusForceApplied
+	}
+	return s.markUsersAsPresent(sessionId, userIds, forceApply, status, "", calledBy)
+}
+
+// Applies the attendances of the users after the session has been closed with the status and the reason.
+// The status is force_applied if it's empty. E.g., "late" for the members who joined after the form was closed.
+func (s *Server) LateApplyAttendance(sessionId string, userIds []string, status attendance.Status, reason string, calledBy string) error {
+	if status == "" {
+		status = attendance.StatusForceApplied
+	}
+	if !status.IsValid() {
+		return newBadRequestError(fmt.Errorf("invalid status: %s", status))
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return newBadRequestError(errors.New("reason is required"))
+	}
+	return s.markUsersAsPresent(sessionId, userIds, true /* =forceApply */, status, reason, calledBy)
+}
+
+func (s *Server) markUsersAsPresent(sessionId string, userIds []string, forceApply bool, status attendance.Status, reason string, calledBy string) error {
 	// TODO(#223): Simplify the method. Refactor it.
 	dbSession, err := s.sessionRepo.Get(sessionId)
 	if err != nil {
@@ -234,7 +452,13 @@ func (s *Server) MarkUsersAsPresent(sessionId string, userIds []string, forceApp
 			len(usersToMark), strings.Join(array.Map(usersToMark, func(user user.User) string { return user.Id }), ",")))
 	}
 
-	if err := s.attendanceRepo.BulkInsert(array.Map(usersToMark, func(user user.User) attendance.AddAttendanceReq {
+	return s.applyAttendances(sessionId, array.Map(usersToMark, func(user user.User) attendance.AddAttendanceReq {
+		// The time when the users joined is unknown once the session is closed.
+		// Regard them as joined at the start so that it's not taken as the days of lateness.
+		joinedAt := dbSession.StartsAt
+		if !forceApply {
+			joinedAt = s.clock.Now()
+		}
 		return attendance.AddAttendanceReq{
 			SessionId:        sessionId,
 			SessionName:      dbSession.Name,
@@ -243,15 +467,31 @@ func (s *Server) MarkUsersAsPresent(sessionId string, userIds []string, forceApp
 			UserId:           user.Id,
 			UserExternalName: user.ExternalName,
 			UserGeneration:   user.Generation,
-			UserJoinedAt:     s.clock.Now(),
+			UserJoinedAt:     joinedAt,
 			CreatedBy:        calledBy,
-			ForceApply:       forceApply,
+			Status:           status,
+			StatusReason:     reason,
+			StatusSetBy:      calledBy,
+		}
+	}))
+}
+
+// Inserts the attendances and closes the session as if they are one write.
+// If closing the session fails, the inserted attendances are deleted so that they are not orphaned.
+func (s *Server) applyAttendances(sessionId string, requests []attendance.AddAttendanceReq) error {
+	ids, err := s.attendanceRepo.BulkInsert(requests)
+	if err != nil {
+		if errors.Is(err, attendance.ErrDuplicate) {
+			return newConflictError(fmt.Errorf("the attendance of the session has been applied by someone else: %w", err))
 		}
-	})); err != nil {
 		return newInternalServerError(fmt.Errorf("failed to bulk insert attendances: %w", err))
 	}
 
 	if err := s.openSessionRepo.MarkAsAttendanceApplied(sessionId); err != nil {
+		if deleteErr := s.attendanceRepo.Delete(ids); deleteErr != nil {
+			return newInternalServerError(fmt.Errorf("failed to close the session: %w and failed to delete the inserted attendances (%s): %v",
+				err, strings.Join(ids, ","), deleteErr))
+		}
 		return newInternalServerError(fmt.Errorf("failed to close the session: %w", err))
 	}
 
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"rush/attendance"
+	"rush/excuse"
 	"rush/session"
+	"rush/term"
 	"rush/user"
 	"testing"
 	"time"
@@ -18,10 +20,16 @@ func TestGetHalfYearAttendance(t *testing.T) {
 	t.Run("Fails if it fails to get users", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockUserRepo := NewMockuserRepo(controller)
-		server := New(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
-
+		mockTermRepo := NewMocktermRepo(controller)
+		server := New(Deps{
+			UserRepo: mockUserRepo,
+			TermRepo: mockTermRepo,
+			Clock:    clock.NewMock(),
+		})
+
+		mockTermRepo.EXPECT().GetByTime(gomock.Any()).Return(term.Term{}, term.ErrNotFound)
 		mockUserRepo.EXPECT().GetAllActive().Return(nil, errors.New("failed to get active users"))
-		_, err := server.GetHalfYearAttendance()
+		_, err := server.GetHalfYearAttendance("")
 
 		assert.Equal(t, newInternalServerError(fmt.Errorf("failed to get users: %w",
 			errors.New("failed to get active users"))), err)
@@ -31,13 +39,20 @@ func TestGetHalfYearAttendance(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockUserRepo := NewMockuserRepo(controller)
 		mockAttendanceRepo := NewMockattendanceRepo(controller)
-		server := New(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, mockAttendanceRepo, nil, nil)
-
+		mockTermRepo := NewMocktermRepo(controller)
+		server := New(Deps{
+			UserRepo:       mockUserRepo,
+			AttendanceRepo: mockAttendanceRepo,
+			TermRepo:       mockTermRepo,
+			Clock:          clock.NewMock(),
+		})
+
+		mockTermRepo.EXPECT().GetByTime(gomock.Any()).Return(term.Term{}, term.ErrNotFound)
 		mockUserRepo.EXPECT().GetAllActive().Return([]user.User{
 			{Id: "1", Name: "김건", Generation: 9},
 		}, nil)
 		mockAttendanceRepo.EXPECT().GetAll().Return(nil, errors.New("failed to get attendances"))
-		_, err := server.GetHalfYearAttendance()
+		_, err := server.GetHalfYearAttendance("")
 
 		assert.Equal(t, newInternalServerError(fmt.Errorf("failed to get all attendances: %w",
 			errors.New("failed to get attendances"))), err)
@@ -47,8 +62,18 @@ func TestGetHalfYearAttendance(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockUserRepo := NewMockuserRepo(controller)
 		mockAttendanceRepo := NewMockattendanceRepo(controller)
-		server := New(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, mockAttendanceRepo, nil, nil)
-
+		mockTermRepo := NewMocktermRepo(controller)
+		mockExcuseRepo := NewMockexcuseRepo(controller)
+		server := New(Deps{
+			UserRepo:       mockUserRepo,
+			AttendanceRepo: mockAttendanceRepo,
+			TermRepo:       mockTermRepo,
+			ExcuseRepo:     mockExcuseRepo,
+			Clock:          clock.NewMock(),
+		})
+
+		mockTermRepo.EXPECT().GetByTime(gomock.Any()).Return(term.Term{}, term.ErrNotFound)
+		mockExcuseRepo.EXPECT().FindByStatus(excuse.StatusApproved).Return([]excuse.Excuse{}, nil)
 		// Different generations, different names for the same generation.
 		mockUserRepo.EXPECT().GetAllActive().Return([]user.User{
 			{Id: "1", Name: "김건", Generation: 9, ExternalName: "김건ExName", IsActive: true},
@@ -101,7 +126,7 @@ func TestGetHalfYearAttendance(t *testing.T) {
 			},
 		}, nil)
 
-		halfYearAttendance, err := server.GetHalfYearAttendance()
+		halfYearAttendance, err := server.GetHalfYearAttendance("")
 		assert.NoError(t, err)
 		assert.Equal(t, HalfYearAttendace{
 			// Should be sorted by startedAt.
@@ -139,6 +164,106 @@ func TestGetHalfYearAttendance(t *testing.T) {
 					UserId:           "1", UserExternalName: "김건ExName", UserGeneration: 9,
 				},
 			},
+			Excuses: []excusedAbsence{},
+		}, halfYearAttendance)
+	})
+
+	t.Run("Fails if the given term is not found", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockTermRepo := NewMocktermRepo(controller)
+		server := New(Deps{TermRepo: mockTermRepo})
+
+		mockTermRepo.EXPECT().Get("term_id").Return(term.Term{}, term.ErrNotFound)
+		_, err := server.GetHalfYearAttendance("term_id")
+
+		assert.Equal(t, newNotFoundError(fmt.Errorf("failed to get term: %w", term.ErrNotFound)), err)
+	})
+
+	t.Run("Fails if it fails to get the current term", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockTermRepo := NewMocktermRepo(controller)
+		mockClock := clock.NewMock()
+		server := New(Deps{TermRepo: mockTermRepo, Clock: mockClock})
+
+		mockClock.Set(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		mockTermRepo.EXPECT().GetByTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).Return(term.Term{}, assert.AnError)
+		_, err := server.GetHalfYearAttendance("")
+
+		assert.Equal(t, newInternalServerError(fmt.Errorf("failed to get the current term: %w", assert.AnError)), err)
+	})
+
+	t.Run("Return the users of the term and the attendances within the term", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockUserRepo := NewMockuserRepo(controller)
+		mockAttendanceRepo := NewMockattendanceRepo(controller)
+		mockTermRepo := NewMocktermRepo(controller)
+		mockExcuseRepo := NewMockexcuseRepo(controller)
+		mockClock := clock.NewMock()
+		server := New(Deps{
+			UserRepo:       mockUserRepo,
+			AttendanceRepo: mockAttendanceRepo,
+			TermRepo:       mockTermRepo,
+			ExcuseRepo:     mockExcuseRepo,
+			Clock:          mockClock,
+		})
+		mockExcuseRepo.EXPECT().FindByStatus(excuse.StatusApproved).Return([]excuse.Excuse{}, nil)
+
+		dbTerm := term.Term{
+			Id:          "term_id",
+			Name:        "2024-1",
+			StartsAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:      time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			Generations: []float64{9, 10},
+		}
+		mockClock.Set(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		mockTermRepo.EXPECT().GetByTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).Return(dbTerm, nil)
+		mockUserRepo.EXPECT().GetAll().Return([]user.User{
+			{Id: "1", Name: "김건", Generation: 9, IsActive: true},
+			// Not a generation of the term.
+			{Id: "2", Name: "양현우", Generation: 8, IsActive: true},
+			// Inactive but attended in the term.
+			{Id: "3", Name: "강민경", Generation: 8, IsActive: false},
+			// Inactive and not attended.
+			{Id: "4", Name: "어떤10기", Generation: 10, IsActive: false},
+		}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionStartedAtBetween(dbTerm.StartsAt, dbTerm.EndsAt).Return([]attendance.Attendance{
+			{
+				Id:               "attendance_id_1",
+				SessionId:        "session_id_1",
+				SessionName:      "연트",
+				SessionScore:     2,
+				SessionStartedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				UserId:           "3",
+				UserExternalName: "강민경",
+				UserGeneration:   8,
+			},
+		}, nil)
+
+		halfYearAttendance, err := server.GetHalfYearAttendance("")
+		assert.NoError(t, err)
+		assert.Equal(t, HalfYearAttendace{
+			Term: &Term{
+				Id:          "term_id",
+				Name:        "2024-1",
+				StartsAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndsAt:      time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+				Generations: []float64{9, 10},
+			},
+			Sessions: []sessionForAttendance{
+				{Id: "session_id_1", Name: "연트", StartedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			Users: []userForAttendance{
+				{Id: "3", Name: "강민경", Generation: 8},
+				{Id: "1", Name: "김건", Generation: 9},
+			},
+			Attendances: []Attendance{
+				{Id: "attendance_id_1", SessionId: "session_id_1", SessionName: "연트",
+					SessionScore:     2,
+					SessionStartedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+					UserId:           "3", UserExternalName: "강민경", UserGeneration: 8,
+				},
+			},
+			Excuses: []excusedAbsence{},
 		}, halfYearAttendance)
 	})
 }
@@ -147,7 +272,7 @@ func TestMarkUsersAsPresent(t *testing.T) {
 	t.Run("Fails if it fails to get session", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockSessionRepo := NewMocksessionRepo(controller)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{}, errors.New("failed to get session"))
 		err := server.MarkUsersAsPresent("session_id", []string{"user_id"}, false /* =forceApply */, "caller")
@@ -159,7 +284,7 @@ func TestMarkUsersAsPresent(t *testing.T) {
 	t.Run("Fails if the session is already closed", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockSessionRepo := NewMocksessionRepo(controller)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		// TODO(#54): Fix it to mock the returned session's CanApplyAttendanceManually as it's hard to test.
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
@@ -175,7 +300,7 @@ func TestMarkUsersAsPresent(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockSessionRepo := NewMocksessionRepo(controller)
 		mockAttendanceRepo := NewMockattendanceRepo(controller)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, mockAttendanceRepo, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo, AttendanceRepo: mockAttendanceRepo})
 
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
 			Id:               "session_id",
@@ -193,7 +318,11 @@ func TestMarkUsersAsPresent(t *testing.T) {
 		mockSessionRepo := NewMocksessionRepo(controller)
 		mockAttendanceRepo := NewMockattendanceRepo(controller)
 		mockUserRepo := NewMockuserRepo(controller)
-		server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, nil, nil, mockAttendanceRepo, nil, nil)
+		server := New(Deps{
+			UserRepo:       mockUserRepo,
+			SessionRepo:    mockSessionRepo,
+			AttendanceRepo: mockAttendanceRepo,
+		})
 
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
 			Id:               "session_id",
@@ -214,7 +343,11 @@ func TestMarkUsersAsPresent(t *testing.T) {
 		mockSessionRepo := NewMocksessionRepo(controller)
 		mockAttendanceRepo := NewMockattendanceRepo(controller)
 		mockUserRepo := NewMockuserRepo(controller)
-		server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, nil, nil, mockAttendanceRepo, nil, nil)
+		server := New(Deps{
+			UserRepo:       mockUserRepo,
+			SessionRepo:    mockSessionRepo,
+			AttendanceRepo: mockAttendanceRepo,
+		})
 
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
 			Id:               "session_id",
@@ -241,7 +374,11 @@ func TestMarkUsersAsPresent(t *testing.T) {
 		mockSessionRepo := NewMocksessionRepo(controller)
 		mockAttendanceRepo := NewMockattendanceRepo(controller)
 		mockUserRepo := NewMockuserRepo(controller)
-		server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, nil, nil, mockAttendanceRepo, nil, nil)
+		server := New(Deps{
+			UserRepo:       mockUserRepo,
+			SessionRepo:    mockSessionRepo,
+			AttendanceRepo: mockAttendanceRepo,
+		})
 
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
 			Id:               "session_id",
@@ -253,20 +390,52 @@ func TestMarkUsersAsPresent(t *testing.T) {
 		mockUserRepo.EXPECT().GetAllActive().Return([]user.User{
 			{Id: "user_id_1", IsActive: true},
 		}, nil)
-		mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return(errors.New("failed to insert attendances"))
+		mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return(nil, errors.New("failed to insert attendances"))
 		err := server.MarkUsersAsPresent("session_id", []string{"user_id_1"}, false /* =forceApply */, "caller")
 
 		assert.Equal(t, newInternalServerError(fmt.Errorf("failed to bulk insert attendances: %w",
 			errors.New("failed to insert attendances"))), err)
 	})
 
+	t.Run("Returns conflict error if someone else has applied the attendance at the same time", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(controller)
+		mockAttendanceRepo := NewMockattendanceRepo(controller)
+		mockUserRepo := NewMockuserRepo(controller)
+		server := New(Deps{
+			UserRepo:       mockUserRepo,
+			SessionRepo:    mockSessionRepo,
+			AttendanceRepo: mockAttendanceRepo,
+			Clock:          clock.NewMock(),
+		})
+
+		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
+			Id:               "session_id",
+			AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+		}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionId("session_id").Return([]attendance.Attendance{}, nil)
+		mockUserRepo.EXPECT().GetAllActive().Return([]user.User{
+			{Id: "user_id_1", IsActive: true},
+		}, nil)
+		mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return(nil, attendance.ErrDuplicate)
+		err := server.MarkUsersAsPresent("session_id", []string{"user_id_1"}, false /* =forceApply */, "caller")
+
+		assert.Equal(t, newConflictError(fmt.Errorf("the attendance of the session has been applied by someone else: %w",
+			attendance.ErrDuplicate)), err)
+	})
+
 	t.Run("Fails if it fails to mark the session as attendance applied", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockSessionRepo := NewMocksessionRepo(controller)
 		mockAttendanceRepo := NewMockattendanceRepo(controller)
 		mockUserRepo := NewMockuserRepo(controller)
 		mockOpenSessionRepo := NewMockopenSessionRepo(controller)
-		server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo, nil, mockAttendanceRepo, nil, nil)
+		server := New(Deps{
+			UserRepo:        mockUserRepo,
+			SessionRepo:     mockSessionRepo,
+			OpenSessionRepo: mockOpenSessionRepo,
+			AttendanceRepo:  mockAttendanceRepo,
+		})
 
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
 			Id:               "session_id",
@@ -278,8 +447,10 @@ func TestMarkUsersAsPresent(t *testing.T) {
 		mockUserRepo.EXPECT().GetAllActive().Return([]user.User{
 			{Id: "user_id_1", IsActive: true},
 		}, nil)
-		mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return(nil)
+		mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return([]string{"attendance-id-1"}, nil)
 		mockOpenSessionRepo.EXPECT().MarkAsAttendanceApplied("session_id").Return(errors.New("failed to mark the session as attendance applied"))
+		// The inserted attendances are deleted not to be orphaned.
+		mockAttendanceRepo.EXPECT().Delete([]string{"attendance-id-1"}).Return(nil)
 		err := server.MarkUsersAsPresent("session_id", []string{"user_id_1"}, false /* =forceApply */, "caller")
 
 		assert.Equal(t, newInternalServerError(fmt.Errorf("failed to close the session: %w",
@@ -293,7 +464,13 @@ func TestMarkUsersAsPresent(t *testing.T) {
 		mockUserRepo := NewMockuserRepo(controller)
 		mockOpenSessionRepo := NewMockopenSessionRepo(controller)
 		mockClock := clock.NewMock()
-		server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo, nil, mockAttendanceRepo, nil, mockClock)
+		server := New(Deps{
+			UserRepo:        mockUserRepo,
+			SessionRepo:     mockSessionRepo,
+			OpenSessionRepo: mockOpenSessionRepo,
+			AttendanceRepo:  mockAttendanceRepo,
+			Clock:           mockClock,
+		})
 
 		mockClock.Set(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
@@ -325,8 +502,10 @@ func TestMarkUsersAsPresent(t *testing.T) {
 				UserGeneration:   9,
 				UserJoinedAt:     time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
 				CreatedBy:        "caller",
+				Status:           attendance.StatusOnTime,
+				StatusSetBy:      "caller",
 			},
-		}).Return(nil)
+		}).Return([]string{"attendance-id-1"}, nil)
 		mockOpenSessionRepo.EXPECT().MarkAsAttendanceApplied("session_id").Return(nil)
 
 		err := server.MarkUsersAsPresent("session_id", []string{"user_id_1", "user_id_2"}, false /* =forceApply */, "caller")
@@ -340,7 +519,13 @@ func TestMarkUsersAsPresent(t *testing.T) {
 		mockUserRepo := NewMockuserRepo(controller)
 		mockOpenSessionRepo := NewMockopenSessionRepo(controller)
 		mockClock := clock.NewMock()
-		server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo, nil, mockAttendanceRepo, nil, mockClock)
+		server := New(Deps{
+			UserRepo:        mockUserRepo,
+			SessionRepo:     mockSessionRepo,
+			OpenSessionRepo: mockOpenSessionRepo,
+			AttendanceRepo:  mockAttendanceRepo,
+			Clock:           mockClock,
+		})
 
 		mockClock.Set(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
 		mockSessionRepo.EXPECT().Get("session_id").Return(session.Session{
@@ -366,12 +551,52 @@ func TestMarkUsersAsPresent(t *testing.T) {
 				UserGeneration:   9,
 				UserJoinedAt:     time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
 				CreatedBy:        "caller",
-				ForceApply:       true,
+				Status:           attendance.StatusForceApplied,
+				StatusSetBy:      "caller",
 			},
-		}).Return(nil)
+		}).Return([]string{"attendance-id-1"}, nil)
 		mockOpenSessionRepo.EXPECT().MarkAsAttendanceApplied("session_id").Return(nil)
 
 		err := server.MarkUsersAsPresent("session_id", []string{"user_id_1"}, true /* =forceApply */, "caller")
 		assert.NoError(t, err)
 	})
 }
+
+func TestLateApplyAttendance(t *testing.T) {
+	t.Run("Fails without the reason or with an invalid status", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+
+		err := server.LateApplyAttendance(sessionId, []string{userId}, attendance.StatusLate, " ", "admin-id")
+		assert.Equal(t, newBadRequestError(errors.New("reason is required")), err)
+
+		err = server.LateApplyAttendance(sessionId, []string{userId}, "absent", "늦게 도착", "admin-id")
+		assert.Equal(t, newBadRequestError(errors.New("invalid status: absent")), err)
+	})
+
+	t.Run("Applies the attendances of the closed session with the status, the reason and the admin", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{}, false, "admin-id"))
+
+		mockClock.Add(time.Hour)
+		assert.NoError(t, server.LateApplyAttendance(sessionId, []string{userId}, attendance.StatusLate, " 폼 마감 후 도착 ", "admin-id"))
+
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, attendance.StatusLate, attendances[0].Status)
+		assert.Equal(t, "폼 마감 후 도착", attendances[0].StatusReason)
+		assert.Equal(t, "admin-id", attendances[0].StatusSetBy)
+		// The time when the user joined is unknown after the session is closed.
+		assert.Equal(t, checkInSessionStartsAt, attendances[0].UserJoinedAt)
+	})
+
+	t.Run("Applies the attendances as force-applied without the status", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+
+		assert.NoError(t, server.LateApplyAttendance(sessionId, []string{userId}, "", "출석 누락", "admin-id"))
+
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, attendance.StatusForceApplied, attendances[0].Status)
+	})
+}
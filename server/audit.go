@@ -0,0 +1,196 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/attendance"
+	"rush/audit"
+	"rush/golang/array"
+	"rush/session"
+	"strings"
+	"time"
+)
+
+// The correction of an attendance of an applied session. The fields left empty are kept as they are.
+type CorrectAttendanceReq struct {
+	// The time in UTC when the user actually joined the session.
+	UserJoinedAt *time.Time `json:"user_joined_at"`
+	// How the user actually attended the session. E.g., "on_time"
+	Status attendance.Status `json:"status"`
+	// Why the admin corrects it. It's required and shown to the member. E.g., "체크인 시간 오류"
+	Reason string `json:"reason"`
+}
+
+// Removes the wrong attendance of an applied session and records it in the audit log with the reason.
+// E.g., a member checked in for another member. The reliability of the session is evaluated again with the change.
+func (s *Server) RemoveAttendance(attendanceId string, reason string, calledBy string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return newBadRequestError(errors.New("reason is required"))
+	}
+	dbAttendance, err := s.getAttendanceOfAppliedSession(attendanceId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attendanceRepo.Delete([]string{dbAttendance.Id}); err != nil {
+		return newInternalServerError(fmt.Errorf("failed to delete attendance: %w", err))
+	}
+	if _, err := s.auditRepo.Add(audit.Entry{
+		Action:    audit.ActionAttendanceRemoved,
+		UserId:    dbAttendance.UserId,
+		SessionId: dbAttendance.SessionId,
+		TargetId:  dbAttendance.Id,
+		Reason:    reason,
+		Changes: []audit.Change{
+			{Field: "user_joined_at", Before: formatAuditTime(dbAttendance.UserJoinedAt)},
+			{Field: "status", Before: string(dbAttendance.Status)},
+		},
+		ActorId:   calledBy,
+		CreatedAt: s.clock.Now(),
+	}); err != nil {
+		// The removal that nobody can see is worse than failing it. Put it back with its ID so that it can be removed again.
+		if restoreErr := s.attendanceRepo.Restore(dbAttendance); restoreErr != nil {
+			return newInternalServerError(fmt.Errorf("failed to add audit entry: %w and failed to restore the attendance: %v", err, restoreErr))
+		}
+		return newInternalServerError(fmt.Errorf("failed to add audit entry: %w", err))
+	}
+	return s.evaluateCorrectedSessionReliability(dbAttendance.SessionId)
+}
+
+// Corrects the joined time or the status of an attendance of an applied session and records it in the audit log with the reason.
+// The admin is recorded as the one who set the status when the status is corrected.
+// The reliability of the session is evaluated again with the change.
+func (s *Server) CorrectAttendance(attendanceId string, req CorrectAttendanceReq, calledBy string) (Attendance, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return Attendance{}, newBadRequestError(errors.New("reason is required"))
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return Attendance{}, newBadRequestError(fmt.Errorf("invalid status: %s", req.Status))
+	}
+	dbAttendance, err := s.getAttendanceOfAppliedSession(attendanceId)
+	if err != nil {
+		return Attendance{}, err
+	}
+
+	updateForm := attendance.UpdateAttendanceForm{}
+	// The form that puts the changed fields back when the audit log fails.
+	undoForm := attendance.UpdateAttendanceForm{}
+	changes := []audit.Change{}
+	if req.UserJoinedAt != nil && !req.UserJoinedAt.Equal(dbAttendance.UserJoinedAt) {
+		updateForm.UserJoinedAt = req.UserJoinedAt
+		undoForm.UserJoinedAt = &dbAttendance.UserJoinedAt
+		changes = append(changes, audit.Change{
+			Field:  "user_joined_at",
+			Before: formatAuditTime(dbAttendance.UserJoinedAt),
+			After:  formatAuditTime(*req.UserJoinedAt),
+		})
+	}
+	if req.Status != "" && req.Status != dbAttendance.Status {
+		updateForm.Status = &req.Status
+		updateForm.StatusReason = &reason
+		updateForm.StatusSetBy = &calledBy
+		undoForm.Status = &dbAttendance.Status
+		undoForm.StatusReason = &dbAttendance.StatusReason
+		undoForm.StatusSetBy = &dbAttendance.StatusSetBy
+		changes = append(changes, audit.Change{Field: "status", Before: string(dbAttendance.Status), After: string(req.Status)})
+	}
+	if len(changes) == 0 {
+		return Attendance{}, newBadRequestError(errors.New("nothing to correct"))
+	}
+
+	if err := s.attendanceRepo.Update(dbAttendance.Id, updateForm); err != nil {
+		if errors.Is(err, attendance.ErrNotFound) {
+			return Attendance{}, newConflictError(fmt.Errorf("attendance has been removed by someone else: %w", err))
+		}
+		return Attendance{}, newInternalServerError(fmt.Errorf("failed to update attendance: %w", err))
+	}
+	if _, err := s.auditRepo.Add(audit.Entry{
+		Action:    audit.ActionAttendanceCorrected,
+		UserId:    dbAttendance.UserId,
+		SessionId: dbAttendance.SessionId,
+		TargetId:  dbAttendance.Id,
+		Reason:    reason,
+		Changes:   changes,
+		ActorId:   calledBy,
+		CreatedAt: s.clock.Now(),
+	}); err != nil {
+		if undoErr := s.attendanceRepo.Update(dbAttendance.Id, undoForm); undoErr != nil {
+			return Attendance{}, newInternalServerError(fmt.Errorf("failed to add audit entry: %w and failed to undo the correction: %v", err, undoErr))
+		}
+		return Attendance{}, newInternalServerError(fmt.Errorf("failed to add audit entry: %w", err))
+	}
+
+	if err := s.evaluateCorrectedSessionReliability(dbAttendance.SessionId); err != nil {
+		return Attendance{}, err
+	}
+	corrected, err := s.attendanceRepo.Get(dbAttendance.Id)
+	if err != nil {
+		return Attendance{}, newInternalServerError(fmt.Errorf("failed to get the corrected attendance: %w", err))
+	}
+	return *fromAttendance(&corrected), nil
+}
+
+// Returns the changes that the admins made to the records of the member.
+func (s *Server) ListMyAuditEntries(userId string) ([]AuditEntry, error) {
+	entries, err := s.auditRepo.FindByUserId(userId)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the audit entries of the user: %w", err))
+	}
+	return array.Map(entries, fromAuditEntry), nil
+}
+
+// Returns the changes that the admins made to the attendances of the session.
+func (s *Server) AdminListSessionAuditEntries(sessionId string) ([]AuditEntry, error) {
+	entries, err := s.auditRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the audit entries of the session: %w", err))
+	}
+	return array.Map(entries, fromAuditEntry), nil
+}
+
+// Returns the changes that the admins made to the records of the user. E.g., their attendances and their role.
+func (s *Server) AdminListUserAuditEntries(userId string) ([]AuditEntry, error) {
+	return s.ListMyAuditEntries(userId)
+}
+
+// Returns the attendance if its session is applied. The attendances of the other sessions are changed by applying them again.
+func (s *Server) getAttendanceOfAppliedSession(attendanceId string) (attendance.Attendance, error) {
+	dbAttendance, err := s.attendanceRepo.Get(attendanceId)
+	if err != nil {
+		if errors.Is(err, attendance.ErrNotFound) {
+			return attendance.Attendance{}, newNotFoundError(fmt.Errorf("failed to get attendance: %w", err))
+		}
+		return attendance.Attendance{}, newInternalServerError(fmt.Errorf("failed to get attendance: %w", err))
+	}
+
+	dbSession, err := s.sessionRepo.Get(dbAttendance.SessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return attendance.Attendance{}, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return attendance.Attendance{}, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if dbSession.AttendanceStatus != session.AttendanceStatusApplied {
+		return attendance.Attendance{}, newBadRequestError(errors.New("session's attendance is not applied yet"))
+	}
+	return dbAttendance, nil
+}
+
+// Evaluates the reliability of the session again after its attendance is corrected.
+// The job only evaluates the recent sessions again, so the corrections of the older sessions would be missed.
+func (s *Server) evaluateCorrectedSessionReliability(sessionId string) error {
+	if err := s.EvaluateSessionReliability(sessionId); err != nil {
+		return newInternalServerError(fmt.Errorf("attendance is corrected but failed to evaluate the reliability of the session: %w", err))
+	}
+	return nil
+}
+
+// Formats the time in the audit log. The zero time is empty.
+func formatAuditTime(at time.Time) string {
+	if at.IsZero() {
+		return ""
+	}
+	return at.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"errors"
+	"rush/attendance"
+	"rush/audit"
+	"rush/reliability"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fails to add any entry.
+type failingAuditRepo struct {
+	auditRepo
+}
+
+func (r failingAuditRepo) Add(entry audit.Entry) (string, error) {
+	return "", assert.AnError
+}
+
+func TestCorrectAndRemoveAttendance(t *testing.T) {
+	// Returns the server whose session is applied with the late check-in of the user.
+	newAppliedServer := func(t *testing.T) (*Server, string, string, string) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+		mockClock.Add(10 * time.Second)
+		assert.NoError(t, server.CheckIn(sessionId, userId, code.Code))
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		return server, sessionId, userId, attendances[0].Id
+	}
+
+	t.Run("Fails to change the attendance of the session that is not applied yet", func(t *testing.T) {
+		server, _, _, attendanceId := newAppliedServer(t)
+
+		err := server.RemoveAttendance(attendanceId, "잘못 체크인", "admin-id")
+
+		assert.Equal(t, newBadRequestError(errors.New("session's attendance is not applied yet")), err)
+	})
+
+	t.Run("Fails without the reason or any change", func(t *testing.T) {
+		server, sessionId, _, attendanceId := newAppliedServer(t)
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{}, false, "admin-id"))
+
+		_, err := server.CorrectAttendance(attendanceId, CorrectAttendanceReq{Status: attendance.StatusOnTime}, "admin-id")
+		assert.Equal(t, newBadRequestError(errors.New("reason is required")), err)
+
+		_, err = server.CorrectAttendance(attendanceId, CorrectAttendanceReq{Status: attendance.StatusLate, Reason: "확인"}, "admin-id")
+		assert.Equal(t, newBadRequestError(errors.New("nothing to correct")), err)
+
+		var notFoundError *NotFoundError
+		assert.ErrorAs(t, server.RemoveAttendance("unknown-id", "중복", "admin-id"), &notFoundError)
+	})
+
+	t.Run("Corrects and removes the attendance with the audit entries that the member can see", func(t *testing.T) {
+		server, sessionId, userId, attendanceId := newAppliedServer(t)
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{}, false, "admin-id"))
+
+		joinedAt := checkInSessionStartsAt.Add(-time.Minute)
+		corrected, err := server.CorrectAttendance(attendanceId, CorrectAttendanceReq{
+			UserJoinedAt: &joinedAt,
+			Status:       attendance.StatusOnTime,
+			Reason:       " 체크인 코드 오류 ",
+		}, "admin-id")
+		assert.NoError(t, err)
+		assert.Equal(t, joinedAt, corrected.UserJoinedAt)
+		assert.Equal(t, attendance.StatusOnTime, corrected.Status)
+		assert.Equal(t, "체크인 코드 오류", corrected.StatusReason)
+		assert.Equal(t, "admin-id", corrected.StatusSetBy)
+
+		assert.NoError(t, server.RemoveAttendance(attendanceId, "다른 회원으로 잘못 체크인", "admin-id"))
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Empty(t, attendances)
+
+		entries, err := server.ListMyAuditEntries(userId)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, audit.ActionAttendanceCorrected, entries[0].Action)
+		assert.Equal(t, "체크인 코드 오류", entries[0].Reason)
+		assert.Equal(t, []audit.Change{
+			{Field: "user_joined_at", Before: "2025-07-01T20:00:10Z", After: "2025-07-01T19:59:00Z"},
+			{Field: "status", Before: "late", After: "on_time"},
+		}, entries[0].Changes)
+		assert.Equal(t, AuditEntry{
+			Id:        entries[1].Id,
+			Action:    audit.ActionAttendanceRemoved,
+			UserId:    userId,
+			SessionId: sessionId,
+			TargetId:  attendanceId,
+			Reason:    "다른 회원으로 잘못 체크인",
+			Changes: []audit.Change{
+				{Field: "user_joined_at", Before: "2025-07-01T19:59:00Z"},
+				{Field: "status", Before: "on_time"},
+			},
+			ActorId:   "admin-id",
+			CreatedAt: checkInSessionStartsAt.Add(10 * time.Second),
+		}, entries[1])
+
+		sessionEntries, err := server.AdminListSessionAuditEntries(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, entries, sessionEntries)
+	})
+
+	t.Run("Restores the removed attendance with its ID when failed to add the audit entry", func(t *testing.T) {
+		server, sessionId, _, attendanceId := newAppliedServer(t)
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{}, false, "admin-id"))
+		removed, err := server.attendanceRepo.Get(attendanceId)
+		assert.NoError(t, err)
+		server.auditRepo = failingAuditRepo{auditRepo: server.auditRepo}
+
+		err = server.RemoveAttendance(attendanceId, "다른 회원으로 잘못 체크인", "admin-id")
+
+		var internalServerError *InternalServerError
+		assert.ErrorAs(t, err, &internalServerError)
+		restored, err := server.attendanceRepo.Get(attendanceId)
+		assert.NoError(t, err)
+		assert.Equal(t, removed, restored)
+	})
+
+	t.Run("Evaluates the reliability of the session again after the change", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		mockClock.Set(checkInSessionStartsAt.Add(-time.Hour))
+		_, err := server.RsvpToSession(sessionId, userId)
+		assert.NoError(t, err)
+		mockClock.Set(checkInSessionStartsAt)
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{userId}, false, "admin-id"))
+		assert.NoError(t, server.EvaluateSessionReliability(sessionId))
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+
+		assert.NoError(t, server.RemoveAttendance(attendances[0].Id, "다른 회원으로 잘못 체크인", "admin-id"))
+
+		removedUser, err := server.AdminGetUser(userId)
+		assert.NoError(t, err)
+		assert.Equal(t, reliability.Score{SignUps: 1, NoShows: 1, Score: 0}, removedUser.Reliability)
+	})
+}
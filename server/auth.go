@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"rush/auth"
+	"rush/permission"
 	"rush/user"
 	"time"
 )
@@ -24,7 +25,7 @@ func (s *Server) SignIn(token string) (string, error) {
 		return "", newInternalServerError(fmt.Errorf("failed to get user by email (%s): %w", email, err))
 	}
 
-	rushToken, err := s.authHandler.SignIn(dbUser.Id, dbUser.Role)
+	rushToken, _, err := s.issueToken(dbUser.Id, dbUser.Role)
 	if err != nil {
 		return "", newInternalServerError(fmt.Errorf("failed to sign in: %w", err))
 	}
@@ -32,6 +33,23 @@ func (s *Server) SignIn(token string) (string, error) {
 	return rushToken, nil
 }
 
+// Signs in the user and keeps the issued token so that it can be revoked.
+func (s *Server) issueToken(userId string, role permission.Role) (string, auth.Session, error) {
+	rushToken, session, err := s.authHandler.SignIn(userId, role)
+	if err != nil {
+		return "", auth.Session{}, err
+	}
+	if err := s.tokenRepo.Add(auth.Token{
+		Id:        session.TokenId,
+		UserId:    userId,
+		IssuedAt:  s.clock.Now(),
+		ExpiresAt: session.ExpiresAt,
+	}); err != nil {
+		return "", auth.Session{}, fmt.Errorf("failed to add token (%s): %w", session.TokenId, err)
+	}
+	return rushToken, session, nil
+}
+
 // Returns the user session and the new token if it was refreshed.
 func (s *Server) GetUserSession(token string) (UserSession, string, error) {
 	session, err := s.authHandler.GetSession(token)
@@ -47,21 +65,73 @@ func (s *Server) GetUserSession(token string) (UserSession, string, error) {
 		return UserSession{}, "", newInternalServerError(fmt.Errorf("failed to get user session: %w", err))
 	}
 
+	// The admin token has no ID and can't be revoked.
+	if session.TokenId != "" {
+		storedToken, err := s.tokenRepo.Get(session.TokenId)
+		if err != nil {
+			if errors.Is(err, auth.ErrNotFound) {
+				return UserSession{}, "", newBadRequestError(fmt.Errorf("unknown token (%s): %w", session.TokenId, err))
+			}
+			return UserSession{}, "", newInternalServerError(fmt.Errorf("failed to get token (%s): %w", session.TokenId, err))
+		}
+		if storedToken.RevokedAt != nil {
+			return UserSession{}, "", newBadRequestError(fmt.Errorf("token is revoked (%s)", session.TokenId))
+		}
+	}
+
 	if session.ExpiresAt.Sub(s.clock.Now()) > 24*time.Hour {
 		return UserSession{
 			UserId:    session.Id,
+			TokenId:   session.TokenId,
 			Role:      session.Role,
 			ExpiresAt: session.ExpiresAt,
 		}, token, nil
 	}
 
-	newToken, err := s.authHandler.SignIn(session.Id, session.Role)
+	// The client replaces its token with the new one, so the session refers to the new token from now on.
+	// The presented token isn't revoked because the requests sent in parallel still carry it until the client replaces it.
+	// It expires on its own within 24 hours.
+	newToken, newSession, err := s.issueToken(session.Id, session.Role)
 	if err != nil {
 		return UserSession{}, "", newInternalServerError(fmt.Errorf("failed to refresh token: %w", err))
 	}
 	return UserSession{
 		UserId:    session.Id,
+		TokenId:   newSession.TokenId,
 		Role:      session.Role,
 		ExpiresAt: session.ExpiresAt,
 	}, newToken, nil
 }
+
+// Revokes the token so that the session can't be used anymore.
+func (s *Server) SignOut(tokenId string) error {
+	if tokenId == "" {
+		return newBadRequestError(errors.New("session has no token to revoke"))
+	}
+	if err := s.tokenRepo.Revoke(tokenId, s.clock.Now()); err != nil {
+		if errors.Is(err, auth.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to revoke token (%s): %w", tokenId, err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to revoke token (%s): %w", tokenId, err))
+	}
+	return nil
+}
+
+// Revokes all the tokens of the user so that the user is signed out on every device.
+func (s *Server) SignOutEverywhere(userId string) error {
+	if err := s.tokenRepo.RevokeAllByUserId(userId, s.clock.Now()); err != nil {
+		return newInternalServerError(fmt.Errorf("failed to revoke tokens of user (%s): %w", userId, err))
+	}
+	return nil
+}
+
+// Signs the user out on every device on behalf of the user. E.g., when the device is lost.
+func (s *Server) AdminSignOutUser(userId string) error {
+	if _, err := s.userRepo.Get(userId); err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get user (%s): %w", userId, err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get user (%s): %w", userId, err))
+	}
+	return s.SignOutEverywhere(userId)
+}
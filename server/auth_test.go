@@ -18,7 +18,7 @@ func TestSignIn(t *testing.T) {
 	t.Run("Returns bad request error if failed to get user identifier", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockOauthClient := NewMockoauthClient(controller)
-		server := New(mockOauthClient, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{OauthClient: mockOauthClient})
 
 		mockOauthClient.EXPECT().GetEmail("token").Return("", assert.AnError)
 		token, err := server.SignIn("token")
@@ -31,7 +31,7 @@ func TestSignIn(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockOauthClient := NewMockoauthClient(controller)
 		mockUserRepo := NewMockuserRepo(controller)
-		server := New(mockOauthClient, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{OauthClient: mockOauthClient, UserRepo: mockUserRepo})
 
 		mockOauthClient.EXPECT().GetEmail("token").Return("email@example.com", nil)
 		mockUserRepo.EXPECT().GetByEmail("email@example.com").Return(nil, user.ErrNotFound)
@@ -45,7 +45,7 @@ func TestSignIn(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockOauthClient := NewMockoauthClient(controller)
 		mockUserRepo := NewMockuserRepo(controller)
-		server := New(mockOauthClient, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{OauthClient: mockOauthClient, UserRepo: mockUserRepo})
 
 		mockOauthClient.EXPECT().GetEmail("token").Return("email@example.com", nil)
 		mockUserRepo.EXPECT().GetByEmail("email@example.com").Return(nil, assert.AnError)
@@ -60,14 +60,18 @@ func TestSignIn(t *testing.T) {
 		mockOauthClient := NewMockoauthClient(controller)
 		mockUserRepo := NewMockuserRepo(controller)
 		mockAuthHandler := NewMockauthHandler(controller)
-		server := New(mockOauthClient, mockAuthHandler, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{
+			OauthClient: mockOauthClient,
+			AuthHandler: mockAuthHandler,
+			UserRepo:    mockUserRepo,
+		})
 
 		mockOauthClient.EXPECT().GetEmail("token").Return("email@example.com", nil)
 		mockUserRepo.EXPECT().GetByEmail("email@example.com").Return(&user.User{
 			Id:   "user_id",
 			Role: permission.RoleMember,
 		}, nil)
-		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("", assert.AnError)
+		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("", auth.Session{}, assert.AnError)
 		token, err := server.SignIn("token")
 
 		assert.Equal(t, "", token)
@@ -79,26 +83,73 @@ func TestSignIn(t *testing.T) {
 		mockOauthClient := NewMockoauthClient(controller)
 		mockUserRepo := NewMockuserRepo(controller)
 		mockAuthHandler := NewMockauthHandler(controller)
-		server := New(mockOauthClient, mockAuthHandler, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
-
+		mockTokenRepo := NewMocktokenRepo(controller)
+		mockClock := clock.NewMock()
+		server := New(Deps{
+			OauthClient: mockOauthClient,
+			AuthHandler: mockAuthHandler,
+			UserRepo:    mockUserRepo,
+			TokenRepo:   mockTokenRepo,
+			Clock:       mockClock,
+		})
+
+		mockClock.Set(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
 		mockOauthClient.EXPECT().GetEmail("token").Return("email@example.com", nil)
 		mockUserRepo.EXPECT().GetByEmail("email@example.com").Return(&user.User{
 			Id:   "user_id",
 			Role: permission.RoleMember,
 		}, nil)
-		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("rush_token", nil)
+		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("rush_token", auth.Session{
+			Id:        "user_id",
+			TokenId:   "token_id",
+			Role:      permission.RoleMember,
+			ExpiresAt: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		}, nil)
+		mockTokenRepo.EXPECT().Add(auth.Token{
+			Id:        "token_id",
+			UserId:    "user_id",
+			IssuedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		}).Return(nil)
 		token, err := server.SignIn("token")
 
 		assert.Equal(t, "rush_token", token)
 		assert.Nil(t, err)
 	})
+
+	t.Run("Returns internal server error if failed to keep the token", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockOauthClient := NewMockoauthClient(controller)
+		mockUserRepo := NewMockuserRepo(controller)
+		mockAuthHandler := NewMockauthHandler(controller)
+		mockTokenRepo := NewMocktokenRepo(controller)
+		server := New(Deps{
+			OauthClient: mockOauthClient,
+			AuthHandler: mockAuthHandler,
+			UserRepo:    mockUserRepo,
+			TokenRepo:   mockTokenRepo,
+			Clock:       clock.NewMock(),
+		})
+
+		mockOauthClient.EXPECT().GetEmail("token").Return("email@example.com", nil)
+		mockUserRepo.EXPECT().GetByEmail("email@example.com").Return(&user.User{
+			Id:   "user_id",
+			Role: permission.RoleMember,
+		}, nil)
+		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("rush_token", auth.Session{TokenId: "token_id"}, nil)
+		mockTokenRepo.EXPECT().Add(gomock.Any()).Return(assert.AnError)
+		token, err := server.SignIn("token")
+
+		assert.Equal(t, "", token)
+		assert.Equal(t, newInternalServerError(fmt.Errorf("failed to sign in: %w", fmt.Errorf("failed to add token (%s): %w", "token_id", assert.AnError))), err)
+	})
 }
 
 func TestGetUserSession(t *testing.T) {
 	t.Run("Returns bad request error if auth handler returns token expired error", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockAuthHandler := NewMockauthHandler(controller)
-		server := New(nil, mockAuthHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{AuthHandler: mockAuthHandler})
 
 		mockAuthHandler.EXPECT().GetSession("token").Return(auth.Session{}, &auth.TokenExpiredError{})
 		userSession, newToken, err := server.GetUserSession("token")
@@ -111,7 +162,7 @@ func TestGetUserSession(t *testing.T) {
 	t.Run("Returns bad request error if auth handler returns invalid token error", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockAuthHandler := NewMockauthHandler(controller)
-		server := New(nil, mockAuthHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{AuthHandler: mockAuthHandler})
 
 		mockAuthHandler.EXPECT().GetSession("token").Return(auth.Session{}, &auth.InvalidTokenError{})
 		userSession, newToken, err := server.GetUserSession("token")
@@ -124,7 +175,7 @@ func TestGetUserSession(t *testing.T) {
 	t.Run("Returns internal server error if auth handler returns other error", func(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockAuthHandler := NewMockauthHandler(controller)
-		server := New(nil, mockAuthHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{AuthHandler: mockAuthHandler})
 
 		mockAuthHandler.EXPECT().GetSession("token").Return(auth.Session{}, errors.New("unknown error"))
 		userSession, newToken, err := server.GetUserSession("token")
@@ -138,7 +189,12 @@ func TestGetUserSession(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockAuthHandler := NewMockauthHandler(controller)
 		mockClock := clock.NewMock()
-		server := New(nil, mockAuthHandler, nil, nil, nil, nil, nil, nil, nil, nil, mockClock)
+		tokenRepo := auth.NewMemoryRepo()
+		server := New(Deps{
+			AuthHandler: mockAuthHandler,
+			TokenRepo:   tokenRepo,
+			Clock:       mockClock,
+		})
 
 		mockClock.Set(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
 		mockAuthHandler.EXPECT().GetSession("token").Return(auth.Session{
@@ -146,11 +202,17 @@ func TestGetUserSession(t *testing.T) {
 			Role:      permission.RoleMember,
 			ExpiresAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 		}, nil)
-		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("new_token", nil)
+		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("new_token", auth.Session{
+			Id:        "user_id",
+			TokenId:   "new_token_id",
+			Role:      permission.RoleMember,
+			ExpiresAt: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		}, nil)
 		userSession, newToken, err := server.GetUserSession("token")
 
 		assert.Equal(t, UserSession{
 			UserId:    "user_id",
+			TokenId:   "new_token_id",
 			Role:      permission.RoleMember,
 			ExpiresAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 		}, userSession)
@@ -162,7 +224,7 @@ func TestGetUserSession(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockAuthHandler := NewMockauthHandler(controller)
 		mockClock := clock.NewMock()
-		server := New(nil, mockAuthHandler, nil, nil, nil, nil, nil, nil, nil, nil, mockClock)
+		server := New(Deps{AuthHandler: mockAuthHandler, Clock: mockClock})
 
 		mockClock.Set(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
 		mockAuthHandler.EXPECT().GetSession("token").Return(auth.Session{
@@ -170,7 +232,7 @@ func TestGetUserSession(t *testing.T) {
 			Role:      permission.RoleMember,
 			ExpiresAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 		}, nil)
-		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("", errors.New("unknown error"))
+		mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return("", auth.Session{}, errors.New("unknown error"))
 		userSession, newToken, err := server.GetUserSession("token")
 
 		assert.Equal(t, UserSession{}, userSession)
@@ -182,7 +244,7 @@ func TestGetUserSession(t *testing.T) {
 		controller := gomock.NewController(t)
 		mockAuthHandler := NewMockauthHandler(controller)
 		mockClock := clock.NewMock()
-		server := New(nil, mockAuthHandler, nil, nil, nil, nil, nil, nil, nil, nil, mockClock)
+		server := New(Deps{AuthHandler: mockAuthHandler, Clock: mockClock})
 
 		mockClock.Set(time.Date(2023, 12, 30, 23, 59, 59, 0, time.UTC))
 		mockAuthHandler.EXPECT().GetSession("token").Return(auth.Session{
@@ -201,3 +263,107 @@ func TestGetUserSession(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestRevokeToken(t *testing.T) {
+	// Returns the server with the memory token repo and the mock auth handler that issues the given token IDs in order.
+	newTokenServer := func(t *testing.T, tokenIds ...string) (*Server, *MockauthHandler, *clock.Mock) {
+		controller := gomock.NewController(t)
+		mockAuthHandler := NewMockauthHandler(controller)
+		userRepo := user.NewMemoryRepo()
+		mockClock := clock.NewMock()
+		mockClock.Set(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		server := New(Deps{
+			AuthHandler: mockAuthHandler,
+			UserRepo:    userRepo,
+			TokenRepo:   auth.NewMemoryRepo(),
+			Clock:       mockClock,
+		})
+		for _, tokenId := range tokenIds {
+			mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return(tokenId, auth.Session{
+				Id:        "user_id",
+				TokenId:   tokenId,
+				Role:      permission.RoleMember,
+				ExpiresAt: mockClock.Now().Add(7 * 24 * time.Hour),
+			}, nil)
+			_, _, err := server.issueToken("user_id", permission.RoleMember)
+			assert.NoError(t, err)
+		}
+		return server, mockAuthHandler, mockClock
+	}
+	expectSession := func(mockAuthHandler *MockauthHandler, mockClock *clock.Mock, tokenId string) {
+		mockAuthHandler.EXPECT().GetSession(tokenId).Return(auth.Session{
+			Id:        "user_id",
+			TokenId:   tokenId,
+			Role:      permission.RoleMember,
+			ExpiresAt: mockClock.Now().Add(7 * 24 * time.Hour),
+		}, nil)
+	}
+
+	t.Run("Rejects the token after signing out of its session only", func(t *testing.T) {
+		server, mockAuthHandler, mockClock := newTokenServer(t, "token_1", "token_2")
+
+		assert.NoError(t, server.SignOut("token_1"))
+
+		expectSession(mockAuthHandler, mockClock, "token_1")
+		_, _, err := server.GetUserSession("token_1")
+		assert.Equal(t, newBadRequestError(errors.New("token is revoked (token_1)")), err)
+		expectSession(mockAuthHandler, mockClock, "token_2")
+		userSession, _, err := server.GetUserSession("token_2")
+		assert.NoError(t, err)
+		assert.Equal(t, "token_2", userSession.TokenId)
+	})
+
+	t.Run("Keeps the presented token valid after refreshing it for the requests sent in parallel", func(t *testing.T) {
+		server, mockAuthHandler, mockClock := newTokenServer(t, "token_1")
+		mockClock.Add(7*24*time.Hour - time.Hour)
+
+		for _, newTokenId := range []string{"token_2", "token_3"} {
+			mockAuthHandler.EXPECT().GetSession("token_1").Return(auth.Session{
+				Id:        "user_id",
+				TokenId:   "token_1",
+				Role:      permission.RoleMember,
+				ExpiresAt: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+			}, nil)
+			mockAuthHandler.EXPECT().SignIn("user_id", permission.RoleMember).Return(newTokenId, auth.Session{
+				Id:        "user_id",
+				TokenId:   newTokenId,
+				Role:      permission.RoleMember,
+				ExpiresAt: mockClock.Now().Add(7 * 24 * time.Hour),
+			}, nil)
+			userSession, newToken, err := server.GetUserSession("token_1")
+			assert.NoError(t, err)
+			assert.Equal(t, newTokenId, newToken)
+			assert.Equal(t, newTokenId, userSession.TokenId)
+		}
+	})
+
+	t.Run("Rejects the token that the server didn't issue", func(t *testing.T) {
+		server, mockAuthHandler, mockClock := newTokenServer(t)
+
+		expectSession(mockAuthHandler, mockClock, "token_1")
+		_, _, err := server.GetUserSession("token_1")
+
+		assert.True(t, isBadRequestError(err))
+		var notFoundError *NotFoundError
+		assert.ErrorAs(t, server.SignOut("token_1"), &notFoundError)
+	})
+
+	t.Run("Rejects every token of the user after signing out everywhere", func(t *testing.T) {
+		server, mockAuthHandler, mockClock := newTokenServer(t, "token_1", "token_2")
+
+		assert.NoError(t, server.SignOutEverywhere("user_id"))
+
+		for _, tokenId := range []string{"token_1", "token_2"} {
+			expectSession(mockAuthHandler, mockClock, tokenId)
+			_, _, err := server.GetUserSession(tokenId)
+			assert.True(t, isBadRequestError(err))
+		}
+	})
+
+	t.Run("Fails to sign out the user who doesn't exist on behalf of the user", func(t *testing.T) {
+		server, _, _ := newTokenServer(t)
+
+		var notFoundError *NotFoundError
+		assert.ErrorAs(t, server.AdminSignOutUser("unknown"), &notFoundError)
+	})
+}
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/attendance"
+	"rush/checkin"
+	"rush/golang/array"
+	"rush/session"
+	"rush/user"
+	"time"
+)
+
+// How many invalid codes a member can submit for a session before they are locked out of the check-in with the code.
+// The codes have 6 digits, so it keeps the members from guessing them.
+const maxInvalidCheckInCodes = 5
+
+type CheckInCode struct {
+	// The 6-digit code that the members submit to check in. E.g., "012345"
+	// The UI shows it as it is or as a QR code.
+	Code string `json:"code"`
+	// The time in UTC when the code is rotated. The UI should fetch the next code after it.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Returns the check-in code of the session for the admin to show at the meeting point.
+// It sets the check-in as the attendance source of the session on the first call.
+// Fails if the session is already closed or has another attendance source.
+func (s *Server) GetCheckInCode(sessionId string) (CheckInCode, error) {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return CheckInCode{}, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return CheckInCode{}, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() {
+		return CheckInCode{}, newBadRequestError(errors.New("session is already closed"))
+	}
+	if err := checkNoOtherAttendanceSource(dbSession); err != nil {
+		return CheckInCode{}, err
+	}
+
+	if !dbSession.AttendanceSource.IsSet() {
+		if _, err := s.openSessionRepo.UpdateOpenSession(sessionId, session.OpenSessionUpdateForm{
+			AttendanceSource: newCheckInAttendanceSource(sessionId),
+		}); err != nil {
+			return CheckInCode{}, newInternalServerError(fmt.Errorf("failed to enable the check-in of the session: %w", err))
+		}
+	}
+
+	code := s.checkInCodeHandler.Issue(sessionId)
+	return CheckInCode{
+		Code:      code.Value,
+		ExpiresAt: code.ExpiresAt,
+	}, nil
+}
+
+// Checks the user in to the session with the code shown at the meeting point.
+// It's rejected if it's not around the start time of the session. The invalid codes are recorded as the rejections
+// and the user is locked out after maxInvalidCheckInCodes of them, so that the admin has to mark the user as present.
+// The check-in becomes the attendance of the user right away with the time of the check-in as the joined time.
+// The session stays open so that the admin can close it with MarkUsersAsPresent after everyone has checked in.
+// Otherwise, it's closed by the job after the check-in window.
+func (s *Server) CheckIn(sessionId string, userId string, code string) error {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() {
+		return newBadRequestError(errors.New("session is already closed"))
+	}
+	if dbSession.AttendanceSource.Provider != session.AttendanceSourceProviderCheckIn {
+		return newBadRequestError(errors.New("check-in is not enabled for the session"))
+	}
+
+	now := s.clock.Now()
+	if !isInCheckInWindow(dbSession, now) {
+		return s.rejectCheckIn(checkin.Rejection{SessionId: sessionId, UserId: userId, Reason: checkin.RejectionReasonOutsideTimeWindow, AttemptedAt: now})
+	}
+	rejections, err := s.checkInRejectionRepo.FindRejectionsBySessionId(sessionId)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get the rejected check-ins: %w", err))
+	}
+	invalidCodes := array.Filter(rejections, func(rejection checkin.Rejection) bool {
+		return rejection.UserId == userId && rejection.Reason == checkin.RejectionReasonInvalidCode
+	})
+	if len(invalidCodes) >= maxInvalidCheckInCodes {
+		return newBadRequestError(errors.New("too many invalid check-in codes. Ask the admin to mark you as present"))
+	}
+	if !s.checkInCodeHandler.Verify(sessionId, code) {
+		if _, err := s.checkInRejectionRepo.AddRejection(checkin.Rejection{
+			SessionId:   sessionId,
+			UserId:      userId,
+			Reason:      checkin.RejectionReasonInvalidCode,
+			AttemptedAt: now,
+		}); err != nil {
+			return newInternalServerError(fmt.Errorf("failed to record the invalid check-in code: %w", err))
+		}
+		return newBadRequestError(errors.New("invalid or expired check-in code"))
+	}
+
+	return s.addCheckIn(dbSession, userId)
+}
+
+// Returns true if it's around the start time of the session so that the members can check in.
+func isInCheckInWindow(dbSession session.Session, now time.Time) bool {
+	return !now.Before(dbSession.StartsAt.Add(-session.CheckInWindow)) && !now.After(dbSession.StartsAt.Add(session.CheckInWindow))
+}
+
+// Records the rejected check-in and returns the error that says why it's rejected.
+func (s *Server) rejectCheckIn(rejection checkin.Rejection) error {
+	if _, err := s.checkInRejectionRepo.AddRejection(rejection); err != nil {
+		return newInternalServerError(fmt.Errorf("failed to record the rejected check-in (%s): %w", rejection.Reason, err))
+	}
+	return newBadRequestError(fmt.Errorf("check-in is rejected: %s", rejection.Reason))
+}
+
+// Sets the meeting point of the session where the members check in with their location.
+// It sets the check-in as the attendance source of the session.
+// Fails if the session is already closed or has another attendance source.
+func (s *Server) SetSessionMeetingPoint(sessionId string, latitude float64, longitude float64, radiusMeters float64) (SessionForAdmin, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return SessionForAdmin{}, newBadRequestError(errors.New("invalid coordinates of the meeting point"))
+	}
+	if radiusMeters <= 0 {
+		return SessionForAdmin{}, newBadRequestError(errors.New("radius should be positive"))
+	}
+
+	return s.updateSessionMeetingPoint(sessionId, session.MeetingPoint{Latitude: latitude, Longitude: longitude, RadiusMeters: radiusMeters})
+}
+
+// Unsets the meeting point of the session. The members can still check in with the code.
+func (s *Server) UnsetSessionMeetingPoint(sessionId string) (SessionForAdmin, error) {
+	return s.updateSessionMeetingPoint(sessionId, session.MeetingPoint{})
+}
+
+func (s *Server) updateSessionMeetingPoint(sessionId string, meetingPoint session.MeetingPoint) (SessionForAdmin, error) {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return SessionForAdmin{}, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return SessionForAdmin{}, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() {
+		return SessionForAdmin{}, newBadRequestError(errors.New("session is already closed"))
+	}
+	if err := checkNoOtherAttendanceSource(dbSession); err != nil {
+		return SessionForAdmin{}, err
+	}
+
+	updateForm := session.OpenSessionUpdateForm{MeetingPoint: &meetingPoint, ReturnUpdatedSession: true}
+	if meetingPoint.IsSet() {
+		updateForm.AttendanceSource = newCheckInAttendanceSource(sessionId)
+	}
+	updatedSession, err := s.openSessionRepo.UpdateOpenSession(sessionId, updateForm)
+	if err != nil {
+		return SessionForAdmin{}, newInternalServerError(fmt.Errorf("failed to update the meeting point of the session: %w", err))
+	}
+	return fromSessionToSessionForAdmin(updatedSession), nil
+}
+
+// Checks the user in to the session with the location of the user's device.
+// It's rejected if the user is outside the meeting point or it's not around the start time of the session.
+// The rejected check-ins are recorded so that the admins can review them and mark the users as present manually.
+func (s *Server) CheckInAtMeetingPoint(sessionId string, userId string, latitude float64, longitude float64, accuracyMeters float64) error {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() {
+		return newBadRequestError(errors.New("session is already closed"))
+	}
+	if !dbSession.MeetingPoint.IsSet() {
+		return newBadRequestError(errors.New("session has no meeting point"))
+	}
+	if accuracyMeters < 0 {
+		return newBadRequestError(errors.New("accuracy should not be negative"))
+	}
+
+	now := s.clock.Now()
+	distanceMeters := checkin.Distance(dbSession.MeetingPoint.Latitude, dbSession.MeetingPoint.Longitude, latitude, longitude)
+	var reason checkin.RejectionReason
+	switch {
+	case !isInCheckInWindow(dbSession, now):
+		reason = checkin.RejectionReasonOutsideTimeWindow
+	case accuracyMeters > dbSession.MeetingPoint.RadiusMeters:
+		reason = checkin.RejectionReasonInaccurateLocation
+	case distanceMeters > dbSession.MeetingPoint.RadiusMeters:
+		reason = checkin.RejectionReasonOutsideGeofence
+	}
+	if reason != "" {
+		return s.rejectCheckIn(checkin.Rejection{
+			SessionId:      sessionId,
+			UserId:         userId,
+			Latitude:       latitude,
+			Longitude:      longitude,
+			AccuracyMeters: accuracyMeters,
+			DistanceMeters: distanceMeters,
+			Reason:         reason,
+			AttemptedAt:    now,
+		})
+	}
+
+	return s.addCheckIn(dbSession, userId)
+}
+
+// Returns the rejected check-ins of the session in the order of the attempts. E.g., outside the meeting point.
+func (s *Server) AdminListCheckInRejections(sessionId string) ([]CheckInRejection, error) {
+	if _, err := s.sessionRepo.Get(sessionId); err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return nil, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return nil, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+
+	rejections, err := s.checkInRejectionRepo.FindRejectionsBySessionId(sessionId)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the rejected check-ins: %w", err))
+	}
+	return array.Map(rejections, fromCheckInRejection), nil
+}
+
+// Adds the attendance of the user who has checked in to the session.
+func (s *Server) addCheckIn(dbSession session.Session, userId string) error {
+	dbUser, err := s.userRepo.Get(userId)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get user: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get user: %w", err))
+	}
+	if !dbUser.IsActive {
+		return newBadRequestError(errors.New("inactive user can't check in"))
+	}
+
+	now := s.clock.Now()
+	if _, err := s.attendanceRepo.BulkInsert([]attendance.AddAttendanceReq{{
+		SessionId:        dbSession.Id,
+		SessionName:      dbSession.Name,
+		SessionScore:     dbSession.Score,
+		SessionStartedAt: dbSession.StartsAt,
+		UserId:           dbUser.Id,
+		UserExternalName: dbUser.ExternalName,
+		UserGeneration:   dbUser.Generation,
+		UserJoinedAt:     now,
+		CreatedBy:        userId,
+		Status:           attendance.StatusByJoinedAt(dbSession.StartsAt, now),
+	}}); err != nil {
+		if errors.Is(err, attendance.ErrDuplicate) {
+			return newConflictError(fmt.Errorf("the user has already checked in: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to insert the attendance: %w", err))
+	}
+	return nil
+}
+
+// Returns the attendance source of the check-in. The check-ins are kept as the attendances of the session
+// so that the session ID is used as the external ID.
+func newCheckInAttendanceSource(sessionId string) *session.AttendanceSource {
+	return &session.AttendanceSource{Provider: session.AttendanceSourceProviderCheckIn, ExternalId: sessionId}
+}
+
+// Returns an error if the session has an attendance source other than the check-in.
+func checkNoOtherAttendanceSource(dbSession session.Session) error {
+	if dbSession.AttendanceSource.IsSet() && dbSession.AttendanceSource.Provider != session.AttendanceSourceProviderCheckIn {
+		return newBadRequestError(fmt.Errorf("session already has another attendance source: %s", dbSession.AttendanceSource.Provider))
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"errors"
+	"rush/attendance"
+	"rush/audit"
+	"rush/auth"
+	"rush/checkin"
+	"rush/excuse"
+	"rush/golang/array"
+	"rush/reliability"
+	"rush/rsvp"
+	"rush/session"
+	"rush/unmatched"
+	"rush/user"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// The start time of the session that newCheckInServer adds.
+var checkInSessionStartsAt = time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC)
+
+// Returns the server with the memory repos, the ID of an open session and the ID of an active user.
+// The clock is set to the start time of the session.
+func newCheckInServer(t *testing.T) (*Server, string, string, *clock.Mock) {
+	userRepo := user.NewMemoryRepo()
+	sessionRepo := session.NewMemoryRepo()
+	mockClock := clock.NewMock()
+	mockClock.Set(checkInSessionStartsAt)
+	attendanceRepo := attendance.NewMemoryRepo(mockClock)
+	server := New(Deps{
+		UserRepo:                userRepo,
+		UserUpdater:             user.NewUpdater(userRepo, attendanceRepo),
+		SessionRepo:             sessionRepo,
+		OpenSessionRepo:         session.NewService(sessionRepo),
+		AttendanceRepo:          attendanceRepo,
+		CheckInCodeHandler:      checkin.NewCodeIssuer("secret", 30*time.Second, mockClock),
+		CheckInRejectionRepo:    checkin.NewMemoryRepo(),
+		UnmatchedSubmissionRepo: unmatched.NewMemoryRepo(),
+		ExcuseRepo:              excuse.NewMemoryRepo(),
+		AuditRepo:               audit.NewMemoryRepo(),
+		RsvpRepo:                rsvp.NewMemoryRepo(),
+		ReliabilityRepo:         reliability.NewMemoryRepo(),
+		ReliabilityThresholds:   reliability.Thresholds{MinSignUps: 2, MinScore: 50},
+		TokenRepo:               auth.NewMemoryRepo(),
+		FormTimeLocation:        time.UTC,
+		Clock:                   mockClock,
+	})
+
+	assert.NoError(t, userRepo.Add(user.User{Name: "김건", Generation: 9, IsActive: true, ExternalName: "김건"}))
+	users, err := userRepo.GetAll()
+	assert.NoError(t, err)
+	sessionId, err := server.AddSession("정규런", "", "admin-id", checkInSessionStartsAt, 2)
+	assert.NoError(t, err)
+	return server, sessionId, users[0].Id, mockClock
+}
+
+func TestCheckIn(t *testing.T) {
+	t.Run("Fails to check in before the check-in is enabled", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+
+		err := server.CheckIn(sessionId, userId, "000000")
+
+		assert.Equal(t, newBadRequestError(errors.New("check-in is not enabled for the session")), err)
+	})
+
+	t.Run("Fails to check in with an expired code", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+
+		mockClock.Add(time.Minute)
+		err = server.CheckIn(sessionId, userId, code.Code)
+
+		assert.Equal(t, newBadRequestError(errors.New("invalid or expired check-in code")), err)
+	})
+
+	t.Run("Checks in once and applies it as the attendance with the check-in time", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, checkInSessionStartsAt.Add(30*time.Second), code.ExpiresAt)
+
+		mockClock.Add(10 * time.Second)
+		assert.NoError(t, server.CheckIn(sessionId, userId, code.Code))
+		var conflictError *ConflictError
+		assert.ErrorAs(t, server.CheckIn(sessionId, userId, code.Code), &conflictError)
+
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, userId, attendances[0].UserId)
+		assert.Equal(t, checkInSessionStartsAt.Add(10*time.Second), attendances[0].UserJoinedAt)
+		assert.Equal(t, attendance.StatusLate, attendances[0].Status)
+
+		// The session is closed by the admin after everyone has checked in.
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{}, false, "admin-id"))
+		closed, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, SessionAttendanceAppliedByCheckIn, closed.AttendanceAppliedBy)
+		attendances, err = server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+	})
+
+	t.Run("Closes the session by the check-ins as its attendance source", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+		assert.NoError(t, server.CheckIn(sessionId, userId, code.Code))
+
+		assert.NoError(t, server.ApplyAttendanceByFormSubmissions(sessionId, "session-attendance-syncer"))
+
+		closed, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, session.AttendanceStatusApplied, closed.AttendanceStatus)
+		assert.Equal(t, SessionAttendanceAppliedByCheckIn, closed.AttendanceAppliedBy)
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+	})
+
+	t.Run("Doesn't create the form or update the session once it uses the check-in", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+		assert.NoError(t, server.CheckIn(sessionId, userId, code.Code))
+
+		_, err = server.CreateAttendanceForm(sessionId)
+		assert.True(t, isBadRequestError(err))
+		name := "화요 정규런"
+		_, err = server.UpdateSession(sessionId, &name, nil, nil, nil)
+		assert.Equal(t, newBadRequestError(errors.New("session already has attendances")), err)
+	})
+
+	t.Run("Rejects and records the check-in outside the time window", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		mockClock.Set(checkInSessionStartsAt.Add(-time.Hour))
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+
+		err = server.CheckIn(sessionId, userId, code.Code)
+
+		assert.Equal(t, newBadRequestError(errors.New("check-in is rejected: outside_time_window")), err)
+		rejections, err := server.AdminListCheckInRejections(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, rejections, 1)
+		assert.Equal(t, checkin.RejectionReasonOutsideTimeWindow, rejections[0].Reason)
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Empty(t, attendances)
+	})
+
+	t.Run("Locks out the user after too many invalid codes", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+		for i := 0; i < maxInvalidCheckInCodes; i++ {
+			assert.Equal(t, newBadRequestError(errors.New("invalid or expired check-in code")), server.CheckIn(sessionId, userId, "000000"))
+		}
+
+		// Even the valid code is rejected once the user is locked out.
+		err = server.CheckIn(sessionId, userId, code.Code)
+
+		assert.Equal(t, newBadRequestError(errors.New("too many invalid check-in codes. Ask the admin to mark you as present")), err)
+		rejections, err := server.AdminListCheckInRejections(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, rejections, maxInvalidCheckInCodes)
+		assert.Equal(t, checkin.RejectionReasonInvalidCode, rejections[0].Reason)
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Empty(t, attendances)
+	})
+}
+
+func TestCheckInAtMeetingPoint(t *testing.T) {
+	// The entrance of Yeouido Park.
+	meetingPoint := MeetingPoint{Latitude: 37.5283, Longitude: 126.9326, RadiusMeters: 100}
+
+	t.Run("Fails to set an invalid meeting point", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+
+		_, err := server.SetSessionMeetingPoint(sessionId, 91, 126.9326, 100)
+		assert.True(t, isBadRequestError(err))
+		_, err = server.SetSessionMeetingPoint(sessionId, 37.5283, 126.9326, 0)
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Fails to check in to the session without the meeting point", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+
+		err := server.CheckInAtMeetingPoint(sessionId, userId, 37.5283, 126.9326, 10)
+
+		assert.Equal(t, newBadRequestError(errors.New("session has no meeting point")), err)
+	})
+
+	t.Run("Checks in inside the meeting point around the start time", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		updated, err := server.SetSessionMeetingPoint(sessionId, meetingPoint.Latitude, meetingPoint.Longitude, meetingPoint.RadiusMeters)
+		assert.NoError(t, err)
+		assert.Equal(t, &meetingPoint, updated.MeetingPoint)
+		assert.Equal(t, &AttendanceSource{Provider: session.AttendanceSourceProviderCheckIn, ExternalId: sessionId}, updated.AttendanceSource)
+
+		mockClock.Set(checkInSessionStartsAt.Add(-20 * time.Minute))
+		// About 55 meters away from the meeting point.
+		assert.NoError(t, server.CheckInAtMeetingPoint(sessionId, userId, 37.5288, 126.9326, 20))
+
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, checkInSessionStartsAt.Add(-20*time.Minute), attendances[0].UserJoinedAt)
+		rejections, err := server.AdminListCheckInRejections(sessionId)
+		assert.NoError(t, err)
+		assert.Empty(t, rejections)
+	})
+
+	t.Run("Rejects and records the check-ins outside the time window or the meeting point", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		_, err := server.SetSessionMeetingPoint(sessionId, meetingPoint.Latitude, meetingPoint.Longitude, meetingPoint.RadiusMeters)
+		assert.NoError(t, err)
+
+		mockClock.Set(checkInSessionStartsAt.Add(-time.Hour))
+		assert.True(t, isBadRequestError(server.CheckInAtMeetingPoint(sessionId, userId, 37.5283, 126.9326, 10)))
+		mockClock.Set(checkInSessionStartsAt)
+		assert.True(t, isBadRequestError(server.CheckInAtMeetingPoint(sessionId, userId, 37.5283, 126.9326, 500)))
+		// About 1.1 km away from the meeting point.
+		assert.True(t, isBadRequestError(server.CheckInAtMeetingPoint(sessionId, userId, 37.5383, 126.9326, 10)))
+
+		rejections, err := server.AdminListCheckInRejections(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, []checkin.RejectionReason{
+			checkin.RejectionReasonOutsideTimeWindow,
+			checkin.RejectionReasonInaccurateLocation,
+			checkin.RejectionReasonOutsideGeofence,
+		}, array.Map(rejections, func(rejection CheckInRejection) checkin.RejectionReason { return rejection.Reason }))
+		assert.Equal(t, userId, rejections[2].UserId)
+		assert.InDelta(t, 1112, rejections[2].DistanceMeters, 1)
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Empty(t, attendances)
+	})
+
+	t.Run("Unsets the meeting point", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+		_, err := server.SetSessionMeetingPoint(sessionId, meetingPoint.Latitude, meetingPoint.Longitude, meetingPoint.RadiusMeters)
+		assert.NoError(t, err)
+
+		updated, err := server.UnsetSessionMeetingPoint(sessionId)
+
+		assert.NoError(t, err)
+		assert.Nil(t, updated.MeetingPoint)
+	})
+}
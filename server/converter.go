@@ -2,7 +2,15 @@ package server
 
 import (
 	"rush/attendance"
+	"rush/audit"
+	"rush/checkin"
+	"rush/excuse"
+	"rush/reliability"
+	"rush/rsvp"
+	"rush/series"
 	"rush/session"
+	"rush/term"
+	"rush/unmatched"
 	"rush/user"
 )
 
@@ -17,14 +25,32 @@ func fromUser(user *user.User) *User {
 	}
 }
 
+func fromUserToUserForAdmin(user user.User, score reliability.Score) UserForAdmin {
+	return UserForAdmin{
+		Id:           user.Id,
+		Name:         user.Name,
+		Generation:   user.Generation,
+		IsActive:     user.IsActive,
+		Email:        user.Email,
+		ExternalName: user.ExternalName,
+		Role:         user.Role,
+		Reliability:  score,
+	}
+}
+
 func fromSessionToSessionForAdmin(sessionData session.Session) SessionForAdmin {
+	// The UI still reads the Google form of the session from the dedicated fields.
+	googleFormId, googleFormUri := "", ""
+	if sessionData.AttendanceSource.Provider == session.AttendanceSourceProviderGoogleForm {
+		googleFormId, googleFormUri = sessionData.AttendanceSource.ExternalId, sessionData.AttendanceSource.Uri
+	}
 	return SessionForAdmin{
 		Id:               sessionData.Id,
 		Name:             sessionData.Name,
 		Description:      sessionData.Description,
 		CreatedBy:        sessionData.CreatedBy,
-		GoogleFormUri:    sessionData.GoogleFormUri,
-		GoogleFormId:     sessionData.GoogleFormId,
+		GoogleFormUri:    googleFormUri,
+		GoogleFormId:     googleFormId,
 		CreatedAt:        sessionData.CreatedAt,
 		StartsAt:         sessionData.StartsAt,
 		Score:            sessionData.Score,
@@ -33,6 +59,12 @@ func fromSessionToSessionForAdmin(sessionData session.Session) SessionForAdmin {
 			if sessionData.AttendanceAppliedBy() == session.AttendanceAppliedByUnspecified {
 				return SessionAttendanceAppliedByUnspecified
 			}
+			if sessionData.AttendanceAppliedBy() == session.AttendanceAppliedByCheckIn {
+				return SessionAttendanceAppliedByCheckIn
+			}
+			if sessionData.AttendanceAppliedBy() == session.AttendanceAppliedByCsv {
+				return SessionAttendanceAppliedByCsv
+			}
 			if sessionData.AttendanceAppliedBy() == session.AttendanceAppliedByManual {
 				return SessionAttendanceAppliedByManual
 			}
@@ -42,6 +74,104 @@ func fromSessionToSessionForAdmin(sessionData session.Session) SessionForAdmin {
 			}
 			return SessionAttendanceAppliedByUnknown
 		}(),
+		SeriesId: sessionData.SeriesId,
+		AttendanceSource: func() *AttendanceSource {
+			if !sessionData.AttendanceSource.IsSet() {
+				return nil
+			}
+			return &AttendanceSource{
+				Provider:   sessionData.AttendanceSource.Provider,
+				ExternalId: sessionData.AttendanceSource.ExternalId,
+				Uri:        sessionData.AttendanceSource.Uri,
+			}
+		}(),
+		MeetingPoint: func() *MeetingPoint {
+			if !sessionData.MeetingPoint.IsSet() {
+				return nil
+			}
+			return &MeetingPoint{
+				Latitude:     sessionData.MeetingPoint.Latitude,
+				Longitude:    sessionData.MeetingPoint.Longitude,
+				RadiusMeters: sessionData.MeetingPoint.RadiusMeters,
+			}
+		}(),
+		Capacity: sessionData.Capacity,
+	}
+}
+
+func fromCheckInRejection(rejection checkin.Rejection) CheckInRejection {
+	return CheckInRejection{
+		Id:             rejection.Id,
+		UserId:         rejection.UserId,
+		Latitude:       rejection.Latitude,
+		Longitude:      rejection.Longitude,
+		AccuracyMeters: rejection.AccuracyMeters,
+		DistanceMeters: rejection.DistanceMeters,
+		Reason:         rejection.Reason,
+		AttemptedAt:    rejection.AttemptedAt,
+	}
+}
+
+func fromUnmatchedSubmission(submission unmatched.Submission) UnmatchedSubmission {
+	return UnmatchedSubmission{
+		Id:               submission.Id,
+		Answer:           submission.Answer,
+		UserExternalName: submission.UserExternalName,
+		Reason:           submission.Reason,
+		Detail:           submission.Detail,
+		SubmissionTime:   submission.SubmissionTime,
+		Resolution: func() *UnmatchedSubmissionResolution {
+			if !submission.Resolution.IsResolved() {
+				return nil
+			}
+			return &UnmatchedSubmissionResolution{
+				Action:     submission.Resolution.Action,
+				UserId:     submission.Resolution.UserId,
+				ResolvedBy: submission.Resolution.ResolvedBy,
+				ResolvedAt: submission.Resolution.ResolvedAt,
+			}
+		}(),
+	}
+}
+
+func fromExcuse(excuse excuse.Excuse) Excuse {
+	return Excuse{
+		Id:                excuse.Id,
+		UserId:            excuse.UserId,
+		SessionId:         excuse.SessionId,
+		StartsAt:          excuse.StartsAt,
+		EndsAt:            excuse.EndsAt,
+		Reason:            excuse.Reason,
+		Status:            excuse.Status,
+		CountsTowardScore: excuse.CountsTowardScore,
+		ReviewedBy:        excuse.ReviewedBy,
+		ReviewedAt:        excuse.ReviewedAt,
+		CreatedAt:         excuse.CreatedAt,
+	}
+}
+
+func fromRsvp(rsvp rsvp.Rsvp) Rsvp {
+	return Rsvp{
+		Id:        rsvp.Id,
+		SessionId: rsvp.SessionId,
+		UserId:    rsvp.UserId,
+		Status:    rsvp.Status,
+		CreatedAt: rsvp.CreatedAt,
+		UpdatedAt: rsvp.UpdatedAt,
+	}
+}
+
+func fromAuditEntry(entry audit.Entry) AuditEntry {
+	return AuditEntry{
+		Id:        entry.Id,
+		Action:    entry.Action,
+		UserId:    entry.UserId,
+		SessionId: entry.SessionId,
+		TargetId:  entry.TargetId,
+		Reason:    entry.Reason,
+		Changes:   entry.Changes,
+		ActorId:   entry.ActorId,
+		CreatedAt: entry.CreatedAt,
 	}
 }
 
@@ -54,6 +184,7 @@ func fromSessionToSessionForUser(sessionData session.Session) Session {
 		CreatedAt:   sessionData.CreatedAt,
 		StartsAt:    sessionData.StartsAt,
 		Score:       sessionData.Score,
+		Capacity:    sessionData.Capacity,
 	}
 }
 
@@ -69,5 +200,37 @@ func fromAttendance(attendance *attendance.Attendance) *Attendance {
 		UserGeneration:   attendance.UserGeneration,
 		UserJoinedAt:     attendance.UserJoinedAt,
 		CreatedAt:        attendance.CreatedAt,
+		Status:           attendance.Status,
+		StatusReason:     attendance.StatusReason,
+		StatusSetBy:      attendance.StatusSetBy,
+	}
+}
+
+func fromTerm(term term.Term) Term {
+	return Term{
+		Id:           term.Id,
+		Name:         term.Name,
+		StartsAt:     term.StartsAt,
+		EndsAt:       term.EndsAt,
+		Generations:  term.Generations,
+		IsArchived:   term.IsArchived,
+		ScoringRules: term.ScoringRules,
+		CreatedAt:    term.CreatedAt,
+	}
+}
+
+func fromSeries(series series.Series) SessionSeries {
+	return SessionSeries{
+		Id:                series.Id,
+		Name:              series.NameTemplate,
+		Description:       series.DescriptionTemplate,
+		CreatedBy:         series.CreatedBy,
+		Weekday:           series.Weekday,
+		Hour:              series.Hour,
+		Minute:            series.Minute,
+		Score:             series.Score,
+		MaterializedUntil: series.MaterializedUntil,
+		IsCancelled:       series.IsCancelled,
+		CreatedAt:         series.CreatedAt,
 	}
 }
@@ -73,3 +73,21 @@ func (e *InternalServerError) InternalServer() {}
 func newInternalServerError(err error) *InternalServerError {
 	return &InternalServerError{originalError: err}
 }
+
+type ConflictError struct {
+	originalError error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflict: %v", e.originalError)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.originalError
+}
+
+func (e *ConflictError) Conflict() {}
+
+func newConflictError(err error) *ConflictError {
+	return &ConflictError{originalError: err}
+}
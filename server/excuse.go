@@ -0,0 +1,130 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/excuse"
+	"rush/golang/array"
+	"rush/session"
+	"strings"
+	"time"
+)
+
+// Submits the excuse of the member for a session or a date range that they will miss.
+// Either the session ID or both of the start and the end time should be given.
+func (s *Server) SubmitExcuse(userId string, sessionId string, startsAt *time.Time, endsAt *time.Time, reason string) (Excuse, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return Excuse{}, newBadRequestError(errors.New("reason is required"))
+	}
+
+	newExcuse := excuse.Excuse{UserId: userId, SessionId: sessionId, Reason: reason}
+	switch {
+	case sessionId != "" && (startsAt != nil || endsAt != nil):
+		return Excuse{}, newBadRequestError(errors.New("either the session or the date range should be given, not both"))
+	case sessionId != "":
+		dbSession, err := s.sessionRepo.Get(sessionId)
+		if err != nil {
+			if errors.Is(err, session.ErrNotFound) {
+				return Excuse{}, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+			}
+			return Excuse{}, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+		}
+		newExcuse.StartsAt = dbSession.StartsAt
+		newExcuse.EndsAt = dbSession.StartsAt
+	case startsAt != nil && endsAt != nil:
+		if !startsAt.Before(*endsAt) {
+			return Excuse{}, newBadRequestError(errors.New("the start time should be before the end time"))
+		}
+		newExcuse.StartsAt = *startsAt
+		newExcuse.EndsAt = *endsAt
+	default:
+		return Excuse{}, newBadRequestError(errors.New("either the session or the date range is required"))
+	}
+
+	if sessionId != "" {
+		excuses, err := s.excuseRepo.FindByUserId(userId)
+		if err != nil {
+			return Excuse{}, newInternalServerError(fmt.Errorf("failed to get the excuses of the user: %w", err))
+		}
+		for _, existing := range excuses {
+			if existing.SessionId == sessionId && existing.Status != excuse.StatusRejected {
+				return Excuse{}, newConflictError(errors.New("excuse for the session is already submitted"))
+			}
+		}
+	}
+
+	id, err := s.excuseRepo.Add(newExcuse)
+	if err != nil {
+		return Excuse{}, newInternalServerError(fmt.Errorf("failed to add excuse: %w", err))
+	}
+	added, err := s.excuseRepo.Get(id)
+	if err != nil {
+		return Excuse{}, newInternalServerError(fmt.Errorf("failed to get the added excuse: %w", err))
+	}
+	return fromExcuse(added), nil
+}
+
+// Returns the excuses that the member has submitted in the order of submission.
+func (s *Server) ListMyExcuses(userId string) ([]Excuse, error) {
+	excuses, err := s.excuseRepo.FindByUserId(userId)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the excuses of the user: %w", err))
+	}
+	return array.Map(excuses, fromExcuse), nil
+}
+
+// Returns the excuses of the status in the order of submission. The pending ones are returned if the status is empty.
+func (s *Server) AdminListExcuses(status excuse.Status) ([]Excuse, error) {
+	if status == "" {
+		status = excuse.StatusPending
+	}
+	if !status.IsValid() {
+		return nil, newBadRequestError(fmt.Errorf("invalid status: %s", status))
+	}
+
+	excuses, err := s.excuseRepo.FindByStatus(status)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the excuses: %w", err))
+	}
+	return array.Map(excuses, fromExcuse), nil
+}
+
+// Approves or rejects the pending excuse. Only the approved excuse can count toward the score.
+func (s *Server) ReviewExcuse(id string, approve bool, countsTowardScore bool, reviewedBy string) (Excuse, error) {
+	dbExcuse, err := s.excuseRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, excuse.ErrNotFound) {
+			return Excuse{}, newNotFoundError(fmt.Errorf("failed to get excuse: %w", err))
+		}
+		return Excuse{}, newInternalServerError(fmt.Errorf("failed to get excuse: %w", err))
+	}
+	if dbExcuse.Status != excuse.StatusPending {
+		return Excuse{}, newBadRequestError(errors.New("excuse is already reviewed"))
+	}
+	if !approve && countsTowardScore {
+		return Excuse{}, newBadRequestError(errors.New("rejected excuse can't count toward the score"))
+	}
+
+	status := excuse.StatusRejected
+	if approve {
+		status = excuse.StatusApproved
+	}
+	if err := s.excuseRepo.Review(id, excuse.ReviewForm{
+		Status:            status,
+		CountsTowardScore: countsTowardScore,
+		ReviewedBy:        reviewedBy,
+		ReviewedAt:        s.clock.Now(),
+	}); err != nil {
+		if errors.Is(err, excuse.ErrNotFound) {
+			return Excuse{}, newConflictError(errors.New("excuse is reviewed by someone else"))
+		}
+		return Excuse{}, newInternalServerError(fmt.Errorf("failed to review excuse: %w", err))
+	}
+
+	reviewed, err := s.excuseRepo.Get(id)
+	if err != nil {
+		return Excuse{}, newInternalServerError(fmt.Errorf("failed to get the reviewed excuse: %w", err))
+	}
+	return fromExcuse(reviewed), nil
+}
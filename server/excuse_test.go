@@ -0,0 +1,162 @@
+package server
+
+import (
+	"errors"
+	"rush/excuse"
+	"rush/term"
+	"rush/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSubmitExcuse(t *testing.T) {
+	t.Run("Submits the excuse for the session with its start time", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+
+		submitted, err := server.SubmitExcuse(userId, sessionId, nil, nil, " 발목 부상 ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, sessionId, submitted.SessionId)
+		assert.Equal(t, checkInSessionStartsAt, submitted.StartsAt)
+		assert.Equal(t, checkInSessionStartsAt, submitted.EndsAt)
+		assert.Equal(t, "발목 부상", submitted.Reason)
+		assert.Equal(t, excuse.StatusPending, submitted.Status)
+		excuses, err := server.ListMyExcuses(userId)
+		assert.NoError(t, err)
+		assert.Equal(t, []Excuse{submitted}, excuses)
+	})
+
+	t.Run("Fails to submit the invalid excuses", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		startsAt := checkInSessionStartsAt
+		endsAt := checkInSessionStartsAt.Add(-time.Hour)
+
+		_, err := server.SubmitExcuse(userId, sessionId, nil, nil, " ")
+		assert.Equal(t, newBadRequestError(errors.New("reason is required")), err)
+		_, err = server.SubmitExcuse(userId, "", nil, nil, "해외 출장")
+		assert.Equal(t, newBadRequestError(errors.New("either the session or the date range is required")), err)
+		_, err = server.SubmitExcuse(userId, sessionId, &startsAt, nil, "해외 출장")
+		assert.True(t, isBadRequestError(err))
+		_, err = server.SubmitExcuse(userId, "", &startsAt, &endsAt, "해외 출장")
+		assert.Equal(t, newBadRequestError(errors.New("the start time should be before the end time")), err)
+		_, err = server.SubmitExcuse(userId, primitive.NewObjectID().Hex(), nil, nil, "해외 출장")
+		var notFoundError *NotFoundError
+		assert.ErrorAs(t, err, &notFoundError)
+	})
+
+	t.Run("Fails to submit the excuse for the same session twice unless it's rejected", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		submitted, err := server.SubmitExcuse(userId, sessionId, nil, nil, "발목 부상")
+		assert.NoError(t, err)
+
+		_, err = server.SubmitExcuse(userId, sessionId, nil, nil, "발목 부상")
+		var conflictError *ConflictError
+		assert.ErrorAs(t, err, &conflictError)
+
+		_, err = server.ReviewExcuse(submitted.Id, false, false, "admin-id")
+		assert.NoError(t, err)
+		_, err = server.SubmitExcuse(userId, sessionId, nil, nil, "발목 부상, 진단서 첨부")
+		assert.NoError(t, err)
+	})
+}
+
+func TestReviewExcuse(t *testing.T) {
+	t.Run("Approves the pending excuse once", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		submitted, err := server.SubmitExcuse(userId, sessionId, nil, nil, "발목 부상")
+		assert.NoError(t, err)
+
+		reviewed, err := server.ReviewExcuse(submitted.Id, true, true, "admin-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, excuse.StatusApproved, reviewed.Status)
+		assert.True(t, reviewed.CountsTowardScore)
+		assert.Equal(t, "admin-id", reviewed.ReviewedBy)
+		assert.Equal(t, checkInSessionStartsAt, reviewed.ReviewedAt)
+		_, err = server.ReviewExcuse(submitted.Id, false, false, "admin-id")
+		assert.Equal(t, newBadRequestError(errors.New("excuse is already reviewed")), err)
+		pending, err := server.AdminListExcuses("")
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+		approved, err := server.AdminListExcuses(excuse.StatusApproved)
+		assert.NoError(t, err)
+		assert.Equal(t, []Excuse{reviewed}, approved)
+	})
+
+	t.Run("Fails to reject the excuse that counts toward the score", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		submitted, err := server.SubmitExcuse(userId, sessionId, nil, nil, "발목 부상")
+		assert.NoError(t, err)
+
+		_, err = server.ReviewExcuse(submitted.Id, false, true, "admin-id")
+
+		assert.Equal(t, newBadRequestError(errors.New("rejected excuse can't count toward the score")), err)
+	})
+
+	t.Run("Fails to list the excuses of an unknown status", func(t *testing.T) {
+		server, _, _, _ := newCheckInServer(t)
+
+		_, err := server.AdminListExcuses("cancelled")
+
+		assert.True(t, isBadRequestError(err))
+	})
+}
+
+func TestGetHalfYearAttendanceWithExcuses(t *testing.T) {
+	t.Run("Marks the absences of the approved excuses apart from the others", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		server.termRepo = term.NewMemoryRepo()
+		// The memory repo of newCheckInServer can add the users.
+		userAdder := server.userRepo.(user.UserRepo)
+		assert.NoError(t, userAdder.Add(user.User{Name: "양현우", Generation: 8, IsActive: true, ExternalName: "양현우"}))
+		assert.NoError(t, userAdder.Add(user.User{Name: "강민경", Generation: 8, IsActive: true, ExternalName: "강민경"}))
+		users, err := server.userRepo.GetAllActive()
+		assert.NoError(t, err)
+		traveler, injured := users[1].Id, users[2].Id
+		startsAt := checkInSessionStartsAt.Add(-24 * time.Hour)
+		endsAt := checkInSessionStartsAt.Add(24 * time.Hour)
+		travel, err := server.SubmitExcuse(traveler, "", &startsAt, &endsAt, "해외 출장")
+		assert.NoError(t, err)
+		_, err = server.ReviewExcuse(travel.Id, true, true, "admin-id")
+		assert.NoError(t, err)
+		// The pending excuse is regarded as absent.
+		_, err = server.SubmitExcuse(injured, sessionId, nil, nil, "발목 부상")
+		assert.NoError(t, err)
+		// The attendance takes precedence over the excuse.
+		attended, err := server.SubmitExcuse(userId, sessionId, nil, nil, "야근")
+		assert.NoError(t, err)
+		_, err = server.ReviewExcuse(attended.Id, true, false, "admin-id")
+		assert.NoError(t, err)
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{userId}, false, "admin-id"))
+
+		halfYearAttendance, err := server.GetHalfYearAttendance("")
+
+		assert.NoError(t, err)
+		assert.Len(t, halfYearAttendance.Attendances, 1)
+		assert.Equal(t, []excusedAbsence{
+			{UserId: traveler, SessionId: sessionId, SessionScore: 2, ExcuseId: travel.Id, CountsTowardScore: true},
+		}, halfYearAttendance.Excuses)
+	})
+
+	t.Run("Marks the absences of the applied session that nobody attended", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		server.termRepo = term.NewMemoryRepo()
+		excused, err := server.SubmitExcuse(userId, sessionId, nil, nil, "발목 부상")
+		assert.NoError(t, err)
+		_, err = server.ReviewExcuse(excused.Id, true, false, "admin-id")
+		assert.NoError(t, err)
+		assert.NoError(t, server.openSessionRepo.MarkAsAttendanceApplied(sessionId))
+
+		halfYearAttendance, err := server.GetHalfYearAttendance("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, halfYearAttendance.Attendances)
+		assert.Equal(t, []sessionForAttendance{{Id: sessionId, Name: "정규런", StartedAt: checkInSessionStartsAt}}, halfYearAttendance.Sessions)
+		assert.Equal(t, []excusedAbsence{
+			{UserId: userId, SessionId: sessionId, SessionScore: 2, ExcuseId: excused.Id},
+		}, halfYearAttendance.Excuses)
+	})
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/excuse"
+	"rush/reliability"
+	"rush/rsvp"
+	"rush/session"
+	"rush/user"
+	"sort"
+)
+
+// Compares the confirmed RSVPs of the applied session with its attendances, and keeps whether each member showed up.
+// The members who missed it with the approved excuse are not regarded as no-shows.
+// It can run again for the same session. E.g., after the admins correct its attendance.
+func (s *Server) EvaluateSessionReliability(sessionId string) error {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if dbSession.AttendanceStatus != session.AttendanceStatusApplied {
+		return newBadRequestError(errors.New("session's attendance is not applied yet"))
+	}
+
+	rsvps, err := s.rsvpRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+	}
+	attendances, err := s.attendanceRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get attendances: %w", err))
+	}
+	attendedSet := map[string]bool{}
+	for _, attendance := range attendances {
+		attendedSet[attendance.UserId] = true
+	}
+	excuses, err := s.excuseRepo.FindByStatus(excuse.StatusApproved)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get the approved excuses: %w", err))
+	}
+	excusedSet := map[string]bool{}
+	for _, approved := range excuses {
+		if approved.Covers(sessionId, dbSession.StartsAt) {
+			excusedSet[approved.UserId] = true
+		}
+	}
+
+	now := s.clock.Now()
+	outcomes := []reliability.Outcome{}
+	for _, sessionRsvp := range rsvps {
+		// The waitlisted or cancelled members didn't take a spot of anyone else.
+		if sessionRsvp.Status != rsvp.StatusConfirmed {
+			continue
+		}
+		if excusedSet[sessionRsvp.UserId] && !attendedSet[sessionRsvp.UserId] {
+			continue
+		}
+		outcomes = append(outcomes, reliability.Outcome{
+			SessionId:       sessionId,
+			UserId:          sessionRsvp.UserId,
+			Attended:        attendedSet[sessionRsvp.UserId],
+			SessionStartsAt: dbSession.StartsAt,
+			EvaluatedAt:     now,
+		})
+	}
+	if err := s.reliabilityRepo.ReplaceBySessionId(sessionId, outcomes); err != nil {
+		return newInternalServerError(fmt.Errorf("failed to keep the outcomes of the session: %w", err))
+	}
+	return nil
+}
+
+// Returns the active users with how reliable they are.
+func (s *Server) AdminListUsers() ([]UserForAdmin, error) {
+	users, err := s.userRepo.GetAllActive()
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get users: %w", err))
+	}
+	scores, err := s.getReliabilityScores()
+	if err != nil {
+		return nil, err
+	}
+
+	converted := []UserForAdmin{}
+	for _, user := range users {
+		converted = append(converted, fromUserToUserForAdmin(user, scores(user.Id)))
+	}
+	return converted, nil
+}
+
+// Returns the user by the given ID with how reliable they are.
+func (s *Server) AdminGetUser(id string) (UserForAdmin, error) {
+	dbUser, err := s.userRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return UserForAdmin{}, newNotFoundError(fmt.Errorf("failed to get user: %w", err))
+		}
+		return UserForAdmin{}, newInternalServerError(fmt.Errorf("failed to get user: %w", err))
+	}
+	outcomes, err := s.reliabilityRepo.FindByUserId(id)
+	if err != nil {
+		return UserForAdmin{}, newInternalServerError(fmt.Errorf("failed to get the outcomes of the user: %w", err))
+	}
+
+	return fromUserToUserForAdmin(*dbUser, reliability.Calculate(outcomes, s.reliabilityThresholds)), nil
+}
+
+// Returns the active users who repeatedly RSVP but don't show up, from the least reliable one.
+func (s *Server) AdminListUnreliableUsers() ([]UserForAdmin, error) {
+	users, err := s.AdminListUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	unreliableUsers := []UserForAdmin{}
+	for _, user := range users {
+		if user.Reliability.IsUnreliable {
+			unreliableUsers = append(unreliableUsers, user)
+		}
+	}
+	sort.SliceStable(unreliableUsers, func(i, j int) bool {
+		return unreliableUsers[i].Reliability.Score < unreliableUsers[j].Reliability.Score
+	})
+	return unreliableUsers, nil
+}
+
+// Returns the function that scores the user by the ID with all the outcomes.
+func (s *Server) getReliabilityScores() (func(userId string) reliability.Score, error) {
+	outcomes, err := s.reliabilityRepo.GetAll()
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the outcomes: %w", err))
+	}
+
+	userOutcomes := map[string][]reliability.Outcome{}
+	for _, outcome := range outcomes {
+		userOutcomes[outcome.UserId] = append(userOutcomes[outcome.UserId], outcome)
+	}
+	return func(userId string) reliability.Score {
+		return reliability.Calculate(userOutcomes[userId], s.reliabilityThresholds)
+	}, nil
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"errors"
+	"rush/reliability"
+	"rush/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReliability(t *testing.T) {
+	t.Run("Fails to evaluate the session whose attendance is not applied yet", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+
+		err := server.EvaluateSessionReliability(sessionId)
+
+		assert.Equal(t, newBadRequestError(errors.New("session's attendance is not applied yet")), err)
+	})
+
+	t.Run("Scores the members by the sessions they RSVPed to and regards the repeated no-shows as unreliable", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		assert.NoError(t, server.userRepo.(user.UserRepo).Add(user.User{Name: "박지수", Generation: 10, IsActive: true, ExternalName: "박지수"}))
+		users, err := server.userRepo.GetAll()
+		assert.NoError(t, err)
+		noShowUserId := users[1].Id
+		nextSessionId, err := server.AddSession("정규런", "", "admin-id", checkInSessionStartsAt.AddDate(0, 0, 7), 2)
+		assert.NoError(t, err)
+
+		mockClock.Set(checkInSessionStartsAt.Add(-time.Hour))
+		for _, id := range []string{sessionId, nextSessionId} {
+			_, err = server.RsvpToSession(id, userId)
+			assert.NoError(t, err)
+			_, err = server.RsvpToSession(id, noShowUserId)
+			assert.NoError(t, err)
+		}
+		mockClock.Set(checkInSessionStartsAt)
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{userId}, false, "admin-id"))
+		assert.NoError(t, server.EvaluateSessionReliability(sessionId))
+
+		unreliableUsers, err := server.AdminListUnreliableUsers()
+		assert.NoError(t, err)
+		// One no-show is not enough to be regarded as unreliable.
+		assert.Empty(t, unreliableUsers)
+
+		assert.NoError(t, server.MarkUsersAsPresent(nextSessionId, []string{userId}, false, "admin-id"))
+		assert.NoError(t, server.EvaluateSessionReliability(nextSessionId))
+		// Evaluating it again doesn't count the sign-ups twice.
+		assert.NoError(t, server.EvaluateSessionReliability(nextSessionId))
+
+		listedUsers, err := server.AdminListUsers()
+		assert.NoError(t, err)
+		assert.Equal(t, reliability.Score{SignUps: 2, Attended: 2, Score: 100}, listedUsers[0].Reliability)
+		noShowUser, err := server.AdminGetUser(noShowUserId)
+		assert.NoError(t, err)
+		assert.Equal(t, reliability.Score{SignUps: 2, NoShows: 2, Score: 0, IsUnreliable: true}, noShowUser.Reliability)
+		unreliableUsers, err = server.AdminListUnreliableUsers()
+		assert.NoError(t, err)
+		assert.Equal(t, []UserForAdmin{noShowUser}, unreliableUsers)
+	})
+	t.Run("Doesn't regard the member who missed the session with the approved excuse as a no-show", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		mockClock.Set(checkInSessionStartsAt.Add(-time.Hour))
+		_, err := server.RsvpToSession(sessionId, userId)
+		assert.NoError(t, err)
+		excused, err := server.SubmitExcuse(userId, sessionId, nil, nil, "발목 부상")
+		assert.NoError(t, err)
+		_, err = server.ReviewExcuse(excused.Id, true, false, "admin-id")
+		assert.NoError(t, err)
+		mockClock.Set(checkInSessionStartsAt)
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{}, false, "admin-id"))
+
+		assert.NoError(t, server.EvaluateSessionReliability(sessionId))
+
+		excusedUser, err := server.AdminGetUser(userId)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, excusedUser.Reliability.SignUps)
+		assert.Equal(t, 0, excusedUser.Reliability.NoShows)
+	})
+}
@@ -0,0 +1,349 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/rsvp"
+	"rush/session"
+	"rush/user"
+	"slices"
+)
+
+// The comparison of the RSVPs with the attendance of the session. The admins use it to spot the no-shows.
+type RsvpReport struct {
+	// The ID of the session. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// How many members can RSVP to the session. 0 means no limit. E.g., 12
+	Capacity int `json:"capacity"`
+	// The no-shows are not final until the attendance is applied. E.g., "applied"
+	AttendanceStatus session.AttendanceStatus `json:"attendance_status"`
+	// The members who had a spot and attended.
+	Attended []userForAttendance `json:"attended"`
+	// The members who had a spot but didn't attend.
+	NoShows []userForAttendance `json:"no_shows"`
+	// The members who attended without a spot. E.g., the waitlisted members or the ones who didn't RSVP.
+	WalkIns []userForAttendance `json:"walk_ins"`
+	// The members who are still on the waitlist and didn't attend.
+	Waitlisted []userForAttendance `json:"waitlisted"`
+}
+
+// RSVPs the member to the upcoming session. The member is put on the waitlist if the session is full.
+// If others have RSVPed at the same time, the ones that came first get the spots.
+func (s *Server) RsvpToSession(sessionId string, userId string) (Rsvp, error) {
+	dbSession, err := s.getUpcomingSession(sessionId)
+	if err != nil {
+		return Rsvp{}, err
+	}
+
+	rsvps, err := s.rsvpRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return Rsvp{}, newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+	}
+	confirmedCount := 0
+	for _, existing := range rsvps {
+		if existing.UserId == userId && existing.Status.IsActive() {
+			return Rsvp{}, newConflictError(errors.New("already RSVPed to the session"))
+		}
+		if existing.Status == rsvp.StatusConfirmed {
+			confirmedCount++
+		}
+	}
+
+	now := s.clock.Now()
+	newRsvp := rsvp.Rsvp{SessionId: sessionId, UserId: userId, Status: rsvp.StatusConfirmed, CreatedAt: now, UpdatedAt: now}
+	if dbSession.Capacity > 0 && confirmedCount >= dbSession.Capacity {
+		newRsvp.Status = rsvp.StatusWaitlisted
+	}
+	if newRsvp.Id, err = s.rsvpRepo.Add(newRsvp); err != nil {
+		if errors.Is(err, rsvp.ErrDuplicate) {
+			return Rsvp{}, newConflictError(fmt.Errorf("already RSVPed to the session: %w", err))
+		}
+		return Rsvp{}, newInternalServerError(fmt.Errorf("failed to add RSVP: %w", err))
+	}
+
+	// Others may have taken the last spots at the same time. Check again with the RSVPs that came first.
+	rsvps, err = s.rsvpRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return Rsvp{}, newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+	}
+	if newRsvp.Status == rsvp.StatusConfirmed && dbSession.Capacity > 0 {
+		confirmedBefore := 0
+		for _, existing := range rsvps {
+			if existing.Id == newRsvp.Id {
+				break
+			}
+			if existing.Status == rsvp.StatusConfirmed {
+				confirmedBefore++
+			}
+		}
+		if confirmedBefore >= dbSession.Capacity {
+			if err := s.rsvpRepo.UpdateStatus(newRsvp.Id, rsvp.StatusConfirmed, rsvp.StatusWaitlisted, now); err != nil {
+				if errors.Is(err, rsvp.ErrNotFound) {
+					return Rsvp{}, newConflictError(fmt.Errorf("RSVP has been changed by someone else: %w", err))
+				}
+				return Rsvp{}, newInternalServerError(fmt.Errorf("failed to waitlist the RSVP over the capacity: %w", err))
+			}
+			newRsvp.Status = rsvp.StatusWaitlisted
+			for index := range rsvps {
+				if rsvps[index].Id == newRsvp.Id {
+					rsvps[index].Status = rsvp.StatusWaitlisted
+				}
+			}
+		}
+	}
+
+	converted := fromRsvp(newRsvp)
+	converted.WaitlistPosition = waitlistPositions(rsvps)[newRsvp.Id]
+	return converted, nil
+}
+
+// Cancels the RSVP of the member to the upcoming session.
+// If the member had a spot, the first member on the waitlist gets it.
+func (s *Server) CancelRsvp(sessionId string, userId string) error {
+	dbSession, err := s.getUpcomingSession(sessionId)
+	if err != nil {
+		return err
+	}
+
+	rsvps, err := s.rsvpRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+	}
+	var active *rsvp.Rsvp
+	for index := range rsvps {
+		if rsvps[index].UserId == userId && rsvps[index].Status.IsActive() {
+			active = &rsvps[index]
+			break
+		}
+	}
+	if active == nil {
+		return newNotFoundError(errors.New("there is no RSVP to cancel"))
+	}
+
+	if err := s.rsvpRepo.UpdateStatus(active.Id, active.Status, rsvp.StatusCancelled, s.clock.Now()); err != nil {
+		if errors.Is(err, rsvp.ErrNotFound) {
+			return newConflictError(fmt.Errorf("RSVP has been changed by someone else: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to cancel RSVP: %w", err))
+	}
+	if active.Status != rsvp.StatusConfirmed {
+		return nil
+	}
+	return s.promoteWaitlist(dbSession)
+}
+
+// Returns the RSVPs of the member including the cancelled ones.
+func (s *Server) ListMyRsvps(userId string) ([]Rsvp, error) {
+	rsvps, err := s.rsvpRepo.FindByUserId(userId)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the RSVPs of the user: %w", err))
+	}
+
+	converted := []Rsvp{}
+	for _, userRsvp := range rsvps {
+		convertedRsvp := fromRsvp(userRsvp)
+		if userRsvp.Status == rsvp.StatusWaitlisted {
+			sessionRsvps, err := s.rsvpRepo.FindBySessionId(userRsvp.SessionId)
+			if err != nil {
+				return nil, newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+			}
+			convertedRsvp.WaitlistPosition = waitlistPositions(sessionRsvps)[userRsvp.Id]
+		}
+		converted = append(converted, convertedRsvp)
+	}
+	return converted, nil
+}
+
+// Returns the RSVPs of the session in the order of the RSVPs including the cancelled ones.
+func (s *Server) AdminListSessionRsvps(sessionId string) ([]Rsvp, error) {
+	rsvps, err := s.rsvpRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+	}
+
+	positions := waitlistPositions(rsvps)
+	converted := []Rsvp{}
+	for _, sessionRsvp := range rsvps {
+		convertedRsvp := fromRsvp(sessionRsvp)
+		convertedRsvp.WaitlistPosition = positions[sessionRsvp.Id]
+		converted = append(converted, convertedRsvp)
+	}
+	return converted, nil
+}
+
+// Compares the RSVPs of the session with its attendances.
+func (s *Server) AdminGetRsvpReport(sessionId string) (RsvpReport, error) {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return RsvpReport{}, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return RsvpReport{}, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	rsvps, err := s.rsvpRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return RsvpReport{}, newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+	}
+	attendances, err := s.attendanceRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return RsvpReport{}, newInternalServerError(fmt.Errorf("failed to get attendances: %w", err))
+	}
+	users, err := s.userRepo.GetAll()
+	if err != nil {
+		return RsvpReport{}, newInternalServerError(fmt.Errorf("failed to get users: %w", err))
+	}
+	idUserMap := map[string]user.User{}
+	for _, user := range users {
+		idUserMap[user.Id] = user
+	}
+	toUser := func(userId string) userForAttendance {
+		user := idUserMap[userId]
+		return userForAttendance{Id: userId, Name: user.Name, Generation: user.Generation}
+	}
+
+	attendedSet := map[string]bool{}
+	for _, attendance := range attendances {
+		attendedSet[attendance.UserId] = true
+	}
+	report := RsvpReport{
+		SessionId:        sessionId,
+		Capacity:         dbSession.Capacity,
+		AttendanceStatus: dbSession.AttendanceStatus,
+		Attended:         []userForAttendance{},
+		NoShows:          []userForAttendance{},
+		WalkIns:          []userForAttendance{},
+		Waitlisted:       []userForAttendance{},
+	}
+	confirmedSet := map[string]bool{}
+	for _, sessionRsvp := range rsvps {
+		switch {
+		case sessionRsvp.Status == rsvp.StatusConfirmed && attendedSet[sessionRsvp.UserId]:
+			report.Attended = append(report.Attended, toUser(sessionRsvp.UserId))
+		case sessionRsvp.Status == rsvp.StatusConfirmed:
+			report.NoShows = append(report.NoShows, toUser(sessionRsvp.UserId))
+		case sessionRsvp.Status == rsvp.StatusWaitlisted && !attendedSet[sessionRsvp.UserId]:
+			report.Waitlisted = append(report.Waitlisted, toUser(sessionRsvp.UserId))
+		}
+		if sessionRsvp.Status == rsvp.StatusConfirmed {
+			confirmedSet[sessionRsvp.UserId] = true
+		}
+	}
+	for _, attendance := range attendances {
+		if !confirmedSet[attendance.UserId] {
+			report.WalkIns = append(report.WalkIns, toUser(attendance.UserId))
+		}
+	}
+	return report, nil
+}
+
+// Sets how many members can RSVP to the open session. 0 removes the limit.
+// The members who already have a spot keep it when it's lowered, and the waitlisted members get the new spots when it's raised.
+func (s *Server) SetSessionCapacity(sessionId string, capacity int) (SessionForAdmin, error) {
+	if capacity < 0 {
+		return SessionForAdmin{}, newBadRequestError(errors.New("capacity should not be negative"))
+	}
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return SessionForAdmin{}, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return SessionForAdmin{}, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() {
+		return SessionForAdmin{}, newBadRequestError(errors.New("session is already closed"))
+	}
+
+	updatedSession, err := s.openSessionRepo.UpdateOpenSession(sessionId, session.OpenSessionUpdateForm{Capacity: &capacity, ReturnUpdatedSession: true})
+	if err != nil {
+		return SessionForAdmin{}, newInternalServerError(fmt.Errorf("failed to update the capacity of the session: %w", err))
+	}
+	if err := s.promoteWaitlist(updatedSession); err != nil {
+		return SessionForAdmin{}, err
+	}
+	return fromSessionToSessionForAdmin(updatedSession), nil
+}
+
+// Returns the session that the members can RSVP to. It should be open and not started yet.
+func (s *Server) getUpcomingSession(sessionId string) (session.Session, error) {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return session.Session{}, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return session.Session{}, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() || !s.clock.Now().Before(dbSession.StartsAt) {
+		return session.Session{}, newBadRequestError(errors.New("session has already started"))
+	}
+	return dbSession, nil
+}
+
+// Gives the spots of the session to the waitlisted members in the order of their RSVPs.
+// Others may have taken the spots at the same time, e.g., by RSVPing or by another promotion. So it checks again after each
+// promotion and puts the promoted member back on the waitlist if the session is over the capacity with the others.
+func (s *Server) promoteWaitlist(dbSession session.Session) error {
+	for {
+		rsvps, err := s.rsvpRepo.FindBySessionId(dbSession.Id)
+		if err != nil {
+			return newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+		}
+		if dbSession.Capacity > 0 && countConfirmed(rsvps, "") >= dbSession.Capacity {
+			return nil
+		}
+		index := slices.IndexFunc(rsvps, func(sessionRsvp rsvp.Rsvp) bool { return sessionRsvp.Status == rsvp.StatusWaitlisted })
+		if index < 0 {
+			return nil
+		}
+
+		now := s.clock.Now()
+		promoted := rsvps[index]
+		if err := s.rsvpRepo.UpdateStatus(promoted.Id, rsvp.StatusWaitlisted, rsvp.StatusConfirmed, now); err != nil {
+			// The member has cancelled it in the meantime.
+			if errors.Is(err, rsvp.ErrNotFound) {
+				continue
+			}
+			return newInternalServerError(fmt.Errorf("failed to promote the waitlisted RSVP: %w", err))
+		}
+		if dbSession.Capacity == 0 {
+			continue
+		}
+
+		rsvps, err = s.rsvpRepo.FindBySessionId(dbSession.Id)
+		if err != nil {
+			return newInternalServerError(fmt.Errorf("failed to get the RSVPs of the session: %w", err))
+		}
+		if countConfirmed(rsvps, promoted.Id) < dbSession.Capacity {
+			continue
+		}
+		if err := s.rsvpRepo.UpdateStatus(promoted.Id, rsvp.StatusConfirmed, rsvp.StatusWaitlisted, now); err != nil {
+			// The member has cancelled it in the meantime, so the spot is given back.
+			if errors.Is(err, rsvp.ErrNotFound) {
+				return nil
+			}
+			return newInternalServerError(fmt.Errorf("failed to waitlist the promoted RSVP over the capacity: %w", err))
+		}
+		return nil
+	}
+}
+
+// Returns the number of the confirmed RSVPs except the one with the ID.
+func countConfirmed(rsvps []rsvp.Rsvp, exceptId string) int {
+	count := 0
+	for _, sessionRsvp := range rsvps {
+		if sessionRsvp.Status == rsvp.StatusConfirmed && sessionRsvp.Id != exceptId {
+			count++
+		}
+	}
+	return count
+}
+
+// Returns the positions of the waitlisted RSVPs from 1 by their IDs.
+func waitlistPositions(rsvps []rsvp.Rsvp) map[string]int {
+	positions := map[string]int{}
+	for _, sessionRsvp := range rsvps {
+		if sessionRsvp.Status == rsvp.StatusWaitlisted {
+			positions[sessionRsvp.Id] = len(positions) + 1
+		}
+	}
+	return positions
+}
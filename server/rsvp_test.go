@@ -0,0 +1,188 @@
+package server
+
+import (
+	"errors"
+	"rush/rsvp"
+	"rush/user"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the RSVPs before the others RSVPed at the same time for the first read.
+type staleRsvpRepo struct {
+	rsvpRepo
+	isStale bool
+}
+
+func (r *staleRsvpRepo) FindBySessionId(sessionId string) ([]rsvp.Rsvp, error) {
+	if r.isStale {
+		r.isStale = false
+		return []rsvp.Rsvp{}, nil
+	}
+	return r.rsvpRepo.FindBySessionId(sessionId)
+}
+
+// Adds the RSVP of another member that takes the spot at the same time as the first promotion.
+type racingRsvpRepo struct {
+	rsvpRepo
+	racingRsvp *rsvp.Rsvp
+}
+
+func (r *racingRsvpRepo) UpdateStatus(id string, from rsvp.Status, to rsvp.Status, updatedAt time.Time) error {
+	if r.racingRsvp != nil && from == rsvp.StatusWaitlisted && to == rsvp.StatusConfirmed {
+		if _, err := r.rsvpRepo.Add(*r.racingRsvp); err != nil {
+			return err
+		}
+		r.racingRsvp = nil
+	}
+	return r.rsvpRepo.UpdateStatus(id, from, to, updatedAt)
+}
+
+func TestRsvp(t *testing.T) {
+	// Returns the server whose session with the capacity 1 starts in an hour, the IDs of 3 members and the clock.
+	newRsvpServer := func(t *testing.T) (*Server, string, []string, *clock.Mock) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		mockClock.Set(checkInSessionStartsAt.Add(-time.Hour))
+		userAdder := server.userRepo.(user.UserRepo)
+		assert.NoError(t, userAdder.Add(user.User{Name: "박지수", Generation: 10, IsActive: true, ExternalName: "박지수"}))
+		assert.NoError(t, userAdder.Add(user.User{Name: "이도현", Generation: 10, IsActive: true, ExternalName: "이도현"}))
+		users, err := server.userRepo.GetAll()
+		assert.NoError(t, err)
+		userIds := []string{userId}
+		for _, user := range users {
+			if user.Id != userId {
+				userIds = append(userIds, user.Id)
+			}
+		}
+		_, err = server.SetSessionCapacity(sessionId, 1)
+		assert.NoError(t, err)
+		return server, sessionId, userIds, mockClock
+	}
+
+	t.Run("Fails to RSVP twice or after the session has started", func(t *testing.T) {
+		server, sessionId, userIds, mockClock := newRsvpServer(t)
+		_, err := server.RsvpToSession(sessionId, userIds[0])
+		assert.NoError(t, err)
+
+		_, err = server.RsvpToSession(sessionId, userIds[0])
+		assert.Equal(t, newConflictError(errors.New("already RSVPed to the session")), err)
+
+		mockClock.Set(checkInSessionStartsAt)
+		_, err = server.RsvpToSession(sessionId, userIds[1])
+		assert.Equal(t, newBadRequestError(errors.New("session has already started")), err)
+		assert.Equal(t, newBadRequestError(errors.New("session has already started")), server.CancelRsvp(sessionId, userIds[0]))
+	})
+
+	t.Run("Fails to RSVP twice at the same time", func(t *testing.T) {
+		server, sessionId, userIds, _ := newRsvpServer(t)
+		_, err := server.RsvpToSession(sessionId, userIds[0])
+		assert.NoError(t, err)
+		server.rsvpRepo = &staleRsvpRepo{rsvpRepo: server.rsvpRepo, isStale: true}
+
+		_, err = server.RsvpToSession(sessionId, userIds[0])
+
+		var conflictError *ConflictError
+		assert.ErrorAs(t, err, &conflictError)
+	})
+
+	t.Run("Waitlists the member who RSVPed later at the same time for the last spot", func(t *testing.T) {
+		server, sessionId, userIds, _ := newRsvpServer(t)
+		_, err := server.RsvpToSession(sessionId, userIds[0])
+		assert.NoError(t, err)
+		server.rsvpRepo = &staleRsvpRepo{rsvpRepo: server.rsvpRepo, isStale: true}
+
+		later, err := server.RsvpToSession(sessionId, userIds[1])
+
+		assert.NoError(t, err)
+		assert.Equal(t, rsvp.StatusWaitlisted, later.Status)
+		assert.Equal(t, 1, later.WaitlistPosition)
+		rsvps, err := server.AdminListSessionRsvps(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, []rsvp.Status{rsvp.StatusConfirmed, rsvp.StatusWaitlisted}, []rsvp.Status{rsvps[0].Status, rsvps[1].Status})
+	})
+
+	t.Run("Waitlists the members when the session is full and promotes them in order", func(t *testing.T) {
+		server, sessionId, userIds, _ := newRsvpServer(t)
+		first, err := server.RsvpToSession(sessionId, userIds[0])
+		assert.NoError(t, err)
+		assert.Equal(t, rsvp.StatusConfirmed, first.Status)
+		second, err := server.RsvpToSession(sessionId, userIds[1])
+		assert.NoError(t, err)
+		assert.Equal(t, rsvp.StatusWaitlisted, second.Status)
+		assert.Equal(t, 1, second.WaitlistPosition)
+		third, err := server.RsvpToSession(sessionId, userIds[2])
+		assert.NoError(t, err)
+		assert.Equal(t, 2, third.WaitlistPosition)
+
+		assert.NoError(t, server.CancelRsvp(sessionId, userIds[0]))
+		var notFoundError *NotFoundError
+		assert.ErrorAs(t, server.CancelRsvp(sessionId, userIds[0]), &notFoundError)
+
+		rsvps, err := server.AdminListSessionRsvps(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, []rsvp.Status{rsvp.StatusCancelled, rsvp.StatusConfirmed, rsvp.StatusWaitlisted}, []rsvp.Status{rsvps[0].Status, rsvps[1].Status, rsvps[2].Status})
+		assert.Equal(t, 1, rsvps[2].WaitlistPosition)
+
+		_, err = server.SetSessionCapacity(sessionId, 0)
+		assert.NoError(t, err)
+		myRsvps, err := server.ListMyRsvps(userIds[2])
+		assert.NoError(t, err)
+		assert.Equal(t, rsvp.StatusConfirmed, myRsvps[0].Status)
+		assert.Equal(t, 0, myRsvps[0].WaitlistPosition)
+	})
+
+	t.Run("Waitlists the promoted member again when another member took the spot at the same time", func(t *testing.T) {
+		server, sessionId, userIds, mockClock := newRsvpServer(t)
+		_, err := server.RsvpToSession(sessionId, userIds[0])
+		assert.NoError(t, err)
+		_, err = server.RsvpToSession(sessionId, userIds[1])
+		assert.NoError(t, err)
+		server.rsvpRepo = &racingRsvpRepo{rsvpRepo: server.rsvpRepo, racingRsvp: &rsvp.Rsvp{
+			SessionId: sessionId,
+			UserId:    userIds[2],
+			Status:    rsvp.StatusConfirmed,
+			CreatedAt: mockClock.Now(),
+			UpdatedAt: mockClock.Now(),
+		}}
+
+		assert.NoError(t, server.CancelRsvp(sessionId, userIds[0]))
+
+		rsvps, err := server.AdminListSessionRsvps(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, []rsvp.Status{rsvp.StatusCancelled, rsvp.StatusWaitlisted, rsvp.StatusConfirmed}, []rsvp.Status{rsvps[0].Status, rsvps[1].Status, rsvps[2].Status})
+		assert.Equal(t, userIds[1], rsvps[1].UserId)
+		assert.Equal(t, 1, rsvps[1].WaitlistPosition)
+	})
+
+	t.Run("Reports the no-shows and the walk-ins", func(t *testing.T) {
+		server, sessionId, userIds, mockClock := newRsvpServer(t)
+		_, err := server.RsvpToSession(sessionId, userIds[0])
+		assert.NoError(t, err)
+		_, err = server.RsvpToSession(sessionId, userIds[1])
+		assert.NoError(t, err)
+
+		mockClock.Set(checkInSessionStartsAt)
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+		assert.NoError(t, server.CheckIn(sessionId, userIds[2], code.Code))
+		report, err := server.AdminGetRsvpReport(sessionId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Capacity)
+		assert.Empty(t, report.Attended)
+		assert.Equal(t, []string{userIds[0]}, idsOfUsersForAttendance(report.NoShows))
+		assert.Equal(t, []string{userIds[2]}, idsOfUsersForAttendance(report.WalkIns))
+		assert.Equal(t, []string{userIds[1]}, idsOfUsersForAttendance(report.Waitlisted))
+	})
+}
+
+func idsOfUsersForAttendance(users []userForAttendance) []string {
+	ids := []string{}
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+	return ids
+}
@@ -0,0 +1,265 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/golang/array"
+	"rush/series"
+	"rush/session"
+	"time"
+)
+
+// Returns all the session series including the cancelled ones.
+func (s *Server) AdminListSessionSeries() ([]SessionSeries, error) {
+	seriesList, err := s.sessionSeriesRepo.GetAll()
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get series: %w", err))
+	}
+	return array.Map(seriesList, fromSeries), nil
+}
+
+func (s *Server) AdminGetSessionSeries(id string) (SessionSeries, error) {
+	dbSeries, err := s.getSessionSeries(id)
+	if err != nil {
+		return SessionSeries{}, err
+	}
+	return fromSeries(dbSeries), nil
+}
+
+// Adds a series of the sessions that start every week at the given weekday and time in the form time location.
+// The name and the description are the templates of each session's. The sessions are created by the job from now on.
+func (s *Server) AddSessionSeries(name string, description string, createdBy string, weekday time.Weekday, hour int, minute int, score int) (string, error) {
+	newSeries := series.Series{
+		NameTemplate:        name,
+		DescriptionTemplate: description,
+		CreatedBy:           createdBy,
+		Weekday:             weekday,
+		Hour:                hour,
+		Minute:              minute,
+		Score:               score,
+		MaterializedUntil:   s.clock.Now(),
+	}
+	if err := newSeries.Validate(); err != nil {
+		return "", newBadRequestError(fmt.Errorf("invalid series: %w", err))
+	}
+
+	id, err := s.sessionSeriesRepo.Add(newSeries)
+	if err != nil {
+		return "", newInternalServerError(fmt.Errorf("failed to add series: %w", err))
+	}
+	return id, nil
+}
+
+// Updates the series and its future sessions that are still open. Nil arguments are not updated.
+// The past or closed sessions are kept as they are. It can't be rescheduled while any of its future sessions has
+// an attendance source or RSVPs because they would be lost by creating the sessions again.
+func (s *Server) UpdateSessionSeries(id string, name *string, description *string, weekday *time.Weekday, hour *int, minute *int, score *int) error {
+	dbSeries, err := s.getSessionSeries(id)
+	if err != nil {
+		return err
+	}
+	if dbSeries.IsCancelled {
+		return newBadRequestError(errors.New("series is already cancelled"))
+	}
+
+	updatedSeries := dbSeries
+	if name != nil {
+		updatedSeries.NameTemplate = *name
+	}
+	if description != nil {
+		updatedSeries.DescriptionTemplate = *description
+	}
+	if weekday != nil {
+		updatedSeries.Weekday = *weekday
+	}
+	if hour != nil {
+		updatedSeries.Hour = *hour
+	}
+	if minute != nil {
+		updatedSeries.Minute = *minute
+	}
+	if score != nil {
+		updatedSeries.Score = *score
+	}
+	if err := updatedSeries.Validate(); err != nil {
+		return newBadRequestError(fmt.Errorf("invalid series: %w", err))
+	}
+
+	futureSessions, err := s.getFutureOpenSessionsOfSeries(id)
+	if err != nil {
+		return err
+	}
+	isRescheduled := updatedSeries.Weekday != dbSeries.Weekday || updatedSeries.Hour != dbSeries.Hour || updatedSeries.Minute != dbSeries.Minute
+	if isRescheduled {
+		for _, futureSession := range futureSessions {
+			hasDependents, err := s.hasSessionDependents(futureSession)
+			if err != nil {
+				return err
+			}
+			if hasDependents {
+				return newBadRequestError(fmt.Errorf("session %s already has an attendance source or RSVPs. Change or delete it first", futureSession.Id))
+			}
+		}
+	}
+
+	if err := s.sessionSeriesRepo.Update(id, series.UpdateForm{
+		NameTemplate:        name,
+		DescriptionTemplate: description,
+		Weekday:             weekday,
+		Hour:                hour,
+		Minute:              minute,
+		Score:               score,
+	}); err != nil {
+		return newInternalServerError(fmt.Errorf("failed to update series: %w", err))
+	}
+
+	if isRescheduled {
+		// A week may not have the session at the new time. E.g., the new time is already past.
+		// Thus the future sessions are created again rather than moved.
+		for _, futureSession := range futureSessions {
+			if err := s.openSessionRepo.DeleteOpenSession(futureSession.Id); err != nil {
+				return newInternalServerError(fmt.Errorf("failed to delete session %s: %w", futureSession.Id, err))
+			}
+		}
+		updatedSeries.MaterializedUntil = s.clock.Now()
+		if _, err := s.materializeSessionSeries(updatedSeries, dbSeries.MaterializedUntil); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if name == nil && description == nil && score == nil {
+		return nil
+	}
+	for _, futureSession := range futureSessions {
+		sessionName, sessionDescription, err := updatedSeries.Render(futureSession.StartsAt, s.formTimeLocation)
+		if err != nil {
+			return newInternalServerError(fmt.Errorf("failed to render session: %w", err))
+		}
+		if name == nil {
+			sessionName = futureSession.Name
+		}
+		if description == nil {
+			sessionDescription = futureSession.Description
+		}
+		// It keeps the attendance form in sync as well.
+		if _, err := s.UpdateSession(futureSession.Id, &sessionName, &sessionDescription, nil, score); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cancels the series. Its future sessions that are still open are deleted and no more sessions are created.
+// The sessions that already have an attendance source or RSVPs are kept so that the members don't lose them.
+func (s *Server) CancelSessionSeries(id string) error {
+	if _, err := s.getSessionSeries(id); err != nil {
+		return err
+	}
+
+	isCancelled := true
+	if err := s.sessionSeriesRepo.Update(id, series.UpdateForm{IsCancelled: &isCancelled}); err != nil {
+		return newInternalServerError(fmt.Errorf("failed to cancel series: %w", err))
+	}
+
+	futureSessions, err := s.getFutureOpenSessionsOfSeries(id)
+	if err != nil {
+		return err
+	}
+	for _, futureSession := range futureSessions {
+		hasDependents, err := s.hasSessionDependents(futureSession)
+		if err != nil {
+			return err
+		}
+		if hasDependents {
+			continue
+		}
+		if err := s.openSessionRepo.DeleteOpenSession(futureSession.Id); err != nil {
+			return newInternalServerError(fmt.Errorf("failed to delete session %s: %w", futureSession.Id, err))
+		}
+	}
+	return nil
+}
+
+// Creates the sessions of the series that start before `until` and returns their IDs.
+// The sessions that have been created once are not created again even if they are deleted.
+func (s *Server) MaterializeSessionSeries(id string, until time.Time) ([]string, error) {
+	dbSeries, err := s.getSessionSeries(id)
+	if err != nil {
+		return nil, err
+	}
+	if dbSeries.IsCancelled {
+		return nil, newBadRequestError(errors.New("series is already cancelled"))
+	}
+	return s.materializeSessionSeries(dbSeries, until)
+}
+
+func (s *Server) materializeSessionSeries(dbSeries series.Series, until time.Time) ([]string, error) {
+	// The past sessions are not created even if the job has not run for a while.
+	from := dbSeries.MaterializedUntil
+	if now := s.clock.Now(); from.Before(now) {
+		from = now
+	}
+
+	sessionIds := []string{}
+	for _, startsAt := range dbSeries.Occurrences(from, until, s.formTimeLocation) {
+		name, description, err := dbSeries.Render(startsAt, s.formTimeLocation)
+		if err != nil {
+			return sessionIds, newInternalServerError(fmt.Errorf("failed to render session: %w", err))
+		}
+
+		sessionId, err := s.sessionRepo.AddToSeries(dbSeries.Id, name, description, dbSeries.CreatedBy, startsAt, dbSeries.Score)
+		if err != nil {
+			// Keep the progress so that the added sessions are not added again on the next run.
+			if updateErr := s.sessionSeriesRepo.Update(dbSeries.Id, series.UpdateForm{MaterializedUntil: &startsAt}); updateErr != nil {
+				return sessionIds, newInternalServerError(fmt.Errorf("failed to add session: %w, and failed to update series: %v", err, updateErr))
+			}
+			return sessionIds, newInternalServerError(fmt.Errorf("failed to add session: %w", err))
+		}
+		sessionIds = append(sessionIds, sessionId)
+	}
+
+	if until.After(dbSeries.MaterializedUntil) {
+		if err := s.sessionSeriesRepo.Update(dbSeries.Id, series.UpdateForm{MaterializedUntil: &until}); err != nil {
+			return sessionIds, newInternalServerError(fmt.Errorf("failed to update series: %w", err))
+		}
+	}
+	return sessionIds, nil
+}
+
+func (s *Server) getSessionSeries(id string) (series.Series, error) {
+	dbSeries, err := s.sessionSeriesRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, series.ErrNotFound) {
+			return series.Series{}, newNotFoundError(fmt.Errorf("failed to get series: %w", err))
+		}
+		return series.Series{}, newInternalServerError(fmt.Errorf("failed to get series: %w", err))
+	}
+	return dbSeries, nil
+}
+
+// Returns the sessions of the series that have not started and are still open.
+func (s *Server) getFutureOpenSessionsOfSeries(id string) ([]session.Session, error) {
+	sessions, err := s.sessionRepo.GetAllBySeriesId(id)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get sessions of series: %w", err))
+	}
+
+	now := s.clock.Now()
+	return array.Filter(sessions, func(session session.Session) bool {
+		return session.StartsAt.After(now) && session.CanUpdateMetadata()
+	}), nil
+}
+
+// Returns true if the session has what would be left behind by deleting it. E.g., the attendance form, the check-in
+// with the meeting point or the RSVPs of the members.
+func (s *Server) hasSessionDependents(dbSession session.Session) (bool, error) {
+	if dbSession.AttendanceSource.IsSet() {
+		return true, nil
+	}
+	rsvps, err := s.rsvpRepo.FindBySessionId(dbSession.Id)
+	if err != nil {
+		return false, newInternalServerError(fmt.Errorf("failed to get the RSVPs of session %s: %w", dbSession.Id, err))
+	}
+	return len(rsvps) > 0, nil
+}
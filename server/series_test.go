@@ -0,0 +1,193 @@
+package server
+
+import (
+	"fmt"
+	"rush/attendance"
+	"rush/golang/array"
+	"rush/rsvp"
+	"rush/series"
+	"rush/session"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionSeries(t *testing.T) {
+	// 2025-07-01 is Tuesday.
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	newServer := func() (*Server, session.Repo, *clock.Mock) {
+		sessionRepo := session.NewMemoryRepo()
+		mockClock := clock.NewMock()
+		mockClock.Set(now)
+		server := New(Deps{
+			SessionRepo:       sessionRepo,
+			OpenSessionRepo:   session.NewService(sessionRepo),
+			SessionSeriesRepo: series.NewMemoryRepo(),
+			AttendanceRepo:    attendance.NewMemoryRepo(mockClock),
+			RsvpRepo:          rsvp.NewMemoryRepo(),
+			FormTimeLocation:  time.UTC,
+			Clock:             mockClock,
+		})
+		return server, sessionRepo, mockClock
+	}
+	startTimes := func(server *Server, seriesId string) []time.Time {
+		sessions, err := server.sessionRepo.GetAllBySeriesId(seriesId)
+		assert.NoError(t, err)
+		return array.Map(sessions, func(session session.Session) time.Time { return session.StartsAt })
+	}
+
+	t.Run("Fails to add an invalid series", func(t *testing.T) {
+		server, _, _ := newServer()
+
+		_, err := server.AddSessionSeries("정규런", "", "user-id", time.Tuesday, 24, 0, 2)
+
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Returns not found error when the series is not found", func(t *testing.T) {
+		server, _, _ := newServer()
+
+		_, err := server.AdminGetSessionSeries("series-id")
+
+		assert.Equal(t, newNotFoundError(fmt.Errorf("failed to get series: %w", series.ErrNotFound)), err)
+	})
+
+	t.Run("Creates the sessions ahead only once", func(t *testing.T) {
+		server, _, _ := newServer()
+		id, err := server.AddSessionSeries("{{.Date}} 정규런", "{{.Time}} 여의도", "user-id", time.Tuesday, 20, 0, 2)
+		assert.NoError(t, err)
+
+		sessionIds, err := server.MaterializeSessionSeries(id, time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Len(t, sessionIds, 2)
+		created, err := server.AdminGetSession(sessionIds[0])
+		assert.NoError(t, err)
+		assert.Equal(t, SessionForAdmin{
+			Id:                  sessionIds[0],
+			Name:                "2025-07-01 정규런",
+			Description:         "20:00 여의도",
+			CreatedBy:           "user-id",
+			CreatedAt:           created.CreatedAt,
+			StartsAt:            time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC),
+			Score:               2,
+			AttendanceStatus:    session.AttendanceStatusNotAppliedYet,
+			AttendanceAppliedBy: SessionAttendanceAppliedByUnspecified,
+			SeriesId:            id,
+		}, created)
+
+		// The deleted session is not created again.
+		assert.NoError(t, server.DeleteSession(sessionIds[1]))
+		sessionIds, err = server.MaterializeSessionSeries(id, time.Date(2025, 7, 22, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Len(t, sessionIds, 1)
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC),
+			time.Date(2025, 7, 15, 20, 0, 0, 0, time.UTC),
+		}, startTimes(server, id))
+	})
+
+	t.Run("Updates only the future open sessions", func(t *testing.T) {
+		server, sessionRepo, mockClock := newServer()
+		id, err := server.AddSessionSeries("정규런", "", "user-id", time.Tuesday, 20, 0, 2)
+		assert.NoError(t, err)
+		sessionIds, err := server.MaterializeSessionSeries(id, time.Date(2025, 7, 22, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		applied := session.AttendanceStatusApplied
+		_, err = sessionRepo.Update(sessionIds[0], session.UpdateForm{AttendanceStatus: &applied})
+		assert.NoError(t, err)
+		mockClock.Set(time.Date(2025, 7, 9, 0, 0, 0, 0, time.UTC))
+
+		name := "화요 정규런"
+		score := 3
+		assert.NoError(t, server.UpdateSessionSeries(id, &name, nil, nil, nil, nil, &score))
+
+		sessions, err := sessionRepo.GetAllBySeriesId(id)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"정규런", "정규런", "화요 정규런"}, array.Map(sessions, func(session session.Session) string { return session.Name }))
+		assert.Equal(t, []int{2, 2, 3}, array.Map(sessions, func(session session.Session) int { return session.Score }))
+	})
+
+	t.Run("Creates the future open sessions again when it's rescheduled", func(t *testing.T) {
+		server, _, mockClock := newServer()
+		id, err := server.AddSessionSeries("정규런", "", "user-id", time.Tuesday, 20, 0, 2)
+		assert.NoError(t, err)
+		_, err = server.MaterializeSessionSeries(id, time.Date(2025, 7, 22, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		mockClock.Set(time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC))
+
+		weekday := time.Thursday
+		assert.NoError(t, server.UpdateSessionSeries(id, nil, nil, &weekday, nil, nil, nil))
+
+		assert.Equal(t, []time.Time{
+			// It has already started.
+			time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC),
+			time.Date(2025, 7, 3, 20, 0, 0, 0, time.UTC),
+			time.Date(2025, 7, 10, 20, 0, 0, 0, time.UTC),
+			time.Date(2025, 7, 17, 20, 0, 0, 0, time.UTC),
+		}, startTimes(server, id))
+	})
+
+	t.Run("Fails to reschedule the series whose future session has RSVPs", func(t *testing.T) {
+		server, _, mockClock := newServer()
+		id, err := server.AddSessionSeries("정규런", "", "user-id", time.Tuesday, 20, 0, 2)
+		assert.NoError(t, err)
+		sessionIds, err := server.MaterializeSessionSeries(id, time.Date(2025, 7, 22, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		mockClock.Set(time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC))
+		_, err = server.RsvpToSession(sessionIds[1], "user-id")
+		assert.NoError(t, err)
+
+		weekday := time.Thursday
+		err = server.UpdateSessionSeries(id, nil, nil, &weekday, nil, nil, nil)
+
+		assert.Equal(t, newBadRequestError(fmt.Errorf("session %s already has an attendance source or RSVPs. Change or delete it first", sessionIds[1])), err)
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC),
+			time.Date(2025, 7, 8, 20, 0, 0, 0, time.UTC),
+			time.Date(2025, 7, 15, 20, 0, 0, 0, time.UTC),
+		}, startTimes(server, id))
+		notRescheduled, err := server.AdminGetSessionSeries(id)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Tuesday, notRescheduled.Weekday)
+		rsvps, err := server.AdminListSessionRsvps(sessionIds[1])
+		assert.NoError(t, err)
+		assert.Len(t, rsvps, 1)
+	})
+
+	t.Run("Cancels the series and deletes the future open sessions", func(t *testing.T) {
+		server, _, mockClock := newServer()
+		id, err := server.AddSessionSeries("정규런", "", "user-id", time.Tuesday, 20, 0, 2)
+		assert.NoError(t, err)
+		_, err = server.MaterializeSessionSeries(id, time.Date(2025, 7, 22, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		mockClock.Set(time.Date(2025, 7, 9, 0, 0, 0, 0, time.UTC))
+
+		assert.NoError(t, server.CancelSessionSeries(id))
+
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC),
+			time.Date(2025, 7, 8, 20, 0, 0, 0, time.UTC),
+		}, startTimes(server, id))
+		cancelled, err := server.AdminGetSessionSeries(id)
+		assert.NoError(t, err)
+		assert.True(t, cancelled.IsCancelled)
+		_, err = server.MaterializeSessionSeries(id, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC))
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Keeps the future session with RSVPs when the series is cancelled", func(t *testing.T) {
+		server, _, _ := newServer()
+		id, err := server.AddSessionSeries("정규런", "", "user-id", time.Tuesday, 20, 0, 2)
+		assert.NoError(t, err)
+		sessionIds, err := server.MaterializeSessionSeries(id, time.Date(2025, 7, 22, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		_, err = server.RsvpToSession(sessionIds[1], "user-id")
+		assert.NoError(t, err)
+
+		assert.NoError(t, server.CancelSessionSeries(id))
+
+		assert.Equal(t, []time.Time{time.Date(2025, 7, 8, 20, 0, 0, 0, time.UTC)}, startTimes(server, id))
+	})
+}
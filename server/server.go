@@ -2,9 +2,18 @@ package server
 
 import (
 	"rush/attendance"
+	"rush/audit"
 	"rush/auth"
+	"rush/checkin"
+	"rush/excuse"
 	"rush/permission"
+	"rush/reliability"
+	"rush/rsvp"
+	"rush/scoring"
+	"rush/series"
 	"rush/session"
+	"rush/term"
+	"rush/unmatched"
 	"rush/user"
 	"time"
 
@@ -39,9 +48,10 @@ type SessionForAdmin struct {
 	Description string `json:"description"`
 	// The ID of the user who created the session. E.g., "abc123"
 	CreatedBy string `json:"created_by"`
-	// The URI of the Google form for the session. E.g., "https://docs.google.com/forms/d/e/1FAIpQLSd..."
+	// The URI of the Google form for the session. Empty if the attendance source is not the Google form.
+	// E.g., "https://docs.google.com/forms/d/e/1FAIpQLSd..."
 	GoogleFormUri string `json:"google_form_uri"`
-	// The ID of the Google form for the session. E.g., "1FAIpQLSd..."
+	// The ID of the Google form for the session. Empty if the attendance source is not the Google form. E.g., "1FAIpQLSd..."
 	GoogleFormId string `json:"google_form_id"`
 	// The time in UTC when the session is created.
 	CreatedAt time.Time `json:"created_at"`
@@ -54,6 +64,166 @@ type SessionForAdmin struct {
 	AttendanceStatus session.AttendanceStatus `json:"attendance_status"`
 	// The flag to indicate how the attendance is applied. E.g., "manual" or "form".
 	AttendanceAppliedBy SessionAttendanceAppliedBy `json:"attendance_applied_by"`
+	// The ID of the series that the session is created for. Empty if it's not a recurring session. E.g., "abc123"
+	SeriesId string `json:"series_id"`
+	// Where the attendance of the session is collected from. Nil if it's not set.
+	AttendanceSource *AttendanceSource `json:"attendance_source"`
+	// Where the members meet and check in. Nil if it's not set.
+	MeetingPoint *MeetingPoint `json:"meeting_point"`
+	// How many members can RSVP to the session. 0 means no limit. E.g., 12
+	Capacity int `json:"capacity"`
+}
+
+type AttendanceSource struct {
+	// The provider of the source. E.g., "google_form", "check_in" or "csv"
+	Provider session.AttendanceSourceProvider `json:"provider"`
+	// The ID of the source in the provider. E.g., the Google form ID or the name of the imported CSV file
+	ExternalId string `json:"external_id"`
+	// The URI that the members open to submit their attendance. Empty if the provider doesn't have one.
+	Uri string `json:"uri"`
+}
+
+type MeetingPoint struct {
+	// The latitude of the meeting point. E.g., 37.5283
+	Latitude float64 `json:"latitude"`
+	// The longitude of the meeting point. E.g., 126.9326
+	Longitude float64 `json:"longitude"`
+	// How far from the meeting point the members can check in. E.g., 100
+	RadiusMeters float64 `json:"radius_meters"`
+}
+
+// The submission from the attendance source that couldn't be matched to any user. The admins resolve it.
+type UnmatchedSubmission struct {
+	// The ID of the submission. E.g., "abc123"
+	Id string `json:"id"`
+	// The answer as it was submitted. E.g., "9:김건"
+	Answer string `json:"answer"`
+	// The external name read from the answer. It's empty if the answer couldn't be read. E.g., "김건4"
+	UserExternalName string `json:"user_external_name"`
+	// Why it couldn't be matched. E.g., "user_not_found"
+	Reason unmatched.Reason `json:"reason"`
+	// The details of the reason. E.g., "invalid option format: 9:김건"
+	Detail string `json:"detail"`
+	// The time in UTC when it was submitted. It's zero if it couldn't be read.
+	SubmissionTime time.Time `json:"submission_time"`
+	// How the admin resolved it. It's nil until it's resolved.
+	Resolution *UnmatchedSubmissionResolution `json:"resolution"`
+}
+
+type UnmatchedSubmissionResolution struct {
+	// What the admin decided. E.g., "mapped" or "discarded"
+	Action unmatched.ResolutionAction `json:"action"`
+	// The ID of the user that the submission is mapped to. It's empty if it's discarded. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// The ID of the admin who resolved it. E.g., "abc123"
+	ResolvedBy string `json:"resolved_by"`
+	// The time in UTC when it was resolved.
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// The excuse of a member for the sessions that they will miss. The admins approve or reject it.
+type Excuse struct {
+	// The ID of the excuse. E.g., "abc123"
+	Id string `json:"id"`
+	// The ID of the member who will miss the sessions. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// The ID of the session that the member will miss. It's empty if the excuse is for a date range. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The time range [StartsAt, EndsAt) in UTC that the member will miss. Both are the start time of the session for a session.
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// Why the member will miss the sessions. E.g., "발목 부상"
+	Reason string `json:"reason"`
+	// E.g., "pending", "approved" or "rejected"
+	Status excuse.Status `json:"status"`
+	// Whether the excused sessions count toward the score of the member.
+	CountsTowardScore bool `json:"counts_toward_score"`
+	// The ID of the admin who reviewed it. It's empty while it's pending. E.g., "abc123"
+	ReviewedBy string `json:"reviewed_by"`
+	// The time in UTC when it was reviewed. It's zero while it's pending.
+	ReviewedAt time.Time `json:"reviewed_at"`
+	// The time in UTC when it was submitted.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// A change that an admin made to a record of a member. The members can see the changes of their own records.
+type AuditEntry struct {
+	// The ID of the entry. E.g., "abc123"
+	Id string `json:"id"`
+	// E.g., "attendance_removed" or "attendance_corrected"
+	Action audit.Action `json:"action"`
+	// The ID of the member whose record was changed. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// The ID of the session that the record belongs to. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The ID of the changed record such as the attendance. E.g., "abc123"
+	TargetId string `json:"target_id"`
+	// Why the admin made the change. E.g., "다른 회원으로 잘못 체크인"
+	Reason string `json:"reason"`
+	// The values of the fields before and after the change.
+	Changes []audit.Change `json:"changes"`
+	// The ID of the admin who made the change. E.g., "abc123"
+	ActorId string `json:"actor_id"`
+	// The time in UTC when the change was made.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// The RSVP of a member to a session.
+type Rsvp struct {
+	// The ID of the RSVP. E.g., "abc123"
+	Id string `json:"id"`
+	// The ID of the session. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The ID of the member. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// E.g., "confirmed", "waitlisted" or "cancelled"
+	Status rsvp.Status `json:"status"`
+	// The position in the waitlist from 1. It's 0 if it's not waitlisted. E.g., 2
+	WaitlistPosition int `json:"waitlist_position"`
+	// The time in UTC when the member RSVPed.
+	CreatedAt time.Time `json:"created_at"`
+	// The time in UTC when the status was changed last time.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// The user with how reliable they are. Only the admins can see it.
+type UserForAdmin struct {
+	// The ID of the user. E.g., "abc123"
+	Id string `json:"id"`
+	// The name of the user. E.g., "김건"
+	Name string `json:"name"`
+	// The generation of the user. E.g., 9.5
+	Generation float64 `json:"generation"`
+	// The activity status of the user. E.g., true
+	IsActive bool `json:"is_active"`
+	// The email address of the user. E.g., "kim.geon@gmail.com"
+	Email string `json:"email"`
+	// The external name of the user. E.g., "김건3"
+	ExternalName string `json:"external_name"`
+	// E.g., "admin"
+	Role permission.Role `json:"role"`
+	// How often the user attends the sessions that they RSVPed to.
+	Reliability reliability.Score `json:"reliability"`
+}
+
+// The check-in at the meeting point that is rejected. The admins review it to check if the member was really there.
+type CheckInRejection struct {
+	// The ID of the rejection. E.g., "abc123"
+	Id string `json:"id"`
+	// The ID of the user who tried to check in. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// The latitude that the user submitted. E.g., 37.5283
+	Latitude float64 `json:"latitude"`
+	// The longitude that the user submitted. E.g., 126.9326
+	Longitude float64 `json:"longitude"`
+	// The accuracy of the location in meters that the device reported. E.g., 15
+	AccuracyMeters float64 `json:"accuracy_meters"`
+	// The distance in meters from the meeting point. E.g., 320.5
+	DistanceMeters float64 `json:"distance_meters"`
+	// Why it's rejected. E.g., "outside_geofence"
+	Reason checkin.RejectionReason `json:"reason"`
+	// The time in UTC when the user tried to check in.
+	AttemptedAt time.Time `json:"attempted_at"`
 }
 
 // Session for a user. It includes fields that are safe for a user to know.
@@ -65,6 +235,8 @@ type Session struct {
 	CreatedAt   time.Time `json:"created_at"`
 	StartsAt    time.Time `json:"starts_at"`
 	Score       int       `json:"score"`
+	// How many members can RSVP to the session. 0 means no limit.
+	Capacity int `json:"capacity"`
 }
 
 type SessionAttendanceAppliedBy string
@@ -79,6 +251,10 @@ const (
 	SessionAttendanceAppliedByManual SessionAttendanceAppliedBy = "manual"
 	// The attendance is applied by the form submissions.
 	SessionAttendanceAppliedByForm SessionAttendanceAppliedBy = "form"
+	// The attendance is applied by the check-ins with the code shown at the meeting point.
+	SessionAttendanceAppliedByCheckIn SessionAttendanceAppliedBy = "check_in"
+	// The attendance is applied by the imported CSV file.
+	SessionAttendanceAppliedByCsv SessionAttendanceAppliedBy = "csv"
 )
 
 type Attendance struct {
@@ -105,12 +281,66 @@ type Attendance struct {
 	UserJoinedAt time.Time `json:"user_joined_at"`
 	// The time in UTC when the attendance is created.
 	CreatedAt time.Time `json:"created_at"`
+	// How the user attended the session. E.g., "on_time", "late", "excused" or "force_applied"
+	Status attendance.Status `json:"status"`
+	// Why the admin set the status. Empty if it's decided by the time when the user joined. E.g., "교통 체증"
+	StatusReason string `json:"status_reason"`
+	// The ID of the admin who set the status. Empty if it's decided by the time when the user joined. E.g., "abc123"
+	StatusSetBy string `json:"status_set_by"`
+}
+
+type Term struct {
+	// The ID of the term. E.g., "abc123"
+	Id string `json:"id"`
+	// The name of the term. E.g., "2025-2"
+	Name string `json:"name"`
+	// The time in UTC when the term starts. It's inclusive.
+	StartsAt time.Time `json:"starts_at"`
+	// The time in UTC when the term ends. It's exclusive.
+	EndsAt time.Time `json:"ends_at"`
+	// The generations of the users who participate in the term. E.g., [9, 9.5, 10]
+	// Empty means every generation participates.
+	Generations []float64 `json:"generations"`
+	// Whether the term is archived. A term is archived when the club rolls over to the next term.
+	IsArchived bool `json:"is_archived"`
+	// The rules to score the attendances of the term. Nil if the admins haven't set them.
+	ScoringRules *scoring.Rules `json:"scoring_rules"`
+	// The time in UTC when the term is created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// The sessions that repeat every week. The sessions are created ahead of time by the job.
+type SessionSeries struct {
+	// The ID of the series. E.g., "abc123"
+	Id string `json:"id"`
+	// The template of the session names. It can have {{.Date}} and {{.Time}}. E.g., "{{.Date}} 여의도 정규런"
+	Name string `json:"name"`
+	// The template of the session descriptions. It can have {{.Date}} and {{.Time}}.
+	Description string `json:"description"`
+	// The ID of the user who created the series. E.g., "abc123"
+	CreatedBy string `json:"created_by"`
+	// The weekday when the sessions start. 0 is Sunday. E.g., 2
+	Weekday time.Weekday `json:"weekday"`
+	// The hour when the sessions start in the local time. E.g., 20
+	Hour int `json:"hour"`
+	// The minute when the sessions start in the local time. E.g., 30
+	Minute int `json:"minute"`
+	// The score of the sessions. E.g., 2
+	Score int `json:"score"`
+	// The time in UTC until when the sessions have been created.
+	MaterializedUntil time.Time `json:"materialized_until"`
+	// Whether the series is cancelled. The cancelled series doesn't create sessions anymore.
+	IsCancelled bool `json:"is_cancelled"`
+	// The time in UTC when the series is created.
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // The API request session. It contains the user information and some more to
 // specify the session for the API request.
 type UserSession struct {
-	UserId    string          `json:"user_id"`
+	UserId string `json:"user_id"`
+	// The ID of the token that the session is from. It's used to sign out of the session.
+	TokenId   string          `json:"token_id"`
 	Role      permission.Role `json:"role"`
 	ExpiresAt time.Time       `json:"expires_at"`
 }
@@ -123,8 +353,19 @@ type oauthClient interface {
 type authHandler interface {
 	// Extracts session from the rush token.
 	GetSession(token string) (auth.Session, error)
-	// Returns the rush token that is used for API calls after signing in.
-	SignIn(userId string, role permission.Role) (string, error)
+	// Returns the rush token that is used for API calls after signing in, and its session.
+	SignIn(userId string, role permission.Role) (string, auth.Session, error)
+}
+
+type tokenRepo interface {
+	// Keeps the issued token.
+	Add(token auth.Token) error
+	// Returns the token by its ID. If not found, it returns auth.ErrNotFound.
+	Get(id string) (auth.Token, error)
+	// Revokes the token. The time of the first revocation is kept. If not found, it returns auth.ErrNotFound.
+	Revoke(id string, revokedAt time.Time) error
+	// Revokes all the tokens of the user that are not revoked yet.
+	RevokeAllByUserId(userId string, revokedAt time.Time) error
 }
 
 type userRepo interface {
@@ -154,11 +395,24 @@ type userUpdater interface {
 	Update(id string, updateForm user.UpdateForm) error
 }
 
+type userRoller interface {
+	// Compares the current users with the roster of the new term and returns what will be changed.
+	Plan(roster []user.User) (user.RolloverPlan, error)
+	// Applies the plan. Nothing is deleted.
+	Apply(plan user.RolloverPlan) error
+}
+
 type sessionRepo interface {
 	Get(id string) (session.Session, error)
 	GetAll() ([]session.Session, error)
 	List(offset int, pageSize int) (*session.ListResult, error)
+	// Returns the sessions that start within [from, to). Typically used to get the sessions of a term.
+	GetAllStartingBetween(from time.Time, to time.Time) ([]session.Session, error)
 	Add(name string, description string, createdBy string, startsAt time.Time, score int) (string, error)
+	// Adds a session that is created for the series.
+	AddToSeries(seriesId string, name string, description string, createdBy string, startsAt time.Time, score int) (string, error)
+	// Returns the sessions of the series sorted by the start time.
+	GetAllBySeriesId(seriesId string) ([]session.Session, error)
 }
 
 // The repo that includes logics to update or delete the open sessions.
@@ -177,19 +431,131 @@ type openSessionRepo interface {
 type attendanceFormHandler interface {
 	// Generates a form with the title, description, and user external names/generations for attendance.
 	GenerateForm(title string, description string, userOptions []attendance.UserOption) (attendance.Form, error)
+	// Updates the title and the description of the form. It's used to keep the form in sync with its session.
+	UpdateFormInfo(formId string, title string, description string) error
 	// Extracts the submissions submitted to the form by the users.
-	GetSubmissions(formId string) ([]attendance.FormSubmission, error)
+	GetSubmissions(formId string) ([]attendance.FormSubmission, []attendance.InvalidSubmission, error)
+}
+
+// The source that the attendance of the sessions is collected from. Each provider implements it.
+type attendanceSource interface {
+	// Returns the submissions of the source with the ID in the provider.
+	// The submissions that can't be read are returned as the invalid submissions instead of failing all.
+	GetSubmissions(externalId string) ([]attendance.FormSubmission, []attendance.InvalidSubmission, error)
 }
 
 type attendanceRepo interface {
 	// Returns all the attendance requests. It is used to provide admins with the attendance result of all users.
 	GetAll() ([]attendance.Attendance, error)
-	// Inserts the attendance requests in bulk. It's used to insert the attendance requests after closing the session.
-	BulkInsert(requests []attendance.AddAttendanceReq) error
+	// Inserts the attendance requests in bulk and returns their IDs. It's used to insert the attendance requests after closing the session.
+	// It inserts all or nothing. If a user already has an attendance for the session, it returns attendance.ErrDuplicate.
+	BulkInsert(requests []attendance.AddAttendanceReq) ([]string, error)
+	// Returns the attendance by the given ID.
+	// If not found, it returns attendance.ErrNotFound.
+	Get(id string) (attendance.Attendance, error)
+	// Inserts the deleted attendance back with its ID. It's used to undo Delete when the audit log of the removal fails.
+	// If the user already has an attendance for the session, it returns attendance.ErrDuplicate.
+	Restore(attendance attendance.Attendance) error
+	// Deletes the attendances by the IDs. It's used to undo BulkInsert when closing the session fails or to remove a wrong attendance.
+	Delete(ids []string) error
+	// Corrects the attendance. If not found, it returns attendance.ErrNotFound.
+	Update(id string, updateForm attendance.UpdateAttendanceForm) error
 	// Returns the attendances that are related to the user. Typically used to get the attendances for each user.
 	FindByUserId(userId string) ([]attendance.Attendance, error)
 	// Returns the attendances that are related to the session. Typically used for admins to see if attendance is applied well.
 	FindBySessionId(sessionId string) ([]attendance.Attendance, error)
+	// Returns the attendances of the sessions that started within [from, to). Typically used to get the attendances of a term.
+	FindBySessionStartedAtBetween(from time.Time, to time.Time) ([]attendance.Attendance, error)
+}
+
+type termRepo interface {
+	// Returns the term by the given ID.
+	// If not found, it returns ErrNotFound.
+	Get(id string) (term.Term, error)
+	// Returns all the terms. The latest term comes first.
+	GetAll() ([]term.Term, error)
+	// Returns the term whose period includes the given time. Typically used to get the current term.
+	// If not found, it returns ErrNotFound.
+	GetByTime(at time.Time) (term.Term, error)
+	Add(name string, startsAt time.Time, endsAt time.Time, generations []float64) (string, error)
+	Update(id string, updateForm term.UpdateForm) error
+	Delete(id string) error
+}
+
+type sessionSeriesRepo interface {
+	// Returns the series by the given ID.
+	// If not found, it returns ErrNotFound.
+	Get(id string) (series.Series, error)
+	// Returns all the series including the cancelled ones.
+	GetAll() ([]series.Series, error)
+	Add(series series.Series) (string, error)
+	Update(id string, updateForm series.UpdateForm) error
+}
+
+type checkInCodeHandler interface {
+	// Returns the check-in code of the session that is valid for now.
+	Issue(sessionId string) checkin.Code
+	// Checks if the code is the valid check-in code of the session.
+	Verify(sessionId string, code string) bool
+}
+
+type checkInRejectionRepo interface {
+	AddRejection(rejection checkin.Rejection) (string, error)
+	// Returns the rejections of the session in the order of the attempts.
+	FindRejectionsBySessionId(sessionId string) ([]checkin.Rejection, error)
+}
+
+type unmatchedSubmissionRepo interface {
+	// Replaces the unmatched submissions of the session. Empty submissions clear them.
+	ReplaceBySessionId(sessionId string, submissions []unmatched.Submission) error
+	// Returns the unmatched submissions of the session in the order of submission.
+	FindBySessionId(sessionId string) ([]unmatched.Submission, error)
+	// Resolves the pending submission. If it's not found or already resolved, it returns unmatched.ErrNotFound.
+	Resolve(id string, resolution unmatched.Resolution) error
+}
+
+type excuseRepo interface {
+	// If not found, it returns excuse.ErrNotFound.
+	Get(id string) (excuse.Excuse, error)
+	// Adds the excuse as pending.
+	Add(excuse excuse.Excuse) (string, error)
+	// Returns the excuses of the member in the order of submission.
+	FindByUserId(userId string) ([]excuse.Excuse, error)
+	// Returns the excuses of the status in the order of submission.
+	FindByStatus(status excuse.Status) ([]excuse.Excuse, error)
+	// Reviews the pending excuse. If it's not found or already reviewed, it returns excuse.ErrNotFound.
+	Review(id string, reviewForm excuse.ReviewForm) error
+}
+
+type auditRepo interface {
+	// Appends the entry with its creation time. There is no way to update or delete the entries.
+	Add(entry audit.Entry) (string, error)
+	// Returns the entries about the records of the member in the order of the changes.
+	FindByUserId(userId string) ([]audit.Entry, error)
+	// Returns the entries about the records of the session in the order of the changes.
+	FindBySessionId(sessionId string) ([]audit.Entry, error)
+}
+
+type rsvpRepo interface {
+	// Adds the RSVP with the given status and times.
+	Add(rsvp rsvp.Rsvp) (string, error)
+	// Returns the RSVPs of the session in the order of the RSVPs. It's the order of the waitlist as well.
+	FindBySessionId(sessionId string) ([]rsvp.Rsvp, error)
+	// Returns the RSVPs of the member in the order of the RSVPs.
+	FindByUserId(userId string) ([]rsvp.Rsvp, error)
+	// Changes the status of the RSVP only if it's still in the given status.
+	// It returns rsvp.ErrNotFound if it's not found or the status has been changed by someone else.
+	UpdateStatus(id string, from rsvp.Status, to rsvp.Status, updatedAt time.Time) error
+}
+
+type reliabilityRepo interface {
+	// Replaces the outcomes of the session with the given ones. The session can be evaluated again this way,
+	// e.g., after the admins correct its attendance.
+	ReplaceBySessionId(sessionId string, outcomes []reliability.Outcome) error
+	// Returns the outcomes of the member in the order of the start time of the sessions.
+	FindByUserId(userId string) ([]reliability.Outcome, error)
+	// Returns all the outcomes in the order of the start time of the sessions.
+	GetAll() ([]reliability.Outcome, error)
 }
 
 type Server struct {
@@ -208,25 +574,88 @@ type Server struct {
 	// Used to generate the form for attendance and get the submissions from the form.
 	attendanceFormHandler attendanceFormHandler
 	attendanceRepo        attendanceRepo
+	// Used to scope sessions and attendances to a half year.
+	termRepo termRepo
+	// Used to roll the users over to a new term.
+	userRoller userRoller
+	// Used to create the sessions that repeat every week.
+	sessionSeriesRepo sessionSeriesRepo
+	// Used to issue and verify the codes that the members check in with.
+	checkInCodeHandler checkInCodeHandler
+	// Used to keep the check-ins rejected by the meeting point for the admins to review.
+	checkInRejectionRepo checkInRejectionRepo
+	// Used to keep the submissions that couldn't be matched to any user for the admins to resolve.
+	unmatchedSubmissionRepo unmatchedSubmissionRepo
+	// Used to keep the excuses of the members who will miss the sessions.
+	excuseRepo excuseRepo
+	// Used to keep the changes that the admins made to the records of the members.
+	auditRepo auditRepo
+	// Used to keep the RSVPs of the members to the sessions with the waitlist.
+	rsvpRepo rsvpRepo
+	// Used to keep whether the members attended the sessions that they had a spot for.
+	reliabilityRepo reliabilityRepo
+	// When the members are regarded as unreliable by their no-shows.
+	reliabilityThresholds reliability.Thresholds
+	// Used to keep the issued tokens so that they can be revoked before they expire.
+	tokenRepo tokenRepo
 	// The location of the time for the form. It's used to convert the time in the form to the local time.
 	formTimeLocation *time.Location
 	// Used to get the current time.
 	clock clock.Clock
 }
 
-func New(oauthClient oauthClient, authHandler authHandler, userRepo userRepo, userAdder userAdder, userUpdater userUpdater, sessionRepo sessionRepo, openSessionRepo openSessionRepo,
-	attendanceFormHandler attendanceFormHandler, attendanceRepo attendanceRepo, formTimeLocation *time.Location, clock clock.Clock) *Server {
+// The dependencies of the server. Each of them is documented on the field of Server with the same name.
+// The ones that the caller doesn't use can be left out, e.g., in the tests.
+type Deps struct {
+	OauthClient             oauthClient
+	AuthHandler             authHandler
+	UserRepo                userRepo
+	UserAdder               userAdder
+	UserUpdater             userUpdater
+	SessionRepo             sessionRepo
+	OpenSessionRepo         openSessionRepo
+	AttendanceFormHandler   attendanceFormHandler
+	AttendanceRepo          attendanceRepo
+	TermRepo                termRepo
+	UserRoller              userRoller
+	SessionSeriesRepo       sessionSeriesRepo
+	CheckInCodeHandler      checkInCodeHandler
+	CheckInRejectionRepo    checkInRejectionRepo
+	UnmatchedSubmissionRepo unmatchedSubmissionRepo
+	ExcuseRepo              excuseRepo
+	AuditRepo               auditRepo
+	RsvpRepo                rsvpRepo
+	ReliabilityRepo         reliabilityRepo
+	ReliabilityThresholds   reliability.Thresholds
+	TokenRepo               tokenRepo
+	FormTimeLocation        *time.Location
+	Clock                   clock.Clock
+}
+
+func New(deps Deps) *Server {
 	return &Server{
-		oauthClient:           oauthClient,
-		authHandler:           authHandler,
-		userRepo:              userRepo,
-		userAdder:             userAdder,
-		userUpdater:           userUpdater,
-		sessionRepo:           sessionRepo,
-		openSessionRepo:       openSessionRepo,
-		attendanceFormHandler: attendanceFormHandler,
-		attendanceRepo:        attendanceRepo,
-		formTimeLocation:      formTimeLocation,
-		clock:                 clock,
+		oauthClient:             deps.OauthClient,
+		authHandler:             deps.AuthHandler,
+		userRepo:                deps.UserRepo,
+		userAdder:               deps.UserAdder,
+		userUpdater:             deps.UserUpdater,
+		sessionRepo:             deps.SessionRepo,
+		openSessionRepo:         deps.OpenSessionRepo,
+		attendanceFormHandler:   deps.AttendanceFormHandler,
+		attendanceRepo:          deps.AttendanceRepo,
+		termRepo:                deps.TermRepo,
+		userRoller:              deps.UserRoller,
+		sessionSeriesRepo:       deps.SessionSeriesRepo,
+		checkInCodeHandler:      deps.CheckInCodeHandler,
+		checkInRejectionRepo:    deps.CheckInRejectionRepo,
+		unmatchedSubmissionRepo: deps.UnmatchedSubmissionRepo,
+		excuseRepo:              deps.ExcuseRepo,
+		auditRepo:               deps.AuditRepo,
+		rsvpRepo:                deps.RsvpRepo,
+		reliabilityRepo:         deps.ReliabilityRepo,
+		reliabilityThresholds:   deps.ReliabilityThresholds,
+		tokenRepo:               deps.TokenRepo,
+		formTimeLocation:        deps.FormTimeLocation,
+		clock:                   deps.Clock,
 	}
 }
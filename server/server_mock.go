@@ -12,9 +12,17 @@ package server
 import (
 	reflect "reflect"
 	attendance "rush/attendance"
+	audit "rush/audit"
 	auth "rush/auth"
+	checkin "rush/checkin"
+	excuse "rush/excuse"
 	permission "rush/permission"
+	reliability "rush/reliability"
+	rsvp "rush/rsvp"
+	series "rush/series"
 	session "rush/session"
+	term "rush/term"
+	unmatched "rush/unmatched"
 	user "rush/user"
 	time "time"
 
@@ -98,12 +106,13 @@ func (mr *MockauthHandlerMockRecorder) GetSession(token any) *gomock.Call {
 }
 
 // SignIn mocks base method.
-func (m *MockauthHandler) SignIn(userId string, role permission.Role) (string, error) {
+func (m *MockauthHandler) SignIn(userId string, role permission.Role) (string, auth.Session, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SignIn", userId, role)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(auth.Session)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // SignIn indicates an expected call of SignIn.
@@ -112,6 +121,86 @@ func (mr *MockauthHandlerMockRecorder) SignIn(userId, role any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockauthHandler)(nil).SignIn), userId, role)
 }
 
+// MocktokenRepo is a mock of tokenRepo interface.
+type MocktokenRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenRepoMockRecorder
+}
+
+// MocktokenRepoMockRecorder is the mock recorder for MocktokenRepo.
+type MocktokenRepoMockRecorder struct {
+	mock *MocktokenRepo
+}
+
+// NewMocktokenRepo creates a new mock instance.
+func NewMocktokenRepo(ctrl *gomock.Controller) *MocktokenRepo {
+	mock := &MocktokenRepo{ctrl: ctrl}
+	mock.recorder = &MocktokenRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenRepo) EXPECT() *MocktokenRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MocktokenRepo) Add(token auth.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MocktokenRepoMockRecorder) Add(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MocktokenRepo)(nil).Add), token)
+}
+
+// Get mocks base method.
+func (m *MocktokenRepo) Get(id string) (auth.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(auth.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MocktokenRepoMockRecorder) Get(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MocktokenRepo)(nil).Get), id)
+}
+
+// Revoke mocks base method.
+func (m *MocktokenRepo) Revoke(id string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MocktokenRepoMockRecorder) Revoke(id, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MocktokenRepo)(nil).Revoke), id, revokedAt)
+}
+
+// RevokeAllByUserId mocks base method.
+func (m *MocktokenRepo) RevokeAllByUserId(userId string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllByUserId", userId, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllByUserId indicates an expected call of RevokeAllByUserId.
+func (mr *MocktokenRepoMockRecorder) RevokeAllByUserId(userId, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllByUserId", reflect.TypeOf((*MocktokenRepo)(nil).RevokeAllByUserId), userId, revokedAt)
+}
+
 // MockuserRepo is a mock of userRepo interface.
 type MockuserRepo struct {
 	ctrl     *gomock.Controller
@@ -299,6 +388,58 @@ func (mr *MockuserUpdaterMockRecorder) Update(id, updateForm any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockuserUpdater)(nil).Update), id, updateForm)
 }
 
+// MockuserRoller is a mock of userRoller interface.
+type MockuserRoller struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRollerMockRecorder
+}
+
+// MockuserRollerMockRecorder is the mock recorder for MockuserRoller.
+type MockuserRollerMockRecorder struct {
+	mock *MockuserRoller
+}
+
+// NewMockuserRoller creates a new mock instance.
+func NewMockuserRoller(ctrl *gomock.Controller) *MockuserRoller {
+	mock := &MockuserRoller{ctrl: ctrl}
+	mock.recorder = &MockuserRollerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRoller) EXPECT() *MockuserRollerMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockuserRoller) Apply(plan user.RolloverPlan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", plan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockuserRollerMockRecorder) Apply(plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockuserRoller)(nil).Apply), plan)
+}
+
+// Plan mocks base method.
+func (m *MockuserRoller) Plan(roster []user.User) (user.RolloverPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", roster)
+	ret0, _ := ret[0].(user.RolloverPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockuserRollerMockRecorder) Plan(roster any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockuserRoller)(nil).Plan), roster)
+}
+
 // MocksessionRepo is a mock of sessionRepo interface.
 type MocksessionRepo struct {
 	ctrl     *gomock.Controller
@@ -337,6 +478,21 @@ func (mr *MocksessionRepoMockRecorder) Add(name, description, createdBy, startsA
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MocksessionRepo)(nil).Add), name, description, createdBy, startsAt, score)
 }
 
+// AddToSeries mocks base method.
+func (m *MocksessionRepo) AddToSeries(seriesId, name, description, createdBy string, startsAt time.Time, score int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToSeries", seriesId, name, description, createdBy, startsAt, score)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToSeries indicates an expected call of AddToSeries.
+func (mr *MocksessionRepoMockRecorder) AddToSeries(seriesId, name, description, createdBy, startsAt, score any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToSeries", reflect.TypeOf((*MocksessionRepo)(nil).AddToSeries), seriesId, name, description, createdBy, startsAt, score)
+}
+
 // Get mocks base method.
 func (m *MocksessionRepo) Get(id string) (session.Session, error) {
 	m.ctrl.T.Helper()
@@ -367,6 +523,36 @@ func (mr *MocksessionRepoMockRecorder) GetAll() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MocksessionRepo)(nil).GetAll))
 }
 
+// GetAllBySeriesId mocks base method.
+func (m *MocksessionRepo) GetAllBySeriesId(seriesId string) ([]session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllBySeriesId", seriesId)
+	ret0, _ := ret[0].([]session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllBySeriesId indicates an expected call of GetAllBySeriesId.
+func (mr *MocksessionRepoMockRecorder) GetAllBySeriesId(seriesId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBySeriesId", reflect.TypeOf((*MocksessionRepo)(nil).GetAllBySeriesId), seriesId)
+}
+
+// GetAllStartingBetween mocks base method.
+func (m *MocksessionRepo) GetAllStartingBetween(from, to time.Time) ([]session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllStartingBetween", from, to)
+	ret0, _ := ret[0].([]session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllStartingBetween indicates an expected call of GetAllStartingBetween.
+func (mr *MocksessionRepoMockRecorder) GetAllStartingBetween(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStartingBetween", reflect.TypeOf((*MocksessionRepo)(nil).GetAllStartingBetween), from, to)
+}
+
 // List mocks base method.
 func (m *MocksessionRepo) List(offset, pageSize int) (*session.ListResult, error) {
 	m.ctrl.T.Helper()
@@ -501,12 +687,13 @@ func (mr *MockattendanceFormHandlerMockRecorder) GenerateForm(title, description
 }
 
 // GetSubmissions mocks base method.
-func (m *MockattendanceFormHandler) GetSubmissions(formId string) ([]attendance.FormSubmission, error) {
+func (m *MockattendanceFormHandler) GetSubmissions(formId string) ([]attendance.FormSubmission, []attendance.InvalidSubmission, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSubmissions", formId)
 	ret0, _ := ret[0].([]attendance.FormSubmission)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].([]attendance.InvalidSubmission)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetSubmissions indicates an expected call of GetSubmissions.
@@ -515,6 +702,59 @@ func (mr *MockattendanceFormHandlerMockRecorder) GetSubmissions(formId any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissions", reflect.TypeOf((*MockattendanceFormHandler)(nil).GetSubmissions), formId)
 }
 
+// UpdateFormInfo mocks base method.
+func (m *MockattendanceFormHandler) UpdateFormInfo(formId, title, description string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFormInfo", formId, title, description)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFormInfo indicates an expected call of UpdateFormInfo.
+func (mr *MockattendanceFormHandlerMockRecorder) UpdateFormInfo(formId, title, description any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFormInfo", reflect.TypeOf((*MockattendanceFormHandler)(nil).UpdateFormInfo), formId, title, description)
+}
+
+// MockattendanceSource is a mock of attendanceSource interface.
+type MockattendanceSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockattendanceSourceMockRecorder
+}
+
+// MockattendanceSourceMockRecorder is the mock recorder for MockattendanceSource.
+type MockattendanceSourceMockRecorder struct {
+	mock *MockattendanceSource
+}
+
+// NewMockattendanceSource creates a new mock instance.
+func NewMockattendanceSource(ctrl *gomock.Controller) *MockattendanceSource {
+	mock := &MockattendanceSource{ctrl: ctrl}
+	mock.recorder = &MockattendanceSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockattendanceSource) EXPECT() *MockattendanceSourceMockRecorder {
+	return m.recorder
+}
+
+// GetSubmissions mocks base method.
+func (m *MockattendanceSource) GetSubmissions(externalId string) ([]attendance.FormSubmission, []attendance.InvalidSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissions", externalId)
+	ret0, _ := ret[0].([]attendance.FormSubmission)
+	ret1, _ := ret[1].([]attendance.InvalidSubmission)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSubmissions indicates an expected call of GetSubmissions.
+func (mr *MockattendanceSourceMockRecorder) GetSubmissions(externalId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissions", reflect.TypeOf((*MockattendanceSource)(nil).GetSubmissions), externalId)
+}
+
 // MockattendanceRepo is a mock of attendanceRepo interface.
 type MockattendanceRepo struct {
 	ctrl     *gomock.Controller
@@ -539,11 +779,12 @@ func (m *MockattendanceRepo) EXPECT() *MockattendanceRepoMockRecorder {
 }
 
 // BulkInsert mocks base method.
-func (m *MockattendanceRepo) BulkInsert(requests []attendance.AddAttendanceReq) error {
+func (m *MockattendanceRepo) BulkInsert(requests []attendance.AddAttendanceReq) ([]string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "BulkInsert", requests)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BulkInsert indicates an expected call of BulkInsert.
@@ -552,6 +793,20 @@ func (mr *MockattendanceRepoMockRecorder) BulkInsert(requests any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkInsert", reflect.TypeOf((*MockattendanceRepo)(nil).BulkInsert), requests)
 }
 
+// Delete mocks base method.
+func (m *MockattendanceRepo) Delete(ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockattendanceRepoMockRecorder) Delete(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockattendanceRepo)(nil).Delete), ids)
+}
+
 // FindBySessionId mocks base method.
 func (m *MockattendanceRepo) FindBySessionId(sessionId string) ([]attendance.Attendance, error) {
 	m.ctrl.T.Helper()
@@ -567,6 +822,21 @@ func (mr *MockattendanceRepoMockRecorder) FindBySessionId(sessionId any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionId", reflect.TypeOf((*MockattendanceRepo)(nil).FindBySessionId), sessionId)
 }
 
+// FindBySessionStartedAtBetween mocks base method.
+func (m *MockattendanceRepo) FindBySessionStartedAtBetween(from, to time.Time) ([]attendance.Attendance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySessionStartedAtBetween", from, to)
+	ret0, _ := ret[0].([]attendance.Attendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySessionStartedAtBetween indicates an expected call of FindBySessionStartedAtBetween.
+func (mr *MockattendanceRepoMockRecorder) FindBySessionStartedAtBetween(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionStartedAtBetween", reflect.TypeOf((*MockattendanceRepo)(nil).FindBySessionStartedAtBetween), from, to)
+}
+
 // FindByUserId mocks base method.
 func (m *MockattendanceRepo) FindByUserId(userId string) ([]attendance.Attendance, error) {
 	m.ctrl.T.Helper()
@@ -582,6 +852,21 @@ func (mr *MockattendanceRepoMockRecorder) FindByUserId(userId any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockattendanceRepo)(nil).FindByUserId), userId)
 }
 
+// Get mocks base method.
+func (m *MockattendanceRepo) Get(id string) (attendance.Attendance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(attendance.Attendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockattendanceRepoMockRecorder) Get(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockattendanceRepo)(nil).Get), id)
+}
+
 // GetAll mocks base method.
 func (m *MockattendanceRepo) GetAll() ([]attendance.Attendance, error) {
 	m.ctrl.T.Helper()
@@ -596,3 +881,708 @@ func (mr *MockattendanceRepoMockRecorder) GetAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockattendanceRepo)(nil).GetAll))
 }
+
+// Restore mocks base method.
+func (m *MockattendanceRepo) Restore(attendance attendance.Attendance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", attendance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockattendanceRepoMockRecorder) Restore(attendance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockattendanceRepo)(nil).Restore), attendance)
+}
+
+// Update mocks base method.
+func (m *MockattendanceRepo) Update(id string, updateForm attendance.UpdateAttendanceForm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, updateForm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockattendanceRepoMockRecorder) Update(id, updateForm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockattendanceRepo)(nil).Update), id, updateForm)
+}
+
+// MocktermRepo is a mock of termRepo interface.
+type MocktermRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocktermRepoMockRecorder
+}
+
+// MocktermRepoMockRecorder is the mock recorder for MocktermRepo.
+type MocktermRepoMockRecorder struct {
+	mock *MocktermRepo
+}
+
+// NewMocktermRepo creates a new mock instance.
+func NewMocktermRepo(ctrl *gomock.Controller) *MocktermRepo {
+	mock := &MocktermRepo{ctrl: ctrl}
+	mock.recorder = &MocktermRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktermRepo) EXPECT() *MocktermRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MocktermRepo) Add(name string, startsAt, endsAt time.Time, generations []float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", name, startsAt, endsAt, generations)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MocktermRepoMockRecorder) Add(name, startsAt, endsAt, generations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MocktermRepo)(nil).Add), name, startsAt, endsAt, generations)
+}
+
+// Delete mocks base method.
+func (m *MocktermRepo) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MocktermRepoMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MocktermRepo)(nil).Delete), id)
+}
+
+// Get mocks base method.
+func (m *MocktermRepo) Get(id string) (term.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(term.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MocktermRepoMockRecorder) Get(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MocktermRepo)(nil).Get), id)
+}
+
+// GetAll mocks base method.
+func (m *MocktermRepo) GetAll() ([]term.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]term.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MocktermRepoMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MocktermRepo)(nil).GetAll))
+}
+
+// GetByTime mocks base method.
+func (m *MocktermRepo) GetByTime(at time.Time) (term.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTime", at)
+	ret0, _ := ret[0].(term.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTime indicates an expected call of GetByTime.
+func (mr *MocktermRepoMockRecorder) GetByTime(at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTime", reflect.TypeOf((*MocktermRepo)(nil).GetByTime), at)
+}
+
+// Update mocks base method.
+func (m *MocktermRepo) Update(id string, updateForm term.UpdateForm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, updateForm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MocktermRepoMockRecorder) Update(id, updateForm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MocktermRepo)(nil).Update), id, updateForm)
+}
+
+// MocksessionSeriesRepo is a mock of sessionSeriesRepo interface.
+type MocksessionSeriesRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionSeriesRepoMockRecorder
+}
+
+// MocksessionSeriesRepoMockRecorder is the mock recorder for MocksessionSeriesRepo.
+type MocksessionSeriesRepoMockRecorder struct {
+	mock *MocksessionSeriesRepo
+}
+
+// NewMocksessionSeriesRepo creates a new mock instance.
+func NewMocksessionSeriesRepo(ctrl *gomock.Controller) *MocksessionSeriesRepo {
+	mock := &MocksessionSeriesRepo{ctrl: ctrl}
+	mock.recorder = &MocksessionSeriesRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionSeriesRepo) EXPECT() *MocksessionSeriesRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MocksessionSeriesRepo) Add(series series.Series) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", series)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MocksessionSeriesRepoMockRecorder) Add(series any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MocksessionSeriesRepo)(nil).Add), series)
+}
+
+// Get mocks base method.
+func (m *MocksessionSeriesRepo) Get(id string) (series.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(series.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MocksessionSeriesRepoMockRecorder) Get(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MocksessionSeriesRepo)(nil).Get), id)
+}
+
+// GetAll mocks base method.
+func (m *MocksessionSeriesRepo) GetAll() ([]series.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]series.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MocksessionSeriesRepoMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MocksessionSeriesRepo)(nil).GetAll))
+}
+
+// Update mocks base method.
+func (m *MocksessionSeriesRepo) Update(id string, updateForm series.UpdateForm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, updateForm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MocksessionSeriesRepoMockRecorder) Update(id, updateForm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MocksessionSeriesRepo)(nil).Update), id, updateForm)
+}
+
+// MockcheckInCodeHandler is a mock of checkInCodeHandler interface.
+type MockcheckInCodeHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockcheckInCodeHandlerMockRecorder
+}
+
+// MockcheckInCodeHandlerMockRecorder is the mock recorder for MockcheckInCodeHandler.
+type MockcheckInCodeHandlerMockRecorder struct {
+	mock *MockcheckInCodeHandler
+}
+
+// NewMockcheckInCodeHandler creates a new mock instance.
+func NewMockcheckInCodeHandler(ctrl *gomock.Controller) *MockcheckInCodeHandler {
+	mock := &MockcheckInCodeHandler{ctrl: ctrl}
+	mock.recorder = &MockcheckInCodeHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcheckInCodeHandler) EXPECT() *MockcheckInCodeHandlerMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockcheckInCodeHandler) Issue(sessionId string) checkin.Code {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", sessionId)
+	ret0, _ := ret[0].(checkin.Code)
+	return ret0
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockcheckInCodeHandlerMockRecorder) Issue(sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockcheckInCodeHandler)(nil).Issue), sessionId)
+}
+
+// Verify mocks base method.
+func (m *MockcheckInCodeHandler) Verify(sessionId, code string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", sessionId, code)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockcheckInCodeHandlerMockRecorder) Verify(sessionId, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockcheckInCodeHandler)(nil).Verify), sessionId, code)
+}
+
+// MockcheckInRejectionRepo is a mock of checkInRejectionRepo interface.
+type MockcheckInRejectionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockcheckInRejectionRepoMockRecorder
+}
+
+// MockcheckInRejectionRepoMockRecorder is the mock recorder for MockcheckInRejectionRepo.
+type MockcheckInRejectionRepoMockRecorder struct {
+	mock *MockcheckInRejectionRepo
+}
+
+// NewMockcheckInRejectionRepo creates a new mock instance.
+func NewMockcheckInRejectionRepo(ctrl *gomock.Controller) *MockcheckInRejectionRepo {
+	mock := &MockcheckInRejectionRepo{ctrl: ctrl}
+	mock.recorder = &MockcheckInRejectionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcheckInRejectionRepo) EXPECT() *MockcheckInRejectionRepoMockRecorder {
+	return m.recorder
+}
+
+// AddRejection mocks base method.
+func (m *MockcheckInRejectionRepo) AddRejection(rejection checkin.Rejection) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRejection", rejection)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRejection indicates an expected call of AddRejection.
+func (mr *MockcheckInRejectionRepoMockRecorder) AddRejection(rejection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRejection", reflect.TypeOf((*MockcheckInRejectionRepo)(nil).AddRejection), rejection)
+}
+
+// FindRejectionsBySessionId mocks base method.
+func (m *MockcheckInRejectionRepo) FindRejectionsBySessionId(sessionId string) ([]checkin.Rejection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRejectionsBySessionId", sessionId)
+	ret0, _ := ret[0].([]checkin.Rejection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRejectionsBySessionId indicates an expected call of FindRejectionsBySessionId.
+func (mr *MockcheckInRejectionRepoMockRecorder) FindRejectionsBySessionId(sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRejectionsBySessionId", reflect.TypeOf((*MockcheckInRejectionRepo)(nil).FindRejectionsBySessionId), sessionId)
+}
+
+// MockunmatchedSubmissionRepo is a mock of unmatchedSubmissionRepo interface.
+type MockunmatchedSubmissionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockunmatchedSubmissionRepoMockRecorder
+}
+
+// MockunmatchedSubmissionRepoMockRecorder is the mock recorder for MockunmatchedSubmissionRepo.
+type MockunmatchedSubmissionRepoMockRecorder struct {
+	mock *MockunmatchedSubmissionRepo
+}
+
+// NewMockunmatchedSubmissionRepo creates a new mock instance.
+func NewMockunmatchedSubmissionRepo(ctrl *gomock.Controller) *MockunmatchedSubmissionRepo {
+	mock := &MockunmatchedSubmissionRepo{ctrl: ctrl}
+	mock.recorder = &MockunmatchedSubmissionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockunmatchedSubmissionRepo) EXPECT() *MockunmatchedSubmissionRepoMockRecorder {
+	return m.recorder
+}
+
+// FindBySessionId mocks base method.
+func (m *MockunmatchedSubmissionRepo) FindBySessionId(sessionId string) ([]unmatched.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySessionId", sessionId)
+	ret0, _ := ret[0].([]unmatched.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySessionId indicates an expected call of FindBySessionId.
+func (mr *MockunmatchedSubmissionRepoMockRecorder) FindBySessionId(sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionId", reflect.TypeOf((*MockunmatchedSubmissionRepo)(nil).FindBySessionId), sessionId)
+}
+
+// ReplaceBySessionId mocks base method.
+func (m *MockunmatchedSubmissionRepo) ReplaceBySessionId(sessionId string, submissions []unmatched.Submission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceBySessionId", sessionId, submissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceBySessionId indicates an expected call of ReplaceBySessionId.
+func (mr *MockunmatchedSubmissionRepoMockRecorder) ReplaceBySessionId(sessionId, submissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBySessionId", reflect.TypeOf((*MockunmatchedSubmissionRepo)(nil).ReplaceBySessionId), sessionId, submissions)
+}
+
+// Resolve mocks base method.
+func (m *MockunmatchedSubmissionRepo) Resolve(id string, resolution unmatched.Resolution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", id, resolution)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockunmatchedSubmissionRepoMockRecorder) Resolve(id, resolution any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockunmatchedSubmissionRepo)(nil).Resolve), id, resolution)
+}
+
+// MockexcuseRepo is a mock of excuseRepo interface.
+type MockexcuseRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockexcuseRepoMockRecorder
+}
+
+// MockexcuseRepoMockRecorder is the mock recorder for MockexcuseRepo.
+type MockexcuseRepoMockRecorder struct {
+	mock *MockexcuseRepo
+}
+
+// NewMockexcuseRepo creates a new mock instance.
+func NewMockexcuseRepo(ctrl *gomock.Controller) *MockexcuseRepo {
+	mock := &MockexcuseRepo{ctrl: ctrl}
+	mock.recorder = &MockexcuseRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexcuseRepo) EXPECT() *MockexcuseRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockexcuseRepo) Add(excuse excuse.Excuse) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", excuse)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockexcuseRepoMockRecorder) Add(excuse any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockexcuseRepo)(nil).Add), excuse)
+}
+
+// FindByStatus mocks base method.
+func (m *MockexcuseRepo) FindByStatus(status excuse.Status) ([]excuse.Excuse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", status)
+	ret0, _ := ret[0].([]excuse.Excuse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockexcuseRepoMockRecorder) FindByStatus(status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockexcuseRepo)(nil).FindByStatus), status)
+}
+
+// FindByUserId mocks base method.
+func (m *MockexcuseRepo) FindByUserId(userId string) ([]excuse.Excuse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserId", userId)
+	ret0, _ := ret[0].([]excuse.Excuse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserId indicates an expected call of FindByUserId.
+func (mr *MockexcuseRepoMockRecorder) FindByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockexcuseRepo)(nil).FindByUserId), userId)
+}
+
+// Get mocks base method.
+func (m *MockexcuseRepo) Get(id string) (excuse.Excuse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(excuse.Excuse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockexcuseRepoMockRecorder) Get(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockexcuseRepo)(nil).Get), id)
+}
+
+// Review mocks base method.
+func (m *MockexcuseRepo) Review(id string, reviewForm excuse.ReviewForm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", id, reviewForm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockexcuseRepoMockRecorder) Review(id, reviewForm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockexcuseRepo)(nil).Review), id, reviewForm)
+}
+
+// MockauditRepo is a mock of auditRepo interface.
+type MockauditRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditRepoMockRecorder
+}
+
+// MockauditRepoMockRecorder is the mock recorder for MockauditRepo.
+type MockauditRepoMockRecorder struct {
+	mock *MockauditRepo
+}
+
+// NewMockauditRepo creates a new mock instance.
+func NewMockauditRepo(ctrl *gomock.Controller) *MockauditRepo {
+	mock := &MockauditRepo{ctrl: ctrl}
+	mock.recorder = &MockauditRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditRepo) EXPECT() *MockauditRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockauditRepo) Add(entry audit.Entry) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", entry)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockauditRepoMockRecorder) Add(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockauditRepo)(nil).Add), entry)
+}
+
+// FindBySessionId mocks base method.
+func (m *MockauditRepo) FindBySessionId(sessionId string) ([]audit.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySessionId", sessionId)
+	ret0, _ := ret[0].([]audit.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySessionId indicates an expected call of FindBySessionId.
+func (mr *MockauditRepoMockRecorder) FindBySessionId(sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionId", reflect.TypeOf((*MockauditRepo)(nil).FindBySessionId), sessionId)
+}
+
+// FindByUserId mocks base method.
+func (m *MockauditRepo) FindByUserId(userId string) ([]audit.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserId", userId)
+	ret0, _ := ret[0].([]audit.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserId indicates an expected call of FindByUserId.
+func (mr *MockauditRepoMockRecorder) FindByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockauditRepo)(nil).FindByUserId), userId)
+}
+
+// MockrsvpRepo is a mock of rsvpRepo interface.
+type MockrsvpRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockrsvpRepoMockRecorder
+}
+
+// MockrsvpRepoMockRecorder is the mock recorder for MockrsvpRepo.
+type MockrsvpRepoMockRecorder struct {
+	mock *MockrsvpRepo
+}
+
+// NewMockrsvpRepo creates a new mock instance.
+func NewMockrsvpRepo(ctrl *gomock.Controller) *MockrsvpRepo {
+	mock := &MockrsvpRepo{ctrl: ctrl}
+	mock.recorder = &MockrsvpRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrsvpRepo) EXPECT() *MockrsvpRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockrsvpRepo) Add(rsvp rsvp.Rsvp) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", rsvp)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockrsvpRepoMockRecorder) Add(rsvp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockrsvpRepo)(nil).Add), rsvp)
+}
+
+// FindBySessionId mocks base method.
+func (m *MockrsvpRepo) FindBySessionId(sessionId string) ([]rsvp.Rsvp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySessionId", sessionId)
+	ret0, _ := ret[0].([]rsvp.Rsvp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySessionId indicates an expected call of FindBySessionId.
+func (mr *MockrsvpRepoMockRecorder) FindBySessionId(sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionId", reflect.TypeOf((*MockrsvpRepo)(nil).FindBySessionId), sessionId)
+}
+
+// FindByUserId mocks base method.
+func (m *MockrsvpRepo) FindByUserId(userId string) ([]rsvp.Rsvp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserId", userId)
+	ret0, _ := ret[0].([]rsvp.Rsvp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserId indicates an expected call of FindByUserId.
+func (mr *MockrsvpRepoMockRecorder) FindByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockrsvpRepo)(nil).FindByUserId), userId)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockrsvpRepo) UpdateStatus(id string, from, to rsvp.Status, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", id, from, to, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockrsvpRepoMockRecorder) UpdateStatus(id, from, to, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockrsvpRepo)(nil).UpdateStatus), id, from, to, updatedAt)
+}
+
+// MockreliabilityRepo is a mock of reliabilityRepo interface.
+type MockreliabilityRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockreliabilityRepoMockRecorder
+}
+
+// MockreliabilityRepoMockRecorder is the mock recorder for MockreliabilityRepo.
+type MockreliabilityRepoMockRecorder struct {
+	mock *MockreliabilityRepo
+}
+
+// NewMockreliabilityRepo creates a new mock instance.
+func NewMockreliabilityRepo(ctrl *gomock.Controller) *MockreliabilityRepo {
+	mock := &MockreliabilityRepo{ctrl: ctrl}
+	mock.recorder = &MockreliabilityRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreliabilityRepo) EXPECT() *MockreliabilityRepoMockRecorder {
+	return m.recorder
+}
+
+// FindByUserId mocks base method.
+func (m *MockreliabilityRepo) FindByUserId(userId string) ([]reliability.Outcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserId", userId)
+	ret0, _ := ret[0].([]reliability.Outcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserId indicates an expected call of FindByUserId.
+func (mr *MockreliabilityRepoMockRecorder) FindByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserId", reflect.TypeOf((*MockreliabilityRepo)(nil).FindByUserId), userId)
+}
+
+// GetAll mocks base method.
+func (m *MockreliabilityRepo) GetAll() ([]reliability.Outcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]reliability.Outcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockreliabilityRepoMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockreliabilityRepo)(nil).GetAll))
+}
+
+// ReplaceBySessionId mocks base method.
+func (m *MockreliabilityRepo) ReplaceBySessionId(sessionId string, outcomes []reliability.Outcome) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceBySessionId", sessionId, outcomes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceBySessionId indicates an expected call of ReplaceBySessionId.
+func (mr *MockreliabilityRepoMockRecorder) ReplaceBySessionId(sessionId, outcomes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBySessionId", reflect.TypeOf((*MockreliabilityRepo)(nil).ReplaceBySessionId), sessionId, outcomes)
+}
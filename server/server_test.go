@@ -1,6 +1,7 @@
 package server
 
 import (
+	"rush/reliability"
 	"testing"
 	"time"
 
@@ -20,22 +21,70 @@ func TestNew(t *testing.T) {
 	mockOpenSessionRepo := NewMockopenSessionRepo(controller)
 	mockAttendanceFormHandler := NewMockattendanceFormHandler(controller)
 	mockAttendanceRepo := NewMockattendanceRepo(controller)
+	mockTermRepo := NewMocktermRepo(controller)
+	mockUserRoller := NewMockuserRoller(controller)
+	mockSessionSeriesRepo := NewMocksessionSeriesRepo(controller)
+	mockCheckInCodeHandler := NewMockcheckInCodeHandler(controller)
+	mockCheckInRejectionRepo := NewMockcheckInRejectionRepo(controller)
+	mockUnmatchedSubmissionRepo := NewMockunmatchedSubmissionRepo(controller)
+	mockExcuseRepo := NewMockexcuseRepo(controller)
+	mockAuditRepo := NewMockauditRepo(controller)
+	mockRsvpRepo := NewMockrsvpRepo(controller)
+	mockReliabilityRepo := NewMockreliabilityRepo(controller)
+	reliabilityThresholds := reliability.Thresholds{MinSignUps: 3, MinScore: 70}
+	mockTokenRepo := NewMocktokenRepo(controller)
 	formTimeLocation := time.UTC
 	clock := clock.NewMock()
 
-	server := New(mockOauthClient, mockAuthHandler, mockUserRepo, mockUserAdder, mockUserUpdater, mockSessionRepo, mockOpenSessionRepo, mockAttendanceFormHandler, mockAttendanceRepo, formTimeLocation, clock)
+	server := New(Deps{
+		OauthClient:             mockOauthClient,
+		AuthHandler:             mockAuthHandler,
+		UserRepo:                mockUserRepo,
+		UserAdder:               mockUserAdder,
+		UserUpdater:             mockUserUpdater,
+		SessionRepo:             mockSessionRepo,
+		OpenSessionRepo:         mockOpenSessionRepo,
+		AttendanceFormHandler:   mockAttendanceFormHandler,
+		AttendanceRepo:          mockAttendanceRepo,
+		TermRepo:                mockTermRepo,
+		UserRoller:              mockUserRoller,
+		SessionSeriesRepo:       mockSessionSeriesRepo,
+		CheckInCodeHandler:      mockCheckInCodeHandler,
+		CheckInRejectionRepo:    mockCheckInRejectionRepo,
+		UnmatchedSubmissionRepo: mockUnmatchedSubmissionRepo,
+		ExcuseRepo:              mockExcuseRepo,
+		AuditRepo:               mockAuditRepo,
+		RsvpRepo:                mockRsvpRepo,
+		ReliabilityRepo:         mockReliabilityRepo,
+		ReliabilityThresholds:   reliabilityThresholds,
+		TokenRepo:               mockTokenRepo,
+		FormTimeLocation:        formTimeLocation,
+		Clock:                   clock,
+	})
 
 	assert.Equal(t, &Server{
-		oauthClient:           mockOauthClient,
-		authHandler:           mockAuthHandler,
-		userRepo:              mockUserRepo,
-		userAdder:             mockUserAdder,
-		userUpdater:           mockUserUpdater,
-		sessionRepo:           mockSessionRepo,
-		openSessionRepo:       mockOpenSessionRepo,
-		attendanceFormHandler: mockAttendanceFormHandler,
-		attendanceRepo:        mockAttendanceRepo,
-		formTimeLocation:      formTimeLocation,
-		clock:                 clock,
+		oauthClient:             mockOauthClient,
+		authHandler:             mockAuthHandler,
+		userRepo:                mockUserRepo,
+		userAdder:               mockUserAdder,
+		userUpdater:             mockUserUpdater,
+		sessionRepo:             mockSessionRepo,
+		openSessionRepo:         mockOpenSessionRepo,
+		attendanceFormHandler:   mockAttendanceFormHandler,
+		attendanceRepo:          mockAttendanceRepo,
+		termRepo:                mockTermRepo,
+		userRoller:              mockUserRoller,
+		sessionSeriesRepo:       mockSessionSeriesRepo,
+		checkInCodeHandler:      mockCheckInCodeHandler,
+		checkInRejectionRepo:    mockCheckInRejectionRepo,
+		unmatchedSubmissionRepo: mockUnmatchedSubmissionRepo,
+		excuseRepo:              mockExcuseRepo,
+		auditRepo:               mockAuditRepo,
+		rsvpRepo:                mockRsvpRepo,
+		reliabilityRepo:         mockReliabilityRepo,
+		reliabilityThresholds:   reliabilityThresholds,
+		tokenRepo:               mockTokenRepo,
+		formTimeLocation:        formTimeLocation,
+		clock:                   clock,
 	}, server)
 }
@@ -6,6 +6,7 @@ import (
 	"rush/attendance"
 	"rush/golang/array"
 	"rush/session"
+	"rush/unmatched"
 	"rush/user"
 	"strings"
 	"time"
@@ -94,15 +95,102 @@ func (s *Server) AddSession(name string, description string, createdBy string, s
 	return id, nil
 }
 
-// Deletes the session.
+// Updates the open session. Nil arguments are not updated.
+// If the session already has the attendance form, the form's title and description are updated as well.
+// The error says which of them has been changed if it fails partway.
+func (s *Server) UpdateSession(id string, name *string, description *string, startsAt *time.Time, score *int) (SessionForAdmin, error) {
+	dbSession, err := s.sessionRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return SessionForAdmin{}, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return SessionForAdmin{}, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() {
+		return SessionForAdmin{}, newBadRequestError(errors.New("session is already closed"))
+	}
+	if name != nil && strings.TrimSpace(*name) == "" {
+		return SessionForAdmin{}, newBadRequestError(errors.New("name is required"))
+	}
+	if score != nil && *score < 0 {
+		return SessionForAdmin{}, newBadRequestError(errors.New("score should not be negative"))
+	}
+	if err := s.checkNoAttendances(dbSession.Id); err != nil {
+		return SessionForAdmin{}, err
+	}
+
+	// The form is synced first so that the session is not changed when the form can't be.
+	// The form only shows the name and the start time of the session.
+	syncsForm := dbSession.AttendanceSource.Provider == session.AttendanceSourceProviderGoogleForm && (name != nil || startsAt != nil)
+	if syncsForm {
+		toBeUpdated := dbSession
+		if name != nil {
+			toBeUpdated.Name = *name
+		}
+		if startsAt != nil {
+			toBeUpdated.StartsAt = *startsAt
+		}
+		formTitle, formDescription := s.attendanceFormInfo(toBeUpdated)
+		if err := s.attendanceFormHandler.UpdateFormInfo(dbSession.AttendanceSource.ExternalId, formTitle, formDescription); err != nil {
+			return SessionForAdmin{}, newInternalServerError(fmt.Errorf("failed to update the form of the session, so the session is not changed: %w", err))
+		}
+	}
+
+	updatedSession, err := s.openSessionRepo.UpdateOpenSession(id, session.OpenSessionUpdateForm{
+		Title:                name,
+		Description:          description,
+		StartsAt:             startsAt,
+		Score:                score,
+		ReturnUpdatedSession: true,
+	})
+	if err != nil {
+		if syncsForm {
+			formTitle, formDescription := s.attendanceFormInfo(dbSession)
+			if restoreErr := s.attendanceFormHandler.UpdateFormInfo(dbSession.AttendanceSource.ExternalId, formTitle, formDescription); restoreErr != nil {
+				return SessionForAdmin{}, newInternalServerError(fmt.Errorf(
+					"failed to update session: %w and failed to restore its form, so the form shows the new info but the session is not changed: %v", err, restoreErr))
+			}
+		}
+		return SessionForAdmin{}, newInternalServerError(fmt.Errorf("failed to update session, so neither the session nor its form is changed: %w", err))
+	}
+
+	return fromSessionToSessionForAdmin(updatedSession), nil
+}
+
+// Deletes the session. The session that already has attendances can't be deleted.
 func (s *Server) DeleteSession(id string) error {
+	if _, err := s.sessionRepo.Get(id); err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if err := s.checkNoAttendances(id); err != nil {
+		return err
+	}
+
 	if err := s.openSessionRepo.DeleteOpenSession(id); err != nil {
 		return newInternalServerError(fmt.Errorf("failed to delete session: %w", err))
 	}
 	return nil
 }
 
-// Fetches the form submissions and applies the attendance by the form submissions.
+// Returns an error if the session has any attendance. E.g., the check-ins or the matched submissions applied while
+// the others are resolved. The attendances have the copy of the session data and refer to the session,
+// so that the session can't be changed or deleted anymore.
+func (s *Server) checkNoAttendances(sessionId string) error {
+	attendances, err := s.attendanceRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get attendances: %w", err))
+	}
+	if len(attendances) > 0 {
+		return newBadRequestError(errors.New("session already has attendances"))
+	}
+	return nil
+}
+
+// Fetches the submissions from the attendance source of the session and applies the attendance by them.
+// It works for any provider of the source. E.g., the Google form or the check-in.
 func (s *Server) ApplyAttendanceByFormSubmissions(sessionId string, calledBy string) error {
 	dbSession, err := s.sessionRepo.Get(sessionId)
 	if err != nil {
@@ -113,27 +201,64 @@ func (s *Server) ApplyAttendanceByFormSubmissions(sessionId string, calledBy str
 		return newBadRequestError(errors.New("session is already closed"))
 	}
 
-	if !dbSession.CanApplyAttendanceByFormSubmissions() {
-		return newBadRequestError(errors.New("session cannot apply attendance by form submissions"))
+	if !dbSession.CanApplyAttendanceBySource() {
+		return newBadRequestError(errors.New("session has no attendance source to apply"))
 	}
 
-	formSubmissions, err := s.attendanceFormHandler.GetSubmissions(dbSession.GoogleFormId)
+	source, ok := s.getAttendanceSource(dbSession.AttendanceSource.Provider)
+	if !ok {
+		return newBadRequestError(fmt.Errorf("submissions of the attendance source (%s) can't be fetched again. Import them again",
+			dbSession.AttendanceSource.Provider))
+	}
+	submissions, invalidSubmissions, err := source.GetSubmissions(dbSession.AttendanceSource.ExternalId)
 	if err != nil {
-		return newInternalServerError(fmt.Errorf("failed to get form submissions: %w", err))
+		return newInternalServerError(fmt.Errorf("failed to get submissions from the attendance source: %w", err))
 	}
 
-	if len(formSubmissions) == 0 {
-		if err := s.openSessionRepo.MarkAttendanceIsIgnored(sessionId, "no form submissions"); err != nil {
+	return s.applySubmissions(dbSession, submissions, invalidSubmissions, calledBy)
+}
+
+// Applies the attendance of the session by the submissions from its attendance source.
+// The users who already have the attendance of the session are skipped. E.g., the users who have checked in.
+// If some of the submissions are invalid or don't match any user, the matched ones are applied and the others are kept
+// as the unmatched submissions of the session. The session stays open with its attendance marked as ignored
+// until they are resolved manually. It can't be updated or deleted meanwhile because it has the applied attendances.
+func (s *Server) applySubmissions(dbSession session.Session, submissions []attendance.FormSubmission,
+	invalidSubmissions []attendance.InvalidSubmission, calledBy string) error {
+	sessionId := dbSession.Id
+	if len(submissions) == 0 && len(invalidSubmissions) == 0 {
+		if err := s.openSessionRepo.MarkAttendanceIsIgnored(sessionId, "no submissions from the attendance source"); err != nil {
 			return newInternalServerError(fmt.Errorf("failed to mark the session's attendance as ignored: %w", err))
 		}
 		return nil
 	}
 
-	submissionsOnTime := array.Filter(formSubmissions, func(submission attendance.FormSubmission) bool {
-		return submission.SubmissionTime.Before(dbSession.StartsAt)
+	// The form is closed when the session starts. The other sources check the time when they are submitted.
+	submissionsOnTime := submissions
+	invalidSubmissionsOnTime := invalidSubmissions
+	if dbSession.AttendanceSource.Provider == session.AttendanceSourceProviderGoogleForm {
+		submissionsOnTime = array.Filter(submissions, func(submission attendance.FormSubmission) bool {
+			return submission.SubmissionTime.Before(dbSession.StartsAt)
+		})
+		// Keep the ones without the time since it can't tell if they are late.
+		invalidSubmissionsOnTime = array.Filter(invalidSubmissions, func(submission attendance.InvalidSubmission) bool {
+			return submission.SubmissionTime.IsZero() || submission.SubmissionTime.Before(dbSession.StartsAt)
+		})
+	}
+
+	attendances, err := s.attendanceRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get attendances: %w", err))
+	}
+	attendedExternalNameSet := map[string]bool{}
+	for _, attendance := range attendances {
+		attendedExternalNameSet[attendance.UserExternalName] = true
+	}
+	submissionsNotAttendedYet := array.Filter(submissionsOnTime, func(submission attendance.FormSubmission) bool {
+		return !attendedExternalNameSet[submission.UserExternalName]
 	})
 
-	externalNames := array.Map(submissionsOnTime, func(submission attendance.FormSubmission) string {
+	externalNames := array.Map(submissionsNotAttendedYet, func(submission attendance.FormSubmission) string {
 		return submission.UserExternalName
 	})
 
@@ -147,13 +272,49 @@ func (s *Server) ApplyAttendanceByFormSubmissions(sessionId string, calledBy str
 		externalNameToUserMap[user.ExternalName] = user
 	}
 
+	// The admins may have resolved the unmatched submissions of the previous try.
+	resolutions := map[string]unmatched.Resolution{}
+	if dbSession.AttendanceStatus == session.AttendanceStatusIgnored {
+		if resolutions, err = s.getUnmatchedSubmissionResolutions(sessionId); err != nil {
+			return err
+		}
+	}
+
+	resolvedSubmissions := []resolvedSubmission{}
+	unmatchedSubmissions := []unmatched.Submission{}
+	invalidAnswerCount := 0
+	for _, submission := range invalidSubmissionsOnTime {
+		if resolution, ok := resolutions[unmatchedSubmissionKey(submission.Answer, submission.SubmissionTime)]; ok {
+			resolvedSubmissions = append(resolvedSubmissions, resolvedSubmission{resolution: resolution, submissionTime: submission.SubmissionTime})
+			continue
+		}
+		invalidAnswerCount++
+		unmatchedSubmissions = append(unmatchedSubmissions, unmatched.Submission{
+			Answer:         submission.Answer,
+			Reason:         unmatched.ReasonInvalidAnswer,
+			Detail:         submission.Reason,
+			SubmissionTime: submission.SubmissionTime,
+		})
+	}
 	notFoundExternalNames := []string{}
-	addAttendanceReqs := array.Map(submissionsOnTime, func(submission attendance.FormSubmission) attendance.AddAttendanceReq {
+	addAttendanceReqs := []attendance.AddAttendanceReq{}
+	for _, submission := range submissionsNotAttendedYet {
 		user, exists := externalNameToUserMap[submission.UserExternalName]
 		if !exists {
+			if resolution, ok := resolutions[unmatchedSubmissionKey(submission.UserExternalName, submission.SubmissionTime)]; ok {
+				resolvedSubmissions = append(resolvedSubmissions, resolvedSubmission{resolution: resolution, submissionTime: submission.SubmissionTime})
+				continue
+			}
 			notFoundExternalNames = append(notFoundExternalNames, submission.UserExternalName)
+			unmatchedSubmissions = append(unmatchedSubmissions, unmatched.Submission{
+				Answer:           submission.UserExternalName,
+				UserExternalName: submission.UserExternalName,
+				Reason:           unmatched.ReasonUserNotFound,
+				SubmissionTime:   submission.SubmissionTime,
+			})
+			continue
 		}
-		return attendance.AddAttendanceReq{
+		addAttendanceReqs = append(addAttendanceReqs, attendance.AddAttendanceReq{
 			SessionId:        sessionId,
 			SessionName:      dbSession.Name,
 			SessionScore:     dbSession.Score,
@@ -163,27 +324,50 @@ func (s *Server) ApplyAttendanceByFormSubmissions(sessionId string, calledBy str
 			UserGeneration:   user.Generation,
 			UserJoinedAt:     submission.SubmissionTime,
 			CreatedBy:        calledBy,
-		}
-	})
+			Status:           attendance.StatusByJoinedAt(dbSession.StartsAt, submission.SubmissionTime),
+		})
+	}
 
-	if len(notFoundExternalNames) > 0 {
-		if err := s.openSessionRepo.MarkAttendanceIsIgnored(sessionId, fmt.Sprintf("some users (%s) were not found although there are form submissions",
-			strings.Join(notFoundExternalNames, ", "))); err != nil {
-			return newInternalServerError(fmt.Errorf(
-				"some users (%s) were not found although there are form submissions and it has failed to mark the session's attendance as ignored: %w",
-				strings.Join(notFoundExternalNames, ", "), err))
-		}
-		return newInternalServerError(fmt.Errorf("some users (%s) were not found although there are form submissions",
-			strings.Join(notFoundExternalNames, ", ")))
+	resolvedAttendanceReqs, err := s.newResolvedAttendanceReqs(dbSession, resolvedSubmissions, attendances, addAttendanceReqs)
+	if err != nil {
+		return err
 	}
+	addAttendanceReqs = append(addAttendanceReqs, resolvedAttendanceReqs...)
 
-	if err := s.attendanceRepo.BulkInsert(addAttendanceReqs); err != nil {
-		return newInternalServerError(fmt.Errorf("failed to bulk insert attendance: %w", err))
+	if len(unmatchedSubmissions) > 0 {
+		// The matched ones are applied now and skipped when it's applied again with the resolutions.
+		if len(addAttendanceReqs) > 0 {
+			if _, err := s.attendanceRepo.BulkInsert(addAttendanceReqs); err != nil {
+				if errors.Is(err, attendance.ErrDuplicate) {
+					return newConflictError(fmt.Errorf("the attendance of the session has been applied by someone else: %w", err))
+				}
+				return newInternalServerError(fmt.Errorf("failed to bulk insert attendances: %w", err))
+			}
+		}
+		if err := s.unmatchedSubmissionRepo.ReplaceBySessionId(sessionId, unmatchedSubmissions); err != nil {
+			return newInternalServerError(fmt.Errorf("failed to keep the unmatched submissions: %w", err))
+		}
+
+		reasons := []string{}
+		if len(notFoundExternalNames) > 0 {
+			reasons = append(reasons, fmt.Sprintf("some users (%s) were not found although there are submissions", strings.Join(notFoundExternalNames, ", ")))
+		}
+		if invalidAnswerCount > 0 {
+			reasons = append(reasons, fmt.Sprintf("some submissions (%d) have invalid answers", invalidAnswerCount))
+		}
+		reason := strings.Join(reasons, " and ")
+		if err := s.openSessionRepo.MarkAttendanceIsIgnored(sessionId, reason); err != nil {
+			return newInternalServerError(fmt.Errorf("%s and it has failed to mark the session's attendance as ignored: %w", reason, err))
+		}
+		return nil
 	}
 
-	if err := s.openSessionRepo.MarkAsAttendanceApplied(sessionId); err != nil {
-		return newInternalServerError(fmt.Errorf("failed to close open session: %w", err))
+	// The unmatched submissions of the previous try are stale once everything is matched.
+	if dbSession.AttendanceStatus == session.AttendanceStatusIgnored {
+		if err := s.unmatchedSubmissionRepo.ReplaceBySessionId(sessionId, nil); err != nil {
+			return newInternalServerError(fmt.Errorf("failed to clear the unmatched submissions: %w", err))
+		}
 	}
 
-	return nil
+	return s.applyAttendances(sessionId, addAttendanceReqs)
 }
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"rush/attendance"
 	"rush/session"
+	"rush/unmatched"
 	"rush/user"
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -18,7 +20,7 @@ func TestAdminGetSession(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{}, session.ErrNotFound)
 		dbSession, err := server.AdminGetSession("session-id")
@@ -31,7 +33,7 @@ func TestAdminGetSession(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{}, assert.AnError)
 		dbSession, err := server.AdminGetSession("session-id")
@@ -44,15 +46,14 @@ func TestAdminGetSession(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 			Id:               "session-id",
 			Name:             "session-name",
 			Description:      "session-description",
 			CreatedBy:        "user-id",
-			GoogleFormId:     "google-form-id",
-			GoogleFormUri:    "google-form-uri",
+			AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id", Uri: "google-form-uri"},
 			CreatedAt:        time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			Score:            1,
@@ -67,6 +68,7 @@ func TestAdminGetSession(t *testing.T) {
 			CreatedBy:           "user-id",
 			GoogleFormId:        "google-form-id",
 			GoogleFormUri:       "google-form-uri",
+			AttendanceSource:    &AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id", Uri: "google-form-uri"},
 			CreatedAt:           time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			StartsAt:            time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			Score:               1,
@@ -82,7 +84,7 @@ func TestGetSession(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{}, session.ErrNotFound)
 		dbSession, err := server.GetSession("session-id")
@@ -95,7 +97,7 @@ func TestGetSession(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{}, assert.AnError)
 		dbSession, err := server.GetSession("session-id")
@@ -108,15 +110,14 @@ func TestGetSession(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 			Id:               "session-id",
 			Name:             "session-name",
 			Description:      "session-description",
 			CreatedBy:        "user-id",
-			GoogleFormId:     "google-form-id",
-			GoogleFormUri:    "google-form-uri",
+			AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id", Uri: "google-form-uri"},
 			CreatedAt:        time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			Score:            1,
@@ -142,7 +143,7 @@ func TestAdminListSessions(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().List(1, 2).Return(nil, assert.AnError)
 		listResult, err := server.AdminListSessions(1, 2)
@@ -155,7 +156,7 @@ func TestAdminListSessions(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().List(1, 2).Return(
 			&session.ListResult{
@@ -165,8 +166,7 @@ func TestAdminListSessions(t *testing.T) {
 						Name:             "session-name",
 						Description:      "session-description",
 						CreatedBy:        "user-id",
-						GoogleFormId:     "google-form-id",
-						GoogleFormUri:    "google-form-uri",
+						AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id", Uri: "google-form-uri"},
 						CreatedAt:        time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 						StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 						Score:            1,
@@ -177,8 +177,7 @@ func TestAdminListSessions(t *testing.T) {
 						Name:             "session-name2",
 						Description:      "session-description2",
 						CreatedBy:        "user-id2",
-						GoogleFormId:     "google-form-id2",
-						GoogleFormUri:    "google-form-uri2",
+						AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id2", Uri: "google-form-uri2"},
 						CreatedAt:        time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC),
 						StartsAt:         time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC),
 						Score:            2,
@@ -199,6 +198,7 @@ func TestAdminListSessions(t *testing.T) {
 					CreatedBy:           "user-id",
 					GoogleFormId:        "google-form-id",
 					GoogleFormUri:       "google-form-uri",
+					AttendanceSource:    &AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id", Uri: "google-form-uri"},
 					CreatedAt:           time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 					StartsAt:            time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 					Score:               1,
@@ -212,6 +212,7 @@ func TestAdminListSessions(t *testing.T) {
 					CreatedBy:           "user-id2",
 					GoogleFormId:        "google-form-id2",
 					GoogleFormUri:       "google-form-uri2",
+					AttendanceSource:    &AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id2", Uri: "google-form-uri2"},
 					CreatedAt:           time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC),
 					StartsAt:            time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC),
 					Score:               2,
@@ -231,7 +232,7 @@ func TestListSessions(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().List(1, 2).Return(nil, assert.AnError)
 		listResult, err := server.ListSessions(1, 2)
@@ -244,7 +245,7 @@ func TestListSessions(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().List(1, 2).Return(
 			&session.ListResult{
@@ -254,8 +255,7 @@ func TestListSessions(t *testing.T) {
 						Name:             "session-name",
 						Description:      "session-description",
 						CreatedBy:        "user-id",
-						GoogleFormId:     "google-form-id",
-						GoogleFormUri:    "google-form-uri",
+						AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id", Uri: "google-form-uri"},
 						CreatedAt:        time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 						StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 						Score:            1,
@@ -266,8 +266,7 @@ func TestListSessions(t *testing.T) {
 						Name:             "session-name2",
 						Description:      "session-description2",
 						CreatedBy:        "user-id2",
-						GoogleFormId:     "google-form-id2",
-						GoogleFormUri:    "google-form-uri2",
+						AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id2", Uri: "google-form-uri2"},
 						CreatedAt:        time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC),
 						StartsAt:         time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC),
 						Score:            2,
@@ -312,7 +311,7 @@ func TestAddSession(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Add("session-name", "session-description", "user-id", time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC), 1).Return("", assert.AnError)
 		id, err := server.AddSession("session-name", "session-description", "user-id", time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC), 1)
@@ -325,7 +324,7 @@ func TestAddSession(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSessionRepo := NewMocksessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo})
 
 		mockSessionRepo.EXPECT().Add("session-name", "session-description", "user-id", time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC), 1).Return("session-id", nil)
 		id, err := server.AddSession("session-name", "session-description", "user-id", time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC), 1)
@@ -336,12 +335,40 @@ func TestAddSession(t *testing.T) {
 }
 
 func TestDeleteSession(t *testing.T) {
+	t.Run("Returns not found error when session is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		server := New(Deps{SessionRepo: mockSessionRepo})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{}, session.ErrNotFound)
+		err := server.DeleteSession("session-id")
+
+		assert.Equal(t, newNotFoundError(fmt.Errorf("failed to get session: %w", session.ErrNotFound)), err)
+	})
+
+	t.Run("Returns bad request error when the session already has attendances", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		mockAttendanceRepo := NewMockattendanceRepo(ctrl)
+		server := New(Deps{SessionRepo: mockSessionRepo, AttendanceRepo: mockAttendanceRepo})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{Id: "session-id"}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{{Id: "attendance-id"}}, nil)
+		err := server.DeleteSession("session-id")
+
+		assert.Equal(t, newBadRequestError(errors.New("session already has attendances")), err)
+	})
+
 	t.Run("Returns internal server error when failed to delete session", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		mockAttendanceRepo := NewMockattendanceRepo(ctrl)
 		mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, nil, mockOpenSessionRepo, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo, AttendanceRepo: mockAttendanceRepo, OpenSessionRepo: mockOpenSessionRepo})
 
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{Id: "session-id"}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 		mockOpenSessionRepo.EXPECT().DeleteOpenSession("session-id").Return(assert.AnError)
 		err := server.DeleteSession("session-id")
 
@@ -351,14 +378,217 @@ func TestDeleteSession(t *testing.T) {
 	t.Run("Returns nil when successfully deletes open session", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		mockAttendanceRepo := NewMockattendanceRepo(ctrl)
 		mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
-		server := New(nil, nil, nil, nil, nil, nil, mockOpenSessionRepo, nil, nil, nil, nil)
+		server := New(Deps{SessionRepo: mockSessionRepo, AttendanceRepo: mockAttendanceRepo, OpenSessionRepo: mockOpenSessionRepo})
 
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{Id: "session-id"}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 		mockOpenSessionRepo.EXPECT().DeleteOpenSession("session-id").Return(nil)
 		err := server.DeleteSession("session-id")
 
 		assert.NoError(t, err)
 	})
+
+	t.Run("Hides the deleted session from the admins and the users", func(t *testing.T) {
+		sessionRepo := session.NewMemoryRepo()
+		server := New(Deps{
+			SessionRepo:     sessionRepo,
+			OpenSessionRepo: session.NewService(sessionRepo),
+			AttendanceRepo:  attendance.NewMemoryRepo(clock.NewMock()),
+		})
+
+		id, err := server.AddSession("session-name", "session-description", "user-id", time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		_, err = server.AddSession("other-session-name", "session-description", "user-id", time.Date(2024, 1, 2, 20, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		assert.NoError(t, server.DeleteSession(id))
+
+		_, err = server.AdminGetSession(id)
+		assert.Equal(t, newNotFoundError(fmt.Errorf("failed to get session: %w", session.ErrNotFound)), err)
+		listResult, err := server.ListSessions(0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, listResult.Sessions, 1)
+		assert.Equal(t, "other-session-name", listResult.Sessions[0].Name)
+		assert.Equal(t, 1, listResult.TotalCount)
+	})
+}
+
+func TestUpdateSession(t *testing.T) {
+	t.Run("Returns not found error when session is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		server := New(Deps{SessionRepo: mockSessionRepo})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{}, session.ErrNotFound)
+		_, err := server.UpdateSession("session-id", nil, nil, nil, nil)
+
+		assert.Equal(t, newNotFoundError(fmt.Errorf("failed to get session: %w", session.ErrNotFound)), err)
+	})
+
+	t.Run("Returns bad request error when session is already closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		server := New(Deps{SessionRepo: mockSessionRepo})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
+			Id:               "session-id",
+			AttendanceStatus: session.AttendanceStatusApplied,
+		}, nil)
+		score := 2
+		_, err := server.UpdateSession("session-id", nil, nil, nil, &score)
+
+		assert.Equal(t, newBadRequestError(errors.New("session is already closed")), err)
+	})
+
+	t.Run("Returns bad request error when the name is empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		server := New(Deps{SessionRepo: mockSessionRepo})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
+			Id:               "session-id",
+			AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+		}, nil)
+		name := " "
+		_, err := server.UpdateSession("session-id", &name, nil, nil, nil)
+
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Does not touch the form when the session doesn't have it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
+		mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
+		mockAttendanceRepo := NewMockattendanceRepo(ctrl)
+		server := New(Deps{
+			SessionRepo:           mockSessionRepo,
+			OpenSessionRepo:       mockOpenSessionRepo,
+			AttendanceFormHandler: mockAttendanceFormHandler,
+			AttendanceRepo:        mockAttendanceRepo,
+			FormTimeLocation:      time.UTC,
+		})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
+			Id:               "session-id",
+			AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+		}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
+		name := "new-name"
+		mockOpenSessionRepo.EXPECT().UpdateOpenSession("session-id", session.OpenSessionUpdateForm{
+			Title:                &name,
+			ReturnUpdatedSession: true,
+		}).Return(session.Session{
+			Id:               "session-id",
+			Name:             "new-name",
+			AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+		}, nil)
+		result, err := server.UpdateSession("session-id", &name, nil, nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new-name", result.Name)
+	})
+
+	t.Run("Syncs the title and the description of the form", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
+		mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
+		mockAttendanceRepo := NewMockattendanceRepo(ctrl)
+		server := New(Deps{
+			SessionRepo:           mockSessionRepo,
+			OpenSessionRepo:       mockOpenSessionRepo,
+			AttendanceFormHandler: mockAttendanceFormHandler,
+			AttendanceRepo:        mockAttendanceRepo,
+			FormTimeLocation:      time.UTC,
+		})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
+			Id:               "session-id",
+			Name:             "session-name",
+			AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id"},
+			AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+		}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
+		startsAt := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+		mockOpenSessionRepo.EXPECT().UpdateOpenSession("session-id", session.OpenSessionUpdateForm{
+			StartsAt:             &startsAt,
+			ReturnUpdatedSession: true,
+		}).Return(session.Session{
+			Id:               "session-id",
+			Name:             "session-name",
+			AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id"},
+			StartsAt:         startsAt,
+			AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+		}, nil)
+		mockAttendanceFormHandler.EXPECT().UpdateFormInfo("form-id", "[출석] session-name", `session-name을(를) 위한 출석용 구글폼입니다.
+폼 마감 시간은 2024-01-01 19:59:59입니다. 2024-01-01 20:00:00 이후 요청은 무시됩니다.`).Return(nil)
+		result, err := server.UpdateSession("session-id", nil, nil, &startsAt, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, startsAt, result.StartsAt)
+	})
+
+	t.Run("Doesn't update the session when failed to update the form", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
+		mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
+		mockAttendanceRepo := NewMockattendanceRepo(ctrl)
+		server := New(Deps{
+			SessionRepo:           mockSessionRepo,
+			OpenSessionRepo:       mockOpenSessionRepo,
+			AttendanceFormHandler: mockAttendanceFormHandler,
+			AttendanceRepo:        mockAttendanceRepo,
+			FormTimeLocation:      time.UTC,
+		})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
+			Id:               "session-id",
+			AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id"},
+			AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+		}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
+		name := "new-name"
+		mockAttendanceFormHandler.EXPECT().UpdateFormInfo("form-id", "[출석] new-name", gomock.Any()).Return(assert.AnError)
+		_, err := server.UpdateSession("session-id", &name, nil, nil, nil)
+
+		assert.Equal(t, newInternalServerError(fmt.Errorf("failed to update the form of the session, so the session is not changed: %w", assert.AnError)), err)
+	})
+
+	t.Run("Restores the form when failed to update the session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSessionRepo := NewMocksessionRepo(ctrl)
+		mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
+		mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
+		mockAttendanceRepo := NewMockattendanceRepo(ctrl)
+		server := New(Deps{
+			SessionRepo:           mockSessionRepo,
+			OpenSessionRepo:       mockOpenSessionRepo,
+			AttendanceFormHandler: mockAttendanceFormHandler,
+			AttendanceRepo:        mockAttendanceRepo,
+			FormTimeLocation:      time.UTC,
+		})
+
+		mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
+			Id:               "session-id",
+			Name:             "old-name",
+			AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id"},
+			AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+		}, nil)
+		mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
+		name := "new-name"
+		gomock.InOrder(
+			mockAttendanceFormHandler.EXPECT().UpdateFormInfo("form-id", "[출석] new-name", gomock.Any()).Return(nil),
+			mockOpenSessionRepo.EXPECT().UpdateOpenSession("session-id", gomock.Any()).Return(session.Session{}, assert.AnError),
+			mockAttendanceFormHandler.EXPECT().UpdateFormInfo("form-id", "[출석] old-name", gomock.Any()).Return(nil),
+		)
+		_, err := server.UpdateSession("session-id", &name, nil, nil, nil)
+
+		assert.Equal(t, newInternalServerError(fmt.Errorf("failed to update session, so neither the session nor its form is changed: %w", assert.AnError)), err)
+	})
 }
 
 func TestApplyAttendanceByFormSubmissions(t *testing.T) {
@@ -367,7 +597,7 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockSessionRepo := NewMocksessionRepo(ctrl)
-			server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+			server := New(Deps{SessionRepo: mockSessionRepo})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{}, errors.New("session not found"))
@@ -383,7 +613,7 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockSessionRepo := NewMocksessionRepo(ctrl)
-			server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+			server := New(Deps{SessionRepo: mockSessionRepo})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
@@ -398,17 +628,15 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			assert.EqualError(t, badRequestError.originalError, "session is already closed")
 		})
 
-		t.Run("Returns bad request error when session cannot apply attendance by form submissions", func(t *testing.T) {
+		t.Run("Returns bad request error when session has no attendance source", func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockSessionRepo := NewMocksessionRepo(ctrl)
-			server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, nil, nil, nil, nil)
+			server := New(Deps{SessionRepo: mockSessionRepo})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
-				GoogleFormId:     "",
-				GoogleFormUri:    "",
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 			}, nil)
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
@@ -416,7 +644,7 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			// Assert.
 			var badRequestError *BadRequestError
 			assert.ErrorAs(t, err, &badRequestError)
-			assert.EqualError(t, badRequestError.originalError, "session cannot apply attendance by form submissions")
+			assert.EqualError(t, badRequestError.originalError, "session has no attendance source to apply")
 		})
 
 		t.Run("Returns internal server error when failed to get form submissions", func(t *testing.T) {
@@ -424,22 +652,21 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			defer ctrl.Finish()
 			mockSessionRepo := NewMocksessionRepo(ctrl)
 			mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
-			server := New(nil, nil, nil, nil, nil, mockSessionRepo, nil, mockAttendanceFormHandler, nil, nil, nil)
+			server := New(Deps{SessionRepo: mockSessionRepo, AttendanceFormHandler: mockAttendanceFormHandler})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 			}, nil)
-			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return(nil, errors.New("failed to get form submissions"))
+			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return(nil, nil, errors.New("failed to get form submissions"))
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
 			// Assert.
 			var internalServerError *InternalServerError
 			assert.ErrorAs(t, err, &internalServerError)
-			assert.EqualError(t, internalServerError.originalError, "failed to get form submissions: failed to get form submissions")
+			assert.EqualError(t, internalServerError.originalError, "failed to get submissions from the attendance source: failed to get form submissions")
 		})
 
 		t.Run("Marks attendance as ignored when there are no submissions", func(t *testing.T) {
@@ -448,18 +675,20 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockSessionRepo := NewMocksessionRepo(ctrl)
 			mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
-			server := New(nil, nil, nil, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, nil, nil, nil)
+			server := New(Deps{
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 			}, nil)
-			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return([]attendance.FormSubmission{}, nil)
-			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "no form submissions").Return(nil)
+			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return([]attendance.FormSubmission{}, nil, nil)
+			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "no submissions from the attendance source").Return(nil)
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
 			assert.NoError(t, err)
@@ -471,18 +700,20 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockSessionRepo := NewMocksessionRepo(ctrl)
 			mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
-			server := New(nil, nil, nil, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, nil, nil, nil)
+			server := New(Deps{
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 			}, nil)
-			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return([]attendance.FormSubmission{}, nil)
-			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "no form submissions").Return(errors.New("failed to mark attendance as ignored"))
+			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return([]attendance.FormSubmission{}, nil, nil)
+			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "no submissions from the attendance source").Return(errors.New("failed to mark attendance as ignored"))
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
 			// Assert.
@@ -498,14 +729,19 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
 			mockUserRepo := NewMockuserRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, nil, nil, nil)
+			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
+			server := New(Deps{
+				UserRepo:              mockUserRepo,
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+				AttendanceRepo:        mockAttendanceRepo,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			}, nil)
@@ -518,7 +754,8 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserExternalName: "user-external-name-2",
 					SubmissionTime:   time.Date(2024, 1, 1, 19, 59, 59, 0, time.UTC),
 				},
-			}, nil)
+			}, nil, nil)
+			mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1", "user-external-name-2"}).
 				Return(nil, errors.New("failed to get users by external names"))
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
@@ -536,14 +773,21 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
 			mockUserRepo := NewMockuserRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, nil, nil, nil)
+			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
+			mockUnmatchedSubmissionRepo := NewMockunmatchedSubmissionRepo(ctrl)
+			server := New(Deps{
+				UserRepo:                mockUserRepo,
+				SessionRepo:             mockSessionRepo,
+				OpenSessionRepo:         mockOpenSessionRepo,
+				AttendanceFormHandler:   mockAttendanceFormHandler,
+				AttendanceRepo:          mockAttendanceRepo,
+				UnmatchedSubmissionRepo: mockUnmatchedSubmissionRepo,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			}, nil)
@@ -556,7 +800,8 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserExternalName: "user-external-name-2",
 					SubmissionTime:   time.Date(2024, 1, 1, 19, 59, 59, 0, time.UTC),
 				},
-			}, nil)
+			}, nil, nil)
+			mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1", "user-external-name-2"}).
 				Return([]user.User{
 					// Only user-external-name-1 is found.
@@ -565,59 +810,33 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 						ExternalName: "user-external-name-1",
 					},
 				}, nil)
-			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "some users (user-external-name-2) were not found although there are form submissions").
-				Return(errors.New("failed to mark attendance as ignored"))
-			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
-
-			// Assert.
-			var internalServerError *InternalServerError
-			assert.ErrorAs(t, err, &internalServerError)
-			assert.EqualError(t, internalServerError.originalError, "some users (user-external-name-2) were not found although there are form submissions and it has failed to mark the session's attendance as ignored: failed to mark attendance as ignored")
-		})
-
-		t.Run("Returns internal server error when there are not-found users and mark attendance as ignored successfully", func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-			mockSessionRepo := NewMocksessionRepo(ctrl)
-			mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
-			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
-			mockUserRepo := NewMockuserRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, nil, nil, nil)
-
-			// Do.
-			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
-				Id:               "session-id",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
-				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
-				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
-			}, nil)
-			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return([]attendance.FormSubmission{
+			mockAttendanceRepo.EXPECT().BulkInsert([]attendance.AddAttendanceReq{
 				{
+					SessionId:        "session-id",
+					SessionStartedAt: time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
+					UserId:           "user-id-1",
 					UserExternalName: "user-external-name-1",
-					SubmissionTime:   time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+					UserJoinedAt:     time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+					CreatedBy:        "caller-id",
+					Status:           attendance.StatusOnTime,
 				},
+			}).Return([]string{"attendance-id-1"}, nil)
+			mockUnmatchedSubmissionRepo.EXPECT().ReplaceBySessionId("session-id", []unmatched.Submission{
 				{
+					Answer:           "user-external-name-2",
 					UserExternalName: "user-external-name-2",
+					Reason:           unmatched.ReasonUserNotFound,
 					SubmissionTime:   time.Date(2024, 1, 1, 19, 59, 59, 0, time.UTC),
 				},
-			}, nil)
-			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1", "user-external-name-2"}).
-				Return([]user.User{
-					{
-						Id:           "user-id-1",
-						ExternalName: "user-external-name-1",
-					},
-				}, nil)
-			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "some users (user-external-name-2) were not found although there are form submissions").
-				Return(nil)
+			}).Return(nil)
+			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "some users (user-external-name-2) were not found although there are submissions").
+				Return(errors.New("failed to mark attendance as ignored"))
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
 			// Assert.
 			var internalServerError *InternalServerError
 			assert.ErrorAs(t, err, &internalServerError)
-			assert.EqualError(t, internalServerError.originalError, "some users (user-external-name-2) were not found although there are form submissions")
+			assert.EqualError(t, internalServerError.originalError, "some users (user-external-name-2) were not found although there are submissions and it has failed to mark the session's attendance as ignored: failed to mark attendance as ignored")
 		})
 
 		t.Run("Returns internal server error when failed to bulk insert attendances", func(t *testing.T) {
@@ -628,14 +847,18 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
 			mockUserRepo := NewMockuserRepo(ctrl)
 			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, mockAttendanceRepo, nil, nil)
+			server := New(Deps{
+				UserRepo:              mockUserRepo,
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+				AttendanceRepo:        mockAttendanceRepo,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			}, nil)
@@ -644,7 +867,8 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserExternalName: "user-external-name-1",
 					SubmissionTime:   time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
 				},
-			}, nil)
+			}, nil, nil)
+			mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1"}).
 				Return([]user.User{
 					{
@@ -652,13 +876,13 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 						ExternalName: "user-external-name-1",
 					},
 				}, nil)
-			mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return(errors.New("failed to bulk insert attendances"))
+			mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return(nil, errors.New("failed to bulk insert attendances"))
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
 			// Assert.
 			var internalServerError *InternalServerError
 			assert.ErrorAs(t, err, &internalServerError)
-			assert.EqualError(t, internalServerError.originalError, "failed to bulk insert attendance: failed to bulk insert attendances")
+			assert.EqualError(t, internalServerError.originalError, "failed to bulk insert attendances: failed to bulk insert attendances")
 		})
 
 		t.Run("Returns internal server error when failed to mark open session as attendance applied", func(t *testing.T) {
@@ -669,14 +893,18 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
 			mockUserRepo := NewMockuserRepo(ctrl)
 			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, mockAttendanceRepo, nil, nil)
+			server := New(Deps{
+				UserRepo:              mockUserRepo,
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+				AttendanceRepo:        mockAttendanceRepo,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			}, nil)
@@ -685,7 +913,8 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserExternalName: "user-external-name-1",
 					SubmissionTime:   time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
 				},
-			}, nil)
+			}, nil, nil)
+			mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1"}).
 				Return([]user.User{
 					{
@@ -693,14 +922,16 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 						ExternalName: "user-external-name-1",
 					},
 				}, nil)
-			mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return(nil)
+			mockAttendanceRepo.EXPECT().BulkInsert(gomock.Any()).Return([]string{"attendance-id-1"}, nil)
 			mockOpenSessionRepo.EXPECT().MarkAsAttendanceApplied("session-id").Return(errors.New("failed to close open session"))
+			// The inserted attendances are deleted not to be orphaned.
+			mockAttendanceRepo.EXPECT().Delete([]string{"attendance-id-1"}).Return(nil)
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
 			// Assert.
 			var internalServerError *InternalServerError
 			assert.ErrorAs(t, err, &internalServerError)
-			assert.EqualError(t, internalServerError.originalError, "failed to close open session: failed to close open session")
+			assert.EqualError(t, internalServerError.originalError, "failed to close the session: failed to close open session")
 		})
 	})
 
@@ -713,21 +944,25 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
 			mockUserRepo := NewMockuserRepo(ctrl)
 			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, mockAttendanceRepo, nil, nil)
+			server := New(Deps{
+				UserRepo:              mockUserRepo,
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+				AttendanceRepo:        mockAttendanceRepo,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
 				Name:             "session-name",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 				Score:            2,
 				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 			}, nil)
-			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return([]attendance.FormSubmission{}, nil)
-			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "no form submissions").Return(nil)
+			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return([]attendance.FormSubmission{}, nil, nil)
+			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "no submissions from the attendance source").Return(nil)
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
 			// Assert.
@@ -742,15 +977,19 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
 			mockUserRepo := NewMockuserRepo(ctrl)
 			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, mockAttendanceRepo, nil, nil)
+			server := New(Deps{
+				UserRepo:              mockUserRepo,
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+				AttendanceRepo:        mockAttendanceRepo,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
 				Name:             "session-name",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 				Score:            2,
 				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
@@ -760,7 +999,8 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserExternalName: "user-external-name-1",
 					SubmissionTime:   time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
 				},
-			}, nil)
+			}, nil, nil)
+			mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1"}).
 				Return([]user.User{
 					{
@@ -780,8 +1020,9 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserGeneration:   1,
 					UserJoinedAt:     time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
 					CreatedBy:        "caller-id",
+					Status:           attendance.StatusOnTime,
 				},
-			}).Return(nil)
+			}).Return([]string{"attendance-id-1"}, nil)
 			mockOpenSessionRepo.EXPECT().MarkAsAttendanceApplied("session-id").Return(nil)
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
@@ -797,15 +1038,19 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
 			mockUserRepo := NewMockuserRepo(ctrl)
 			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, mockAttendanceRepo, nil, nil)
+			server := New(Deps{
+				UserRepo:              mockUserRepo,
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+				AttendanceRepo:        mockAttendanceRepo,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
 				Name:             "session-name",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 				Score:            2,
 				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
@@ -823,7 +1068,8 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserExternalName: "user-external-name-3",
 					SubmissionTime:   time.Date(2024, 1, 1, 19, 59, 59, 0, time.UTC),
 				},
-			}, nil)
+			}, nil, nil)
+			mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1", "user-external-name-2", "user-external-name-3"}).
 				Return([]user.User{
 					{
@@ -853,6 +1099,7 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserGeneration:   1,
 					UserJoinedAt:     time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
 					CreatedBy:        "caller-id",
+					Status:           attendance.StatusOnTime,
 				},
 				{
 					SessionId:        "session-id",
@@ -864,6 +1111,7 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserGeneration:   1,
 					UserJoinedAt:     time.Date(2024, 1, 1, 19, 59, 59, 0, time.UTC),
 					CreatedBy:        "caller-id",
+					Status:           attendance.StatusOnTime,
 				},
 				{
 					SessionId:        "session-id",
@@ -875,8 +1123,9 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserGeneration:   1.5,
 					UserJoinedAt:     time.Date(2024, 1, 1, 19, 59, 59, 0, time.UTC),
 					CreatedBy:        "caller-id",
+					Status:           attendance.StatusOnTime,
 				},
-			}).Return(nil)
+			}).Return([]string{"attendance-id-1"}, nil)
 			mockOpenSessionRepo.EXPECT().MarkAsAttendanceApplied("session-id").Return(nil)
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
@@ -892,15 +1141,19 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
 			mockUserRepo := NewMockuserRepo(ctrl)
 			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
-			server := New(nil, nil, mockUserRepo, nil, nil, mockSessionRepo, mockOpenSessionRepo,
-				mockAttendanceFormHandler, mockAttendanceRepo, nil, nil)
+			server := New(Deps{
+				UserRepo:              mockUserRepo,
+				SessionRepo:           mockSessionRepo,
+				OpenSessionRepo:       mockOpenSessionRepo,
+				AttendanceFormHandler: mockAttendanceFormHandler,
+				AttendanceRepo:        mockAttendanceRepo,
+			})
 
 			// Do.
 			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
 				Id:               "session-id",
 				Name:             "session-name",
-				GoogleFormId:     "form-id",
-				GoogleFormUri:    "form-uri",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
 				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
 				Score:            2,
 				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
@@ -918,7 +1171,8 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserExternalName: "user-external-name-3",
 					SubmissionTime:   time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
 				},
-			}, nil)
+			}, nil, nil)
+			mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
 			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1", "user-external-name-2"}).
 				Return([]user.User{
 					{
@@ -943,6 +1197,7 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserGeneration:   1,
 					UserJoinedAt:     time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
 					CreatedBy:        "caller-id",
+					Status:           attendance.StatusOnTime,
 				},
 				{
 					SessionId:        "session-id",
@@ -954,13 +1209,84 @@ func TestApplyAttendanceByFormSubmissions(t *testing.T) {
 					UserGeneration:   1,
 					UserJoinedAt:     time.Date(2024, 1, 1, 19, 59, 0, 0, time.UTC),
 					CreatedBy:        "caller-id",
+					Status:           attendance.StatusOnTime,
 				},
-			}).Return(nil)
+			}).Return([]string{"attendance-id-1"}, nil)
 			mockOpenSessionRepo.EXPECT().MarkAsAttendanceApplied("session-id").Return(nil)
 			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
 
 			// Assert.
 			assert.NoError(t, err)
 		})
+
+		t.Run("Applies the found users and keeps the not-found ones to be resolved", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockSessionRepo := NewMocksessionRepo(ctrl)
+			mockAttendanceFormHandler := NewMockattendanceFormHandler(ctrl)
+			mockOpenSessionRepo := NewMockopenSessionRepo(ctrl)
+			mockUserRepo := NewMockuserRepo(ctrl)
+			mockAttendanceRepo := NewMockattendanceRepo(ctrl)
+			mockUnmatchedSubmissionRepo := NewMockunmatchedSubmissionRepo(ctrl)
+			server := New(Deps{
+				UserRepo:                mockUserRepo,
+				SessionRepo:             mockSessionRepo,
+				OpenSessionRepo:         mockOpenSessionRepo,
+				AttendanceFormHandler:   mockAttendanceFormHandler,
+				AttendanceRepo:          mockAttendanceRepo,
+				UnmatchedSubmissionRepo: mockUnmatchedSubmissionRepo,
+			})
+
+			// Do.
+			mockSessionRepo.EXPECT().Get("session-id").Return(session.Session{
+				Id:               "session-id",
+				AttendanceSource: session.AttendanceSource{Provider: session.AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "form-uri"},
+				AttendanceStatus: session.AttendanceStatusNotAppliedYet,
+				StartsAt:         time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
+			}, nil)
+			mockAttendanceFormHandler.EXPECT().GetSubmissions("form-id").Return([]attendance.FormSubmission{
+				{
+					UserExternalName: "user-external-name-1",
+					SubmissionTime:   time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+				},
+				{
+					UserExternalName: "user-external-name-2",
+					SubmissionTime:   time.Date(2024, 1, 1, 19, 59, 59, 0, time.UTC),
+				},
+			}, nil, nil)
+			mockAttendanceRepo.EXPECT().FindBySessionId("session-id").Return([]attendance.Attendance{}, nil)
+			mockUserRepo.EXPECT().GetAllByExternalNames([]string{"user-external-name-1", "user-external-name-2"}).
+				Return([]user.User{
+					{
+						Id:           "user-id-1",
+						ExternalName: "user-external-name-1",
+					},
+				}, nil)
+			mockAttendanceRepo.EXPECT().BulkInsert([]attendance.AddAttendanceReq{
+				{
+					SessionId:        "session-id",
+					SessionStartedAt: time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC),
+					UserId:           "user-id-1",
+					UserExternalName: "user-external-name-1",
+					UserJoinedAt:     time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+					CreatedBy:        "caller-id",
+					Status:           attendance.StatusOnTime,
+				},
+			}).Return([]string{"attendance-id-1"}, nil)
+			mockUnmatchedSubmissionRepo.EXPECT().ReplaceBySessionId("session-id", []unmatched.Submission{
+				{
+					Answer:           "user-external-name-2",
+					UserExternalName: "user-external-name-2",
+					Reason:           unmatched.ReasonUserNotFound,
+					SubmissionTime:   time.Date(2024, 1, 1, 19, 59, 59, 0, time.UTC),
+				},
+			}).Return(nil)
+			mockOpenSessionRepo.EXPECT().MarkAttendanceIsIgnored("session-id", "some users (user-external-name-2) were not found although there are submissions").
+				Return(nil)
+			err := server.ApplyAttendanceByFormSubmissions("session-id", "caller-id")
+
+			// Assert.
+			assert.NoError(t, err)
+		})
 	})
 }
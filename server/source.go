@@ -0,0 +1,99 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"rush/attendance"
+	"rush/golang/array"
+	"rush/session"
+	"strings"
+)
+
+// Returns the attendance source of the provider. It returns false if the submissions of the provider
+// can't be fetched again. E.g., the CSV file is applied when it's imported.
+func (s *Server) getAttendanceSource(provider session.AttendanceSourceProvider) (attendanceSource, bool) {
+	switch provider {
+	case session.AttendanceSourceProviderGoogleForm:
+		return s.attendanceFormHandler, true
+	case session.AttendanceSourceProviderCheckIn:
+		return &checkInSource{attendanceRepo: s.attendanceRepo}, true
+	default:
+		return nil, false
+	}
+}
+
+// The source of the built-in check-in. The check-ins are kept as the attendances of the session
+// so that the external ID is the session ID.
+type checkInSource struct {
+	attendanceRepo attendanceRepo
+}
+
+// Returns the check-ins of the session as the submissions.
+// The check-ins are always valid since they are made by the users themselves.
+func (c *checkInSource) GetSubmissions(sessionId string) ([]attendance.FormSubmission, []attendance.InvalidSubmission, error) {
+	attendances, err := c.attendanceRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the check-ins: %w", err)
+	}
+	return array.Map(attendances, func(checkIn attendance.Attendance) attendance.FormSubmission {
+		return attendance.FormSubmission{
+			UserExternalName: checkIn.UserExternalName,
+			SubmissionTime:   checkIn.UserJoinedAt,
+		}
+	}), nil, nil
+}
+
+// Imports the attendance CSV file of the session and applies the attendance by its rows right away.
+// The CSV file is set as the attendance source of the session. See attendance.ParseCsvSubmissions for the format.
+// Fails if the session is already closed or has another attendance source.
+// It can be imported again if the previous import was ignored. E.g., some users in the file were not found.
+// If the rows fail to be applied, the previous attendance source of the session is restored.
+func (s *Server) ImportAttendanceCsv(sessionId string, reader io.Reader, fileName string, calledBy string) error {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() {
+		return newBadRequestError(errors.New("session is already closed"))
+	}
+	if dbSession.AttendanceSource.IsSet() && dbSession.AttendanceSource.Provider != session.AttendanceSourceProviderCsv {
+		return newBadRequestError(fmt.Errorf("session already has another attendance source: %s", dbSession.AttendanceSource.Provider))
+	}
+	if strings.TrimSpace(fileName) == "" {
+		return newBadRequestError(errors.New("file name is required"))
+	}
+
+	submissions, err := attendance.ParseCsvSubmissions(reader)
+	if err != nil {
+		return newBadRequestError(fmt.Errorf("failed to parse the CSV file: %w", err))
+	}
+	if len(submissions) == 0 {
+		return newBadRequestError(errors.New("the CSV file has no rows"))
+	}
+
+	updatedSession, err := s.openSessionRepo.UpdateOpenSession(sessionId, session.OpenSessionUpdateForm{
+		AttendanceSource: &session.AttendanceSource{
+			Provider:   session.AttendanceSourceProviderCsv,
+			ExternalId: fileName,
+		},
+		ReturnUpdatedSession: true,
+	})
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to set the CSV file as the attendance source: %w", err))
+	}
+
+	if err := s.applySubmissions(updatedSession, submissions, nil, calledBy); err != nil {
+		// The CSV file can't be fetched again, so the session would be left with the source that can never be applied.
+		if _, restoreErr := s.openSessionRepo.UpdateOpenSession(sessionId, session.OpenSessionUpdateForm{
+			AttendanceSource: &dbSession.AttendanceSource,
+		}); restoreErr != nil {
+			return newInternalServerError(fmt.Errorf("%w and failed to restore the attendance source: %v", err, restoreErr))
+		}
+		return err
+	}
+	return nil
+}
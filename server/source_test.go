@@ -0,0 +1,137 @@
+package server
+
+import (
+	"rush/attendance"
+	"rush/golang/googletest"
+	"rush/session"
+	"rush/unmatched"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fails to insert any attendance as if the database is down.
+type failingAttendanceRepo struct {
+	attendanceRepo
+}
+
+func (r *failingAttendanceRepo) BulkInsert(requests []attendance.AddAttendanceReq) ([]string, error) {
+	return nil, assert.AnError
+}
+
+func TestImportAttendanceCsv(t *testing.T) {
+	t.Run("Imports the CSV file and applies the attendance by its rows", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+
+		err := server.ImportAttendanceCsv(sessionId, strings.NewReader("external_name,joined_at\n김건,2025-07-01T20:03:00Z\n"), "attendance.csv", "admin-id")
+
+		assert.NoError(t, err)
+		closed, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, &AttendanceSource{Provider: session.AttendanceSourceProviderCsv, ExternalId: "attendance.csv"}, closed.AttendanceSource)
+		assert.Equal(t, SessionAttendanceAppliedByCsv, closed.AttendanceAppliedBy)
+		assert.Empty(t, closed.GoogleFormId)
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, userId, attendances[0].UserId)
+		assert.Equal(t, checkInSessionStartsAt.Add(3*time.Minute), attendances[0].UserJoinedAt)
+	})
+
+	t.Run("Fails to import the invalid CSV file without setting the source", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+
+		err := server.ImportAttendanceCsv(sessionId, strings.NewReader("name\n김건\n"), "attendance.csv", "admin-id")
+
+		assert.True(t, isBadRequestError(err))
+		open, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Nil(t, open.AttendanceSource)
+	})
+
+	t.Run("Restores the attendance source when failed to apply the rows", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+		server.attendanceRepo = &failingAttendanceRepo{attendanceRepo: server.attendanceRepo}
+
+		err := server.ImportAttendanceCsv(sessionId, strings.NewReader("external_name,joined_at\n김건,2025-07-01T20:03:00Z\n"), "attendance.csv", "admin-id")
+
+		assert.ErrorIs(t, err, assert.AnError)
+		open, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, session.AttendanceStatusNotAppliedYet, open.AttendanceStatus)
+		// The job doesn't try to close the session by the CSV file that can't be fetched.
+		assert.Nil(t, open.AttendanceSource)
+	})
+
+	t.Run("Fails to import to the session with another attendance source", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+		_, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+
+		err = server.ImportAttendanceCsv(sessionId, strings.NewReader("external_name,joined_at\n김건,2025-07-01T20:03:00Z\n"), "attendance.csv", "admin-id")
+
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Can't fetch the submissions of the imported CSV file again", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+		// The users in the file are not found so that the session stays open.
+		err := server.ImportAttendanceCsv(sessionId, strings.NewReader("external_name,joined_at\n양현우,2025-07-01T20:03:00Z\n"), "attendance.csv", "admin-id")
+		assert.NoError(t, err)
+
+		err = server.ApplyAttendanceByFormSubmissions(sessionId, "session-attendance-syncer")
+
+		assert.True(t, isBadRequestError(err))
+	})
+}
+
+func TestAdminListUnmatchedSubmissions(t *testing.T) {
+	t.Run("Keeps the invalid and the not-found submissions of the form and applies the matched ones", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		fakeServer := googletest.NewFakeServer(t)
+		server.attendanceFormHandler = attendance.NewFormHandler(fakeServer.FormsService(t), fakeServer.DriveService(t))
+		_, err := server.CreateAttendanceForm(sessionId)
+		assert.NoError(t, err)
+		open, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		formId := open.AttendanceSource.ExternalId
+		fakeServer.SetResponsePageSize(1)
+		fakeServer.AddResponse(formId, "9 - 김건", checkInSessionStartsAt.Add(-3*time.Minute))
+		fakeServer.AddResponse(formId, "9:김건", checkInSessionStartsAt.Add(-2*time.Minute))
+		fakeServer.AddResponse(formId, "9 - 양현우", checkInSessionStartsAt.Add(-time.Minute))
+		// Late submissions are not reported.
+		fakeServer.AddResponse(formId, "9;김건", checkInSessionStartsAt.Add(time.Minute))
+
+		err = server.ApplyAttendanceByFormSubmissions(sessionId, "session-attendance-syncer")
+
+		assert.NoError(t, err)
+		ignored, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, session.AttendanceStatusIgnored, ignored.AttendanceStatus)
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, userId, attendances[0].UserId)
+		submissions, err := server.AdminListUnmatchedSubmissions(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 2)
+		assert.Equal(t, "9:김건", submissions[0].Answer)
+		assert.Equal(t, unmatched.ReasonInvalidAnswer, submissions[0].Reason)
+		assert.Contains(t, submissions[0].Detail, "invalid option format")
+		assert.Equal(t, "양현우", submissions[1].UserExternalName)
+		assert.Equal(t, unmatched.ReasonUserNotFound, submissions[1].Reason)
+		assert.True(t, checkInSessionStartsAt.Add(-time.Minute).Equal(submissions[1].SubmissionTime))
+	})
+
+	t.Run("Fails to list the unmatched submissions of an unknown session", func(t *testing.T) {
+		server, _, _, _ := newCheckInServer(t)
+
+		_, err := server.AdminListUnmatchedSubmissions(primitive.NewObjectID().Hex())
+
+		var notFoundError *NotFoundError
+		assert.ErrorAs(t, err, &notFoundError)
+	})
+}
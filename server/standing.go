@@ -0,0 +1,104 @@
+package server
+
+import (
+	"errors"
+	"rush/golang/array"
+	"rush/scoring"
+)
+
+// The standings of the members in a term that are evaluated by its scoring rules.
+type TermStandings struct {
+	Term Term `json:"term"`
+	// The rules that the standings are evaluated by. They are the default rules if the term doesn't have any.
+	Rules scoring.Rules `json:"rules"`
+	// The members of the term in the order of the ranks.
+	Standings []MemberStanding `json:"standings"`
+}
+
+type MemberStanding struct {
+	UserId     string  `json:"user_id"`
+	Name       string  `json:"name"`
+	Generation float64 `json:"generation"`
+	// The score after applying the rules. E.g., 24
+	Total int `json:"total"`
+	// 1 is the highest. The members of the same total share the rank. E.g., 2
+	Rank int `json:"rank"`
+	// Whether the total reaches the minimum score of the term.
+	Passed bool `json:"passed"`
+	// How each rule changed the total. It explains the total to the member.
+	Evaluations []scoring.Evaluation `json:"evaluations"`
+}
+
+// Evaluates the attendances and the excused absences of the term by its scoring rules.
+// If the term ID is empty, the term of the current time is used.
+// The weeks of the weekly cap are split in the local time.
+func (s *Server) GetTermStandings(termId string) (TermStandings, error) {
+	dbTerm, err := s.getTermOrCurrent(termId)
+	if err != nil {
+		return TermStandings{}, err
+	}
+	if dbTerm == nil {
+		return TermStandings{}, newNotFoundError(errors.New("there is no term for the current time"))
+	}
+
+	halfYearAttendance, err := s.GetHalfYearAttendance(dbTerm.Id)
+	if err != nil {
+		return TermStandings{}, err
+	}
+
+	records := []scoring.Record{}
+	for _, attendance := range halfYearAttendance.Attendances {
+		records = append(records, scoring.Record{
+			UserId:           attendance.UserId,
+			SessionId:        attendance.SessionId,
+			SessionScore:     attendance.SessionScore,
+			SessionStartedAt: attendance.SessionStartedAt,
+			UserJoinedAt:     attendance.UserJoinedAt,
+			Status:           attendance.Status,
+		})
+	}
+	idSessionMap := map[string]sessionForAttendance{}
+	for _, halfYearSession := range halfYearAttendance.Sessions {
+		idSessionMap[halfYearSession.Id] = halfYearSession
+	}
+	for _, excused := range halfYearAttendance.Excuses {
+		if !excused.CountsTowardScore {
+			continue
+		}
+		records = append(records, scoring.Record{
+			UserId:           excused.UserId,
+			SessionId:        excused.SessionId,
+			SessionScore:     excused.SessionScore,
+			SessionStartedAt: idSessionMap[excused.SessionId].StartedAt,
+			IsExcused:        true,
+		})
+	}
+
+	rules := scoring.Rules{BonusSessions: []scoring.BonusSession{}}
+	if dbTerm.ScoringRules != nil {
+		rules = *dbTerm.ScoringRules
+	}
+	idUserMap := map[string]userForAttendance{}
+	for _, user := range halfYearAttendance.Users {
+		idUserMap[user.Id] = user
+	}
+	standings := scoring.Evaluate(rules, array.Map(halfYearAttendance.Users, func(user userForAttendance) string { return user.Id }),
+		records, s.formTimeLocation)
+
+	return TermStandings{
+		Term:  *halfYearAttendance.Term,
+		Rules: rules,
+		Standings: array.Map(standings, func(standing scoring.Standing) MemberStanding {
+			user := idUserMap[standing.UserId]
+			return MemberStanding{
+				UserId:      standing.UserId,
+				Name:        user.Name,
+				Generation:  user.Generation,
+				Total:       standing.Total,
+				Rank:        standing.Rank,
+				Passed:      standing.Passed,
+				Evaluations: standing.Evaluations,
+			}
+		}),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"errors"
+	"rush/scoring"
+	"rush/term"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTermStandings(t *testing.T) {
+	t.Run("Evaluates the attendances of the term by its scoring rules", func(t *testing.T) {
+		server, sessionId, userId, mockClock := newCheckInServer(t)
+		server.termRepo = term.NewMemoryRepo()
+		termId, err := server.AddTerm("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+		updated, err := server.SetTermScoringRules(termId, scoring.Rules{MinimumScore: 2, LateGraceMinutes: 10, LatePenalty: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, updated.ScoringRules.MinimumScore)
+		// The check-in is enabled at the start time and the member checks in 15 minutes later.
+		_, err = server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+		mockClock.Add(15 * time.Minute)
+		code, err := server.GetCheckInCode(sessionId)
+		assert.NoError(t, err)
+		assert.NoError(t, server.CheckIn(sessionId, userId, code.Code))
+		assert.NoError(t, server.MarkUsersAsPresent(sessionId, []string{}, false, "admin-id"))
+
+		standings, err := server.GetTermStandings("")
+
+		assert.NoError(t, err)
+		assert.Equal(t, termId, standings.Term.Id)
+		assert.Equal(t, []MemberStanding{{
+			UserId:     userId,
+			Name:       "김건",
+			Generation: 9,
+			Total:      1,
+			Rank:       1,
+			Passed:     false,
+			Evaluations: []scoring.Evaluation{
+				{Rule: scoring.RuleSession, SessionId: sessionId, Points: 2, Detail: "attended"},
+				{Rule: scoring.RuleLatePenalty, SessionId: sessionId, Points: -1, Detail: "joined 15 minutes late"},
+				{Rule: scoring.RuleMinimumScore, Detail: "1 of 2 points required"},
+			},
+		}}, standings.Standings)
+	})
+
+	t.Run("Fails to set the invalid scoring rules", func(t *testing.T) {
+		server, _, _, _ := newCheckInServer(t)
+		server.termRepo = term.NewMemoryRepo()
+		termId, err := server.AddTerm("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+
+		_, err = server.SetTermScoringRules(termId, scoring.Rules{WeeklyCap: -1})
+
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Fails without the term of the current time", func(t *testing.T) {
+		server, _, _, _ := newCheckInServer(t)
+		server.termRepo = term.NewMemoryRepo()
+
+		_, err := server.GetTermStandings("")
+
+		assert.Equal(t, newNotFoundError(errors.New("there is no term for the current time")), err)
+	})
+}
@@ -0,0 +1,301 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/golang/array"
+	"rush/permission"
+	"rush/scoring"
+	"rush/session"
+	"rush/term"
+	"rush/user"
+	"time"
+)
+
+// Returns all the terms. The latest term comes first.
+func (s *Server) AdminListTerms() ([]Term, error) {
+	terms, err := s.termRepo.GetAll()
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get terms: %w", err))
+	}
+	return array.Map(terms, fromTerm), nil
+}
+
+// Returns the term by the given ID.
+func (s *Server) AdminGetTerm(id string) (Term, error) {
+	dbTerm, err := s.termRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, term.ErrNotFound) {
+			return Term{}, newNotFoundError(fmt.Errorf("failed to get term: %w", err))
+		}
+		return Term{}, newInternalServerError(fmt.Errorf("failed to get term: %w", err))
+	}
+	return fromTerm(dbTerm), nil
+}
+
+// Adds a new term.
+func (s *Server) AddTerm(name string, startsAt time.Time, endsAt time.Time, generations []float64) (string, error) {
+	if name == "" {
+		return "", newBadRequestError(errors.New("name is required"))
+	}
+	if !startsAt.Before(endsAt) {
+		return "", newBadRequestError(fmt.Errorf("term should start (%s) before it ends (%s)", startsAt, endsAt))
+	}
+
+	if err := s.checkTermOverlap("", startsAt, endsAt); err != nil {
+		return "", err
+	}
+
+	id, err := s.termRepo.Add(name, startsAt, endsAt, generations)
+	if err != nil {
+		return "", newInternalServerError(fmt.Errorf("failed to add term: %w", err))
+	}
+	return id, nil
+}
+
+// Returns a conflict error if the period overlaps with any other term than the given one.
+// The terms should not overlap so that the current term is never ambiguous.
+func (s *Server) checkTermOverlap(id string, startsAt time.Time, endsAt time.Time) error {
+	terms, err := s.termRepo.GetAll()
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get terms: %w", err))
+	}
+	for _, other := range terms {
+		if other.Id != id && other.StartsAt.Before(endsAt) && startsAt.Before(other.EndsAt) {
+			return newConflictError(fmt.Errorf("term overlaps with the term %s (%s - %s)", other.Name, other.StartsAt, other.EndsAt))
+		}
+	}
+	return nil
+}
+
+// Updates the term. Nil arguments are not updated.
+func (s *Server) UpdateTerm(id string, name *string, startsAt *time.Time, endsAt *time.Time, generations *[]float64) error {
+	if name != nil && *name == "" {
+		return newBadRequestError(errors.New("name is required"))
+	}
+
+	dbTerm, err := s.termRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, term.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get term: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get term: %w", err))
+	}
+	newStartsAt := dbTerm.StartsAt
+	if startsAt != nil {
+		newStartsAt = *startsAt
+	}
+	newEndsAt := dbTerm.EndsAt
+	if endsAt != nil {
+		newEndsAt = *endsAt
+	}
+	if !newStartsAt.Before(newEndsAt) {
+		return newBadRequestError(fmt.Errorf("term should start (%s) before it ends (%s)", newStartsAt, newEndsAt))
+	}
+	if startsAt != nil || endsAt != nil {
+		if err := s.checkTermOverlap(id, newStartsAt, newEndsAt); err != nil {
+			return err
+		}
+	}
+
+	if err := s.termRepo.Update(id, term.UpdateForm{
+		Name:        name,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		Generations: generations,
+	}); err != nil {
+		return newInternalServerError(fmt.Errorf("failed to update term: %w", err))
+	}
+	return nil
+}
+
+// Replaces the scoring rules of the term. The standings of the term are evaluated by them.
+func (s *Server) SetTermScoringRules(id string, rules scoring.Rules) (Term, error) {
+	if err := rules.Validate(); err != nil {
+		return Term{}, newBadRequestError(fmt.Errorf("invalid scoring rules: %w", err))
+	}
+	if _, err := s.termRepo.Get(id); err != nil {
+		if errors.Is(err, term.ErrNotFound) {
+			return Term{}, newNotFoundError(fmt.Errorf("failed to get term: %w", err))
+		}
+		return Term{}, newInternalServerError(fmt.Errorf("failed to get term: %w", err))
+	}
+
+	if err := s.termRepo.Update(id, term.UpdateForm{ScoringRules: &rules}); err != nil {
+		return Term{}, newInternalServerError(fmt.Errorf("failed to update the scoring rules: %w", err))
+	}
+	updated, err := s.termRepo.Get(id)
+	if err != nil {
+		return Term{}, newInternalServerError(fmt.Errorf("failed to get the updated term: %w", err))
+	}
+	return fromTerm(updated), nil
+}
+
+// Deletes the term. Sessions and attendances of the term are not deleted.
+func (s *Server) DeleteTerm(id string) error {
+	if err := s.termRepo.Delete(id); err != nil {
+		if errors.Is(err, term.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to delete term: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to delete term: %w", err))
+	}
+	return nil
+}
+
+// Returns the sessions of the term sorted by the start time.
+func (s *Server) AdminGetTermSessions(id string) ([]SessionForAdmin, error) {
+	dbTerm, err := s.termRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, term.ErrNotFound) {
+			return nil, newNotFoundError(fmt.Errorf("failed to get term: %w", err))
+		}
+		return nil, newInternalServerError(fmt.Errorf("failed to get term: %w", err))
+	}
+
+	sessions, err := s.sessionRepo.GetAllStartingBetween(dbTerm.StartsAt, dbTerm.EndsAt)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get sessions of the term: %w", err))
+	}
+	return array.Map(sessions, func(session session.Session) SessionForAdmin {
+		return fromSessionToSessionForAdmin(session)
+	}), nil
+}
+
+// The member of the roster for the new term.
+type RosterMember struct {
+	Name         string  `json:"name"`
+	ExternalName string  `json:"external_name"`
+	Generation   float64 `json:"generation"`
+	Email        string  `json:"email"`
+}
+
+type ReturningUser struct {
+	// The user before the rollover.
+	Before User `json:"before"`
+	// The user after the rollover.
+	After User `json:"after"`
+}
+
+// The report of the term rollover. It's the diff to review when it's a dry run.
+type TermRolloverReport struct {
+	// Whether nothing has been written.
+	DryRun bool `json:"dry_run"`
+	// The term that is archived by the rollover. Nil if there was no term for the current time.
+	ArchivedTerm *Term `json:"archived_term"`
+	// The new term. Its ID is empty if it's a dry run.
+	NewTerm Term `json:"new_term"`
+	// Active users who are not in the roster. They are deactivated.
+	Deactivated []User `json:"deactivated"`
+	// Active admins and super admins who are not in the roster. They stay active.
+	Kept []User `json:"kept"`
+	// Users who are in the roster and already exist. They are matched by the email.
+	Returning []ReturningUser `json:"returning"`
+	// Users who are in the roster but don't exist yet. They are added as members.
+	Added []User `json:"added"`
+}
+
+// Rolls over to the new term without deleting anything.
+// It archives the current term, deactivates the members who are not in the roster,
+// updates the returning users and adds the new ones. The admins who are not in the roster stay active.
+// If dryRun is true, it only returns the report of what would be changed.
+// The users are not written atomically. If it fails partway, it can be run again with the same roster.
+func (s *Server) RolloverTerm(name string, startsAt time.Time, endsAt time.Time, generations []float64, roster []RosterMember, dryRun bool) (TermRolloverReport, error) {
+	if name == "" {
+		return TermRolloverReport{}, newBadRequestError(errors.New("name is required"))
+	}
+	if !startsAt.Before(endsAt) {
+		return TermRolloverReport{}, newBadRequestError(fmt.Errorf("term should start (%s) before it ends (%s)", startsAt, endsAt))
+	}
+	if len(roster) == 0 {
+		return TermRolloverReport{}, newBadRequestError(errors.New("roster is empty"))
+	}
+
+	plan, err := s.userRoller.Plan(array.Map(roster, func(member RosterMember) user.User {
+		externalName := member.ExternalName
+		if externalName == "" {
+			externalName = member.Name
+		}
+		return user.User{
+			Name:         member.Name,
+			Role:         permission.RoleMember,
+			Generation:   member.Generation,
+			IsActive:     true,
+			Email:        member.Email,
+			ExternalName: externalName,
+		}
+	}))
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidRoster) {
+			return TermRolloverReport{}, newBadRequestError(fmt.Errorf("failed to plan the rollover: %w", err))
+		}
+		return TermRolloverReport{}, newInternalServerError(fmt.Errorf("failed to plan the rollover: %w", err))
+	}
+
+	currentTerm, err := s.getTermOrCurrent("")
+	if err != nil {
+		return TermRolloverReport{}, err
+	}
+	var archivedTerm *Term
+	if currentTerm != nil {
+		converted := fromTerm(*currentTerm)
+		converted.IsArchived = true
+		// The terms should not overlap.
+		if converted.EndsAt.After(startsAt) {
+			if !converted.StartsAt.Before(startsAt) {
+				return TermRolloverReport{}, newBadRequestError(fmt.Errorf("new term should start (%s) after the current term %s starts (%s)",
+					startsAt, converted.Name, converted.StartsAt))
+			}
+			converted.EndsAt = startsAt
+		}
+		archivedTerm = &converted
+	}
+	// The current term is cut to end when the new term starts, so only the other terms may overlap.
+	currentTermId := ""
+	if currentTerm != nil {
+		currentTermId = currentTerm.Id
+	}
+	if err := s.checkTermOverlap(currentTermId, startsAt, endsAt); err != nil {
+		return TermRolloverReport{}, err
+	}
+
+	report := TermRolloverReport{
+		DryRun:       dryRun,
+		ArchivedTerm: archivedTerm,
+		NewTerm: Term{
+			Name:        name,
+			StartsAt:    startsAt,
+			EndsAt:      endsAt,
+			Generations: generations,
+		},
+		Deactivated: array.Map(plan.Deactivated, func(deactivated user.User) User { return *fromUser(&deactivated) }),
+		Kept:        array.Map(plan.Kept, func(kept user.User) User { return *fromUser(&kept) }),
+		Returning: array.Map(plan.Returning, func(returning user.ReturningUser) ReturningUser {
+			return ReturningUser{Before: *fromUser(&returning.Before), After: *fromUser(&returning.After)}
+		}),
+		Added: array.Map(plan.Added, func(added user.User) User { return *fromUser(&added) }),
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	if err := s.userRoller.Apply(plan); err != nil {
+		return TermRolloverReport{}, newInternalServerError(fmt.Errorf("failed to apply the rollover to the users: %w", err))
+	}
+
+	if archivedTerm != nil {
+		if err := s.termRepo.Update(archivedTerm.Id, term.UpdateForm{
+			EndsAt:     &archivedTerm.EndsAt,
+			IsArchived: &archivedTerm.IsArchived,
+		}); err != nil {
+			return TermRolloverReport{}, newInternalServerError(fmt.Errorf("failed to archive the current term: %w", err))
+		}
+	}
+
+	id, err := s.termRepo.Add(name, startsAt, endsAt, generations)
+	if err != nil {
+		return TermRolloverReport{}, newInternalServerError(fmt.Errorf("failed to add the new term: %w", err))
+	}
+	report.NewTerm.Id = id
+
+	return report, nil
+}
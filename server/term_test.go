@@ -0,0 +1,347 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/permission"
+	"rush/session"
+	"rush/term"
+	"rush/user"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestAdminGetTerm(t *testing.T) {
+	t.Run("Returns not found error when term is not found", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockTermRepo := NewMocktermRepo(controller)
+		server := New(Deps{TermRepo: mockTermRepo})
+
+		mockTermRepo.EXPECT().Get("term-id").Return(term.Term{}, term.ErrNotFound)
+		_, err := server.AdminGetTerm("term-id")
+
+		assert.Equal(t, newNotFoundError(fmt.Errorf("failed to get term: %w", term.ErrNotFound)), err)
+	})
+
+	t.Run("Returns the term", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockTermRepo := NewMocktermRepo(controller)
+		server := New(Deps{TermRepo: mockTermRepo})
+
+		mockTermRepo.EXPECT().Get("term-id").Return(term.Term{
+			Id:          "term-id",
+			Name:        "2025-2",
+			StartsAt:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Generations: []float64{9, 9.5},
+		}, nil)
+		result, err := server.AdminGetTerm("term-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Term{
+			Id:          "term-id",
+			Name:        "2025-2",
+			StartsAt:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Generations: []float64{9, 9.5},
+		}, result)
+	})
+}
+
+func TestAddTerm(t *testing.T) {
+	t.Run("Fails if the name is empty", func(t *testing.T) {
+		server := New(Deps{})
+
+		_, err := server.AddTerm("", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+
+		assert.Equal(t, newBadRequestError(errors.New("name is required")), err)
+	})
+
+	t.Run("Fails if it ends before it starts", func(t *testing.T) {
+		server := New(Deps{})
+
+		_, err := server.AddTerm("2025-2", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), nil)
+
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Returns the ID of the added term", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockTermRepo := NewMocktermRepo(controller)
+		server := New(Deps{TermRepo: mockTermRepo})
+
+		mockTermRepo.EXPECT().GetAll().Return([]term.Term{{
+			Id:       "previous-term-id",
+			Name:     "2025-1",
+			StartsAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		}}, nil)
+		mockTermRepo.EXPECT().Add("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), []float64{9}).Return("term-id", nil)
+		id, err := server.AddTerm("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), []float64{9})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "term-id", id)
+	})
+
+	t.Run("Fails if it overlaps with another term", func(t *testing.T) {
+		server := New(Deps{TermRepo: term.NewMemoryRepo()})
+		_, err := server.AddTerm("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+
+		_, err = server.AddTerm("2025-winter", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), nil)
+
+		var conflictError *ConflictError
+		assert.ErrorAs(t, err, &conflictError)
+	})
+}
+
+func TestUpdateTerm(t *testing.T) {
+	t.Run("Fails if the updated period is invalid", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockTermRepo := NewMocktermRepo(controller)
+		server := New(Deps{TermRepo: mockTermRepo})
+
+		mockTermRepo.EXPECT().Get("term-id").Return(term.Term{
+			Id:       "term-id",
+			StartsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, nil)
+		endsAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		err := server.UpdateTerm("term-id", nil, nil, &endsAt, nil)
+
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Updates the term", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockTermRepo := NewMocktermRepo(controller)
+		server := New(Deps{TermRepo: mockTermRepo})
+
+		name := "2025-2"
+		generations := []float64{10}
+		mockTermRepo.EXPECT().Get("term-id").Return(term.Term{
+			Id:       "term-id",
+			StartsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, nil)
+		mockTermRepo.EXPECT().Update("term-id", term.UpdateForm{Name: &name, Generations: &generations}).Return(nil)
+		err := server.UpdateTerm("term-id", &name, nil, nil, &generations)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fails if the updated period overlaps with another term but not with itself", func(t *testing.T) {
+		server := New(Deps{TermRepo: term.NewMemoryRepo()})
+		firstId, err := server.AddTerm("2025-1", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+		secondId, err := server.AddTerm("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+
+		endsAt := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+		var conflictError *ConflictError
+		assert.ErrorAs(t, server.UpdateTerm(firstId, nil, nil, &endsAt, nil), &conflictError)
+		startsAt := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+		assert.NoError(t, server.UpdateTerm(secondId, nil, &startsAt, nil, nil))
+	})
+}
+
+func TestDeleteTerm(t *testing.T) {
+	t.Run("Returns not found error when the term is not found", func(t *testing.T) {
+		server := New(Deps{TermRepo: term.NewMemoryRepo()})
+
+		err := server.DeleteTerm("unknown")
+
+		assert.Equal(t, newNotFoundError(fmt.Errorf("failed to delete term: %w", term.ErrNotFound)), err)
+	})
+}
+
+func TestAdminGetTermSessions(t *testing.T) {
+	t.Run("Returns the sessions within the term", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockTermRepo := NewMocktermRepo(controller)
+		mockSessionRepo := NewMocksessionRepo(controller)
+		server := New(Deps{SessionRepo: mockSessionRepo, TermRepo: mockTermRepo})
+
+		mockTermRepo.EXPECT().Get("term-id").Return(term.Term{
+			Id:       "term-id",
+			StartsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, nil)
+		mockSessionRepo.EXPECT().GetAllStartingBetween(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).
+			Return([]session.Session{{Id: "session-id", AttendanceStatus: session.AttendanceStatusNotAppliedYet}}, nil)
+		sessions, err := server.AdminGetTermSessions("term-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []SessionForAdmin{{
+			Id:                  "session-id",
+			AttendanceStatus:    session.AttendanceStatusNotAppliedYet,
+			AttendanceAppliedBy: SessionAttendanceAppliedByUnspecified,
+		}}, sessions)
+	})
+}
+
+func isBadRequestError(err error) bool {
+	var badRequestError *BadRequestError
+	return errors.As(err, &badRequestError)
+}
+
+func TestRolloverTerm(t *testing.T) {
+	startsAt := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	roster := []RosterMember{
+		{Name: "김건", Generation: 9, Email: "kim@gmail.com"},
+	}
+	rosterUsers := []user.User{
+		{Name: "김건", Role: permission.RoleMember, Generation: 9, IsActive: true, Email: "kim@gmail.com", ExternalName: "김건"},
+	}
+	plan := user.RolloverPlan{
+		Deactivated: []user.User{{Id: "2", Name: "양현우", IsActive: true}},
+		Kept:        []user.User{},
+		Returning: []user.ReturningUser{{
+			Before: user.User{Id: "1", Name: "김건", Generation: 8.5},
+			After:  user.User{Id: "1", Name: "김건", Generation: 9, IsActive: true},
+		}},
+		Added: []user.User{},
+	}
+
+	t.Run("Fails if the roster is invalid", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockUserRoller := NewMockuserRoller(controller)
+		server := New(Deps{UserRoller: mockUserRoller})
+
+		mockUserRoller.EXPECT().Plan(rosterUsers).Return(user.RolloverPlan{}, user.ErrInvalidRoster)
+		_, err := server.RolloverTerm("2025-2", startsAt, endsAt, nil, roster, true)
+
+		assert.True(t, isBadRequestError(err))
+	})
+
+	t.Run("Returns the report without applying it if it's a dry run", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockUserRoller := NewMockuserRoller(controller)
+		mockTermRepo := NewMocktermRepo(controller)
+		mockClock := clock.NewMock()
+		server := New(Deps{
+			TermRepo:   mockTermRepo,
+			UserRoller: mockUserRoller,
+			Clock:      mockClock,
+		})
+
+		mockClock.Set(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC))
+		mockUserRoller.EXPECT().Plan(rosterUsers).Return(plan, nil)
+		mockTermRepo.EXPECT().GetByTime(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)).Return(term.Term{}, term.ErrNotFound)
+		mockTermRepo.EXPECT().GetAll().Return([]term.Term{}, nil)
+		report, err := server.RolloverTerm("2025-2", startsAt, endsAt, []float64{9}, roster, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, TermRolloverReport{
+			DryRun:      true,
+			NewTerm:     Term{Name: "2025-2", StartsAt: startsAt, EndsAt: endsAt, Generations: []float64{9}},
+			Deactivated: []User{{Id: "2", Name: "양현우", IsActive: true}},
+			Kept:        []User{},
+			Returning: []ReturningUser{{
+				Before: User{Id: "1", Name: "김건", Generation: 8.5},
+				After:  User{Id: "1", Name: "김건", Generation: 9, IsActive: true},
+			}},
+			Added: []User{},
+		}, report)
+	})
+
+	t.Run("Applies the plan, archives the current term and adds the new term", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockUserRoller := NewMockuserRoller(controller)
+		mockTermRepo := NewMocktermRepo(controller)
+		mockClock := clock.NewMock()
+		server := New(Deps{
+			TermRepo:   mockTermRepo,
+			UserRoller: mockUserRoller,
+			Clock:      mockClock,
+		})
+
+		mockClock.Set(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC))
+		mockUserRoller.EXPECT().Plan(rosterUsers).Return(plan, nil)
+		mockTermRepo.EXPECT().GetByTime(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)).Return(term.Term{
+			Id:       "old-term-id",
+			Name:     "2025-1",
+			StartsAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC),
+		}, nil)
+		mockTermRepo.EXPECT().GetAll().Return([]term.Term{{
+			Id:       "old-term-id",
+			Name:     "2025-1",
+			StartsAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC),
+		}}, nil)
+		mockUserRoller.EXPECT().Apply(plan).Return(nil)
+		isArchived := true
+		mockTermRepo.EXPECT().Update("old-term-id", term.UpdateForm{EndsAt: &startsAt, IsArchived: &isArchived}).Return(nil)
+		mockTermRepo.EXPECT().Add("2025-2", startsAt, endsAt, []float64{9}).Return("new-term-id", nil)
+		report, err := server.RolloverTerm("2025-2", startsAt, endsAt, []float64{9}, roster, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Term{
+			Id:       "old-term-id",
+			Name:     "2025-1",
+			StartsAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			// It's cut so that the terms don't overlap.
+			EndsAt:     startsAt,
+			IsArchived: true,
+		}, report.ArchivedTerm)
+		assert.Equal(t, "new-term-id", report.NewTerm.Id)
+		assert.False(t, report.DryRun)
+	})
+
+	t.Run("Fails if the new term doesn't start after the current term starts", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockUserRoller := NewMockuserRoller(controller)
+		mockTermRepo := NewMocktermRepo(controller)
+		mockClock := clock.NewMock()
+		server := New(Deps{
+			TermRepo:   mockTermRepo,
+			UserRoller: mockUserRoller,
+			Clock:      mockClock,
+		})
+
+		mockClock.Set(time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC))
+		mockUserRoller.EXPECT().Plan(rosterUsers).Return(plan, nil)
+		mockTermRepo.EXPECT().GetByTime(time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC)).Return(term.Term{
+			Id:       "current-term-id",
+			Name:     "2025-2",
+			StartsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, nil)
+		// It would make the current term end before it starts.
+		_, err := server.RolloverTerm("2025-2", startsAt.AddDate(0, -1, 0), endsAt, []float64{9}, roster, false)
+
+		assert.True(t, isBadRequestError(err))
+	})
+	t.Run("Fails even on a dry run if the new term overlaps with another term than the current one", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockUserRoller := NewMockuserRoller(controller)
+		mockTermRepo := NewMocktermRepo(controller)
+		mockClock := clock.NewMock()
+		server := New(Deps{
+			TermRepo:   mockTermRepo,
+			UserRoller: mockUserRoller,
+			Clock:      mockClock,
+		})
+
+		mockClock.Set(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC))
+		mockUserRoller.EXPECT().Plan(rosterUsers).Return(plan, nil)
+		mockTermRepo.EXPECT().GetByTime(time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)).Return(term.Term{}, term.ErrNotFound)
+		// The admin has already added the next term.
+		mockTermRepo.EXPECT().GetAll().Return([]term.Term{{
+			Id:       "next-term-id",
+			Name:     "2025-2",
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+		}}, nil)
+		_, err := server.RolloverTerm("2025-2", startsAt, endsAt, []float64{9}, roster, true)
+
+		var conflictError *ConflictError
+		assert.ErrorAs(t, err, &conflictError)
+	})
+}
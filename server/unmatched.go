@@ -0,0 +1,193 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"rush/attendance"
+	"rush/golang/array"
+	"rush/session"
+	"rush/unmatched"
+	"rush/user"
+	"time"
+)
+
+// The decision of the admin on an unmatched submission. Either the user or the discard should be set.
+type ResolveUnmatchedSubmissionReq struct {
+	// The ID of the unmatched submission. E.g., "abc123"
+	SubmissionId string `json:"submission_id"`
+	// The ID of the user that the submission is mapped to. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// Whether to drop the submission. E.g., a test submission or a guest who is not a member.
+	Discard bool `json:"discard"`
+}
+
+// The unmatched submission that the admin has resolved. It's applied with the other submissions.
+type resolvedSubmission struct {
+	resolution     unmatched.Resolution
+	submissionTime time.Time
+}
+
+// Returns the submissions of the session that couldn't be matched to any user when the attendance was applied last time.
+// The resolved ones are included as the audit of the attendance.
+func (s *Server) AdminListUnmatchedSubmissions(sessionId string) ([]UnmatchedSubmission, error) {
+	if _, err := s.sessionRepo.Get(sessionId); err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return nil, newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return nil, newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+
+	submissions, err := s.unmatchedSubmissionRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the unmatched submissions: %w", err))
+	}
+	return array.Map(submissions, fromUnmatchedSubmission), nil
+}
+
+// Resolves all the pending unmatched submissions of the ignored session and applies the attendance again with them.
+// The admin who resolved them is recorded in the resolutions and as the creator of the attendances of the mapped users.
+// The session of the CSV file is applied when the file is imported again since its rows can't be fetched again.
+func (s *Server) ResolveUnmatchedSubmissions(sessionId string, reqs []ResolveUnmatchedSubmissionReq, calledBy string) error {
+	dbSession, err := s.sessionRepo.Get(sessionId)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return newNotFoundError(fmt.Errorf("failed to get session: %w", err))
+		}
+		return newInternalServerError(fmt.Errorf("failed to get session: %w", err))
+	}
+	if !dbSession.CanUpdateMetadata() {
+		return newBadRequestError(errors.New("session is already closed"))
+	}
+	if dbSession.AttendanceStatus != session.AttendanceStatusIgnored {
+		return newBadRequestError(errors.New("session's attendance is not ignored"))
+	}
+	if len(reqs) == 0 {
+		return newBadRequestError(errors.New("resolutions are required"))
+	}
+
+	submissions, err := s.unmatchedSubmissionRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get the unmatched submissions: %w", err))
+	}
+	pendingIdSet := map[string]bool{}
+	for _, submission := range submissions {
+		if !submission.Resolution.IsResolved() {
+			pendingIdSet[submission.Id] = true
+		}
+	}
+
+	now := s.clock.Now()
+	resolutions := map[string]unmatched.Resolution{}
+	for _, req := range reqs {
+		if !pendingIdSet[req.SubmissionId] {
+			return newBadRequestError(fmt.Errorf("unmatched submission %s is not found or already resolved", req.SubmissionId))
+		}
+		if _, ok := resolutions[req.SubmissionId]; ok {
+			return newBadRequestError(fmt.Errorf("unmatched submission %s is resolved more than once", req.SubmissionId))
+		}
+		if req.Discard == (req.UserId != "") {
+			return newBadRequestError(fmt.Errorf("either user_id or discard should be set for unmatched submission %s", req.SubmissionId))
+		}
+
+		resolution := unmatched.Resolution{Action: unmatched.ResolutionActionDiscarded, ResolvedBy: calledBy, ResolvedAt: now}
+		if !req.Discard {
+			if _, err := s.userRepo.Get(req.UserId); err != nil {
+				if errors.Is(err, user.ErrNotFound) {
+					return newBadRequestError(fmt.Errorf("user %s is not found", req.UserId))
+				}
+				return newInternalServerError(fmt.Errorf("failed to get user: %w", err))
+			}
+			resolution.Action = unmatched.ResolutionActionMapped
+			resolution.UserId = req.UserId
+		}
+		resolutions[req.SubmissionId] = resolution
+	}
+	if len(resolutions) < len(pendingIdSet) {
+		return newBadRequestError(fmt.Errorf("all the unmatched submissions should be resolved: %d left", len(pendingIdSet)-len(resolutions)))
+	}
+
+	for _, req := range reqs {
+		if err := s.unmatchedSubmissionRepo.Resolve(req.SubmissionId, resolutions[req.SubmissionId]); err != nil {
+			if errors.Is(err, unmatched.ErrNotFound) {
+				return newConflictError(fmt.Errorf("unmatched submission %s has been resolved by someone else: %w", req.SubmissionId, err))
+			}
+			return newInternalServerError(fmt.Errorf("failed to resolve unmatched submission %s: %w", req.SubmissionId, err))
+		}
+	}
+
+	source, ok := s.getAttendanceSource(dbSession.AttendanceSource.Provider)
+	if !ok {
+		return nil
+	}
+	fetchedSubmissions, invalidSubmissions, err := source.GetSubmissions(dbSession.AttendanceSource.ExternalId)
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get submissions from the attendance source: %w", err))
+	}
+	return s.applySubmissions(dbSession, fetchedSubmissions, invalidSubmissions, calledBy)
+}
+
+// Returns the resolutions of the unmatched submissions of the session by their keys. See unmatchedSubmissionKey.
+func (s *Server) getUnmatchedSubmissionResolutions(sessionId string) (map[string]unmatched.Resolution, error) {
+	submissions, err := s.unmatchedSubmissionRepo.FindBySessionId(sessionId)
+	if err != nil {
+		return nil, newInternalServerError(fmt.Errorf("failed to get the unmatched submissions: %w", err))
+	}
+
+	resolutions := map[string]unmatched.Resolution{}
+	for _, submission := range submissions {
+		if submission.Resolution.IsResolved() {
+			resolutions[unmatchedSubmissionKey(submission.Answer, submission.SubmissionTime)] = submission.Resolution
+		}
+	}
+	return resolutions, nil
+}
+
+// Returns the attendance requests of the users that the resolved submissions are mapped to.
+// The users who already have the attendance of the session or are in the requests are skipped.
+func (s *Server) newResolvedAttendanceReqs(dbSession session.Session, resolvedSubmissions []resolvedSubmission,
+	attendances []attendance.Attendance, reqs []attendance.AddAttendanceReq) ([]attendance.AddAttendanceReq, error) {
+	userIdSet := map[string]bool{}
+	for _, attendance := range attendances {
+		userIdSet[attendance.UserId] = true
+	}
+	for _, req := range reqs {
+		userIdSet[req.UserId] = true
+	}
+
+	resolvedReqs := []attendance.AddAttendanceReq{}
+	for _, submission := range resolvedSubmissions {
+		if submission.resolution.Action != unmatched.ResolutionActionMapped || userIdSet[submission.resolution.UserId] {
+			continue
+		}
+		dbUser, err := s.userRepo.Get(submission.resolution.UserId)
+		if err != nil {
+			return nil, newInternalServerError(fmt.Errorf("failed to get the user of the resolved submission: %w", err))
+		}
+
+		// The invalid answer may not have the time.
+		joinedAt := submission.submissionTime
+		if joinedAt.IsZero() {
+			joinedAt = dbSession.StartsAt
+		}
+		userIdSet[dbUser.Id] = true
+		resolvedReqs = append(resolvedReqs, attendance.AddAttendanceReq{
+			SessionId:        dbSession.Id,
+			SessionName:      dbSession.Name,
+			SessionScore:     dbSession.Score,
+			SessionStartedAt: dbSession.StartsAt,
+			UserId:           dbUser.Id,
+			UserExternalName: dbUser.ExternalName,
+			UserGeneration:   dbUser.Generation,
+			UserJoinedAt:     joinedAt,
+			CreatedBy:        submission.resolution.ResolvedBy,
+			Status:           attendance.StatusByJoinedAt(dbSession.StartsAt, joinedAt),
+		})
+	}
+	return resolvedReqs, nil
+}
+
+// Returns the key to find the resolution of the submission when it's fetched again.
+// The time is in milliseconds since the storage may drop the smaller units.
+func unmatchedSubmissionKey(answer string, submissionTime time.Time) string {
+	return fmt.Sprintf("%s@%d", answer, submissionTime.UnixMilli())
+}
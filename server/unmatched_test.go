@@ -0,0 +1,144 @@
+package server
+
+import (
+	"errors"
+	"rush/attendance"
+	"rush/golang/googletest"
+	"rush/session"
+	"rush/unmatched"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the server whose session is ignored by an invalid answer and a user not found in its form.
+// The invalid answer is submitted by the user that newCheckInServer adds.
+func newIgnoredSessionServer(t *testing.T) (*Server, string, string) {
+	server, sessionId, userId, _ := newCheckInServer(t)
+	fakeServer := googletest.NewFakeServer(t)
+	server.attendanceFormHandler = attendance.NewFormHandler(fakeServer.FormsService(t), fakeServer.DriveService(t))
+	_, err := server.CreateAttendanceForm(sessionId)
+	assert.NoError(t, err)
+	open, err := server.AdminGetSession(sessionId)
+	assert.NoError(t, err)
+	fakeServer.AddResponse(open.AttendanceSource.ExternalId, "9:김건", checkInSessionStartsAt.Add(-2*time.Minute))
+	fakeServer.AddResponse(open.AttendanceSource.ExternalId, "9 - 양현우", checkInSessionStartsAt.Add(-time.Minute))
+
+	assert.NoError(t, server.ApplyAttendanceByFormSubmissions(sessionId, "session-attendance-syncer"))
+	return server, sessionId, userId
+}
+
+func TestResolveUnmatchedSubmissions(t *testing.T) {
+	t.Run("Maps and discards the unmatched submissions and applies the attendance with them", func(t *testing.T) {
+		server, sessionId, userId := newIgnoredSessionServer(t)
+		submissions, err := server.AdminListUnmatchedSubmissions(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 2)
+
+		err = server.ResolveUnmatchedSubmissions(sessionId, []ResolveUnmatchedSubmissionReq{
+			{SubmissionId: submissions[0].Id, UserId: userId},
+			{SubmissionId: submissions[1].Id, Discard: true},
+		}, "admin-id")
+
+		assert.NoError(t, err)
+		closed, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, session.AttendanceStatusApplied, closed.AttendanceStatus)
+		attendances, err := server.attendanceRepo.FindBySessionId(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, userId, attendances[0].UserId)
+		assert.Equal(t, "admin-id", attendances[0].CreatedBy)
+		assert.Equal(t, checkInSessionStartsAt.Add(-2*time.Minute), attendances[0].UserJoinedAt)
+		// The resolutions are kept as the audit.
+		resolved, err := server.AdminListUnmatchedSubmissions(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, resolved, 2)
+		assert.Equal(t, &UnmatchedSubmissionResolution{
+			Action:     unmatched.ResolutionActionMapped,
+			UserId:     userId,
+			ResolvedBy: "admin-id",
+			ResolvedAt: checkInSessionStartsAt,
+		}, resolved[0].Resolution)
+		assert.Equal(t, unmatched.ResolutionActionDiscarded, resolved[1].Resolution.Action)
+	})
+
+	t.Run("Applies the resolutions of the CSV file when it's imported again", func(t *testing.T) {
+		server, sessionId, userId, _ := newCheckInServer(t)
+		csv := "external_name,joined_at\n김건,2025-07-01T20:03:00Z\n김건2,2025-07-01T20:04:00Z\n"
+		assert.NoError(t, server.ImportAttendanceCsv(sessionId, strings.NewReader(csv), "attendance.csv", "admin-id"))
+		submissions, err := server.AdminListUnmatchedSubmissions(sessionId)
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 1)
+
+		assert.NoError(t, server.ResolveUnmatchedSubmissions(sessionId, []ResolveUnmatchedSubmissionReq{
+			{SubmissionId: submissions[0].Id, UserId: userId},
+		}, "admin-id"))
+		ignored, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, session.AttendanceStatusIgnored, ignored.AttendanceStatus)
+
+		assert.NoError(t, server.ImportAttendanceCsv(sessionId, strings.NewReader(csv), "attendance.csv", "admin-id"))
+		attendances, err := server.GetAttendanceBySessionId(sessionId)
+		assert.NoError(t, err)
+		// Both rows are mapped to the same user.
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, userId, attendances[0].UserId)
+	})
+
+	t.Run("Keeps the session from being changed or deleted once the matched submissions are applied", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+		csv := "external_name,joined_at\n김건,2025-07-01T20:03:00Z\n김건2,2025-07-01T20:04:00Z\n"
+		assert.NoError(t, server.ImportAttendanceCsv(sessionId, strings.NewReader(csv), "attendance.csv", "admin-id"))
+
+		score := 3
+		_, err := server.UpdateSession(sessionId, nil, nil, nil, &score)
+		assert.Equal(t, newBadRequestError(errors.New("session already has attendances")), err)
+		assert.Equal(t, newBadRequestError(errors.New("session already has attendances")), server.DeleteSession(sessionId))
+		ignored, err := server.AdminGetSession(sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, ignored.Score)
+	})
+
+	t.Run("Fails unless all the unmatched submissions are resolved", func(t *testing.T) {
+		server, sessionId, userId := newIgnoredSessionServer(t)
+		submissions, err := server.AdminListUnmatchedSubmissions(sessionId)
+		assert.NoError(t, err)
+
+		err = server.ResolveUnmatchedSubmissions(sessionId, []ResolveUnmatchedSubmissionReq{
+			{SubmissionId: submissions[0].Id, UserId: userId},
+		}, "admin-id")
+
+		assert.Equal(t, newBadRequestError(errors.New("all the unmatched submissions should be resolved: 1 left")), err)
+		pending, err := server.AdminListUnmatchedSubmissions(sessionId)
+		assert.NoError(t, err)
+		assert.Nil(t, pending[0].Resolution)
+	})
+
+	t.Run("Fails with the invalid resolutions", func(t *testing.T) {
+		server, sessionId, userId := newIgnoredSessionServer(t)
+		submissions, err := server.AdminListUnmatchedSubmissions(sessionId)
+		assert.NoError(t, err)
+
+		for _, reqs := range [][]ResolveUnmatchedSubmissionReq{
+			{},
+			{{SubmissionId: "unknown", Discard: true}},
+			{{SubmissionId: submissions[0].Id, UserId: userId, Discard: true}, {SubmissionId: submissions[1].Id, Discard: true}},
+			{{SubmissionId: submissions[0].Id}, {SubmissionId: submissions[1].Id, Discard: true}},
+			{{SubmissionId: submissions[0].Id, Discard: true}, {SubmissionId: submissions[0].Id, Discard: true}},
+			{{SubmissionId: submissions[0].Id, UserId: "unknown"}, {SubmissionId: submissions[1].Id, Discard: true}},
+		} {
+			assert.True(t, isBadRequestError(server.ResolveUnmatchedSubmissions(sessionId, reqs, "admin-id")), reqs)
+		}
+	})
+
+	t.Run("Fails to resolve the session that is not ignored", func(t *testing.T) {
+		server, sessionId, _, _ := newCheckInServer(t)
+
+		err := server.ResolveUnmatchedSubmissions(sessionId, []ResolveUnmatchedSubmissionReq{{SubmissionId: "id", Discard: true}}, "admin-id")
+
+		assert.Equal(t, newBadRequestError(errors.New("session's attendance is not ignored")), err)
+	})
+}
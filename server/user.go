@@ -3,8 +3,11 @@ package server
 import (
 	"errors"
 	"fmt"
+	"rush/audit"
 	"rush/golang/array"
+	"rush/permission"
 	"rush/user"
+	"strings"
 )
 
 func (s *Server) GetAllActiveUsers() ([]*User, error) {
@@ -62,3 +65,88 @@ func (s *Server) UpdateUser(id string, externalName *string, generation *float64
 	}
 	return nil
 }
+
+// Promotes or demotes the user and records it in the audit log with the reason. Only the super admins can call it.
+// The callers can't change their own role, and the last super admin can't be demoted so that someone can always
+// manage the roles. The tokens of the user are revoked so that the old role can't be used anymore.
+func (s *Server) ChangeUserRole(id string, role permission.Role, reason string, calledBy string) (UserForAdmin, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return UserForAdmin{}, newBadRequestError(errors.New("reason is required"))
+	}
+	if !role.IsValid() {
+		return UserForAdmin{}, newBadRequestError(fmt.Errorf("invalid role: %s", role))
+	}
+	if id == calledBy {
+		return UserForAdmin{}, newBadRequestError(errors.New("cannot change your own role"))
+	}
+	dbUser, err := s.userRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return UserForAdmin{}, newNotFoundError(fmt.Errorf("failed to get user: %w", err))
+		}
+		return UserForAdmin{}, newInternalServerError(fmt.Errorf("failed to get user: %w", err))
+	}
+	if dbUser.Role == role {
+		return UserForAdmin{}, newBadRequestError(fmt.Errorf("user is already %s", role))
+	}
+	if dbUser.Role == permission.RoleSuperAdmin {
+		users, err := s.userRepo.GetAll()
+		if err != nil {
+			return UserForAdmin{}, newInternalServerError(fmt.Errorf("failed to get users: %w", err))
+		}
+		superAdmins := array.Filter(users, func(user user.User) bool { return user.Role == permission.RoleSuperAdmin })
+		if len(superAdmins) <= 1 {
+			return UserForAdmin{}, newConflictError(errors.New("cannot demote the last super admin"))
+		}
+	}
+
+	newRole := string(role)
+	oldRole := string(dbUser.Role)
+	if err := s.userUpdater.Update(id, user.UpdateForm{Role: &newRole}); err != nil {
+		return UserForAdmin{}, newInternalServerError(fmt.Errorf("failed to update the role of the user: %w", err))
+	}
+	if dbUser.Role == permission.RoleSuperAdmin {
+		// The other super admins may have been demoted at the same time. Check again after the write and undo it
+		// if nobody is left, so that one of the demotions always fails.
+		if err := s.checkSuperAdminLeft(); err != nil {
+			if undoErr := s.userUpdater.Update(id, user.UpdateForm{Role: &oldRole}); undoErr != nil {
+				return UserForAdmin{}, newInternalServerError(fmt.Errorf("%w and failed to restore the role: %v", err, undoErr))
+			}
+			return UserForAdmin{}, err
+		}
+	}
+	if _, err := s.auditRepo.Add(audit.Entry{
+		Action:    audit.ActionRoleChanged,
+		UserId:    id,
+		TargetId:  id,
+		Reason:    reason,
+		Changes:   []audit.Change{{Field: "role", Before: string(dbUser.Role), After: newRole}},
+		ActorId:   calledBy,
+		CreatedAt: s.clock.Now(),
+	}); err != nil {
+		// The role change that nobody can see is not allowed.
+		if undoErr := s.userUpdater.Update(id, user.UpdateForm{Role: &oldRole}); undoErr != nil {
+			return UserForAdmin{}, newInternalServerError(fmt.Errorf("failed to add audit entry: %w and failed to restore the role: %v", err, undoErr))
+		}
+		return UserForAdmin{}, newInternalServerError(fmt.Errorf("failed to add audit entry: %w", err))
+	}
+	// The tokens have the old role in them, so the user has to sign in again to get the new role.
+	if err := s.tokenRepo.RevokeAllByUserId(id, s.clock.Now()); err != nil {
+		return UserForAdmin{}, newInternalServerError(fmt.Errorf("role is changed but failed to revoke tokens of user (%s): %w", id, err))
+	}
+	return s.AdminGetUser(id)
+}
+
+// Returns a conflict error if there is no super admin left.
+func (s *Server) checkSuperAdminLeft() error {
+	users, err := s.userRepo.GetAll()
+	if err != nil {
+		return newInternalServerError(fmt.Errorf("failed to get users: %w", err))
+	}
+	superAdmins := array.Filter(users, func(user user.User) bool { return user.Role == permission.RoleSuperAdmin })
+	if len(superAdmins) == 0 {
+		return newConflictError(errors.New("cannot demote the last super admin"))
+	}
+	return nil
+}
@@ -1,10 +1,16 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"rush/audit"
+	"rush/auth"
+	"rush/golang/array"
 	"rush/permission"
 	"rush/user"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
@@ -15,7 +21,7 @@ func TestGetAllActiveUsers(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserRepo := NewMockuserRepo(ctrl)
-		server := New(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserRepo: mockUserRepo})
 
 		mockUserRepo.EXPECT().GetAll().Return(nil, assert.AnError)
 		users, err := server.GetAllActiveUsers()
@@ -28,7 +34,7 @@ func TestGetAllActiveUsers(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserRepo := NewMockuserRepo(ctrl)
-		server := New(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserRepo: mockUserRepo})
 
 		mockUserRepo.EXPECT().GetAll().Return([]user.User{
 			{
@@ -88,7 +94,7 @@ func TestGetUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserRepo := NewMockuserRepo(ctrl)
-		server := New(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserRepo: mockUserRepo})
 
 		mockUserRepo.EXPECT().Get("user-id").Return(nil, user.ErrNotFound)
 		dbUser, err := server.GetUser("user-id")
@@ -101,7 +107,7 @@ func TestGetUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserRepo := NewMockuserRepo(ctrl)
-		server := New(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserRepo: mockUserRepo})
 
 		mockUserRepo.EXPECT().Get("user-id").Return(nil, assert.AnError)
 		dbUser, err := server.GetUser("user-id")
@@ -114,7 +120,7 @@ func TestGetUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserRepo := NewMockuserRepo(ctrl)
-		server := New(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserRepo: mockUserRepo})
 
 		mockUserRepo.EXPECT().Get("user-id").Return(&user.User{
 			Id:           "user-id",
@@ -144,7 +150,7 @@ func TestAddUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserAdder := NewMockuserAdder(ctrl)
-		server := New(nil, nil, nil, mockUserAdder, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserAdder: mockUserAdder})
 
 		mockUserAdder.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError)
 		err := server.AddUser("user-name", 9.5, true, "user-email")
@@ -156,7 +162,7 @@ func TestAddUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserAdder := NewMockuserAdder(ctrl)
-		server := New(nil, nil, nil, mockUserAdder, nil, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserAdder: mockUserAdder})
 
 		mockUserAdder.EXPECT().Add("user-name", 9.5, true, "user-email").Return(nil)
 		err := server.AddUser("user-name", 9.5, true, "user-email")
@@ -170,7 +176,7 @@ func TestUpdateUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserUpdater := NewMockuserUpdater(ctrl)
-		server := New(nil, nil, nil, nil, mockUserUpdater, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserUpdater: mockUserUpdater})
 
 		externalName := ""
 		err := server.UpdateUser("user-id", &externalName, nil)
@@ -182,7 +188,7 @@ func TestUpdateUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserUpdater := NewMockuserUpdater(ctrl)
-		server := New(nil, nil, nil, nil, mockUserUpdater, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserUpdater: mockUserUpdater})
 
 		generation := 0.0
 		err := server.UpdateUser("user-id", nil, &generation)
@@ -194,7 +200,7 @@ func TestUpdateUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserUpdater := NewMockuserUpdater(ctrl)
-		server := New(nil, nil, nil, nil, mockUserUpdater, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserUpdater: mockUserUpdater})
 
 		externalName := "user-external-name"
 		generation := 9.5
@@ -211,7 +217,7 @@ func TestUpdateUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockUserUpdater := NewMockuserUpdater(ctrl)
-		server := New(nil, nil, nil, nil, mockUserUpdater, nil, nil, nil, nil, nil, nil)
+		server := New(Deps{UserUpdater: mockUserUpdater})
 
 		externalName := "user-external-name"
 		generation := 9.5
@@ -225,3 +231,132 @@ func TestUpdateUser(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+// Holds the demotions until all of them have passed the check before the write as if they are called at the same time.
+type concurrentUserUpdater struct {
+	userUpdater
+	demotions *sync.WaitGroup
+}
+
+func (u concurrentUserUpdater) Update(id string, updateForm user.UpdateForm) error {
+	if updateForm.Role != nil && *updateForm.Role != string(permission.RoleSuperAdmin) {
+		u.demotions.Done()
+		u.demotions.Wait()
+	}
+	return u.userUpdater.Update(id, updateForm)
+}
+
+func TestChangeUserRole(t *testing.T) {
+	// Returns the server with a super admin and a member.
+	newRoleServer := func(t *testing.T) (*Server, string, string) {
+		server, _, _, _ := newCheckInServer(t)
+		userAdder := server.userRepo.(user.UserRepo)
+		assert.NoError(t, userAdder.Add(user.User{Name: "박지수", Role: permission.RoleSuperAdmin, Generation: 8, IsActive: true, ExternalName: "박지수"}))
+		assert.NoError(t, userAdder.Add(user.User{Name: "이도현", Role: permission.RoleMember, Generation: 10, IsActive: true, ExternalName: "이도현"}))
+		users, err := server.userRepo.GetAll()
+		assert.NoError(t, err)
+		return server, users[1].Id, users[2].Id
+	}
+
+	t.Run("Fails without the reason, with an invalid role or for the caller's own role", func(t *testing.T) {
+		server, superAdminId, memberId := newRoleServer(t)
+
+		_, err := server.ChangeUserRole(memberId, permission.RoleAdmin, " ", superAdminId)
+		assert.Equal(t, newBadRequestError(errors.New("reason is required")), err)
+		_, err = server.ChangeUserRole(memberId, permission.RoleUnknown, "운영진 합류", superAdminId)
+		assert.Equal(t, newBadRequestError(errors.New("invalid role: unknown")), err)
+		_, err = server.ChangeUserRole(superAdminId, permission.RoleMember, "운영진 탈퇴", superAdminId)
+		assert.Equal(t, newBadRequestError(errors.New("cannot change your own role")), err)
+		_, err = server.ChangeUserRole(memberId, permission.RoleMember, "운영진 합류", superAdminId)
+		assert.Equal(t, newBadRequestError(errors.New("user is already member")), err)
+	})
+
+	t.Run("Doesn't demote the last super admin", func(t *testing.T) {
+		server, superAdminId, memberId := newRoleServer(t)
+
+		// E.g., the token of the caller still has the role that was changed after signing in.
+		_, err := server.ChangeUserRole(superAdminId, permission.RoleAdmin, "권한 정리", memberId)
+
+		assert.Equal(t, newConflictError(errors.New("cannot demote the last super admin")), err)
+	})
+
+	t.Run("Doesn't demote the last super admins who demote each other at the same time", func(t *testing.T) {
+		server, superAdminId, memberId := newRoleServer(t)
+		_, err := server.ChangeUserRole(memberId, permission.RoleSuperAdmin, "회장 인수인계", superAdminId)
+		assert.NoError(t, err)
+		demotions := &sync.WaitGroup{}
+		demotions.Add(2)
+		server.userUpdater = concurrentUserUpdater{userUpdater: server.userUpdater, demotions: demotions}
+
+		errs := make([]error, 2)
+		called := &sync.WaitGroup{}
+		for index, ids := range [][2]string{{superAdminId, memberId}, {memberId, superAdminId}} {
+			called.Add(1)
+			go func() {
+				defer called.Done()
+				_, errs[index] = server.ChangeUserRole(ids[0], permission.RoleAdmin, "권한 정리", ids[1])
+			}()
+		}
+		called.Wait()
+
+		// Either one or both of them fail depending on the order of the checks after the writes.
+		demotedIds := []string{}
+		for index, err := range errs {
+			if err == nil {
+				demotedIds = append(demotedIds, [2]string{superAdminId, memberId}[index])
+				continue
+			}
+			assert.Equal(t, newConflictError(errors.New("cannot demote the last super admin")), err)
+		}
+		assert.LessOrEqual(t, len(demotedIds), 1)
+		for _, id := range []string{superAdminId, memberId} {
+			changed, err := server.AdminGetUser(id)
+			assert.NoError(t, err)
+			assert.Equal(t, !array.Contains(demotedIds, id), changed.Role == permission.RoleSuperAdmin)
+		}
+	})
+
+	t.Run("Revokes the tokens of the user whose role is changed", func(t *testing.T) {
+		server, superAdminId, memberId := newRoleServer(t)
+		expiresAt := server.clock.Now().Add(7 * 24 * time.Hour)
+		assert.NoError(t, server.tokenRepo.Add(auth.Token{Id: "member-token", UserId: memberId, ExpiresAt: expiresAt}))
+		assert.NoError(t, server.tokenRepo.Add(auth.Token{Id: "super-admin-token", UserId: superAdminId, ExpiresAt: expiresAt}))
+
+		_, err := server.ChangeUserRole(memberId, permission.RoleAdmin, "운영진 합류", superAdminId)
+		assert.NoError(t, err)
+
+		memberToken, err := server.tokenRepo.Get("member-token")
+		assert.NoError(t, err)
+		assert.NotNil(t, memberToken.RevokedAt)
+		superAdminToken, err := server.tokenRepo.Get("super-admin-token")
+		assert.NoError(t, err)
+		assert.Nil(t, superAdminToken.RevokedAt)
+	})
+
+	t.Run("Promotes and demotes the user with the audit entries", func(t *testing.T) {
+		server, superAdminId, memberId := newRoleServer(t)
+
+		promoted, err := server.ChangeUserRole(memberId, permission.RoleSuperAdmin, "회장 인수인계", superAdminId)
+		assert.NoError(t, err)
+		assert.Equal(t, permission.RoleSuperAdmin, promoted.Role)
+		demoted, err := server.ChangeUserRole(superAdminId, permission.RoleAdmin, "회장 인수인계", memberId)
+		assert.NoError(t, err)
+		assert.Equal(t, permission.RoleAdmin, demoted.Role)
+
+		entries, err := server.AdminListUserAuditEntries(memberId)
+		assert.NoError(t, err)
+		assert.Equal(t, []AuditEntry{{
+			Id:        entries[0].Id,
+			Action:    audit.ActionRoleChanged,
+			UserId:    memberId,
+			TargetId:  memberId,
+			Reason:    "회장 인수인계",
+			Changes:   []audit.Change{{Field: "role", Before: "member", After: "super_admin"}},
+			ActorId:   superAdminId,
+			CreatedAt: checkInSessionStartsAt,
+		}}, entries)
+		entries, err = server.ListMyAuditEntries(superAdminId)
+		assert.NoError(t, err)
+		assert.Equal(t, []audit.Change{{Field: "role", Before: "super_admin", After: "admin"}}, entries[0].Changes)
+	})
+}
@@ -0,0 +1,201 @@
+package session
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The session record in the memory.
+type memorySession struct {
+	Session
+	// The reason why the attendance is ignored. E.g. "The user is not a member."
+	attendanceIgnoredReason string
+	// Whether the session is deleted. E.g. false
+	isDeleted bool
+}
+
+// The repo that keeps the sessions in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The sessions in the order of insertion.
+	sessions []*memorySession
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		sessions: []*memorySession{},
+	}
+}
+
+func (r *memoryRepo) Get(id string) (Session, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, session := range r.sessions {
+		if session.Id == id && !session.isDeleted {
+			return session.Session, nil
+		}
+	}
+	return Session{}, ErrNotFound
+}
+
+// Get open sessions that has its attendance source. Open means the session has not closed, as in the attendance
+// is not applied yet.
+func (r *memoryRepo) GetOpenSessionsWithSource() ([]Session, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(session Session) bool {
+		return session.AttendanceStatus == AttendanceStatusNotAppliedYet && session.AttendanceSource.IsSet()
+	}), nil
+}
+
+func (r *memoryRepo) GetAll() ([]Session, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(Session) bool { return true }), nil
+}
+
+// Returns the sessions that start within [from, to), sorted by the start time.
+func (r *memoryRepo) GetAllStartingBetween(from time.Time, to time.Time) ([]Session, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	sessions := r.filter(func(session Session) bool {
+		return !session.StartsAt.Before(from) && session.StartsAt.Before(to)
+	})
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].StartsAt.Before(sessions[j].StartsAt) })
+	return sessions, nil
+}
+
+// Returns the sessions of the series sorted by the start time.
+func (r *memoryRepo) GetAllBySeriesId(seriesId string) ([]Session, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	sessions := r.filter(func(session Session) bool { return session.SeriesId == seriesId })
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].StartsAt.Before(sessions[j].StartsAt) })
+	return sessions, nil
+}
+
+// List sessions with pagination.
+func (r *memoryRepo) List(offset int, pageSize int) (*ListResult, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	sorted := r.filter(func(Session) bool { return true })
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].StartsAt.After(sorted[j].StartsAt) })
+
+	sessions := []Session{}
+	if offset < len(sorted) {
+		sessions = sorted[offset:]
+	}
+	isEnd := len(sessions) <= pageSize
+	if !isEnd {
+		sessions = sessions[:pageSize]
+	}
+
+	return &ListResult{
+		Sessions:   sessions,
+		IsEnd:      isEnd,
+		TotalCount: len(sorted),
+	}, nil
+}
+
+func (r *memoryRepo) Add(name string, description string, createdBy string, startsAt time.Time, score int) (string, error) {
+	return r.AddToSeries("", name, description, createdBy, startsAt, score)
+}
+
+// Adds a session of the series.
+func (r *memoryRepo) AddToSeries(seriesId string, name string, description string, createdBy string, startsAt time.Time, score int) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	id := primitive.NewObjectID().Hex()
+	r.sessions = append(r.sessions, &memorySession{
+		Session: Session{
+			Id:               id,
+			Name:             name,
+			Description:      description,
+			CreatedBy:        createdBy,
+			CreatedAt:        time.Now(),
+			StartsAt:         startsAt,
+			Score:            score,
+			AttendanceStatus: AttendanceStatusNotAppliedYet,
+			SeriesId:         seriesId,
+		},
+	})
+	return id, nil
+}
+
+func (r *memoryRepo) Update(id string, updateForm UpdateForm) (Session, error) {
+	r.mutex.Lock()
+	for _, session := range r.sessions {
+		if session.Id != id {
+			continue
+		}
+
+		if updateForm.Title != nil {
+			session.Name = *updateForm.Title
+		}
+		if updateForm.Description != nil {
+			session.Description = *updateForm.Description
+		}
+		if updateForm.AttendanceSource != nil {
+			session.AttendanceSource = *updateForm.AttendanceSource
+		}
+		if updateForm.StartsAt != nil {
+			session.StartsAt = *updateForm.StartsAt
+		}
+		if updateForm.Score != nil {
+			session.Score = *updateForm.Score
+		}
+		if updateForm.AttendanceStatus != nil {
+			session.AttendanceStatus = *updateForm.AttendanceStatus
+		}
+		if updateForm.AttendanceIgnoredReason != nil {
+			session.attendanceIgnoredReason = *updateForm.AttendanceIgnoredReason
+		}
+		if updateForm.MeetingPoint != nil {
+			session.MeetingPoint = *updateForm.MeetingPoint
+		}
+		if updateForm.Capacity != nil {
+			session.Capacity = *updateForm.Capacity
+		}
+	}
+	r.mutex.Unlock()
+
+	if updateForm.ReturnUpdatedSession {
+		return r.Get(id)
+	}
+
+	return Session{}, nil
+}
+
+func (r *memoryRepo) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, session := range r.sessions {
+		if session.Id == id {
+			session.isDeleted = true
+		}
+	}
+	return nil
+}
+
+// Returns the copies of the sessions that are not deleted and match the predicate.
+func (r *memoryRepo) filter(predicate func(Session) bool) []Session {
+	sessions := []Session{}
+	for _, session := range r.sessions {
+		if !session.isDeleted && predicate(session.Session) {
+			sessions = append(sessions, session.Session)
+		}
+	}
+	return sessions
+}
@@ -22,10 +22,8 @@ type mongodbSession struct {
 	Description string `bson:"description"`
 	// The unique identifier for the user who created the session. E.g. "1"
 	CreatedBy string `bson:"created_by"`
-	// The unique identifier for the Google Form that the session is associated with. E.g. "1"
-	GoogleFormId string `bson:"google_form_id"`
-	// The URI of the Google Form that the session is associated with. E.g. "https://docs.google.com/forms/d/e/1FAIpQLSf9dFVMN-7HgPXl8jUMyL4ynq-e3fKUZXIQaQ/viewform?usp=sf_link"
-	GoogleFormUri string `bson:"google_form_uri"`
+	// Where the attendance of the session is collected from. The provider is empty if it's not set.
+	AttendanceSource mongodbAttendanceSource `bson:"attendance_source"`
 	// The time when the session was created. E.g. "2021-01-01T00:00:00Z"
 	CreatedAt time.Time `bson:"created_at"`
 	// The time when the session starts. E.g. "2021-01-01T00:00:00Z"
@@ -39,6 +37,32 @@ type mongodbSession struct {
 	AttendanceIgnoredReason string `bson:"attendance_ignored_reason"`
 	// Whether the session is deleted. E.g. false
 	IsDeleted bool `bson:"is_deleted"`
+	// The unique identifier for the series that the session is created for. Empty if it's not recurring. E.g. "1"
+	SeriesId string `bson:"series_id"`
+	// Where the members meet. The radius is 0 if it's not set.
+	MeetingPoint mongodbMeetingPoint `bson:"meeting_point"`
+	// How many members can RSVP to the session. 0 means no limit. E.g. 12
+	Capacity int `bson:"capacity"`
+}
+
+// The attendance source record in MongoDB. It's embedded in the session.
+type mongodbAttendanceSource struct {
+	// The provider of the source. E.g. "google_form"
+	Provider AttendanceSourceProvider `bson:"provider"`
+	// The ID of the source in the provider. E.g. "1FAIpQLSf9dFVMN-7HgPXl8jUMyL4ynq-e3fKUZXIQaQ"
+	ExternalId string `bson:"external_id"`
+	// The URI of the source. E.g. "https://docs.google.com/forms/d/e/1FAIpQLSf9dFVMN-7HgPXl8jUMyL4ynq-e3fKUZXIQaQ/viewform?usp=sf_link"
+	Uri string `bson:"uri"`
+}
+
+// The meeting point record in MongoDB. It's embedded in the session.
+type mongodbMeetingPoint struct {
+	// The latitude of the spot. E.g. 37.5283
+	Latitude float64 `bson:"latitude"`
+	// The longitude of the spot. E.g. 126.9326
+	Longitude float64 `bson:"longitude"`
+	// How far from the spot the members can check in. E.g. 100
+	RadiusMeters float64 `bson:"radius_meters"`
 }
 
 type mongodbRepo struct {
@@ -47,6 +71,20 @@ type mongodbRepo struct {
 
 var ErrNotFound = errors.New("session not found")
 
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	Get(id string) (Session, error)
+	GetOpenSessionsWithSource() ([]Session, error)
+	GetAll() ([]Session, error)
+	GetAllStartingBetween(from time.Time, to time.Time) ([]Session, error)
+	List(offset int, pageSize int) (*ListResult, error)
+	Add(name string, description string, createdBy string, startsAt time.Time, score int) (string, error)
+	AddToSeries(seriesId string, name string, description string, createdBy string, startsAt time.Time, score int) (string, error)
+	GetAllBySeriesId(seriesId string) ([]Session, error)
+	Update(id string, updateForm UpdateForm) (Session, error)
+	Delete(id string) error
+}
+
 func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
 	return &mongodbRepo{
 		collection: collection,
@@ -71,10 +109,10 @@ func (r *mongodbRepo) Get(id string) (Session, error) {
 	return *fromMongodbSession(session), nil
 }
 
-// Get open sessions that has its attendance form. Open means the session has not closed, as in the attendance
+// Get open sessions that has its attendance source. Open means the session has not closed, as in the attendance
 // is not applied yet.
-func (r *mongodbRepo) GetOpenSessionsWithForm() ([]Session, error) {
-	cursor, err := r.collection.Find(context.Background(), bson.M{"attendance_status": "not_applied_yet", "is_deleted": false, "google_form_id": bson.M{"$ne": ""}})
+func (r *mongodbRepo) GetOpenSessionsWithSource() ([]Session, error) {
+	cursor, err := r.collection.Find(context.Background(), bson.M{"attendance_status": "not_applied_yet", "is_deleted": false, "attendance_source.provider": bson.M{"$ne": ""}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sessions: %w", err)
 	}
@@ -112,6 +150,52 @@ func (r *mongodbRepo) GetAll() ([]Session, error) {
 	return sessions, nil
 }
 
+// Returns the sessions that start within [from, to), sorted by the start time.
+func (r *mongodbRepo) GetAllStartingBetween(from time.Time, to time.Time) ([]Session, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"is_deleted": false, "starts_at": bson.M{"$gte": from, "$lt": to}},
+		options.Find().SetSort(bson.D{{Key: "starts_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongoSessions []mongodbSession
+	if err = cursor.All(ctx, &mongoSessions); err != nil {
+		return nil, fmt.Errorf("failed to decode sessions: %w", err)
+	}
+
+	sessions := []Session{}
+	for _, mongoSession := range mongoSessions {
+		sessions = append(sessions, *fromMongodbSession(&mongoSession))
+	}
+	return sessions, nil
+}
+
+// Returns the sessions of the series sorted by the start time.
+func (r *mongodbRepo) GetAllBySeriesId(seriesId string) ([]Session, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"is_deleted": false, "series_id": seriesId},
+		options.Find().SetSort(bson.D{{Key: "starts_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongoSessions []mongodbSession
+	if err = cursor.All(ctx, &mongoSessions); err != nil {
+		return nil, fmt.Errorf("failed to decode sessions: %w", err)
+	}
+
+	sessions := []Session{}
+	for _, mongoSession := range mongoSessions {
+		sessions = append(sessions, *fromMongodbSession(&mongoSession))
+	}
+	return sessions, nil
+}
+
 type ListResult struct {
 	Sessions   []Session
 	IsEnd      bool
@@ -162,17 +246,21 @@ func (r *mongodbRepo) List(offset int, pageSize int) (*ListResult, error) {
 }
 
 func (r *mongodbRepo) Add(name string, description string, createdBy string, startsAt time.Time, score int) (string, error) {
+	return r.AddToSeries("", name, description, createdBy, startsAt, score)
+}
+
+// Adds a session of the series.
+func (r *mongodbRepo) AddToSeries(seriesId string, name string, description string, createdBy string, startsAt time.Time, score int) (string, error) {
 	session := mongodbSession{
 		Name:             name,
 		Description:      description,
 		CreatedBy:        createdBy,
-		GoogleFormId:     "",
-		GoogleFormUri:    "",
 		CreatedAt:        time.Now(),
 		StartsAt:         startsAt,
 		Score:            score,
 		AttendanceStatus: AttendanceStatusNotAppliedYet,
 		IsDeleted:        false,
+		SeriesId:         seriesId,
 	}
 
 	result, err := r.collection.InsertOne(context.Background(), session)
@@ -190,14 +278,18 @@ func (r *mongodbRepo) Add(name string, description string, createdBy string, sta
 
 // The form to update the session. It only includes fields that can be updated.
 type UpdateForm struct {
-	Title                   *string
-	Description             *string
-	GoogleFormId            *string
-	GoogleFormUri           *string
+	Title       *string
+	Description *string
+	// Set it to the zero value to unset the attendance source.
+	AttendanceSource        *AttendanceSource
 	StartsAt                *time.Time
 	Score                   *int
 	AttendanceStatus        *AttendanceStatus
 	AttendanceIgnoredReason *string
+	// Set it to the zero value to unset the meeting point.
+	MeetingPoint *MeetingPoint
+	// Set it to 0 to remove the limit.
+	Capacity *int
 
 	// Indicator to return the updated session. If false, the session is not returned.
 	ReturnUpdatedSession bool
@@ -216,11 +308,8 @@ func (r *mongodbRepo) Update(id string, updateForm UpdateForm) (Session, error)
 	if updateForm.Description != nil {
 		update["description"] = *updateForm.Description
 	}
-	if updateForm.GoogleFormId != nil {
-		update["google_form_id"] = *updateForm.GoogleFormId
-	}
-	if updateForm.GoogleFormUri != nil {
-		update["google_form_uri"] = *updateForm.GoogleFormUri
+	if updateForm.AttendanceSource != nil {
+		update["attendance_source"] = mongodbAttendanceSource(*updateForm.AttendanceSource)
 	}
 	if updateForm.StartsAt != nil {
 		update["starts_at"] = *updateForm.StartsAt
@@ -234,6 +323,12 @@ func (r *mongodbRepo) Update(id string, updateForm UpdateForm) (Session, error)
 	if updateForm.AttendanceIgnoredReason != nil {
 		update["attendance_ignored_reason"] = *updateForm.AttendanceIgnoredReason
 	}
+	if updateForm.MeetingPoint != nil {
+		update["meeting_point"] = mongodbMeetingPoint(*updateForm.MeetingPoint)
+	}
+	if updateForm.Capacity != nil {
+		update["capacity"] = *updateForm.Capacity
+	}
 
 	if _, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": objectID}, bson.M{"$set": update}); err != nil {
 		return Session{}, fmt.Errorf("failed to update session: %w", err)
@@ -266,11 +361,13 @@ func fromMongodbSession(session *mongodbSession) *Session {
 		Name:             session.Name,
 		Description:      session.Description,
 		CreatedBy:        session.CreatedBy,
-		GoogleFormId:     session.GoogleFormId,
-		GoogleFormUri:    session.GoogleFormUri,
 		CreatedAt:        session.CreatedAt,
 		StartsAt:         session.StartsAt,
 		Score:            session.Score,
 		AttendanceStatus: session.AttendanceStatus,
+		SeriesId:         session.SeriesId,
+		AttendanceSource: AttendanceSource(session.AttendanceSource),
+		MeetingPoint:     MeetingPoint(session.MeetingPoint),
+		Capacity:         session.Capacity,
 	}
 }
@@ -0,0 +1,193 @@
+package session
+
+import (
+	"rush/golang/mongotest"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	t.Run("Adds and gets a session", func(t *testing.T) {
+		repo := newRepo(t)
+
+		id, err := repo.Add("정규런", "여의도", "user-id", time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC), 2)
+		assert.NoError(t, err)
+
+		session, err := repo.Get(id)
+		assert.NoError(t, err)
+		assert.Equal(t, Session{
+			Id:               id,
+			Name:             "정규런",
+			Description:      "여의도",
+			CreatedBy:        "user-id",
+			CreatedAt:        session.CreatedAt,
+			StartsAt:         time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC),
+			Score:            2,
+			AttendanceStatus: AttendanceStatusNotAppliedYet,
+		}, session)
+
+		_, err = repo.Get(primitive.NewObjectID().Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Updates only the given fields", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add("정규런", "여의도", "user-id", time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC), 2)
+		assert.NoError(t, err)
+
+		form := AttendanceSource{Provider: AttendanceSourceProviderGoogleForm, ExternalId: "form-id", Uri: "https://forms.gle/abc123"}
+		score := 3
+		updated, err := repo.Update(id, UpdateForm{AttendanceSource: &form, Score: &score, ReturnUpdatedSession: true})
+		assert.NoError(t, err)
+		assert.Equal(t, form, updated.AttendanceSource)
+		assert.Equal(t, 3, updated.Score)
+		assert.Equal(t, "정규런", updated.Name)
+
+		checkIn := AttendanceSource{Provider: AttendanceSourceProviderCheckIn, ExternalId: id}
+		updated, err = repo.Update(id, UpdateForm{AttendanceSource: &checkIn, ReturnUpdatedSession: true})
+		assert.NoError(t, err)
+		assert.Equal(t, checkIn, updated.AttendanceSource)
+		assert.Equal(t, 3, updated.Score)
+		updated, err = repo.Update(id, UpdateForm{AttendanceSource: &AttendanceSource{}, ReturnUpdatedSession: true})
+		assert.NoError(t, err)
+		assert.False(t, updated.AttendanceSource.IsSet())
+
+		meetingPoint := MeetingPoint{Latitude: 37.5283, Longitude: 126.9326, RadiusMeters: 100}
+		updated, err = repo.Update(id, UpdateForm{MeetingPoint: &meetingPoint, ReturnUpdatedSession: true})
+		assert.NoError(t, err)
+		assert.Equal(t, meetingPoint, updated.MeetingPoint)
+		updated, err = repo.Update(id, UpdateForm{MeetingPoint: &MeetingPoint{}, ReturnUpdatedSession: true})
+		assert.NoError(t, err)
+		assert.False(t, updated.MeetingPoint.IsSet())
+
+		capacity := 12
+		updated, err = repo.Update(id, UpdateForm{Capacity: &capacity, ReturnUpdatedSession: true})
+		assert.NoError(t, err)
+		assert.Equal(t, 12, updated.Capacity)
+
+		notReturned, err := repo.Update(id, UpdateForm{Score: &score})
+		assert.NoError(t, err)
+		assert.Equal(t, Session{}, notReturned)
+	})
+
+	t.Run("Returns the open sessions with the attendance source", func(t *testing.T) {
+		repo := newRepo(t)
+		form := AttendanceSource{Provider: AttendanceSourceProviderGoogleForm, ExternalId: "form-id"}
+		applied := AttendanceStatusApplied
+		withForm, err := repo.Add("with form", "", "user-id", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		_, err = repo.Update(withForm, UpdateForm{AttendanceSource: &form})
+		assert.NoError(t, err)
+		_, err = repo.Add("without form", "", "user-id", time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		closed, err := repo.Add("closed", "", "user-id", time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		_, err = repo.Update(closed, UpdateForm{AttendanceSource: &form, AttendanceStatus: &applied})
+		assert.NoError(t, err)
+
+		open, err := repo.GetOpenSessionsWithSource()
+		assert.NoError(t, err)
+		assert.Len(t, open, 1)
+		assert.Equal(t, withForm, open[0].Id)
+	})
+
+	t.Run("Hides the deleted sessions", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add("정규런", "", "user-id", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		form := AttendanceSource{Provider: AttendanceSourceProviderGoogleForm, ExternalId: "form-id"}
+		_, err = repo.Update(id, UpdateForm{AttendanceSource: &form})
+		assert.NoError(t, err)
+
+		assert.NoError(t, repo.Delete(id))
+
+		_, err = repo.Get(id)
+		assert.ErrorIs(t, err, ErrNotFound)
+		all, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Empty(t, all)
+		open, err := repo.GetOpenSessionsWithSource()
+		assert.NoError(t, err)
+		assert.Empty(t, open)
+		between, err := repo.GetAllStartingBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Empty(t, between)
+		list, err := repo.List(0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, list.Sessions)
+		assert.Equal(t, 0, list.TotalCount)
+	})
+
+	t.Run("Lists sessions by the start time in descending order", func(t *testing.T) {
+		repo := newRepo(t)
+		for day := 1; day <= 3; day++ {
+			_, err := repo.Add("세션", "", "user-id", time.Date(2025, 7, day, 0, 0, 0, 0, time.UTC), 1)
+			assert.NoError(t, err)
+		}
+
+		first, err := repo.List(0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)},
+			[]time.Time{first.Sessions[0].StartsAt, first.Sessions[1].StartsAt})
+		assert.False(t, first.IsEnd)
+		assert.Equal(t, 3, first.TotalCount)
+
+		second, err := repo.List(2, 2)
+		assert.NoError(t, err)
+		assert.Len(t, second.Sessions, 1)
+		assert.True(t, second.IsEnd)
+	})
+
+	t.Run("Returns the sessions starting within the period", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, day := range []int{3, 1, 2} {
+			_, err := repo.Add("세션", "", "user-id", time.Date(2025, 7, day, 0, 0, 0, 0, time.UTC), 1)
+			assert.NoError(t, err)
+		}
+
+		// The start is inclusive and the end is exclusive.
+		between, err := repo.GetAllStartingBetween(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)},
+			[]time.Time{between[0].StartsAt, between[1].StartsAt})
+	})
+
+	t.Run("Returns the sessions of the series", func(t *testing.T) {
+		repo := newRepo(t)
+		second, err := repo.AddToSeries("series-id", "정규런", "", "user-id", time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		first, err := repo.AddToSeries("series-id", "정규런", "", "user-id", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		_, err = repo.AddToSeries("other-series-id", "정규런", "", "user-id", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		_, err = repo.Add("번개런", "", "user-id", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		deleted, err := repo.AddToSeries("series-id", "정규런", "", "user-id", time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC), 1)
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Delete(deleted))
+
+		sessions, err := repo.GetAllBySeriesId("series-id")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{first, second}, []string{sessions[0].Id, sessions[1].Id})
+		assert.Len(t, sessions, 2)
+		assert.Equal(t, "series-id", sessions[0].SeriesId)
+	})
+}
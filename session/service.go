@@ -37,10 +37,11 @@ type OpenSessionUpdateForm struct {
 	StartsAt    *time.Time
 	Score       *int
 
-	GoogleFormId  *string
-	GoogleFormUri *string
+	AttendanceSource *AttendanceSource
 
 	AttendanceStatus *AttendanceStatus
+	MeetingPoint     *MeetingPoint
+	Capacity         *int
 
 	ReturnUpdatedSession bool
 }
@@ -60,9 +61,10 @@ func (s *service) UpdateOpenSession(id string, updateForm OpenSessionUpdateForm)
 			Description:      updateForm.Description,
 			StartsAt:         updateForm.StartsAt,
 			Score:            updateForm.Score,
-			GoogleFormId:     updateForm.GoogleFormId,
-			GoogleFormUri:    updateForm.GoogleFormUri,
+			AttendanceSource: updateForm.AttendanceSource,
 			AttendanceStatus: updateForm.AttendanceStatus,
+			MeetingPoint:     updateForm.MeetingPoint,
+			Capacity:         updateForm.Capacity,
 
 			ReturnUpdatedSession: updateForm.ReturnUpdatedSession,
 		})
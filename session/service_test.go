@@ -91,16 +91,14 @@ func TestUpdateOpenSession(t *testing.T) {
 		newDescription := "new-description"
 		newStartsAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 		newScore := 100
-		newGoogleFormId := "google-form-id"
-		newGoogleFormUri := "google-form-uri"
+		newAttendanceSource := AttendanceSource{Provider: AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id", Uri: "google-form-uri"}
 		newAttendanceStatus := AttendanceStatusIgnored
 		sessionRepo.EXPECT().Update("session-id", UpdateForm{
 			Title:            &newTitle,
 			Description:      &newDescription,
 			StartsAt:         &newStartsAt,
 			Score:            &newScore,
-			GoogleFormId:     &newGoogleFormId,
-			GoogleFormUri:    &newGoogleFormUri,
+			AttendanceSource: &newAttendanceSource,
 			AttendanceStatus: &newAttendanceStatus,
 		}).Return(Session{}, errors.New("failed to update session"))
 		_, err := service.UpdateOpenSession("session-id", OpenSessionUpdateForm{
@@ -108,8 +106,7 @@ func TestUpdateOpenSession(t *testing.T) {
 			Description:      &newDescription,
 			StartsAt:         &newStartsAt,
 			Score:            &newScore,
-			GoogleFormId:     &newGoogleFormId,
-			GoogleFormUri:    &newGoogleFormUri,
+			AttendanceSource: &newAttendanceSource,
 			AttendanceStatus: &newAttendanceStatus,
 		})
 
@@ -126,16 +123,14 @@ func TestUpdateOpenSession(t *testing.T) {
 		newDescription := "new-description"
 		newStartsAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 		newScore := 100
-		newGoogleFormId := "google-form-id"
-		newGoogleFormUri := "google-form-uri"
+		newAttendanceSource := AttendanceSource{Provider: AttendanceSourceProviderGoogleForm, ExternalId: "google-form-id", Uri: "google-form-uri"}
 		newAttendanceStatus := AttendanceStatusIgnored
 		sessionRepo.EXPECT().Update("session-id", UpdateForm{
 			Title:                &newTitle,
 			Description:          &newDescription,
 			StartsAt:             &newStartsAt,
 			Score:                &newScore,
-			GoogleFormId:         &newGoogleFormId,
-			GoogleFormUri:        &newGoogleFormUri,
+			AttendanceSource:     &newAttendanceSource,
 			AttendanceStatus:     &newAttendanceStatus,
 			ReturnUpdatedSession: true,
 		}).Return(Session{
@@ -145,8 +140,7 @@ func TestUpdateOpenSession(t *testing.T) {
 			StartsAt:         newStartsAt,
 			Score:            newScore,
 			CreatedBy:        "created-by",
-			GoogleFormId:     newGoogleFormId,
-			GoogleFormUri:    newGoogleFormUri,
+			AttendanceSource: newAttendanceSource,
 			AttendanceStatus: newAttendanceStatus,
 		}, nil)
 
@@ -155,8 +149,7 @@ func TestUpdateOpenSession(t *testing.T) {
 			Description:          &newDescription,
 			StartsAt:             &newStartsAt,
 			Score:                &newScore,
-			GoogleFormId:         &newGoogleFormId,
-			GoogleFormUri:        &newGoogleFormUri,
+			AttendanceSource:     &newAttendanceSource,
 			AttendanceStatus:     &newAttendanceStatus,
 			ReturnUpdatedSession: true,
 		})
@@ -6,7 +6,7 @@ import (
 
 // Session represents a session of a running event. It has data to identify the session,
 // and the score. Plus, it has data about its attendance.
-// session attendance can be applied by Google form submissions or manually.
+// session attendance can be applied by the submissions of its attendance source or manually.
 // Once it's applied, the session data is immutable. Whether it's applied is indicated by `AttendanceStatus`.
 type Session struct {
 	// The ID of the session. It's a unique identifier. E.g., "abc123"
@@ -17,10 +17,9 @@ type Session struct {
 	Description string `json:"description"`
 	// The ID of the user who created the session. E.g., "abc123"
 	CreatedBy string `json:"created_by"`
-	// The Google form ID for attendance of the session. E.g., "abc123"
-	GoogleFormId string `json:"google_form_id"`
-	// The Google form URI for attendance of the session. E.g., "https://forms.gle/abc123"
-	GoogleFormUri string `json:"google_form_uri"`
+	// Where the attendance of the session is collected from. E.g., the Google form
+	// Zero value if the session doesn't have one yet.
+	AttendanceSource AttendanceSource `json:"attendance_source"`
 	// The time in UTC when the session was created.
 	CreatedAt time.Time `json:"created_at"`
 	// The time in UTC when the session starts.
@@ -30,6 +29,58 @@ type Session struct {
 	// The status of the session's attendance.
 	// It indicates if it is applied, ignored, etc.
 	AttendanceStatus AttendanceStatus `json:"attendance_status"`
+	// The ID of the series that the session is created for. Empty if it's not a recurring session. E.g., "abc123"
+	SeriesId string `json:"series_id"`
+	// Where the members meet. The members can check in only around it. Zero value if it's not set.
+	MeetingPoint MeetingPoint `json:"meeting_point"`
+	// How many members can RSVP to the session. The others are put on the waitlist. 0 means no limit. E.g., 12
+	Capacity int `json:"capacity"`
+}
+
+// The provider that collects the attendance of the sessions. The providers are interchangeable.
+type AttendanceSourceProvider string
+
+const (
+	// The Google form that the members submit before the session starts.
+	AttendanceSourceProviderGoogleForm AttendanceSourceProvider = "google_form"
+	// The built-in check-in with the code or the location at the meeting point.
+	AttendanceSourceProviderCheckIn AttendanceSourceProvider = "check_in"
+	// The CSV file that the admin imports. E.g., the export of another attendance tool.
+	AttendanceSourceProviderCsv AttendanceSourceProvider = "csv"
+)
+
+// The source of the session's attendance in its provider.
+type AttendanceSource struct {
+	// The provider of the source. Empty if it's not set. E.g., "google_form"
+	Provider AttendanceSourceProvider `json:"provider"`
+	// The ID of the source in the provider. E.g., the Google form ID or the name of the imported CSV file
+	ExternalId string `json:"external_id"`
+	// The URI that the members open to submit their attendance. Empty if the provider doesn't have one.
+	// E.g., "https://forms.gle/abc123"
+	Uri string `json:"uri"`
+}
+
+// Returns true if the source is set.
+func (s AttendanceSource) IsSet() bool {
+	return s.Provider != ""
+}
+
+// How long before and after the session starts the members can check in at the meeting point.
+// The check-in sessions are closed after it.
+const CheckInWindow = 30 * time.Minute
+
+// The fixed meeting spot of the session such as the entrance of a park.
+type MeetingPoint struct {
+	// The coordinates of the spot. E.g., 37.5283, 126.9326
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// How far from the spot the members can check in. E.g., 100
+	RadiusMeters float64 `json:"radius_meters"`
+}
+
+// Returns true if the meeting point is set. A meeting point without the radius is regarded as not set.
+func (p MeetingPoint) IsSet() bool {
+	return p.RadiusMeters > 0
 }
 
 type AttendanceStatus string
@@ -49,6 +100,8 @@ const (
 	AttendanceAppliedByUnspecified AttendanceAppliedBy = "unspecified"
 	AttendanceAppliedByManual      AttendanceAppliedBy = "manual"
 	AttendanceAppliedByForm        AttendanceAppliedBy = "form"
+	AttendanceAppliedByCheckIn     AttendanceAppliedBy = "check_in"
+	AttendanceAppliedByCsv         AttendanceAppliedBy = "csv"
 )
 
 // TODO(#223): Fix method names to be more clear, as in, `IsOpen`.
@@ -65,30 +118,31 @@ func (s *Session) CanUpdateMetadata() bool {
 	return false
 }
 
-// Checks the session data and returns true if the attendance can be applied by Google form submissions.
-func (s *Session) CanApplyAttendanceByFormSubmissions() bool {
+// Checks the session data and returns true if the attendance can be applied by the submissions of its source.
+func (s *Session) CanApplyAttendanceBySource() bool {
 	if s.AttendanceStatus == AttendanceStatusApplied {
 		return false
 	}
 
-	if s.GoogleFormId == "" || s.GoogleFormUri == "" {
-		return false
-	}
-
-	return true
+	return s.AttendanceSource.IsSet()
 }
 
 // Checks the session data and returns true if the attendance can be applied manually.
+// The members who couldn't check in can be marked as present manually as well.
 func (s *Session) CanApplyAttendanceManually() bool {
 	if s.AttendanceStatus == AttendanceStatusApplied {
 		return false
 	}
 
-	if s.GoogleFormId != "" || s.GoogleFormUri != "" {
-		return false
-	}
+	return !s.AttendanceSource.IsSet() || s.AttendanceSource.Provider == AttendanceSourceProviderCheckIn
+}
 
-	return true
+// Returns the time when the source stops accepting the submissions. The attendance can be applied after it.
+func (s *Session) AttendanceClosesAt() time.Time {
+	if s.AttendanceSource.Provider == AttendanceSourceProviderCheckIn {
+		return s.StartsAt.Add(CheckInWindow)
+	}
+	return s.StartsAt
 }
 
 func (s *Session) AttendanceAppliedBy() AttendanceAppliedBy {
@@ -96,9 +150,14 @@ func (s *Session) AttendanceAppliedBy() AttendanceAppliedBy {
 		return AttendanceAppliedByUnspecified
 	}
 
-	if s.GoogleFormId == "" && s.GoogleFormUri == "" {
+	switch s.AttendanceSource.Provider {
+	case AttendanceSourceProviderGoogleForm:
+		return AttendanceAppliedByForm
+	case AttendanceSourceProviderCheckIn:
+		return AttendanceAppliedByCheckIn
+	case AttendanceSourceProviderCsv:
+		return AttendanceAppliedByCsv
+	default:
 		return AttendanceAppliedByManual
 	}
-
-	return AttendanceAppliedByForm
 }
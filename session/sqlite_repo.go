@@ -0,0 +1,201 @@
+package session
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"rush/sqlite"
+	"strings"
+	"time"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteSessionColumns = "id, name, description, created_by, attendance_source_provider, attendance_source_external_id, attendance_source_uri, created_at, starts_at, score, attendance_status, series_id, meeting_latitude, meeting_longitude, meeting_radius_meters, capacity"
+
+func (r *sqliteRepo) Get(id string) (Session, error) {
+	session, err := scanSqliteSession(r.db.QueryRow("SELECT "+sqliteSessionColumns+" FROM sessions WHERE id = ? AND is_deleted = 0", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Session{}, ErrNotFound
+		}
+		return Session{}, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+// Get open sessions that has its attendance source. Open means the session has not closed, as in the attendance
+// is not applied yet.
+func (r *sqliteRepo) GetOpenSessionsWithSource() ([]Session, error) {
+	return r.query("SELECT "+sqliteSessionColumns+" FROM sessions WHERE attendance_status = ? AND is_deleted = 0 AND attendance_source_provider != '' ORDER BY rowid",
+		AttendanceStatusNotAppliedYet)
+}
+
+func (r *sqliteRepo) GetAll() ([]Session, error) {
+	return r.query("SELECT " + sqliteSessionColumns + " FROM sessions WHERE is_deleted = 0 ORDER BY rowid")
+}
+
+// Returns the sessions that start within [from, to), sorted by the start time.
+func (r *sqliteRepo) GetAllStartingBetween(from time.Time, to time.Time) ([]Session, error) {
+	return r.query("SELECT "+sqliteSessionColumns+" FROM sessions WHERE is_deleted = 0 AND starts_at >= ? AND starts_at < ? ORDER BY starts_at, rowid",
+		sqlite.FromTime(from), sqlite.FromTime(to))
+}
+
+// Returns the sessions of the series sorted by the start time.
+func (r *sqliteRepo) GetAllBySeriesId(seriesId string) ([]Session, error) {
+	return r.query("SELECT "+sqliteSessionColumns+" FROM sessions WHERE is_deleted = 0 AND series_id = ? ORDER BY starts_at, rowid", seriesId)
+}
+
+// List sessions with pagination.
+func (r *sqliteRepo) List(offset int, pageSize int) (*ListResult, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM sessions WHERE is_deleted = 0").Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count sessions: %w", err)
+	}
+
+	// Fetch pageSize + 1 to check if there are more pages.
+	sessions, err := r.query("SELECT "+sqliteSessionColumns+" FROM sessions WHERE is_deleted = 0 ORDER BY starts_at DESC, rowid LIMIT ? OFFSET ?",
+		pageSize+1, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	isEnd := len(sessions) <= pageSize
+	if !isEnd {
+		sessions = sessions[:pageSize]
+	}
+
+	return &ListResult{
+		Sessions:   sessions,
+		IsEnd:      isEnd,
+		TotalCount: total,
+	}, nil
+}
+
+func (r *sqliteRepo) Add(name string, description string, createdBy string, startsAt time.Time, score int) (string, error) {
+	return r.AddToSeries("", name, description, createdBy, startsAt, score)
+}
+
+// Adds a session of the series.
+func (r *sqliteRepo) AddToSeries(seriesId string, name string, description string, createdBy string, startsAt time.Time, score int) (string, error) {
+	id := sqlite.NewId()
+	_, err := r.db.Exec(`INSERT INTO sessions (id, name, description, created_by, attendance_source_provider, attendance_source_external_id,
+	attendance_source_uri, created_at, starts_at, score, attendance_status, attendance_ignored_reason, is_deleted, series_id, meeting_latitude,
+	meeting_longitude, meeting_radius_meters)
+	VALUES (?, ?, ?, ?, '', '', '', ?, ?, ?, ?, '', 0, ?, 0, 0, 0)`,
+		id, name, description, createdBy, sqlite.FromTime(time.Now()), sqlite.FromTime(startsAt), score, AttendanceStatusNotAppliedYet, seriesId)
+	if err != nil {
+		return "", fmt.Errorf("failed to insert session: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *sqliteRepo) Update(id string, updateForm UpdateForm) (Session, error) {
+	sets := []string{}
+	args := []interface{}{}
+	if updateForm.Title != nil {
+		sets = append(sets, "name = ?")
+		args = append(args, *updateForm.Title)
+	}
+	if updateForm.Description != nil {
+		sets = append(sets, "description = ?")
+		args = append(args, *updateForm.Description)
+	}
+	if updateForm.AttendanceSource != nil {
+		sets = append(sets, "attendance_source_provider = ?", "attendance_source_external_id = ?", "attendance_source_uri = ?")
+		args = append(args, updateForm.AttendanceSource.Provider, updateForm.AttendanceSource.ExternalId, updateForm.AttendanceSource.Uri)
+	}
+	if updateForm.StartsAt != nil {
+		sets = append(sets, "starts_at = ?")
+		args = append(args, sqlite.FromTime(*updateForm.StartsAt))
+	}
+	if updateForm.Score != nil {
+		sets = append(sets, "score = ?")
+		args = append(args, *updateForm.Score)
+	}
+	if updateForm.AttendanceStatus != nil {
+		sets = append(sets, "attendance_status = ?")
+		args = append(args, *updateForm.AttendanceStatus)
+	}
+	if updateForm.AttendanceIgnoredReason != nil {
+		sets = append(sets, "attendance_ignored_reason = ?")
+		args = append(args, *updateForm.AttendanceIgnoredReason)
+	}
+	if updateForm.MeetingPoint != nil {
+		sets = append(sets, "meeting_latitude = ?", "meeting_longitude = ?", "meeting_radius_meters = ?")
+		args = append(args, updateForm.MeetingPoint.Latitude, updateForm.MeetingPoint.Longitude, updateForm.MeetingPoint.RadiusMeters)
+	}
+	if updateForm.Capacity != nil {
+		sets = append(sets, "capacity = ?")
+		args = append(args, *updateForm.Capacity)
+	}
+
+	if len(sets) > 0 {
+		args = append(args, id)
+		if _, err := r.db.Exec("UPDATE sessions SET "+strings.Join(sets, ", ")+" WHERE id = ?", args...); err != nil {
+			return Session{}, fmt.Errorf("failed to update session: %w", err)
+		}
+	}
+
+	if updateForm.ReturnUpdatedSession {
+		return r.Get(id)
+	}
+
+	return Session{}, nil
+}
+
+func (r *sqliteRepo) Delete(id string) error {
+	if _, err := r.db.Exec("UPDATE sessions SET is_deleted = 1 WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteRepo) query(query string, args ...interface{}) ([]Session, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []Session{}
+	for rows.Next() {
+		session, err := scanSqliteSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode sessions: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// Either *sql.Row or *sql.Rows.
+type sqliteScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSqliteSession(scanner sqliteScanner) (Session, error) {
+	var session Session
+	var createdAt, startsAt int64
+	if err := scanner.Scan(&session.Id, &session.Name, &session.Description, &session.CreatedBy, &session.AttendanceSource.Provider,
+		&session.AttendanceSource.ExternalId, &session.AttendanceSource.Uri, &createdAt, &startsAt, &session.Score, &session.AttendanceStatus, &session.SeriesId,
+		&session.MeetingPoint.Latitude, &session.MeetingPoint.Longitude, &session.MeetingPoint.RadiusMeters, &session.Capacity); err != nil {
+		return Session{}, err
+	}
+	session.CreatedAt = sqlite.ToTime(createdAt)
+	session.StartsAt = sqlite.ToTime(startsAt)
+	return session, nil
+}
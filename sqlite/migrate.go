@@ -0,0 +1,283 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// The schema migrations. They are applied in order and each of them is applied only once.
+// Never modify or remove a migration that has been released. Append a new one instead.
+var migrations = []string{
+	// 1: The initial schema.
+	`
+CREATE TABLE users (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	role TEXT NOT NULL,
+	generation REAL NOT NULL,
+	is_active INTEGER NOT NULL,
+	email TEXT NOT NULL,
+	external_name TEXT NOT NULL
+);
+CREATE INDEX users_email ON users (email);
+CREATE INDEX users_external_name ON users (external_name);
+
+CREATE TABLE sessions (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	created_by TEXT NOT NULL,
+	google_form_id TEXT NOT NULL,
+	google_form_uri TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	starts_at INTEGER NOT NULL,
+	score INTEGER NOT NULL,
+	attendance_status TEXT NOT NULL,
+	attendance_ignored_reason TEXT NOT NULL,
+	is_deleted INTEGER NOT NULL
+);
+CREATE INDEX sessions_starts_at ON sessions (starts_at);
+
+CREATE TABLE attendances (
+	id TEXT PRIMARY KEY,
+	session_id TEXT NOT NULL,
+	session_name TEXT NOT NULL,
+	session_score INTEGER NOT NULL,
+	session_started_at INTEGER NOT NULL,
+	user_id TEXT NOT NULL,
+	user_external_name TEXT NOT NULL,
+	user_generation REAL NOT NULL,
+	user_joined_at INTEGER NOT NULL,
+	created_at INTEGER NOT NULL,
+	created_by TEXT NOT NULL,
+	force_apply INTEGER NOT NULL
+);
+CREATE INDEX attendances_session_id ON attendances (session_id);
+CREATE INDEX attendances_user_id ON attendances (user_id);
+CREATE INDEX attendances_session_started_at ON attendances (session_started_at);
+
+CREATE TABLE terms (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	starts_at INTEGER NOT NULL,
+	ends_at INTEGER NOT NULL,
+	-- JSON array of the generations. E.g. [9, 9.5]
+	generations TEXT NOT NULL,
+	is_archived INTEGER NOT NULL,
+	created_at INTEGER NOT NULL,
+	is_deleted INTEGER NOT NULL
+);
+`,
+	// 2: A user can attend a session only once.
+	`
+CREATE UNIQUE INDEX attendances_session_id_user_id ON attendances (session_id, user_id);
+`,
+	// 3: The sessions that repeat every week.
+	`
+CREATE TABLE session_series (
+	id TEXT PRIMARY KEY,
+	name_template TEXT NOT NULL,
+	description_template TEXT NOT NULL,
+	created_by TEXT NOT NULL,
+	weekday INTEGER NOT NULL,
+	hour INTEGER NOT NULL,
+	minute INTEGER NOT NULL,
+	score INTEGER NOT NULL,
+	materialized_until INTEGER NOT NULL,
+	is_cancelled INTEGER NOT NULL,
+	created_at INTEGER NOT NULL
+);
+
+ALTER TABLE sessions ADD COLUMN series_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX sessions_series_id ON sessions (series_id);
+`,
+	// 4: The sessions that the members check in to with the code.
+	`
+ALTER TABLE sessions ADD COLUMN check_in_enabled INTEGER NOT NULL DEFAULT 0;
+`,
+	// 5: The meeting points of the sessions and the check-ins rejected by them.
+	`
+ALTER TABLE sessions ADD COLUMN meeting_latitude REAL NOT NULL DEFAULT 0;
+ALTER TABLE sessions ADD COLUMN meeting_longitude REAL NOT NULL DEFAULT 0;
+ALTER TABLE sessions ADD COLUMN meeting_radius_meters REAL NOT NULL DEFAULT 0;
+
+CREATE TABLE check_in_rejections (
+	id TEXT PRIMARY KEY,
+	session_id TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	latitude REAL NOT NULL,
+	longitude REAL NOT NULL,
+	accuracy_meters REAL NOT NULL,
+	distance_meters REAL NOT NULL,
+	reason TEXT NOT NULL,
+	attempted_at INTEGER NOT NULL
+);
+CREATE INDEX check_in_rejections_session_id ON check_in_rejections (session_id);
+`,
+	// 6: The attendance sources of the sessions that replace the Google form and the check-in columns.
+	`
+ALTER TABLE sessions ADD COLUMN attendance_source_provider TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN attendance_source_external_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN attendance_source_uri TEXT NOT NULL DEFAULT '';
+
+UPDATE sessions SET attendance_source_provider = 'google_form', attendance_source_external_id = google_form_id,
+	attendance_source_uri = google_form_uri
+WHERE google_form_id != '';
+UPDATE sessions SET attendance_source_provider = 'check_in', attendance_source_external_id = id
+WHERE google_form_id = '' AND check_in_enabled = 1;
+
+ALTER TABLE sessions DROP COLUMN google_form_id;
+ALTER TABLE sessions DROP COLUMN google_form_uri;
+ALTER TABLE sessions DROP COLUMN check_in_enabled;
+`,
+	// 7: The submissions from the attendance sources that couldn't be matched to any user.
+	`
+CREATE TABLE unmatched_submissions (
+	id TEXT PRIMARY KEY,
+	session_id TEXT NOT NULL,
+	answer TEXT NOT NULL,
+	user_external_name TEXT NOT NULL,
+	reason TEXT NOT NULL,
+	detail TEXT NOT NULL,
+	submission_time INTEGER NOT NULL
+);
+CREATE INDEX unmatched_submissions_session_id ON unmatched_submissions (session_id);
+`,
+	// 8: How the admins resolved the unmatched submissions.
+	`
+ALTER TABLE unmatched_submissions ADD COLUMN resolution_action TEXT NOT NULL DEFAULT '';
+ALTER TABLE unmatched_submissions ADD COLUMN resolution_user_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE unmatched_submissions ADD COLUMN resolved_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE unmatched_submissions ADD COLUMN resolved_at INTEGER NOT NULL DEFAULT 0;
+`,
+	// 9: The excuses of the members who will miss the sessions.
+	`
+CREATE TABLE excuses (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	session_id TEXT NOT NULL,
+	starts_at INTEGER NOT NULL,
+	ends_at INTEGER NOT NULL,
+	reason TEXT NOT NULL,
+	status TEXT NOT NULL,
+	counts_toward_score INTEGER NOT NULL,
+	reviewed_by TEXT NOT NULL,
+	reviewed_at INTEGER NOT NULL,
+	created_at INTEGER NOT NULL
+);
+CREATE INDEX excuses_user_id ON excuses (user_id);
+CREATE INDEX excuses_status ON excuses (status);
+`,
+	// 10: The scoring rules of the terms as JSON. It's empty if they are not set.
+	`
+ALTER TABLE terms ADD COLUMN scoring_rules TEXT NOT NULL DEFAULT '';
+`,
+	// 11: How the users attended the sessions. The force-applied ones are kept as they are and the others are regarded as on time.
+	`
+ALTER TABLE attendances ADD COLUMN status TEXT NOT NULL DEFAULT '';
+ALTER TABLE attendances ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE attendances ADD COLUMN status_set_by TEXT NOT NULL DEFAULT '';
+
+UPDATE attendances SET status = CASE WHEN force_apply = 1 THEN 'force_applied' ELSE 'on_time' END;
+`,
+	// 12: The append-only log of the changes that the admins made to the records of the members. The changes are JSON.
+	`
+CREATE TABLE audit_entries (
+	id TEXT PRIMARY KEY,
+	action TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	session_id TEXT NOT NULL,
+	target_id TEXT NOT NULL,
+	reason TEXT NOT NULL,
+	changes TEXT NOT NULL,
+	actor_id TEXT NOT NULL,
+	created_at INTEGER NOT NULL
+);
+CREATE INDEX audit_entries_user_id ON audit_entries (user_id);
+CREATE INDEX audit_entries_session_id ON audit_entries (session_id);
+`,
+	// 13: The capacity of the sessions and the RSVPs of the members. The capacity is 0 if there is no limit.
+	`
+ALTER TABLE sessions ADD COLUMN capacity INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE rsvps (
+	id TEXT PRIMARY KEY,
+	session_id TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	status TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL
+);
+CREATE INDEX rsvps_session_id ON rsvps (session_id);
+CREATE INDEX rsvps_user_id ON rsvps (user_id);
+`,
+	// 14: Whether the members attended the sessions that they had a spot for.
+	`
+CREATE TABLE reliability_outcomes (
+	id TEXT PRIMARY KEY,
+	session_id TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	attended INTEGER NOT NULL,
+	session_starts_at INTEGER NOT NULL,
+	evaluated_at INTEGER NOT NULL
+);
+CREATE INDEX reliability_outcomes_session_id ON reliability_outcomes (session_id);
+CREATE INDEX reliability_outcomes_user_id ON reliability_outcomes (user_id);
+`,
+	// 15: The issued tokens so that they can be revoked before they expire. The id is the jti claim.
+	`
+CREATE TABLE auth_tokens (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	issued_at INTEGER NOT NULL,
+	expires_at INTEGER NOT NULL,
+	revoked_at INTEGER
+);
+CREATE INDEX auth_tokens_user_id ON auth_tokens (user_id);
+`,
+	// 16: Only one active RSVP of a member for each session. The cancelled ones are kept.
+	`
+CREATE UNIQUE INDEX rsvps_session_id_user_id_active ON rsvps (session_id, user_id) WHERE status IN ('confirmed', 'waitlisted');
+`,
+}
+
+// Applies the migrations that have not been applied yet. The version of each applied migration
+// is recorded in `schema_migrations`.
+func Migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+	version INTEGER PRIMARY KEY,
+	applied_at INTEGER NOT NULL
+)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return fmt.Errorf("failed to get the schema version: %w", err)
+	}
+
+	for index := current; index < len(migrations); index++ {
+		version := index + 1
+		if err := apply(db, version, migrations[index]); err != nil {
+			return fmt.Errorf("failed to apply migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+func apply(db *sql.DB, version int, migration string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(migration); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)", version, FromTime(time.Now())); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Run("Applies every migration once", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rush.db")
+		db, err := Open(path)
+		assert.NoError(t, err)
+		db.Close()
+
+		// Reopening should not apply the migrations again.
+		db, err = Open(path)
+		assert.NoError(t, err)
+		defer db.Close()
+
+		var count int
+		assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+		assert.Equal(t, len(migrations), count)
+
+		for _, table := range []string{"users", "sessions", "attendances", "terms"} {
+			var name string
+			assert.NoError(t, db.QueryRow("SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&name))
+		}
+	})
+
+	t.Run("Moves the Google forms and the check-ins of the sessions to the attendance sources", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "rush.db"))
+		assert.NoError(t, err)
+		defer db.Close()
+		// Applies the migrations before the attendance sources and adds the sessions of that time.
+		_, err = db.Exec("CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, applied_at INTEGER NOT NULL)")
+		assert.NoError(t, err)
+		for index := 0; index < 5; index++ {
+			assert.NoError(t, apply(db, index+1, migrations[index]))
+		}
+		_, err = db.Exec(`INSERT INTO sessions (id, name, description, created_by, google_form_id, google_form_uri, created_at, starts_at, score,
+	attendance_status, attendance_ignored_reason, is_deleted, check_in_enabled)
+	VALUES ('form', '', '', '', 'form-id', 'form-uri', 0, 0, 1, 'not_applied_yet', '', 0, 0),
+	('check-in', '', '', '', '', '', 0, 0, 1, 'not_applied_yet', '', 0, 1),
+	('manual', '', '', '', '', '', 0, 0, 1, 'not_applied_yet', '', 0, 0)`)
+		assert.NoError(t, err)
+
+		assert.NoError(t, Migrate(db))
+
+		for id, expected := range map[string][3]string{
+			"form":     {"google_form", "form-id", "form-uri"},
+			"check-in": {"check_in", "check-in", ""},
+			"manual":   {"", "", ""},
+		} {
+			var actual [3]string
+			assert.NoError(t, db.QueryRow("SELECT attendance_source_provider, attendance_source_external_id, attendance_source_uri FROM sessions WHERE id = ?", id).
+				Scan(&actual[0], &actual[1], &actual[2]))
+			assert.Equal(t, expected, actual, id)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+// It opens the SQLite database that the repos use instead of MongoDB.
+// It's for small clubs and local development so that RUSH can run as a single binary.
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Opens the database at the path and migrates it to the latest schema.
+// Use ":memory:" for a database that lives only in the memory, e.g. in tests.
+func Open(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite allows only one writer at a time. And each connection has its own database for ":memory:".
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+	if err := Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Returns a new ID for a record. It has the same format as MongoDB's ObjectID
+// so that IDs stay valid when the data moves between the backends.
+func NewId() string {
+	return primitive.NewObjectID().Hex()
+}
+
+// Converts the time to the value of the column. Times are stored as Unix milliseconds in UTC
+// which has the same precision as MongoDB.
+func FromTime(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+// Converts the value of the column to the time in UTC.
+func ToTime(milliseconds int64) time.Time {
+	return time.UnixMilli(milliseconds).UTC()
+}
+
+// Checks if the error is caused by a UNIQUE constraint. E.g. inserting a duplicate of a unique index.
+func IsUniqueConstraintError(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
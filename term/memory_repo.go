@@ -0,0 +1,157 @@
+package term
+
+import (
+	"rush/scoring"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The term record in the memory.
+type memoryTerm struct {
+	Term
+	// Whether the term is deleted. E.g. false
+	isDeleted bool
+}
+
+// The repo that keeps the terms in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The terms in the order of insertion.
+	terms []*memoryTerm
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		terms: []*memoryTerm{},
+	}
+}
+
+// Returns the term by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *memoryRepo) Get(id string) (Term, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, term := range r.filter() {
+		if term.Id == id {
+			return term, nil
+		}
+	}
+	return Term{}, ErrNotFound
+}
+
+// Returns all the terms sorted by the start time in descending order.
+func (r *memoryRepo) GetAll() ([]Term, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	terms := r.filter()
+	sort.SliceStable(terms, func(i, j int) bool { return terms[i].StartsAt.After(terms[j].StartsAt) })
+	return terms, nil
+}
+
+// Returns the term whose period includes the given time.
+// If not found, it returns ErrNotFound.
+func (r *memoryRepo) GetByTime(at time.Time) (Term, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, term := range r.filter() {
+		if term.Contains(at) {
+			return term, nil
+		}
+	}
+	return Term{}, ErrNotFound
+}
+
+func (r *memoryRepo) Add(name string, startsAt time.Time, endsAt time.Time, generations []float64) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	id := primitive.NewObjectID().Hex()
+	r.terms = append(r.terms, &memoryTerm{
+		Term: Term{
+			Id:          id,
+			Name:        name,
+			StartsAt:    startsAt,
+			EndsAt:      endsAt,
+			Generations: copyGenerations(generations),
+			CreatedAt:   time.Now(),
+		},
+	})
+	return id, nil
+}
+
+func (r *memoryRepo) Update(id string, updateForm UpdateForm) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, term := range r.terms {
+		if term.Id != id {
+			continue
+		}
+		if updateForm.Name != nil {
+			term.Name = *updateForm.Name
+		}
+		if updateForm.StartsAt != nil {
+			term.StartsAt = *updateForm.StartsAt
+		}
+		if updateForm.EndsAt != nil {
+			term.EndsAt = *updateForm.EndsAt
+		}
+		if updateForm.Generations != nil {
+			term.Generations = copyGenerations(*updateForm.Generations)
+		}
+		if updateForm.IsArchived != nil {
+			term.IsArchived = *updateForm.IsArchived
+		}
+		if updateForm.ScoringRules != nil {
+			term.ScoringRules = copyScoringRules(updateForm.ScoringRules)
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepo) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, term := range r.terms {
+		if term.Id == id && !term.isDeleted {
+			term.isDeleted = true
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Returns the copies of the terms that are not deleted.
+func (r *memoryRepo) filter() []Term {
+	terms := []Term{}
+	for _, term := range r.terms {
+		if !term.isDeleted {
+			copied := term.Term
+			copied.Generations = copyGenerations(term.Generations)
+			copied.ScoringRules = copyScoringRules(term.ScoringRules)
+			terms = append(terms, copied)
+		}
+	}
+	return terms
+}
+
+func copyGenerations(generations []float64) []float64 {
+	return append([]float64{}, generations...)
+}
+
+func copyScoringRules(rules *scoring.Rules) *scoring.Rules {
+	if rules == nil {
+		return nil
+	}
+	copied := *rules
+	copied.BonusSessions = append([]scoring.BonusSession{}, rules.BonusSessions...)
+	return &copied
+}
@@ -0,0 +1,277 @@
+package term
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"rush/scoring"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The term record in MongoDB.
+type mongodbTerm struct {
+	// The unique identifier for the term. E.g. "1"
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// The name of the term. E.g. "2025-2"
+	Name string `bson:"name"`
+	// The time when the term starts. E.g. "2025-07-01T00:00:00Z"
+	StartsAt time.Time `bson:"starts_at"`
+	// The time when the term ends. E.g. "2026-01-01T00:00:00Z"
+	EndsAt time.Time `bson:"ends_at"`
+	// The generations of the users who participate in the term. E.g. [9, 9.5, 10]
+	Generations []float64 `bson:"generations"`
+	// Whether the term is archived. E.g. false
+	IsArchived bool `bson:"is_archived"`
+	// The rules to score the attendances. It's missing if they are not set.
+	ScoringRules *mongodbScoringRules `bson:"scoring_rules,omitempty"`
+	// The time when the term was created. E.g. "2025-06-20T00:00:00Z"
+	CreatedAt time.Time `bson:"created_at"`
+	// Whether the term is deleted. E.g. false
+	IsDeleted bool `bson:"is_deleted"`
+}
+
+type mongodbScoringRules struct {
+	// The total score to pass the term. E.g. 20
+	MinimumScore int `bson:"minimum_score"`
+	// The minutes to join without the late penalty. E.g. 10
+	LateGraceMinutes int `bson:"late_grace_minutes"`
+	// The points deducted for joining late. E.g. 1
+	LatePenalty int `bson:"late_penalty"`
+	// The maximum points in a week. E.g. 6
+	WeeklyCap int `bson:"weekly_cap"`
+	// Whether the force-applied attendances earn no points. E.g. true
+	ExcludeForceApplied bool `bson:"exclude_force_applied"`
+	// The sessions that give extra points.
+	BonusSessions []mongodbBonusSession `bson:"bonus_sessions"`
+}
+
+type mongodbBonusSession struct {
+	// The unique identifier for the session. E.g. "1"
+	SessionId string `bson:"session_id"`
+	// The extra points. E.g. 3
+	Points int `bson:"points"`
+}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+var ErrNotFound = errors.New("term not found")
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	Get(id string) (Term, error)
+	GetAll() ([]Term, error)
+	GetByTime(at time.Time) (Term, error)
+	Add(name string, startsAt time.Time, endsAt time.Time, generations []float64) (string, error)
+	Update(id string, updateForm UpdateForm) error
+	// Deletes the term. If not found, it returns ErrNotFound.
+	Delete(id string) error
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+// Returns the term by the given ID.
+// If not found, it returns ErrNotFound. The malformed ID is not found either.
+func (r *mongodbRepo) Get(id string) (Term, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Term{}, fmt.Errorf("invalid id (%s): %w", id, ErrNotFound)
+	}
+
+	term := &mongodbTerm{}
+	err = r.collection.FindOne(context.Background(), bson.M{"_id": objectID, "is_deleted": false}).Decode(term)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Term{}, ErrNotFound
+		}
+		return Term{}, fmt.Errorf("failed to get term: %w", err)
+	}
+
+	return *fromMongodbTerm(term), nil
+}
+
+// Returns all the terms sorted by the start time in descending order.
+func (r *mongodbRepo) GetAll() ([]Term, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"is_deleted": false},
+		options.Find().SetSort(bson.D{{Key: "starts_at", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get terms: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongodbTerms []mongodbTerm
+	if err = cursor.All(ctx, &mongodbTerms); err != nil {
+		return nil, fmt.Errorf("failed to decode terms: %w", err)
+	}
+
+	terms := []Term{}
+	for _, mongodbTerm := range mongodbTerms {
+		terms = append(terms, *fromMongodbTerm(&mongodbTerm))
+	}
+	return terms, nil
+}
+
+// Returns the term whose period includes the given time.
+// If not found, it returns ErrNotFound.
+func (r *mongodbRepo) GetByTime(at time.Time) (Term, error) {
+	term := &mongodbTerm{}
+	err := r.collection.FindOne(context.Background(), bson.M{
+		"is_deleted": false,
+		"starts_at":  bson.M{"$lte": at},
+		"ends_at":    bson.M{"$gt": at},
+	}).Decode(term)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Term{}, ErrNotFound
+		}
+		return Term{}, fmt.Errorf("failed to get term: %w", err)
+	}
+
+	return *fromMongodbTerm(term), nil
+}
+
+func (r *mongodbRepo) Add(name string, startsAt time.Time, endsAt time.Time, generations []float64) (string, error) {
+	term := mongodbTerm{
+		Name:        name,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		Generations: generations,
+		CreatedAt:   time.Now(),
+		IsDeleted:   false,
+	}
+
+	result, err := r.collection.InsertOne(context.Background(), term)
+	if err != nil {
+		return "", fmt.Errorf("failed to insert term: %w", err)
+	}
+
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", fmt.Errorf("failed to get inserted id")
+	}
+
+	return id.Hex(), nil
+}
+
+// The form to update the term. It only includes fields that can be updated.
+type UpdateForm struct {
+	Name        *string
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	Generations *[]float64
+	IsArchived  *bool
+	// Replaces the scoring rules of the term.
+	ScoringRules *scoring.Rules
+}
+
+func (r *mongodbRepo) Update(id string, updateForm UpdateForm) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	update := bson.M{}
+	if updateForm.Name != nil {
+		update["name"] = *updateForm.Name
+	}
+	if updateForm.StartsAt != nil {
+		update["starts_at"] = *updateForm.StartsAt
+	}
+	if updateForm.EndsAt != nil {
+		update["ends_at"] = *updateForm.EndsAt
+	}
+	if updateForm.Generations != nil {
+		update["generations"] = *updateForm.Generations
+	}
+	if updateForm.IsArchived != nil {
+		update["is_archived"] = *updateForm.IsArchived
+	}
+	if updateForm.ScoringRules != nil {
+		update["scoring_rules"] = toMongodbScoringRules(*updateForm.ScoringRules)
+	}
+
+	if _, err = r.collection.UpdateOne(context.Background(), bson.M{"_id": objectID}, bson.M{"$set": update}); err != nil {
+		return fmt.Errorf("failed to update term: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mongodbRepo) Delete(id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid id (%s): %w", id, ErrNotFound)
+	}
+
+	result, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": objectID, "is_deleted": false}, bson.M{"$set": bson.M{"is_deleted": true}})
+	if err != nil {
+		return fmt.Errorf("failed to delete term: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func fromMongodbTerm(term *mongodbTerm) *Term {
+	generations := term.Generations
+	if generations == nil {
+		generations = []float64{}
+	}
+	return &Term{
+		Id:           term.Id.Hex(),
+		Name:         term.Name,
+		StartsAt:     term.StartsAt,
+		EndsAt:       term.EndsAt,
+		Generations:  generations,
+		IsArchived:   term.IsArchived,
+		ScoringRules: fromMongodbScoringRules(term.ScoringRules),
+		CreatedAt:    term.CreatedAt,
+	}
+}
+
+func toMongodbScoringRules(rules scoring.Rules) mongodbScoringRules {
+	bonusSessions := []mongodbBonusSession{}
+	for _, bonusSession := range rules.BonusSessions {
+		bonusSessions = append(bonusSessions, mongodbBonusSession{SessionId: bonusSession.SessionId, Points: bonusSession.Points})
+	}
+	return mongodbScoringRules{
+		MinimumScore:        rules.MinimumScore,
+		LateGraceMinutes:    rules.LateGraceMinutes,
+		LatePenalty:         rules.LatePenalty,
+		WeeklyCap:           rules.WeeklyCap,
+		ExcludeForceApplied: rules.ExcludeForceApplied,
+		BonusSessions:       bonusSessions,
+	}
+}
+
+func fromMongodbScoringRules(rules *mongodbScoringRules) *scoring.Rules {
+	if rules == nil {
+		return nil
+	}
+	bonusSessions := []scoring.BonusSession{}
+	for _, bonusSession := range rules.BonusSessions {
+		bonusSessions = append(bonusSessions, scoring.BonusSession{SessionId: bonusSession.SessionId, Points: bonusSession.Points})
+	}
+	return &scoring.Rules{
+		MinimumScore:        rules.MinimumScore,
+		LateGraceMinutes:    rules.LateGraceMinutes,
+		LatePenalty:         rules.LatePenalty,
+		WeeklyCap:           rules.WeeklyCap,
+		ExcludeForceApplied: rules.ExcludeForceApplied,
+		BonusSessions:       bonusSessions,
+	}
+}
@@ -0,0 +1,126 @@
+package term
+
+import (
+	"rush/golang/mongotest"
+	"rush/scoring"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	t.Run("Adds and gets a term", func(t *testing.T) {
+		repo := newRepo(t)
+
+		id, err := repo.Add("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), []float64{9, 9.5})
+		assert.NoError(t, err)
+
+		term, err := repo.Get(id)
+		assert.NoError(t, err)
+		assert.Equal(t, Term{
+			Id:          id,
+			Name:        "2025-2",
+			StartsAt:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Generations: []float64{9, 9.5},
+			CreatedAt:   term.CreatedAt,
+		}, term)
+
+		_, err = repo.Get(primitive.NewObjectID().Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Returns the term that includes the time", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+
+		term, err := repo.GetByTime(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, id, term.Id)
+		assert.Equal(t, []float64{}, term.Generations)
+
+		// The end is exclusive.
+		_, err = repo.GetByTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Returns all the terms with the latest first", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.Add("2025-1", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+		_, err = repo.Add("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+
+		terms, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2025-2", "2025-1"}, []string{terms[0].Name, terms[1].Name})
+	})
+
+	t.Run("Updates only the given fields", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), []float64{9})
+		assert.NoError(t, err)
+
+		isArchived := true
+		generations := []float64{9, 10}
+		assert.NoError(t, repo.Update(id, UpdateForm{IsArchived: &isArchived, Generations: &generations}))
+
+		term, err := repo.Get(id)
+		assert.NoError(t, err)
+		assert.True(t, term.IsArchived)
+		assert.Equal(t, []float64{9, 10}, term.Generations)
+		assert.Equal(t, "2025-2", term.Name)
+		assert.Nil(t, term.ScoringRules)
+	})
+
+	t.Run("Replaces the scoring rules", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+
+		assert.NoError(t, repo.Update(id, UpdateForm{ScoringRules: &scoring.Rules{
+			MinimumScore:  20,
+			BonusSessions: []scoring.BonusSession{{SessionId: "race", Points: 3}},
+		}}))
+		assert.NoError(t, repo.Update(id, UpdateForm{ScoringRules: &scoring.Rules{MinimumScore: 24, LatePenalty: 1, ExcludeForceApplied: true}}))
+
+		term, err := repo.Get(id)
+		assert.NoError(t, err)
+		assert.Equal(t, &scoring.Rules{MinimumScore: 24, LatePenalty: 1, ExcludeForceApplied: true, BonusSessions: []scoring.BonusSession{}}, term.ScoringRules)
+	})
+
+	t.Run("Hides the deleted terms", func(t *testing.T) {
+		repo := newRepo(t)
+		id, err := repo.Add("2025-2", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+		assert.NoError(t, err)
+
+		assert.NoError(t, repo.Delete(id))
+
+		_, err = repo.Get(id)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = repo.GetByTime(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC))
+		assert.ErrorIs(t, err, ErrNotFound)
+		terms, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Empty(t, terms)
+		assert.ErrorIs(t, repo.Delete(id), ErrNotFound)
+	})
+}
@@ -0,0 +1,198 @@
+package term
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"rush/scoring"
+	"rush/sqlite"
+	"strings"
+	"time"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteTermColumns = "id, name, starts_at, ends_at, generations, is_archived, created_at, scoring_rules"
+
+// Returns the term by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *sqliteRepo) Get(id string) (Term, error) {
+	return r.queryOne("SELECT "+sqliteTermColumns+" FROM terms WHERE id = ? AND is_deleted = 0", id)
+}
+
+// Returns all the terms sorted by the start time in descending order.
+func (r *sqliteRepo) GetAll() ([]Term, error) {
+	rows, err := r.db.Query("SELECT " + sqliteTermColumns + " FROM terms WHERE is_deleted = 0 ORDER BY starts_at DESC, rowid")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get terms: %w", err)
+	}
+	defer rows.Close()
+
+	terms := []Term{}
+	for rows.Next() {
+		term, err := scanSqliteTerm(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode terms: %w", err)
+		}
+		terms = append(terms, term)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode terms: %w", err)
+	}
+	return terms, nil
+}
+
+// Returns the term whose period includes the given time.
+// If not found, it returns ErrNotFound.
+func (r *sqliteRepo) GetByTime(at time.Time) (Term, error) {
+	return r.queryOne("SELECT "+sqliteTermColumns+" FROM terms WHERE is_deleted = 0 AND starts_at <= ? AND ends_at > ? ORDER BY rowid LIMIT 1",
+		sqlite.FromTime(at), sqlite.FromTime(at))
+}
+
+func (r *sqliteRepo) Add(name string, startsAt time.Time, endsAt time.Time, generations []float64) (string, error) {
+	encodedGenerations, err := encodeSqliteGenerations(generations)
+	if err != nil {
+		return "", err
+	}
+
+	id := sqlite.NewId()
+	if _, err := r.db.Exec("INSERT INTO terms ("+sqliteTermColumns+", is_deleted) VALUES (?, ?, ?, ?, ?, 0, ?, '', 0)",
+		id, name, sqlite.FromTime(startsAt), sqlite.FromTime(endsAt), encodedGenerations, sqlite.FromTime(time.Now())); err != nil {
+		return "", fmt.Errorf("failed to insert term: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *sqliteRepo) Update(id string, updateForm UpdateForm) error {
+	sets := []string{}
+	args := []interface{}{}
+	if updateForm.Name != nil {
+		sets = append(sets, "name = ?")
+		args = append(args, *updateForm.Name)
+	}
+	if updateForm.StartsAt != nil {
+		sets = append(sets, "starts_at = ?")
+		args = append(args, sqlite.FromTime(*updateForm.StartsAt))
+	}
+	if updateForm.EndsAt != nil {
+		sets = append(sets, "ends_at = ?")
+		args = append(args, sqlite.FromTime(*updateForm.EndsAt))
+	}
+	if updateForm.Generations != nil {
+		encodedGenerations, err := encodeSqliteGenerations(*updateForm.Generations)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, "generations = ?")
+		args = append(args, encodedGenerations)
+	}
+	if updateForm.IsArchived != nil {
+		sets = append(sets, "is_archived = ?")
+		args = append(args, *updateForm.IsArchived)
+	}
+	if updateForm.ScoringRules != nil {
+		encodedScoringRules, err := encodeSqliteScoringRules(*updateForm.ScoringRules)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, "scoring_rules = ?")
+		args = append(args, encodedScoringRules)
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	args = append(args, id)
+	if _, err := r.db.Exec("UPDATE terms SET "+strings.Join(sets, ", ")+" WHERE id = ?", args...); err != nil {
+		return fmt.Errorf("failed to update term: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteRepo) Delete(id string) error {
+	result, err := r.db.Exec("UPDATE terms SET is_deleted = 1 WHERE id = ? AND is_deleted = 0", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete term: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get the deleted term count: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sqliteRepo) queryOne(query string, args ...interface{}) (Term, error) {
+	term, err := scanSqliteTerm(r.db.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Term{}, ErrNotFound
+		}
+		return Term{}, fmt.Errorf("failed to get term: %w", err)
+	}
+	return term, nil
+}
+
+func encodeSqliteGenerations(generations []float64) (string, error) {
+	if generations == nil {
+		generations = []float64{}
+	}
+	encoded, err := json.Marshal(generations)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode generations: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// Encodes the rules as JSON. The column is empty if the rules are not set.
+func encodeSqliteScoringRules(rules scoring.Rules) (string, error) {
+	if rules.BonusSessions == nil {
+		rules.BonusSessions = []scoring.BonusSession{}
+	}
+	encoded, err := json.Marshal(rules)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode scoring rules: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// Either *sql.Row or *sql.Rows.
+type sqliteScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSqliteTerm(scanner sqliteScanner) (Term, error) {
+	var term Term
+	var startsAt, endsAt, createdAt int64
+	var generations, scoringRules string
+	if err := scanner.Scan(&term.Id, &term.Name, &startsAt, &endsAt, &generations, &term.IsArchived, &createdAt, &scoringRules); err != nil {
+		return Term{}, err
+	}
+	if err := json.Unmarshal([]byte(generations), &term.Generations); err != nil {
+		return Term{}, fmt.Errorf("failed to decode generations: %w", err)
+	}
+	if scoringRules != "" {
+		term.ScoringRules = &scoring.Rules{}
+		if err := json.Unmarshal([]byte(scoringRules), term.ScoringRules); err != nil {
+			return Term{}, fmt.Errorf("failed to decode scoring rules: %w", err)
+		}
+	}
+	term.StartsAt = sqlite.ToTime(startsAt)
+	term.EndsAt = sqlite.ToTime(endsAt)
+	term.CreatedAt = sqlite.ToTime(createdAt)
+	return term, nil
+}
@@ -0,0 +1,43 @@
+// It handles the term, the period of time that Rush handles the attendances for.
+package term
+
+import (
+	"rush/golang/array"
+	"rush/scoring"
+	"time"
+)
+
+// Term represents a half year of the club activity. E.g., "2025-2".
+// Sessions and attendances belong to the term whose period includes the session start time.
+type Term struct {
+	// The ID of the term. It's a unique identifier. E.g., "abc123"
+	Id string `json:"id"`
+	// The name of the term. E.g., "2025-2"
+	Name string `json:"name"`
+	// The time in UTC when the term starts. It's inclusive.
+	StartsAt time.Time `json:"starts_at"`
+	// The time in UTC when the term ends. It's exclusive.
+	EndsAt time.Time `json:"ends_at"`
+	// The generations of the users who participate in the term. E.g., [9, 9.5, 10]
+	// Empty means every generation participates.
+	Generations []float64 `json:"generations"`
+	// Whether the term is archived. A term is archived when the club rolls over to the next term.
+	IsArchived bool `json:"is_archived"`
+	// The rules to score the attendances of the term. Nil if the admins haven't set them.
+	ScoringRules *scoring.Rules `json:"scoring_rules"`
+	// The time in UTC when the term was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Checks if the given time is within the term.
+func (t *Term) Contains(at time.Time) bool {
+	return !at.Before(t.StartsAt) && at.Before(t.EndsAt)
+}
+
+// Checks if the users of the given generation participate in the term.
+func (t *Term) HasGeneration(generation float64) bool {
+	if len(t.Generations) == 0 {
+		return true
+	}
+	return array.Contains(t.Generations, generation)
+}
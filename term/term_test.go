@@ -0,0 +1,33 @@
+package term
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerm_Contains(t *testing.T) {
+	term := Term{
+		StartsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.False(t, term.Contains(time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC)))
+	// The start time is inclusive.
+	assert.True(t, term.Contains(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, term.Contains(time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)))
+	// The end time is exclusive.
+	assert.False(t, term.Contains(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestTerm_HasGeneration(t *testing.T) {
+	term := Term{}
+	// Every generation participates if it's not specified.
+	assert.True(t, term.HasGeneration(9))
+
+	term.Generations = []float64{9, 9.5}
+	assert.True(t, term.HasGeneration(9))
+	assert.True(t, term.HasGeneration(9.5))
+	assert.False(t, term.HasGeneration(10))
+}
@@ -9,11 +9,13 @@ import {
   DialogContent,
   DialogContentText,
   DialogTitle,
+  MenuItem,
   Paper,
+  TextField,
   Typography,
 } from '@mui/material';
 import { DataGrid, GridColDef, GridPaginationModel } from '@mui/x-data-grid';
-import { adminLateApplyAttendance, adminListSessions } from '../client/http/admin';
+import { adminLateApplyAttendance, adminListSessions, LateAttendanceStatus } from '../client/http/admin';
 import { AdminSession } from '../client/http/data';
 import { toYYslashMMslashDDspaceHHcolonMMwithDay } from '../common/date';
 import useHandleError from '../common/error';
@@ -22,12 +24,14 @@ import AddAttendance from './SessionDetail/AddAttendance';
 
 const Exception = () => {
   const { handleError } = useHandleError();
-  const { showInfo } = useSnackbar();
+  const { showInfo, showError } = useSnackbar();
 
   const [sessions, setSessions] = useState<AdminSession[]>([]);
   const [isLoadingSessions, setIsLoadingSessions] = useState(false);
   const [selectedSessionId, setSelectedSessionId] = useState<string | null>(null);
   const [showUserSelection, setShowUserSelection] = useState(false);
+  const [status, setStatus] = useState<LateAttendanceStatus>('force_applied');
+  const [reason, setReason] = useState('');
   const [totalCount, setTotalCount] = useState(0);
   const [isEnd, setIsEnd] = useState(false);
   const [paginationModel, setPaginationModel] = useState<GridPaginationModel>({
@@ -67,6 +71,8 @@ const Exception = () => {
 
   const handleSessionSelect = (sessionId: string) => {
     setSelectedSessionId(sessionId);
+    setStatus('force_applied');
+    setReason('');
     setShowUserSelection(true);
   };
 
@@ -76,9 +82,13 @@ const Exception = () => {
 
   const applyExceptionalAttendance = async (userIds: string[]) => {
     if (!selectedSessionId) return;
+    if (reason.trim().length === 0) {
+      showError('Reason is required');
+      return;
+    }
 
     try {
-      await adminLateApplyAttendance(selectedSessionId, userIds);
+      await adminLateApplyAttendance(selectedSessionId, userIds, status, reason.trim());
       setShowUserSelection(false);
       showInfo('Exceptional attendance applied successfully');
     } catch (error) {
@@ -164,6 +174,26 @@ const Exception = () => {
           <DialogContentText paragraph>
             Select users who should receive exceptional attendance for this session.
           </DialogContentText>
+          <TextField
+            select
+            label="Status"
+            value={status}
+            onChange={(e) => setStatus(e.target.value as LateAttendanceStatus)}
+            fullWidth
+            sx={{ mb: 2 }}
+          >
+            <MenuItem value="force_applied">Force applied</MenuItem>
+            <MenuItem value="late">Late</MenuItem>
+          </TextField>
+          <TextField
+            label="Reason"
+            value={reason}
+            onChange={(e) => setReason(e.target.value)}
+            fullWidth
+            sx={{ mb: 2 }}
+            error={reason.trim().length === 0}
+            helperText={reason.trim().length === 0 ? 'Reason is required' : ''}
+          />
           <Box sx={{ height: '60vh' }}>
             {selectedSessionId && <AddAttendance applyAttendances={(userIds) => applyExceptionalAttendance(userIds)} />}
           </Box>
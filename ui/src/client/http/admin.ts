@@ -78,6 +78,13 @@ export const adminMarkUsersAsPresent = async (sessionId: string, userIds: string
   await client.post(`/sessions/${sessionId}/attendance/manual`, { user_ids: userIds });
 };
 
-export const adminLateApplyAttendance = async (sessionId: string, userIds: string[]): Promise<void> => {
-  await client.post(`/sessions/${sessionId}/attendance/late`, { user_ids: userIds });
+export type LateAttendanceStatus = 'force_applied' | 'late';
+
+export const adminLateApplyAttendance = async (
+  sessionId: string,
+  userIds: string[],
+  status: LateAttendanceStatus,
+  reason: string,
+): Promise<void> => {
+  await client.post(`/sessions/${sessionId}/attendance/late`, { user_ids: userIds, status, reason });
 };
@@ -0,0 +1,70 @@
+package unmatched
+
+import (
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the unmatched submissions in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The submissions in the order of insertion.
+	submissions []Submission
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		submissions: []Submission{},
+	}
+}
+
+func (r *memoryRepo) ReplaceBySessionId(sessionId string, submissions []Submission) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	kept := []Submission{}
+	for _, submission := range r.submissions {
+		if submission.SessionId != sessionId || submission.Resolution.IsResolved() {
+			kept = append(kept, submission)
+		}
+	}
+	for _, submission := range submissions {
+		submission.Id = primitive.NewObjectID().Hex()
+		submission.SessionId = sessionId
+		submission.Resolution = Resolution{}
+		kept = append(kept, submission)
+	}
+	r.submissions = kept
+	return nil
+}
+
+// Returns the unmatched submissions of the session in the order of submission.
+func (r *memoryRepo) FindBySessionId(sessionId string) ([]Submission, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	submissions := []Submission{}
+	for _, submission := range r.submissions {
+		if submission.SessionId == sessionId {
+			submissions = append(submissions, submission)
+		}
+	}
+	sort.SliceStable(submissions, func(i, j int) bool { return submissions[i].SubmissionTime.Before(submissions[j].SubmissionTime) })
+	return submissions, nil
+}
+
+func (r *memoryRepo) Resolve(id string, resolution Resolution) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, submission := range r.submissions {
+		if submission.Id == id && !submission.Resolution.IsResolved() {
+			r.submissions[i].Resolution = resolution
+			return nil
+		}
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,159 @@
+package unmatched
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The unmatched submission record in MongoDB.
+type mongodbSubmission struct {
+	// The unique identifier for the submission. E.g. "1"
+	Id primitive.ObjectID `bson:"_id,omitempty"`
+	// The unique identifier for the session. E.g. "1"
+	SessionId string `bson:"session_id"`
+	// The answer as it was submitted. E.g. "9:김건"
+	Answer string `bson:"answer"`
+	// The external name read from the answer. E.g. "김건4"
+	UserExternalName string `bson:"user_external_name"`
+	// Why it couldn't be matched. E.g. "user_not_found"
+	Reason Reason `bson:"reason"`
+	// The details of the reason. E.g. "invalid option format: 9:김건"
+	Detail string `bson:"detail"`
+	// The time when it was submitted. E.g. "2025-07-01T00:00:00Z"
+	SubmissionTime time.Time `bson:"submission_time"`
+	// How the admin resolved it. The documents before the resolution was added don't have it.
+	Resolution mongodbResolution `bson:"resolution"`
+}
+
+type mongodbResolution struct {
+	// What the admin decided. E.g. "mapped"
+	Action ResolutionAction `bson:"action"`
+	// The unique identifier for the user that the submission is mapped to. E.g. "1"
+	UserId string `bson:"user_id"`
+	// The unique identifier for the admin who resolved it. E.g. "1"
+	ResolvedBy string `bson:"resolved_by"`
+	// The time when it was resolved. E.g. "2025-07-01T00:00:00Z"
+	ResolvedAt time.Time `bson:"resolved_at"`
+}
+
+var ErrNotFound = errors.New("unmatched submission not found")
+
+// Matches the submissions that have not been resolved. The field is null for the documents without the resolution.
+var mongodbPendingFilter = bson.M{"$in": bson.A{"", nil}}
+
+type mongodbRepo struct {
+	collection *mongo.Collection
+}
+
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	// Replaces the pending submissions of the session. The submissions are fetched again whenever
+	// the attendance is applied so that the previous ones are stale. The resolved ones are kept as the audit.
+	ReplaceBySessionId(sessionId string, submissions []Submission) error
+	FindBySessionId(sessionId string) ([]Submission, error)
+	// Resolves the pending submission. If it's not found or already resolved, it returns ErrNotFound.
+	Resolve(id string, resolution Resolution) error
+}
+
+func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
+	return &mongodbRepo{
+		collection: collection,
+	}
+}
+
+func (r *mongodbRepo) ReplaceBySessionId(sessionId string, submissions []Submission) error {
+	ctx := context.Background()
+
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"session_id": sessionId, "resolution.action": mongodbPendingFilter}); err != nil {
+		return fmt.Errorf("failed to delete the previous submissions: %w", err)
+	}
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	documents := []interface{}{}
+	for _, submission := range submissions {
+		documents = append(documents, mongodbSubmission{
+			SessionId:        sessionId,
+			Answer:           submission.Answer,
+			UserExternalName: submission.UserExternalName,
+			Reason:           submission.Reason,
+			Detail:           submission.Detail,
+			SubmissionTime:   submission.SubmissionTime,
+		})
+	}
+	if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to insert submissions: %w", err)
+	}
+	return nil
+}
+
+// Returns the unmatched submissions of the session in the order of submission.
+func (r *mongodbRepo) FindBySessionId(sessionId string) ([]Submission, error) {
+	ctx := context.Background()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"session_id": sessionId},
+		options.Find().SetSort(bson.D{{Key: "submission_time", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mongodbSubmissions []mongodbSubmission
+	if err := cursor.All(ctx, &mongodbSubmissions); err != nil {
+		return nil, fmt.Errorf("failed to decode submissions: %w", err)
+	}
+
+	submissions := []Submission{}
+	for _, mongodbSubmission := range mongodbSubmissions {
+		submissions = append(submissions, *fromMongodbSubmission(&mongodbSubmission))
+	}
+	return submissions, nil
+}
+
+func (r *mongodbRepo) Resolve(id string, resolution Resolution) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	result, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": objectID, "resolution.action": mongodbPendingFilter},
+		bson.M{"$set": bson.M{"resolution": mongodbResolution{
+			Action:     resolution.Action,
+			UserId:     resolution.UserId,
+			ResolvedBy: resolution.ResolvedBy,
+			ResolvedAt: resolution.ResolvedAt,
+		}}})
+	if err != nil {
+		return fmt.Errorf("failed to resolve submission: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func fromMongodbSubmission(submission *mongodbSubmission) *Submission {
+	return &Submission{
+		Id:               submission.Id.Hex(),
+		SessionId:        submission.SessionId,
+		Answer:           submission.Answer,
+		UserExternalName: submission.UserExternalName,
+		Reason:           submission.Reason,
+		Detail:           submission.Detail,
+		SubmissionTime:   submission.SubmissionTime,
+		Resolution: Resolution{
+			Action:     submission.Resolution.Action,
+			UserId:     submission.Resolution.UserId,
+			ResolvedBy: submission.Resolution.ResolvedBy,
+			ResolvedAt: submission.Resolution.ResolvedAt,
+		},
+	}
+}
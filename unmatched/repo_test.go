@@ -0,0 +1,109 @@
+package unmatched
+
+import (
+	"rush/golang/mongotest"
+	"rush/sqlite"
+	"testing"
+	"time"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	later := Submission{
+		SessionId:        "session-id",
+		Answer:           "9 - 김건4",
+		UserExternalName: "김건4",
+		Reason:           ReasonUserNotFound,
+		SubmissionTime:   time.Date(2025, 7, 1, 20, 5, 0, 0, time.UTC),
+	}
+	earlier := Submission{
+		SessionId:      "session-id",
+		Answer:         "9:김건",
+		Reason:         ReasonInvalidAnswer,
+		Detail:         "invalid option format: 9:김건",
+		SubmissionTime: time.Date(2025, 7, 1, 19, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("Replaces and finds the submissions of the session in the order of submission", func(t *testing.T) {
+		repo := newRepo(t)
+		other := later
+		other.SessionId = "other-session-id"
+		assert.NoError(t, repo.ReplaceBySessionId("other-session-id", []Submission{other}))
+
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", []Submission{later, earlier}))
+
+		submissions, err := repo.FindBySessionId("session-id")
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 2)
+		for i, expected := range []Submission{earlier, later} {
+			assert.NotEmpty(t, submissions[i].Id)
+			expected.Id = submissions[i].Id
+			assert.Equal(t, expected, submissions[i])
+		}
+
+		submissions, err = repo.FindBySessionId("unknown-session-id")
+		assert.NoError(t, err)
+		assert.Empty(t, submissions)
+	})
+
+	t.Run("Drops the previous submissions of the session only", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", []Submission{later, earlier}))
+		other := later
+		other.SessionId = "other-session-id"
+		assert.NoError(t, repo.ReplaceBySessionId("other-session-id", []Submission{other}))
+
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", []Submission{later}))
+
+		submissions, err := repo.FindBySessionId("session-id")
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 1)
+		assert.Equal(t, later.Answer, submissions[0].Answer)
+
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", nil))
+		submissions, err = repo.FindBySessionId("session-id")
+		assert.NoError(t, err)
+		assert.Empty(t, submissions)
+		submissions, err = repo.FindBySessionId("other-session-id")
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 1)
+	})
+
+	t.Run("Resolves the pending submission and keeps it when the submissions are replaced", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", []Submission{later, earlier}))
+		submissions, err := repo.FindBySessionId("session-id")
+		assert.NoError(t, err)
+		resolution := Resolution{
+			Action:     ResolutionActionMapped,
+			UserId:     "user-id",
+			ResolvedBy: "admin-id",
+			ResolvedAt: time.Date(2025, 7, 2, 9, 0, 0, 0, time.UTC),
+		}
+
+		assert.NoError(t, repo.Resolve(submissions[1].Id, resolution))
+		assert.ErrorIs(t, repo.Resolve(submissions[1].Id, resolution), ErrNotFound)
+		assert.NoError(t, repo.ReplaceBySessionId("session-id", nil))
+
+		submissions, err = repo.FindBySessionId("session-id")
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 1)
+		assert.Equal(t, later.Answer, submissions[0].Answer)
+		assert.Equal(t, resolution, submissions[0].Resolution)
+	})
+}
@@ -0,0 +1,90 @@
+package unmatched
+
+import (
+	"database/sql"
+	"fmt"
+	"rush/sqlite"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteSubmissionColumns = "id, session_id, answer, user_external_name, reason, detail, submission_time, " +
+	"resolution_action, resolution_user_id, resolved_by, resolved_at"
+
+func (r *sqliteRepo) ReplaceBySessionId(sessionId string, submissions []Submission) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM unmatched_submissions WHERE session_id = ? AND resolution_action = ''", sessionId); err != nil {
+		return fmt.Errorf("failed to delete the previous submissions: %w", err)
+	}
+	for _, submission := range submissions {
+		if _, err := tx.Exec("INSERT INTO unmatched_submissions ("+sqliteSubmissionColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, '', '', '', 0)",
+			sqlite.NewId(), sessionId, submission.Answer, submission.UserExternalName, submission.Reason, submission.Detail,
+			sqlite.FromTime(submission.SubmissionTime)); err != nil {
+			return fmt.Errorf("failed to insert submissions: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Returns the unmatched submissions of the session in the order of submission.
+func (r *sqliteRepo) FindBySessionId(sessionId string) ([]Submission, error) {
+	rows, err := r.db.Query("SELECT "+sqliteSubmissionColumns+" FROM unmatched_submissions WHERE session_id = ? ORDER BY submission_time, rowid", sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+	defer rows.Close()
+
+	submissions := []Submission{}
+	for rows.Next() {
+		var submission Submission
+		var submissionTime, resolvedAt int64
+		if err := rows.Scan(&submission.Id, &submission.SessionId, &submission.Answer, &submission.UserExternalName, &submission.Reason,
+			&submission.Detail, &submissionTime, &submission.Resolution.Action, &submission.Resolution.UserId, &submission.Resolution.ResolvedBy,
+			&resolvedAt); err != nil {
+			return nil, fmt.Errorf("failed to decode submissions: %w", err)
+		}
+		submission.SubmissionTime = sqlite.ToTime(submissionTime)
+		if submission.Resolution.IsResolved() {
+			submission.Resolution.ResolvedAt = sqlite.ToTime(resolvedAt)
+		}
+		submissions = append(submissions, submission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode submissions: %w", err)
+	}
+	return submissions, nil
+}
+
+func (r *sqliteRepo) Resolve(id string, resolution Resolution) error {
+	result, err := r.db.Exec("UPDATE unmatched_submissions SET resolution_action = ?, resolution_user_id = ?, resolved_by = ?, resolved_at = ? "+
+		"WHERE id = ? AND resolution_action = ''",
+		resolution.Action, resolution.UserId, resolution.ResolvedBy, sqlite.FromTime(resolution.ResolvedAt), id)
+	if err != nil {
+		return fmt.Errorf("failed to resolve submission: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to resolve submission: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
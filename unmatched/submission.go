@@ -0,0 +1,59 @@
+package unmatched
+
+import "time"
+
+// The submission from the attendance source of a session that couldn't be matched to any user.
+// They are kept as the report of the session so that the admins can resolve them.
+type Submission struct {
+	// The ID of the submission. E.g., "abc123"
+	Id string `json:"id"`
+	// The ID of the session that the submission is for. E.g., "abc123"
+	SessionId string `json:"session_id"`
+	// The answer as it was submitted. E.g., "9:김건" or "9 - 김건4"
+	Answer string `json:"answer"`
+	// The external name read from the answer. It's empty if the answer couldn't be read. E.g., "김건4"
+	UserExternalName string `json:"user_external_name"`
+	// Why it couldn't be matched.
+	Reason Reason `json:"reason"`
+	// The details of the reason. E.g., "invalid option format: 9:김건"
+	Detail string `json:"detail"`
+	// The time in UTC when it was submitted. It's zero if the time couldn't be read.
+	SubmissionTime time.Time `json:"submission_time"`
+	// How the admin resolved it. It's kept as the audit of the attendance once it's resolved.
+	Resolution Resolution `json:"resolution"`
+}
+
+// The decision of the admin on the unmatched submission.
+type Resolution struct {
+	// What the admin decided. It's empty until it's resolved.
+	Action ResolutionAction `json:"action"`
+	// The ID of the user that the submission is mapped to. It's empty if it's discarded. E.g., "abc123"
+	UserId string `json:"user_id"`
+	// The ID of the admin who resolved it. E.g., "abc123"
+	ResolvedBy string `json:"resolved_by"`
+	// The time in UTC when it was resolved.
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// Returns true if the admin has resolved the submission.
+func (r Resolution) IsResolved() bool {
+	return r.Action != ""
+}
+
+type ResolutionAction string
+
+const (
+	// The submission is applied as the attendance of the user that the admin chose.
+	ResolutionActionMapped ResolutionAction = "mapped"
+	// The submission is dropped. E.g., a test submission or a guest who is not a member.
+	ResolutionActionDiscarded ResolutionAction = "discarded"
+)
+
+type Reason string
+
+const (
+	// The answer couldn't be read. E.g., the option of the form was edited by hand.
+	ReasonInvalidAnswer Reason = "invalid_answer"
+	// No user has the external name of the answer. E.g., the user was renamed after the form was created.
+	ReasonUserNotFound Reason = "user_not_found"
+)
@@ -0,0 +1,159 @@
+package user
+
+import (
+	"rush/golang/array"
+	"rush/permission"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The repo that keeps the users in the memory. It behaves the same as the MongoDB repo.
+// It's used for tests and the local mode that doesn't need any database.
+type memoryRepo struct {
+	mutex sync.Mutex
+	// The users in the order of insertion.
+	users []User
+}
+
+func NewMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		users: []User{},
+	}
+}
+
+func (r *memoryRepo) GetAll() ([]User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(User) bool { return true }), nil
+}
+
+func (r *memoryRepo) GetAllActive() ([]User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(user User) bool { return user.IsActive }), nil
+}
+
+// Returns the user by the given email.
+// If not found, it returns ErrNotFound.
+func (r *memoryRepo) GetByEmail(email string) (*User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.find(func(user User) bool { return user.Email == email })
+}
+
+func (r *memoryRepo) List(offset int, pageSize int) (*ListResult, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	sorted := r.filter(func(User) bool { return true })
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Generation > sorted[j].Generation })
+
+	users := []User{}
+	if offset < len(sorted) {
+		users = sorted[offset:]
+	}
+	isEnd := len(users) <= pageSize
+	if !isEnd {
+		users = users[:pageSize]
+	}
+
+	return &ListResult{
+		Users:      users,
+		IsEnd:      isEnd,
+		TotalCount: len(sorted),
+	}, nil
+}
+
+// Returns the user by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *memoryRepo) Get(id string) (*User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.find(func(user User) bool { return user.Id == id })
+}
+
+func (r *memoryRepo) CountByName(name string) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.filter(func(user User) bool { return user.Name == name })), nil
+}
+
+func (r *memoryRepo) GetAllByExternalNames(externalNames []string) ([]User, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.filter(func(user User) bool { return array.Contains(externalNames, user.ExternalName) }), nil
+}
+
+func (r *memoryRepo) Add(user User) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	user.Id = primitive.NewObjectID().Hex()
+	r.users = append(r.users, user)
+	return nil
+}
+
+func (r *memoryRepo) AddMany(users []User) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, user := range users {
+		user.Id = primitive.NewObjectID().Hex()
+		r.users = append(r.users, user)
+	}
+	return len(users), nil
+}
+
+func (r *memoryRepo) Update(id string, updateForm UpdateForm) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for index := range r.users {
+		user := &r.users[index]
+		if user.Id != id {
+			continue
+		}
+
+		if updateForm.Name != nil {
+			user.Name = *updateForm.Name
+		}
+		if updateForm.Role != nil {
+			user.Role = permission.Role(*updateForm.Role)
+		}
+		if updateForm.Generation != nil {
+			user.Generation = *updateForm.Generation
+		}
+		if updateForm.IsActive != nil {
+			user.IsActive = *updateForm.IsActive
+		}
+		if updateForm.Email != nil {
+			user.Email = *updateForm.Email
+		}
+		if updateForm.ExternalName != nil {
+			user.ExternalName = *updateForm.ExternalName
+		}
+	}
+	return nil
+}
+
+// Returns the copies of the users that match the predicate.
+func (r *memoryRepo) filter(predicate func(User) bool) []User {
+	return array.Filter(r.users, predicate)
+}
+
+func (r *memoryRepo) find(predicate func(User) bool) (*User, error) {
+	for _, user := range r.users {
+		if predicate(user) {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
@@ -38,6 +38,20 @@ type mongodbRepo struct {
 
 var ErrNotFound = errors.New("user not found")
 
+// The repo that each storage backend implements. E.g., MongoDB and SQLite.
+type Repo interface {
+	Get(id string) (*User, error)
+	GetAll() ([]User, error)
+	GetAllActive() ([]User, error)
+	List(offset int, pageSize int) (*ListResult, error)
+	GetByEmail(email string) (*User, error)
+	GetAllByExternalNames(externalNames []string) ([]User, error)
+	CountByName(name string) (int, error)
+	Add(user User) error
+	AddMany(users []User) (int, error)
+	Update(id string, updateForm UpdateForm) error
+}
+
 func NewMongoDbRepo(collection *mongo.Collection) *mongodbRepo {
 	return &mongodbRepo{
 		collection: collection,
@@ -231,6 +245,7 @@ func (r *mongodbRepo) Add(user User) error {
 
 	_, err := r.collection.InsertOne(ctx, mongodbUser{
 		Name:         user.Name,
+		Role:         string(user.Role),
 		Generation:   user.Generation,
 		IsActive:     user.IsActive,
 		Email:        user.Email,
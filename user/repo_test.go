@@ -0,0 +1,127 @@
+package user
+
+import (
+	"rush/golang/mongotest"
+	"rush/permission"
+	"rush/sqlite"
+	"testing"
+
+	"github.com/ridge/must/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMongoDbRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMongoDbRepo(mongotest.NewCollection(t)) })
+}
+
+func TestMemoryRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewMemoryRepo() })
+}
+
+func TestSqliteRepo(t *testing.T) {
+	testRepo(t, func(t *testing.T) Repo { return NewSqliteRepo(must.OK1(sqlite.Open(":memory:"))) })
+}
+
+// The conformance tests that every implementation of Repo should pass.
+func testRepo(t *testing.T, newRepo func(t *testing.T) Repo) {
+	t.Run("Adds and gets users", func(t *testing.T) {
+		repo := newRepo(t)
+
+		assert.NoError(t, repo.Add(User{Name: "김건", Role: permission.RoleMember, Generation: 9, IsActive: true, Email: "kim@gmail.com", ExternalName: "김건"}))
+		count, err := repo.AddMany([]User{
+			{Name: "양현우", Role: permission.RoleAdmin, Generation: 8, IsActive: false, Email: "yang@gmail.com", ExternalName: "양현우"},
+			{Name: "김건", Role: permission.RoleMember, Generation: 10, IsActive: true, Email: "kim2@gmail.com", ExternalName: "김건2"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		user, err := repo.GetByEmail("kim@gmail.com")
+		assert.NoError(t, err)
+		assert.Equal(t, User{Id: user.Id, Name: "김건", Role: permission.RoleMember, Generation: 9, IsActive: true, Email: "kim@gmail.com", ExternalName: "김건"}, *user)
+
+		byId, err := repo.Get(user.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, user, byId)
+
+		all, err := repo.GetAll()
+		assert.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		nameCount, err := repo.CountByName("김건")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, nameCount)
+
+		byExternalNames, err := repo.GetAllByExternalNames([]string{"양현우", "김건2", "unknown"})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"양현우", "김건2"}, []string{byExternalNames[0].ExternalName, byExternalNames[1].ExternalName})
+	})
+
+	t.Run("Returns ErrNotFound if the user doesn't exist", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Get(primitive.NewObjectID().Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = repo.GetByEmail("unknown@gmail.com")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Returns only the active users", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.AddMany([]User{
+			{Name: "a", Role: permission.RoleMember, IsActive: true},
+			{Name: "b", Role: permission.RoleMember, IsActive: false},
+		})
+		assert.NoError(t, err)
+
+		active, err := repo.GetAllActive()
+		assert.NoError(t, err)
+		assert.Len(t, active, 1)
+		assert.Equal(t, "a", active[0].Name)
+	})
+
+	t.Run("Updates only the given fields", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.Add(User{Name: "김건", Role: permission.RoleMember, Generation: 9, IsActive: true, Email: "kim@gmail.com", ExternalName: "김건"}))
+		user, err := repo.GetByEmail("kim@gmail.com")
+		assert.NoError(t, err)
+
+		generation := 9.5
+		role := string(permission.RoleAdmin)
+		isActive := false
+		assert.NoError(t, repo.Update(user.Id, UpdateForm{Generation: &generation, Role: &role, IsActive: &isActive}))
+
+		updated, err := repo.Get(user.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, User{Id: user.Id, Name: "김건", Role: permission.RoleAdmin, Generation: 9.5, IsActive: false, Email: "kim@gmail.com", ExternalName: "김건"}, *updated)
+	})
+
+	t.Run("Lists users by the generation in descending order", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.AddMany([]User{
+			{Name: "a", Role: permission.RoleMember, Generation: 8},
+			{Name: "b", Role: permission.RoleMember, Generation: 10},
+			{Name: "c", Role: permission.RoleMember, Generation: 9},
+		})
+		assert.NoError(t, err)
+
+		first, err := repo.List(0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "c"}, []string{first.Users[0].Name, first.Users[1].Name})
+		assert.False(t, first.IsEnd)
+		assert.Equal(t, 3, first.TotalCount)
+
+		second, err := repo.List(2, 2)
+		assert.NoError(t, err)
+		assert.Len(t, second.Users, 1)
+		assert.Equal(t, "a", second.Users[0].Name)
+		assert.True(t, second.IsEnd)
+		assert.Equal(t, 3, second.TotalCount)
+
+		// Exactly the page size is the end.
+		exact, err := repo.List(0, 3)
+		assert.NoError(t, err)
+		assert.Len(t, exact.Users, 3)
+		assert.True(t, exact.IsEnd)
+	})
+}
@@ -0,0 +1,149 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"rush/permission"
+	"strings"
+)
+
+// Returned when the roster can not be used for the rollover.
+var ErrInvalidRoster = errors.New("invalid roster")
+
+// It rolls the users over to a new term without deleting anyone.
+// Users who are not in the new roster are deactivated, returning users are updated and new users are added.
+type roller struct {
+	userRepo RolloverUserRepo
+}
+
+func NewRoller(userRepo RolloverUserRepo) *roller {
+	return &roller{
+		userRepo: userRepo,
+	}
+}
+
+// The user who is in the roster of the new term and already exists.
+type ReturningUser struct {
+	// The user before the rollover.
+	Before User
+	// The user after the rollover. Only the fields from the roster are updated.
+	After User
+}
+
+// The diff between the current users and the roster of the new term.
+// Nothing is written until it's applied so that it can be reviewed first.
+type RolloverPlan struct {
+	// Active users who are not in the roster. They will be deactivated.
+	Deactivated []User
+	// Active admins and super admins who are not in the roster. They stay active so that the rollover by the roster
+	// of the members never locks the administrators out.
+	Kept []User
+	// Users who are in the roster and already exist. They are matched by the email.
+	Returning []ReturningUser
+	// Users who are in the roster but don't exist yet. They will be added as members.
+	Added []User
+}
+
+// Compares the current users with the roster and returns what will be changed.
+// The roster is typically parsed from the CSV file by `ParseCSV`.
+func (r *roller) Plan(roster []User) (RolloverPlan, error) {
+	rosterByEmail := map[string]User{}
+	for _, rosterUser := range roster {
+		email := normalizeEmail(rosterUser.Email)
+		if email == "" {
+			return RolloverPlan{}, fmt.Errorf("%w: email is required for every user but %s does not have it", ErrInvalidRoster, rosterUser.Name)
+		}
+		if _, ok := rosterByEmail[email]; ok {
+			return RolloverPlan{}, fmt.Errorf("%w: duplicate email %s", ErrInvalidRoster, rosterUser.Email)
+		}
+		rosterByEmail[email] = rosterUser
+	}
+
+	users, err := r.userRepo.GetAll()
+	if err != nil {
+		return RolloverPlan{}, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	plan := RolloverPlan{Deactivated: []User{}, Kept: []User{}, Returning: []ReturningUser{}, Added: []User{}}
+	existingEmails := map[string]bool{}
+	for _, user := range users {
+		email := normalizeEmail(user.Email)
+		existingEmails[email] = true
+
+		rosterUser, ok := rosterByEmail[email]
+		if !ok {
+			if !user.IsActive {
+				continue
+			}
+			if user.Role == permission.RoleAdmin || user.Role == permission.RoleSuperAdmin {
+				plan.Kept = append(plan.Kept, user)
+				continue
+			}
+			plan.Deactivated = append(plan.Deactivated, user)
+			continue
+		}
+
+		after := user
+		after.Name = rosterUser.Name
+		after.Generation = rosterUser.Generation
+		after.ExternalName = rosterUser.ExternalName
+		after.IsActive = true
+		plan.Returning = append(plan.Returning, ReturningUser{Before: user, After: after})
+	}
+
+	// Keep the order of the roster for the new users.
+	for _, rosterUser := range roster {
+		if existingEmails[normalizeEmail(rosterUser.Email)] {
+			continue
+		}
+		plan.Added = append(plan.Added, rosterUser)
+	}
+
+	return plan, nil
+}
+
+// Applies the plan. Nothing is deleted.
+// Attendance records are not touched as they keep the user data at the time of the attendance.
+// It's not atomic: if it fails partway, the users that have been written stay written. Planning and applying again is
+// safe though, as the plan is made from the current users. The deactivated users are not active anymore, the returning
+// users are updated to the same values and the added users are returning users.
+func (r *roller) Apply(plan RolloverPlan) error {
+	isActive := false
+	for _, user := range plan.Deactivated {
+		if err := r.userRepo.Update(user.Id, UpdateForm{IsActive: &isActive}); err != nil {
+			return fmt.Errorf("failed to deactivate user (%s): %w", user.Id, err)
+		}
+	}
+
+	for _, returningUser := range plan.Returning {
+		after := returningUser.After
+		if after.Id == "" {
+			return errors.New("returning user should have an ID")
+		}
+		if err := r.userRepo.Update(after.Id, UpdateForm{
+			Name:         &after.Name,
+			Generation:   &after.Generation,
+			ExternalName: &after.ExternalName,
+			IsActive:     &after.IsActive,
+		}); err != nil {
+			return fmt.Errorf("failed to update returning user (%s): %w", after.Id, err)
+		}
+	}
+
+	if _, err := r.userRepo.AddMany(plan.Added); err != nil {
+		return fmt.Errorf("failed to add new users: %w", err)
+	}
+
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+//go:generate mockgen -source=rollover.go -destination=rollover_mock.go -package=user
+type RolloverUserRepo interface {
+	GetAll() ([]User, error)
+	Update(id string, updateForm UpdateForm) error
+	AddMany(users []User) (int, error)
+}
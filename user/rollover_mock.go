@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rollover.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rollover.go -destination=rollover_mock.go -package=user
+//
+
+// Package user is a generated GoMock package.
+package user
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRolloverUserRepo is a mock of RolloverUserRepo interface.
+type MockRolloverUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRolloverUserRepoMockRecorder
+}
+
+// MockRolloverUserRepoMockRecorder is the mock recorder for MockRolloverUserRepo.
+type MockRolloverUserRepoMockRecorder struct {
+	mock *MockRolloverUserRepo
+}
+
+// NewMockRolloverUserRepo creates a new mock instance.
+func NewMockRolloverUserRepo(ctrl *gomock.Controller) *MockRolloverUserRepo {
+	mock := &MockRolloverUserRepo{ctrl: ctrl}
+	mock.recorder = &MockRolloverUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRolloverUserRepo) EXPECT() *MockRolloverUserRepoMockRecorder {
+	return m.recorder
+}
+
+// AddMany mocks base method.
+func (m *MockRolloverUserRepo) AddMany(users []User) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMany", users)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMany indicates an expected call of AddMany.
+func (mr *MockRolloverUserRepoMockRecorder) AddMany(users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMany", reflect.TypeOf((*MockRolloverUserRepo)(nil).AddMany), users)
+}
+
+// GetAll mocks base method.
+func (m *MockRolloverUserRepo) GetAll() ([]User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRolloverUserRepoMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRolloverUserRepo)(nil).GetAll))
+}
+
+// Update mocks base method.
+func (m *MockRolloverUserRepo) Update(id string, updateForm UpdateForm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, updateForm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRolloverUserRepoMockRecorder) Update(id, updateForm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRolloverUserRepo)(nil).Update), id, updateForm)
+}
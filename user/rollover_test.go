@@ -0,0 +1,132 @@
+package user
+
+import (
+	"rush/permission"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPlan(t *testing.T) {
+	t.Run("Fails if the roster has duplicate emails", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		repo := NewMockRolloverUserRepo(controller)
+		roller := NewRoller(repo)
+
+		_, err := roller.Plan([]User{
+			{Name: "김건", Email: "kim@gmail.com"},
+			{Name: "김건2", Email: " KIM@gmail.com"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidRoster)
+	})
+
+	t.Run("Fails if the repo fails to get users", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		repo := NewMockRolloverUserRepo(controller)
+		roller := NewRoller(repo)
+
+		repo.EXPECT().GetAll().Return(nil, assert.AnError)
+		_, err := roller.Plan([]User{{Name: "김건", Email: "kim@gmail.com"}})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Splits the users into deactivated, returning and added", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		repo := NewMockRolloverUserRepo(controller)
+		roller := NewRoller(repo)
+
+		repo.EXPECT().GetAll().Return([]User{
+			{Id: "1", Name: "김건", Role: permission.RoleAdmin, Generation: 9, IsActive: true, Email: "kim@gmail.com", ExternalName: "김건"},
+			{Id: "2", Name: "양현우", Role: permission.RoleMember, Generation: 8, IsActive: true, Email: "yang@gmail.com", ExternalName: "양현우"},
+			// Already inactive. It's neither deactivated nor returning.
+			{Id: "3", Name: "강민경", Role: permission.RoleMember, Generation: 8, IsActive: false, Email: "kang@gmail.com", ExternalName: "강민경"},
+			// Inactive but returning.
+			{Id: "4", Name: "박지성", Role: permission.RoleMember, Generation: 7, IsActive: false, Email: "park@gmail.com", ExternalName: "박지성"},
+		}, nil)
+		plan, err := roller.Plan([]User{
+			{Name: "김건", Role: permission.RoleMember, Generation: 9.5, IsActive: true, Email: "Kim@gmail.com", ExternalName: "김건"},
+			{Name: "박지성", Role: permission.RoleMember, Generation: 7, IsActive: true, Email: "park@gmail.com", ExternalName: "박지성"},
+			{Name: "새회원", Role: permission.RoleMember, Generation: 10, IsActive: true, Email: "new@gmail.com", ExternalName: "새회원"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, RolloverPlan{
+			Deactivated: []User{
+				{Id: "2", Name: "양현우", Role: permission.RoleMember, Generation: 8, IsActive: true, Email: "yang@gmail.com", ExternalName: "양현우"},
+			},
+			Kept: []User{},
+			Returning: []ReturningUser{
+				{
+					Before: User{Id: "1", Name: "김건", Role: permission.RoleAdmin, Generation: 9, IsActive: true, Email: "kim@gmail.com", ExternalName: "김건"},
+					// The role and the email are kept.
+					After: User{Id: "1", Name: "김건", Role: permission.RoleAdmin, Generation: 9.5, IsActive: true, Email: "kim@gmail.com", ExternalName: "김건"},
+				},
+				{
+					Before: User{Id: "4", Name: "박지성", Role: permission.RoleMember, Generation: 7, IsActive: false, Email: "park@gmail.com", ExternalName: "박지성"},
+					After:  User{Id: "4", Name: "박지성", Role: permission.RoleMember, Generation: 7, IsActive: true, Email: "park@gmail.com", ExternalName: "박지성"},
+				},
+			},
+			Added: []User{
+				{Name: "새회원", Role: permission.RoleMember, Generation: 10, IsActive: true, Email: "new@gmail.com", ExternalName: "새회원"},
+			},
+		}, plan)
+	})
+
+	t.Run("Keeps the admins who are not in the roster active", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		repo := NewMockRolloverUserRepo(controller)
+		roller := NewRoller(repo)
+
+		repo.EXPECT().GetAll().Return([]User{
+			{Id: "1", Name: "김건", Role: permission.RoleSuperAdmin, Generation: 9, IsActive: true, Email: "kim@gmail.com"},
+			{Id: "2", Name: "양현우", Role: permission.RoleAdmin, Generation: 8, IsActive: true, Email: "yang@gmail.com"},
+			{Id: "3", Name: "강민경", Role: permission.RoleMember, Generation: 8, IsActive: true, Email: "kang@gmail.com"},
+		}, nil)
+		plan, err := roller.Plan([]User{{Name: "새회원", Role: permission.RoleMember, Generation: 10, IsActive: true, Email: "new@gmail.com"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []User{{Id: "3", Name: "강민경", Role: permission.RoleMember, Generation: 8, IsActive: true, Email: "kang@gmail.com"}}, plan.Deactivated)
+		assert.Equal(t, []User{
+			{Id: "1", Name: "김건", Role: permission.RoleSuperAdmin, Generation: 9, IsActive: true, Email: "kim@gmail.com"},
+			{Id: "2", Name: "양현우", Role: permission.RoleAdmin, Generation: 8, IsActive: true, Email: "yang@gmail.com"},
+		}, plan.Kept)
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("Fails if the repo fails to deactivate a user", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		repo := NewMockRolloverUserRepo(controller)
+		roller := NewRoller(repo)
+
+		isActive := false
+		repo.EXPECT().Update("1", UpdateForm{IsActive: &isActive}).Return(assert.AnError)
+		err := roller.Apply(RolloverPlan{Deactivated: []User{{Id: "1"}}})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Deactivates, updates and adds users", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		repo := NewMockRolloverUserRepo(controller)
+		roller := NewRoller(repo)
+
+		isActive := false
+		name := "김건"
+		generation := 9.5
+		externalName := "김건"
+		isReturningActive := true
+		repo.EXPECT().Update("2", UpdateForm{IsActive: &isActive}).Return(nil)
+		repo.EXPECT().Update("1", UpdateForm{Name: &name, Generation: &generation, ExternalName: &externalName, IsActive: &isReturningActive}).Return(nil)
+		repo.EXPECT().AddMany([]User{{Name: "새회원", Email: "new@gmail.com"}}).Return(1, nil)
+		err := roller.Apply(RolloverPlan{
+			Deactivated: []User{{Id: "2"}},
+			Returning: []ReturningUser{{
+				Before: User{Id: "1", Name: "김건", Generation: 9},
+				After:  User{Id: "1", Name: "김건", Generation: 9.5, ExternalName: "김건", IsActive: true},
+			}},
+			Added: []User{{Name: "새회원", Email: "new@gmail.com"}},
+		})
+		assert.NoError(t, err)
+	})
+}
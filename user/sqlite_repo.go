@@ -0,0 +1,225 @@
+package user
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"rush/sqlite"
+	"strings"
+)
+
+type sqliteRepo struct {
+	db *sql.DB
+}
+
+func NewSqliteRepo(db *sql.DB) *sqliteRepo {
+	return &sqliteRepo{
+		db: db,
+	}
+}
+
+const sqliteUserColumns = "id, name, role, generation, is_active, email, external_name"
+
+func (r *sqliteRepo) GetAll() ([]User, error) {
+	return r.query("SELECT " + sqliteUserColumns + " FROM users ORDER BY rowid")
+}
+
+func (r *sqliteRepo) GetAllActive() ([]User, error) {
+	return r.query("SELECT " + sqliteUserColumns + " FROM users WHERE is_active = 1 ORDER BY rowid")
+}
+
+// Returns the user by the given email.
+// If not found, it returns ErrNotFound.
+func (r *sqliteRepo) GetByEmail(email string) (*User, error) {
+	return r.queryOne("SELECT "+sqliteUserColumns+" FROM users WHERE email = ? ORDER BY rowid LIMIT 1", email)
+}
+
+func (r *sqliteRepo) List(offset int, pageSize int) (*ListResult, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	// Fetch pageSize + 1 to check if there are more pages.
+	users, err := r.query("SELECT "+sqliteUserColumns+" FROM users ORDER BY generation DESC, rowid LIMIT ? OFFSET ?", pageSize+1, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	isEnd := len(users) <= pageSize
+	if !isEnd {
+		users = users[:pageSize]
+	}
+
+	return &ListResult{
+		Users:      users,
+		IsEnd:      isEnd,
+		TotalCount: total,
+	}, nil
+}
+
+// Returns the user by the given ID.
+// If not found, it returns ErrNotFound.
+func (r *sqliteRepo) Get(id string) (*User, error) {
+	return r.queryOne("SELECT "+sqliteUserColumns+" FROM users WHERE id = ?", id)
+}
+
+func (r *sqliteRepo) CountByName(name string) (int, error) {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM users WHERE name = ?", name).Scan(&count); err != nil {
+		return 0, fmt.Errorf("database client has failed: %w", err)
+	}
+	return count, nil
+}
+
+func (r *sqliteRepo) GetAllByExternalNames(externalNames []string) ([]User, error) {
+	if len(externalNames) == 0 {
+		return []User{}, nil
+	}
+
+	args := make([]interface{}, len(externalNames))
+	for index, externalName := range externalNames {
+		args[index] = externalName
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(externalNames)), ", ")
+	return r.query("SELECT "+sqliteUserColumns+" FROM users WHERE external_name IN ("+placeholders+") ORDER BY rowid", args...)
+}
+
+func (r *sqliteRepo) Add(user User) error {
+	if err := insertSqliteUser(r.db, user); err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+	return nil
+}
+
+func (r *sqliteRepo) AddMany(users []User) (int, error) {
+	if len(users) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, u := range users {
+		if err := insertSqliteUser(tx, u); err != nil {
+			return 0, fmt.Errorf("failed to insert users: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to insert users: %w", err)
+	}
+
+	return len(users), nil
+}
+
+func (r *sqliteRepo) Update(id string, updateForm UpdateForm) error {
+	sets := []string{}
+	args := []interface{}{}
+
+	if updateForm.Name != nil {
+		sets = append(sets, "name = ?")
+		args = append(args, *updateForm.Name)
+	}
+
+	if updateForm.Role != nil {
+		sets = append(sets, "role = ?")
+		args = append(args, *updateForm.Role)
+	}
+
+	if updateForm.Generation != nil {
+		sets = append(sets, "generation = ?")
+		args = append(args, *updateForm.Generation)
+	}
+
+	if updateForm.IsActive != nil {
+		sets = append(sets, "is_active = ?")
+		args = append(args, *updateForm.IsActive)
+	}
+
+	if updateForm.Email != nil {
+		sets = append(sets, "email = ?")
+		args = append(args, *updateForm.Email)
+	}
+
+	if updateForm.ExternalName != nil {
+		sets = append(sets, "external_name = ?")
+		args = append(args, *updateForm.ExternalName)
+	}
+
+	if len(sets) == 0 {
+		return nil
+	}
+
+	args = append(args, id)
+	if _, err := r.db.Exec("UPDATE users SET "+strings.Join(sets, ", ")+" WHERE id = ?", args...); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
+// Either *sql.DB or *sql.Tx.
+type sqliteExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertSqliteUser(execer sqliteExecer, user User) error {
+	_, err := execer.Exec("INSERT INTO users ("+sqliteUserColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		sqlite.NewId(), user.Name, string(user.Role), user.Generation, user.IsActive, user.Email, user.ExternalName)
+	return err
+}
+
+func (r *sqliteRepo) query(query string, args ...interface{}) ([]User, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []User{}
+	for rows.Next() {
+		user, err := scanSqliteUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+	return users, nil
+}
+
+func (r *sqliteRepo) queryOne(query string, args ...interface{}) (*User, error) {
+	user, err := scanSqliteUser(r.db.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return &user, nil
+}
+
+// Either *sql.Row or *sql.Rows.
+type sqliteScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSqliteUser(scanner sqliteScanner) (User, error) {
+	var user User
+	var role string
+	if err := scanner.Scan(&user.Id, &user.Name, &role, &user.Generation, &user.IsActive, &user.Email, &user.ExternalName); err != nil {
+		return User{}, err
+	}
+
+	userRole, err := convertRole(role)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to convert user: %w", err)
+	}
+	user.Role = userRole
+	return user, nil
+}